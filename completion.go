@@ -0,0 +1,53 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var completionConfigOnly bool
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "completion",
+		f:     doCompletion,
+		help:  "write the data tree as JSON for completing paths and values in a CLI",
+		flags: flags,
+	})
+	flags.BoolVarLong(&completionConfigOnly, "completion_config_only", 0, "omit the state data nodes")
+}
+
+// doCompletion writes the data nodes of entries to w as a JSON array of
+// yang.CompletionNodes, on a single line.
+func doCompletion(w io.Writer, entries []*yang.Entry) {
+	nodes := []*yang.CompletionNode{}
+	for _, e := range entries {
+		nodes = append(nodes, e.CompletionTree(completionConfigOnly)...)
+	}
+	b, err := json.Marshal(nodes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop(1)
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var (
+	constantsPackage = "constants"
+	constantsProto   bool
+)
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "constants",
+		f:     doConstants,
+		help:  "Go or protobuf constants for the identities and enumerations",
+		flags: flags,
+	})
+	flags.StringVarLong(&constantsPackage, "constants_package", 0, "package of the generated constants", "PACKAGE")
+	flags.BoolVarLong(&constantsProto, "constants_proto", 0, "generate protobuf enums rather than Go")
+}
+
+// A constEnum is a set of constants generated for an enumeration, or for the
+// identities derived from an identity.
+//
+// The name of the constants generated for an enumeration typedef is the
+// typedef name qualified by its module, e.g., Module_Typedef.  The name of
+// those for an enumeration that is not a typedef is the schema path of its
+// leaf, e.g., Module_Container_Leaf, with an Enum suffix when it is a member
+// of a union.  The name of those for the identities derived from an identity
+// is the identity qualified by its module.  Names are converted to camel case
+// as by yang.CamelCase.
+//
+// The name of each constant is the name of the constEnum followed by the
+// upper case name of the enum or identity, with each character that is not a
+// letter or a digit replaced by an underscore.  Identities with the same
+// name from different modules are further qualified by their module.
+type constEnum struct {
+	name   string
+	source string // what the constants were generated from
+	// identity is true if the values are identities, which are numbered in
+	// the order of their qualified names starting with 1.
+	identity bool
+	values   []*constValue
+}
+
+// A constValue is a single constant of a constEnum.
+type constValue struct {
+	name  string // the constant name, without the enum name
+	yang  string // the YANG enum, or module qualified identity
+	value int64
+}
+
+// doConstants writes the constants for the identities and enumerations of
+// the modules of entries to w.
+func doConstants(w io.Writer, entries []*yang.Entry) {
+	var enums []*constEnum
+	seen := map[string]bool{}
+	add := func(ce *constEnum) {
+		if ce != nil && !seen[ce.name] {
+			seen[ce.name] = true
+			enums = append(enums, ce)
+		}
+	}
+	for _, e := range entries {
+		if m, ok := e.Node.(*yang.Module); ok {
+			for _, i := range moduleIdentities(m, map[*yang.Module]bool{}) {
+				add(identityEnum(i))
+			}
+		}
+		walkEnums(e, add)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].name < enums[j].name })
+
+	var b bytes.Buffer
+	if constantsProto {
+		writeProtoEnums(&b, enums)
+		w.Write(b.Bytes())
+		return
+	}
+	writeGoConstants(&b, enums)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot format the generated constants: %v\n", err)
+		stop(1)
+	}
+	w.Write(src)
+}
+
+// moduleIdentities returns the identities defined in m and in the
+// submodules it includes.
+func moduleIdentities(m *yang.Module, seen map[*yang.Module]bool) []*yang.Identity {
+	if m == nil || seen[m] {
+		return nil
+	}
+	seen[m] = true
+	ids := append([]*yang.Identity{}, m.Identity...)
+	for _, in := range m.Include {
+		ids = append(ids, moduleIdentities(in.Module, seen)...)
+	}
+	return ids
+}
+
+// identityModule returns the name of the module defining the identity i.
+func identityModule(i *yang.Identity) string {
+	m := yang.RootNode(i)
+	if m.BelongsTo != nil {
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
+
+// identityEnum returns the constants for the identities derived from i, or
+// nil if there are none.
+func identityEnum(i *yang.Identity) *constEnum {
+	if len(i.Values) == 0 {
+		return nil
+	}
+	mod := identityModule(i)
+	ce := &constEnum{
+		name:     yang.CamelCase(mod) + "_" + yang.CamelCase(i.Name),
+		source:   fmt.Sprintf("the identities derived from %s:%s", mod, i.Name),
+		identity: true,
+	}
+	for _, v := range i.Values {
+		ce.values = append(ce.values, &constValue{
+			name: constName(v.Name),
+			yang: identityModule(v) + ":" + v.Name,
+		})
+	}
+	sort.Slice(ce.values, func(i, j int) bool { return ce.values[i].yang < ce.values[j].yang })
+
+	// Qualify the names used by more than one module.
+	count := map[string]int{}
+	for _, v := range ce.values {
+		count[v.name]++
+	}
+	for n, v := range ce.values {
+		if count[v.name] > 1 {
+			v.name = constName(strings.Replace(v.yang, ":", "_", 1))
+		}
+		v.value = int64(n + 1)
+	}
+	return ce
+}
+
+// walkEnums calls add with the constants of each enumeration used by e and
+// its descendants.
+func walkEnums(e *yang.Entry, add func(*constEnum)) {
+	if e.Type != nil {
+		var path []string
+		for p := e; p != nil; p = p.Parent {
+			path = append([]string{yang.CamelCase(p.Name)}, path...)
+		}
+		addTypeEnums(e.Type, strings.Join(path, "_"), e.Path(), add)
+	}
+	for _, c := range e.SortedDir() {
+		walkEnums(c, add)
+	}
+	if e.RPC != nil {
+		for _, io := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if io != nil {
+				walkEnums(io, add)
+			}
+		}
+	}
+}
+
+// addTypeEnums calls add with the constants of each enumeration in t, the
+// type of the leaf with the path path.  name is the camel case name of the
+// path.
+func addTypeEnums(t *yang.YangType, name, path string, add func(*constEnum)) {
+	if t.Kind == yang.Yunion {
+		n := 0
+		for _, ut := range t.Type {
+			if ut.Kind == yang.Yenum && ut.Name == "enumeration" {
+				n++
+				suffix := "_Enum"
+				if n > 1 {
+					suffix = fmt.Sprintf("_Enum%d", n)
+				}
+				addTypeEnums(ut, name+suffix, path, add)
+				continue
+			}
+			addTypeEnums(ut, name, path, add)
+		}
+		return
+	}
+	if t.Kind != yang.Yenum || t.Enum == nil {
+		return
+	}
+	ce := &constEnum{
+		name:   name,
+		source: "the enumeration of " + path,
+	}
+	if t.Name != "enumeration" && t.Base != nil {
+		if m := yang.RootNode(t.Base); m != nil {
+			mod := m.Name
+			if m.BelongsTo != nil {
+				mod = m.BelongsTo.Name
+			}
+			ce.name = yang.CamelCase(mod) + "_" + yang.CamelCase(t.Name)
+			ce.source = fmt.Sprintf("the enumeration typedef %s:%s", mod, t.Name)
+		}
+	}
+	for _, v := range t.Enum.Values() {
+		n := t.Enum.Name(v)
+		ce.values = append(ce.values, &constValue{name: constName(n), yang: n, value: v})
+	}
+	add(ce)
+}
+
+// constName returns s in upper case with each character that is not a letter
+// or a digit replaced by an underscore.
+func constName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// writeGoConstants writes the Go source of enums to w.  Each constEnum is a
+// type with a constant for each value, along with maps from the values to
+// their YANG names and back.
+func writeGoConstants(w io.Writer, enums []*constEnum) {
+	fmt.Fprintf(w, "// Code generated by goyang; DO NOT EDIT.\n\n")
+	fmt.Fprintf(w, "package %s\n", constantsPackage)
+	for _, ce := range enums {
+		fmt.Fprintf(w, "\n// %s represents %s.\n", ce.name, ce.source)
+		fmt.Fprintf(w, "type %s int64\n\n", ce.name)
+		fmt.Fprintf(w, "const (\n")
+		if ce.identity {
+			fmt.Fprintf(w, "\t// %s_UNSET is no identity.\n", ce.name)
+			fmt.Fprintf(w, "\t%s_UNSET %s = 0\n", ce.name, ce.name)
+		}
+		for _, v := range ce.values {
+			fmt.Fprintf(w, "\t// %s_%s is %s.\n", ce.name, v.name, v.yang)
+			fmt.Fprintf(w, "\t%s_%s %s = %d\n", ce.name, v.name, ce.name, v.value)
+		}
+		fmt.Fprintf(w, ")\n\n")
+		fmt.Fprintf(w, "// %s_name maps the values of %s to their YANG names.\n", ce.name, ce.name)
+		fmt.Fprintf(w, "var %s_name = map[%s]string{\n", ce.name, ce.name)
+		for _, v := range ce.values {
+			fmt.Fprintf(w, "\t%d: %q,\n", v.value, v.yang)
+		}
+		fmt.Fprintf(w, "}\n\n")
+		fmt.Fprintf(w, "// %s_value maps YANG names to the values of %s.\n", ce.name, ce.name)
+		fmt.Fprintf(w, "var %s_value = map[string]%s{\n", ce.name, ce.name)
+		for _, v := range ce.values {
+			fmt.Fprintf(w, "\t%q: %d,\n", v.yang, v.value)
+		}
+		fmt.Fprintf(w, "}\n")
+	}
+}
+
+// writeProtoEnums writes enums to w as protobuf enums.  As protobuf enum
+// values are scoped by their package, each value is prefixed by the upper
+// case name of its enum.  The first value of a protobuf enum must be 0, so
+// each enum starts with an UNSET value and the values of an enumeration are
+// numbered in order starting with 1, rather than by their YANG values.
+func writeProtoEnums(w io.Writer, enums []*constEnum) {
+	fmt.Fprintf(w, "// Code generated by goyang; DO NOT EDIT.\n\n")
+	fmt.Fprintf(w, "syntax = \"proto3\";\n\n")
+	fmt.Fprintf(w, "package %s;\n", constantsPackage)
+	for _, ce := range enums {
+		prefix := constName(ce.name)
+		fmt.Fprintf(w, "\n// %s represents %s.\n", ce.name, ce.source)
+		fmt.Fprintf(w, "enum %s {\n", ce.name)
+		fmt.Fprintf(w, "  %s_UNSET = 0;\n", prefix)
+		for n, v := range ce.values {
+			fmt.Fprintf(w, "  %s_%s = %d; // %s\n", prefix, v.name, n+1, v.yang)
+		}
+		fmt.Fprintf(w, "}\n")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var constraintsKind string
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "constraints",
+		f:     doConstraints,
+		help:  "list the must and when expressions of the schema",
+		flags: flags,
+	})
+	flags.StringVarLong(&constraintsKind, "constraints_kind", 0, "only list the must or the when expressions", "must|when")
+}
+
+// doConstraints writes the location, kind, context node, module and
+// expression of each must and when statement of the modules entries were read
+// from to w, along with the error-app-tag and error-message of must
+// statements.
+func doConstraints(w io.Writer, entries []*yang.Entry) {
+	switch constraintsKind {
+	case "", "must", "when":
+	default:
+		fmt.Fprintf(os.Stderr, "invalid --constraints_kind %q, must be must or when\n", constraintsKind)
+		stop(1)
+	}
+	if len(entries) == 0 {
+		return
+	}
+	for _, c := range entries[0].Modules().Constraints() {
+		if constraintsKind != "" && c.Kind != constraintsKind {
+			continue
+		}
+		fmt.Fprintf(w, "%s: %s %s %s: %q", yang.Source(c.Node), c.Module, c.Kind, c.Context, c.Expression)
+		if c.Error != nil {
+			if c.Error.AppTag != "" {
+				fmt.Fprintf(w, " error-app-tag=%q", c.Error.AppTag)
+			}
+			if c.Error.Message != "" {
+				fmt.Fprintf(w, " error-message=%q", c.Error.Message)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
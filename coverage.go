@@ -0,0 +1,94 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var (
+	coveragePaths string
+	coverageDepth = 2
+)
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "coverage",
+		f:     doCoverage,
+		help:  "report the schema coverage of the telemetry paths received from devices",
+		flags: flags,
+	})
+	flags.StringVarLong(&coveragePaths, "coverage_paths", 0, "file of the telemetry paths, one per line, - for standard input", "FILE")
+	flags.IntVarLong(&coverageDepth, "coverage_depth", 0, "deepest container or list to report the coverage of, 0 for all", "DEPTH")
+}
+
+// doCoverage writes the telemetry paths of --coverage_paths that are not in
+// the schema of the modules entries were read from, the deprecated and
+// obsolete nodes they reach and the coverage of each subtree of the schema
+// to w.
+func doCoverage(w io.Writer, entries []*yang.Entry) {
+	if coveragePaths == "" {
+		fmt.Fprintln(os.Stderr, "coverage: no --coverage_paths")
+		stop(1)
+	}
+	if len(entries) == 0 {
+		return
+	}
+	paths, err := readCoveragePaths(coveragePaths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "coverage: %v\n", err)
+		stop(1)
+	}
+	c := entries[0].Modules().TelemetryCoverage(paths, coverageDepth)
+	for _, p := range c.Unknown {
+		fmt.Fprintf(w, "unknown: %s\n", p)
+	}
+	for _, si := range c.Deprecated {
+		fmt.Fprintf(w, "%s: %s\n", si.Status, si.Path)
+	}
+	for _, s := range append([]*yang.SubtreeCoverage{c.Total}, c.Subtrees...) {
+		fmt.Fprintf(w, "%5.1f%% %d/%d %s\n", s.Percent(), s.Covered, s.Leaves, s.Path)
+	}
+}
+
+// readCoveragePaths returns the paths in the file name, or standard input if
+// name is "-", one per line, ignoring blank lines.
+func readCoveragePaths(name string) ([]string, error) {
+	r := io.Reader(os.Stdin)
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var paths []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if p := strings.TrimSpace(s.Text()); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, s.Err()
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var csvTabs bool
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "csv",
+		f:     doCSV,
+		help:  "list the leaves and leaf-lists of the data tree as CSV",
+		flags: flags,
+	})
+	flags.BoolVarLong(&csvTabs, "csv_tabs", 0, "separate the fields with tabs rather than commas")
+}
+
+// csvHeader names the columns written by doCSV.
+var csvHeader = []string{"path", "type", "base type", "config", "default", "units", "description", "mandatory", "keys"}
+
+// doCSV writes a header and then a row for each leaf and leaf-list of the
+// data tree of entries to w, in the order of their paths.  The keys column
+// holds the keys of the closest list containing the leaf.  Descriptions are
+// normalized to single lines.
+func doCSV(w io.Writer, entries []*yang.Entry) {
+	cw := csv.NewWriter(w)
+	if csvTabs {
+		cw.Comma = '\t'
+	}
+	cw.Write(csvHeader)
+	for _, e := range entries {
+		for _, c := range e.SortedDir() {
+			writeCSV(cw, c, "/"+e.Name+":"+c.Name, "")
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop(1)
+	}
+}
+
+// writeCSV writes the rows of e, with the data path path, and its data node
+// descendants to cw.  keys are the keys of the closest list containing e.
+func writeCSV(cw *csv.Writer, e *yang.Entry, path, keys string) {
+	switch {
+	case e.RPC != nil || e.Kind == yang.NotificationEntry:
+		return
+	case e.IsChoice() || e.IsCase():
+		path = path[:strings.LastIndex(path, "/")]
+	case e.Type != nil:
+		units, _ := e.EffectiveUnits()
+		cw.Write([]string{
+			path,
+			e.Type.Name,
+			e.Type.Kind.String(),
+			strconv.FormatBool(!e.ReadOnly()),
+			strings.Join(e.DefaultValues(), ","),
+			units,
+			e.DescriptionText().Normalized(),
+			strconv.FormatBool(e.Mandatory == yang.TSTrue),
+			keys,
+		})
+		return
+	case e.IsList():
+		keys = strings.Join(strings.Fields(e.Key), " ")
+	}
+	for _, c := range e.SortedDir() {
+		writeCSV(cw, c, path+"/"+c.Name, keys)
+	}
+}
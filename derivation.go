@@ -0,0 +1,86 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var derivationPath string
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "derivation",
+		f:     doDerivation,
+		help:  "show the groupings, uses, refines, augments and deviations that derive a node",
+		flags: flags,
+	})
+	flags.StringVarLong(&derivationPath, "derivation_path", 0, "path of the node, e.g., /module/container/leaf", "PATH")
+}
+
+// doDerivation writes the derivation of the entry at --derivation_path to w,
+// one statement per line.
+func doDerivation(w io.Writer, entries []*yang.Entry) {
+	if derivationPath == "" {
+		fmt.Fprintln(os.Stderr, "derivation: no --derivation_path")
+		stop(1)
+	}
+	path := "/" + strings.Trim(derivationPath, "/")
+	for _, e := range entries {
+		if e := findPath(e, path); e != nil {
+			for _, s := range e.Derivation() {
+				fmt.Fprintln(w, s)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "derivation: %s not found\n", derivationPath)
+	stop(1)
+}
+
+// findPath returns the entry of the tree rooted at e whose path is path, or
+// nil if there is none.
+func findPath(e *yang.Entry, path string) *yang.Entry {
+	p := e.Path()
+	switch {
+	case p == path:
+		return e
+	case !strings.HasPrefix(path, p+"/"):
+		return nil
+	}
+	for _, c := range e.Dir {
+		if e := findPath(c, path); e != nil {
+			return e
+		}
+	}
+	if e.RPC != nil {
+		for _, c := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if c == nil {
+				continue
+			}
+			if e := findPath(c, path); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func init() {
+	register(&formatter{
+		name: "deviations",
+		f:    doDeviations,
+		help: "list the deviations made by the modules",
+	})
+}
+
+// doDeviations writes one line to w for each deviate statement found in the
+// modules that entries were read from.
+func doDeviations(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	for _, d := range entries[0].Modules().Deviations() {
+		fmt.Fprintf(w, "%s: %s %s %s", d.Source, d.Module, d.Type, d.Path)
+		if len(d.Properties) > 0 {
+			fmt.Fprintf(w, " (%s)", strings.Join(d.Properties, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var explainPath string
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "explain",
+		f:     doExplain,
+		help:  "show everything known about the node given with --explain_path",
+		flags: flags,
+	})
+	flags.StringVarLong(&explainPath, "explain_path", 0, "path of the node, e.g., /module/container/leaf or /container/leaf", "PATH")
+}
+
+// explainArgs returns args, the arguments of "goyang explain PATH ...", as
+// the arguments of "goyang --format=explain --explain_path=PATH ...".
+func explainArgs(args []string) []string {
+	out := []string{args[0], "--format=explain"}
+	if len(args) > 2 {
+		out = append(out, "--explain_path="+args[2])
+		out = append(out, args[3:]...)
+	}
+	return out
+}
+
+// doExplain writes what is known about the entry at --explain_path to w: its
+// kind, source, status, config and mandatory state, type and the typedefs it
+// is derived from, defaults, units, list properties, must, when and
+// if-feature statements and the statements that derive it.  The path is
+// either a schema path starting with a module name, as written by the tree
+// format, or a data path.
+func doExplain(w io.Writer, entries []*yang.Entry) {
+	if explainPath == "" {
+		fmt.Fprintln(os.Stderr, "explain: no --explain_path")
+		stop(1)
+	}
+	path := "/" + strings.Trim(explainPath, "/")
+	for _, e := range entries {
+		if e := findPath(e, path); e != nil {
+			explain(w, e)
+			return
+		}
+	}
+	// Try the path as a data path, which does not start with the module.
+	for _, e := range entries {
+		if e := findPath(e, "/"+e.Name+path); e != nil {
+			explain(w, e)
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "explain: %s not found\n", explainPath)
+	stop(1)
+}
+
+// explain writes what is known about e to w.
+func explain(w io.Writer, e *yang.Entry) {
+	fmt.Fprintln(w, e.Path())
+	kind := e.Kind.String()
+	if e.Node != nil {
+		kind = e.Node.Kind()
+	}
+	fmt.Fprintf(w, "  kind: %s\n", kind)
+	fmt.Fprintf(w, "  data path: %s\n", e.PathString(yang.PathOpts{Qualifier: yang.ModuleNames}))
+	if ns := e.Namespace(); ns != nil {
+		fmt.Fprintf(w, "  namespace: %s\n", ns.Name)
+	}
+	fmt.Fprintf(w, "  source: %s\n", yang.Source(e.Node))
+	if s := e.Status(); s != "" {
+		fmt.Fprintf(w, "  status: %s\n", s)
+	}
+	fmt.Fprintf(w, "  config: %t\n", !e.ReadOnly())
+	if e.Mandatory == yang.TSTrue {
+		fmt.Fprintln(w, "  mandatory: true")
+	}
+	if e.IsPresenceContainer() {
+		fmt.Fprintln(w, "  presence: true")
+	}
+	if e.Description != "" {
+		fmt.Fprintf(w, "  description: %q\n", e.Description)
+	}
+
+	if e.Type != nil {
+		fmt.Fprint(w, "  type: ")
+		printType(w, e.Type, false)
+		for _, td := range e.Type.TypedefChain() {
+			fmt.Fprintf(w, "    derived from typedef %s: %s\n", td.Name, yang.Source(td))
+		}
+		if t := e.LeafrefTarget(); t != nil {
+			fmt.Fprintf(w, "    leafref target: %s\n", t.Path())
+		}
+	}
+	if values, src := e.DefaultSource(); len(values) > 0 {
+		fmt.Fprintf(w, "  default: %q from %s\n", values, valueSource(src))
+	}
+	if units, src := e.EffectiveUnits(); units != "" {
+		fmt.Fprintf(w, "  units: %s from %s\n", units, valueSource(src))
+	}
+
+	if e.ListAttr != nil {
+		if keys := e.KeyNames(); len(keys) > 0 {
+			fmt.Fprintf(w, "  keys: %s\n", strings.Join(keys, " "))
+		}
+		if e.ListAttr.MinElements > 0 {
+			fmt.Fprintf(w, "  min-elements: %d\n", e.ListAttr.MinElements)
+		}
+		if e.ListAttr.MaxElements != 0 && e.ListAttr.MaxElements != ^uint64(0) {
+			fmt.Fprintf(w, "  max-elements: %d\n", e.ListAttr.MaxElements)
+		}
+		if e.ListAttr.OrderedByUser {
+			fmt.Fprintln(w, "  ordered-by: user")
+		}
+	}
+
+	for _, m := range e.Musts() {
+		fmt.Fprintf(w, "  must %q: %s\n", m.Name, yang.Source(m))
+		if ce := m.ConstraintError(); ce != nil {
+			if ce.AppTag != "" {
+				fmt.Fprintf(w, "    error-app-tag: %s\n", ce.AppTag)
+			}
+			if ce.Message != "" {
+				fmt.Fprintf(w, "    error-message: %q\n", ce.Message)
+			}
+		}
+	}
+	for _, x := range e.Extra["when"] {
+		if v, ok := x.(*yang.Value); ok {
+			on := ""
+			if v.Parent != nil && v.Parent.Kind() != kind {
+				on = " of " + v.Parent.Kind() + " " + v.Parent.NName()
+			}
+			fmt.Fprintf(w, "  when %q%s: %s\n", v.Name, on, yang.Source(v))
+		}
+	}
+	for _, x := range e.Extra["if-feature"] {
+		if v, ok := x.(*yang.Value); ok {
+			fmt.Fprintf(w, "  if-feature %q: %s\n", v.Name, yang.Source(v))
+		}
+	}
+
+	if steps := e.Derivation(); len(steps) > 0 {
+		fmt.Fprintln(w, "  derivation:")
+		for _, s := range steps {
+			fmt.Fprintf(w, "    %s\n", s)
+		}
+	}
+	if errs := e.GetErrors(); len(errs) > 0 {
+		fmt.Fprintln(w, "  errors:")
+		for _, err := range errs {
+			fmt.Fprintf(w, "    %v\n", err)
+		}
+	}
+}
+
+// valueSource returns a description of where a default or units came from.
+func valueSource(src yang.ValueSource) string {
+	if src.Level == yang.TypedefValue && src.Typedef != nil {
+		return fmt.Sprintf("typedef %s (%s)", src.Typedef.Name, yang.Source(src.Typedef))
+	}
+	return src.Level.String()
+}
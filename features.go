@@ -0,0 +1,66 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func init() {
+	register(&formatter{
+		name: "features",
+		f:    doFeatures,
+		help: "list the features of each module, their if-feature dependencies and circular dependencies",
+	})
+}
+
+// doFeatures writes the features of the modules entries were read from to
+// w, grouped by module, each with its if-feature statements, the references
+// that are not features and the features that must also be enabled to
+// enable it.  The circular feature dependencies follow.
+func doFeatures(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	g := entries[0].Modules().FeatureGraph()
+	module := ""
+	for _, f := range g.Features {
+		if f.Module != module {
+			module = f.Module
+			fmt.Fprintf(w, "module %s\n", module)
+		}
+		fmt.Fprintf(w, "  feature %s\n", f.Name)
+		for _, e := range f.IfFeature {
+			fmt.Fprintf(w, "    if-feature %q\n", e)
+		}
+		for _, u := range f.Unknown {
+			fmt.Fprintf(w, "    unknown feature %s\n", u)
+		}
+		if all := f.AllRequired(); len(all) > 0 {
+			var names []string
+			for _, r := range all {
+				names = append(names, r.String())
+			}
+			fmt.Fprintf(w, "    enable %s\n", strings.Join(names, " "))
+		}
+	}
+	for _, c := range g.Cycles {
+		fmt.Fprintf(w, "%s: circular feature dependency: %s\n", yang.Source(c[0].Feature), c)
+	}
+}
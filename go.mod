@@ -1,10 +1,24 @@
 module github.com/openconfig/goyang
 
-go 1.14
+// Go 1.18 is the oldest version with type parameters, used by the
+// AnnotationKey[T] and AnnotationValue[T] of pkg/yang, with strings.Cut, used
+// by the goyang program and pkg/yangdata, and with net/netip, used by the
+// well-known types of pkg/yangdata.
+go 1.18
 
 require (
 	github.com/google/go-cmp v0.6.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/openconfig/gnmi v0.10.0
 	github.com/pborman/getopt v1.1.0
+	google.golang.org/protobuf v1.28.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.2 // indirect
+	golang.org/x/net v0.0.0-20210805182204-aaa1db679c0d // indirect
+	golang.org/x/sys v0.0.0-20210809222454-d867a43fc93e // indirect
+	golang.org/x/text v0.3.7 // indirect
+	google.golang.org/genproto v0.0.0-20210811021853-ddbe55d93216 // indirect
+	google.golang.org/grpc v1.40.0 // indirect
 )
@@ -0,0 +1,89 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var (
+	grepKeyword   string
+	grepArgument  string
+	grepExtension string
+	grepChild     string
+)
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "grep",
+		f:     doGrep,
+		help:  "search the statements of the modules",
+		flags: flags,
+	})
+	flags.StringVarLong(&grepKeyword, "grep_keyword", 0, "match statements with keyword KEYWORD", "KEYWORD")
+	flags.StringVarLong(&grepArgument, "grep_argument", 0, "match statements with an argument matching REGEX", "REGEX")
+	flags.StringVarLong(&grepExtension, "grep_extension", 0, "match uses of the extension [MODULE:]NAME", "NAME")
+	flags.StringVarLong(&grepChild, "grep_child", 0, "match statements with a substatement KEYWORD, with an argument matching REGEX", "KEYWORD[=REGEX]")
+}
+
+// doGrep writes the location, keyword and argument of each statement of the
+// modules entries were read from that matches the grep flags to w.
+func doGrep(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	q := yang.StatementQuery{
+		Keyword:   grepKeyword,
+		Argument:  grepRegexp("grep_argument", grepArgument),
+		Extension: grepExtension,
+	}
+	if grepChild != "" {
+		keyword, arg, _ := strings.Cut(grepChild, "=")
+		q.Child = &yang.StatementQuery{
+			Keyword:  keyword,
+			Argument: grepRegexp("grep_child", arg),
+		}
+	}
+	for _, m := range entries[0].Modules().FindStatements(q) {
+		s := m.Statement
+		if s.HasArgument {
+			fmt.Fprintf(w, "%s: %s %s\n", s.Location(), s.Keyword, s.Argument)
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", s.Location(), s.Keyword)
+		}
+	}
+}
+
+// grepRegexp returns the regular expression expr given to the flag name, or
+// nil if expr is empty.
+func grepRegexp(name, expr string) *regexp.Regexp {
+	if expr == "" {
+		return nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --%s: %v\n", name, err)
+		stop(1)
+	}
+	return re
+}
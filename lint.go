@@ -0,0 +1,55 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var lintOptions yang.LintOptions
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "lint",
+		f:     doLint,
+		help:  "check the schema against size and documentation thresholds",
+		flags: flags,
+	})
+	flags.IntVarLong(&lintOptions.MaxDepth, "lint_max_depth", 0, "deepest a data node may be nested", "DEPTH")
+	flags.IntVarLong(&lintOptions.MaxChildren, "lint_max_children", 0, "most child data nodes a node may have", "N")
+	flags.IntVarLong(&lintOptions.MaxEnums, "lint_max_enums", 0, "most enums an enumeration may have", "N")
+	flags.IntVarLong(&lintOptions.MinDescription, "lint_min_description", 0, "fewest characters a description may have, 1 requires descriptions", "N")
+}
+
+// doLint writes the nodes of the modules entries were read from that do not
+// meet the thresholds of lintOptions to w, and exits with a status of 1 if
+// there are any.
+func doLint(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	errs := entries[0].Modules().Lint(lintOptions)
+	for _, err := range errs {
+		fmt.Fprintln(w, errorString(err))
+	}
+	if len(errs) > 0 {
+		stop(1)
+	}
+}
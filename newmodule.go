@@ -0,0 +1,291 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+// A moduleSkeleton describes the module written by the new-module command.
+// It is read from the --config file, and then the flags, and is the data of
+// the template.
+type moduleSkeleton struct {
+	Name                string         `json:"name"`
+	Prefix              string         `json:"prefix"`
+	Namespace           string         `json:"namespace"`
+	YangVersion         string         `json:"yang-version"`
+	Organization        string         `json:"organization"`
+	Contact             string         `json:"contact"`
+	Description         string         `json:"description"`
+	Revision            string         `json:"revision"`
+	RevisionDescription string         `json:"revision-description"`
+	Imports             []moduleImport `json:"imports"`
+	// ConfigState adds a container named after the module with config and
+	// state containers using groupings of its config and state leaves, as
+	// OpenConfig modules do.
+	ConfigState bool `json:"config-state"`
+}
+
+// A moduleImport is a module imported by a moduleSkeleton.
+type moduleImport struct {
+	Module string `json:"module"`
+	Prefix string `json:"prefix"`
+}
+
+// standardImports are the prefixes of the modules that may be imported by
+// name alone with --imports.
+var standardImports = map[string]string{
+	"ietf-inet-types":       "inet",
+	"ietf-yang-types":       "yang",
+	"ietf-interfaces":       "if",
+	"openconfig-extensions": "oc-ext",
+	"openconfig-types":      "oc-types",
+}
+
+var skeletonIdentifierRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-]*$`)
+
+// defaultSkeletonTemplate is the template of the module written by
+// new-module when --template is not given.
+const defaultSkeletonTemplate = `module {{.Name}} {
+  yang-version {{.YangVersion}};
+  namespace {{quote .Namespace}};
+  prefix {{.Prefix}};
+{{- if .Imports}}
+{{range .Imports}}
+  import {{.Module}} { prefix {{.Prefix}}; }
+{{- end}}
+{{- end}}
+{{if .Organization}}
+  organization {{quote .Organization}};
+{{- end}}
+{{- if .Contact}}
+  contact {{quote .Contact}};
+{{- end}}
+  description {{quote .Description}};
+
+  revision {{.Revision}} {
+    description {{quote .RevisionDescription}};
+  }
+{{- if .ConfigState}}
+
+  grouping {{.Name}}-config {
+    description "Configuration data of {{.Name}}.";
+  }
+
+  grouping {{.Name}}-state {
+    description "Operational state data of {{.Name}}.";
+  }
+
+  grouping {{.Name}}-top {
+    description "Top-level grouping of {{.Name}}.";
+
+    container {{.Name}} {
+      description "Top-level container of {{.Name}}.";
+
+      container config {
+        description "Configuration data of {{.Name}}.";
+        uses {{.Name}}-config;
+      }
+
+      container state {
+        config false;
+        description "Operational state data of {{.Name}}.";
+        uses {{.Name}}-config;
+        uses {{.Name}}-state;
+      }
+    }
+  }
+
+  uses {{.Name}}-top;
+{{- end}}
+}
+`
+
+// skeletonFuncs are the functions available to new-module templates, in
+// addition to the standard ones.  A template enforces a convention by calling
+// fail, e.g.:
+//
+//	{{if not (hasPrefix .Name "acme-")}}{{fail "names must start with acme-"}}{{end}}
+var skeletonFuncs = template.FuncMap{
+	"quote":     yangQuote,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"fail":      func(msg string) (string, error) { return "", errors.New(msg) },
+}
+
+// yangQuote returns s as a double quoted YANG string.
+func yangQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(s) + `"`
+}
+
+// newModule runs the new-module command with the arguments args, which
+// writes a skeleton module to standard output.
+func newModule(args []string) {
+	var sk moduleSkeleton
+	var config, tmplFile string
+	var imports []string
+	flags := getopt.New()
+	flags.SetProgram("goyang new-module")
+	flags.SetParameters("")
+	flags.StringVarLong(&config, "config", 0, "JSON file of the module's settings, which the other flags override", "FILE")
+	flags.StringVarLong(&tmplFile, "template", 0, "Go template the module is written with", "FILE")
+	flags.StringVarLong(&sk.Name, "name", 0, "name of the module", "NAME")
+	flags.StringVarLong(&sk.Prefix, "prefix", 0, "prefix of the module, defaults to its name", "PREFIX")
+	flags.StringVarLong(&sk.Namespace, "namespace", 0, "namespace of the module, defaults to urn:NAME", "URI")
+	flags.StringVarLong(&sk.YangVersion, "yang-version", 0, "YANG version of the module, defaults to 1.1", "VERSION")
+	flags.StringVarLong(&sk.Organization, "organization", 0, "organization of the module", "TEXT")
+	flags.StringVarLong(&sk.Contact, "contact", 0, "contact of the module", "TEXT")
+	flags.StringVarLong(&sk.Description, "description", 0, "description of the module", "TEXT")
+	flags.StringVarLong(&sk.Revision, "revision", 0, "date of the revision of the module, defaults to today", "YYYY-MM-DD")
+	flags.ListVarLong(&imports, "imports", 0, "comma separated list of modules to import, with their prefixes unless standard", "MODULE[:PREFIX][,...]")
+	flags.BoolVarLong(&sk.ConfigState, "config-state", 0, "add a top-level container with config and state containers")
+	help := flags.BoolLong("help", 'h', "display help")
+	if err := flags.Getopt(append([]string{"goyang new-module"}, args...), nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flags.PrintUsage(os.Stderr)
+		stop(1)
+	}
+	if *help {
+		flags.PrintUsage(os.Stderr)
+		stop(0)
+	}
+
+	// The flags are applied again over the config file.
+	if config != "" {
+		data, err := ioutil.ReadFile(config)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+		var fromFile moduleSkeleton
+		if err := json.Unmarshal(data, &fromFile); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", config, err)
+			stop(1)
+		}
+		sk = mergeSkeleton(fromFile, sk)
+	}
+	for _, imp := range imports {
+		sk.Imports = append(sk.Imports, moduleImport{Module: imp})
+	}
+
+	tmpl := defaultSkeletonTemplate
+	if tmplFile != "" {
+		data, err := ioutil.ReadFile(tmplFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+		tmpl = string(data)
+	}
+	out, err := writeSkeleton(sk, tmpl, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errorString(err))
+		stop(1)
+	}
+	os.Stdout.WriteString(out)
+}
+
+// mergeSkeleton returns base with the fields set in over replacing its own.
+func mergeSkeleton(base, over moduleSkeleton) moduleSkeleton {
+	set := func(b *string, o string) {
+		if o != "" {
+			*b = o
+		}
+	}
+	set(&base.Name, over.Name)
+	set(&base.Prefix, over.Prefix)
+	set(&base.Namespace, over.Namespace)
+	set(&base.YangVersion, over.YangVersion)
+	set(&base.Organization, over.Organization)
+	set(&base.Contact, over.Contact)
+	set(&base.Description, over.Description)
+	set(&base.Revision, over.Revision)
+	set(&base.RevisionDescription, over.RevisionDescription)
+	base.Imports = append(base.Imports, over.Imports...)
+	base.ConfigState = base.ConfigState || over.ConfigState
+	return base
+}
+
+// writeSkeleton returns the module described by sk, written with the template
+// tmpl, with its defaults filled in as of now.  An error is returned if sk is
+// not valid, the template fails, or the module written cannot be parsed.
+func writeSkeleton(sk moduleSkeleton, tmpl string, now time.Time) (string, error) {
+	if sk.Name == "" {
+		return "", errors.New("no module name, use --name")
+	}
+	if sk.Prefix == "" {
+		sk.Prefix = sk.Name
+	}
+	if sk.Namespace == "" {
+		sk.Namespace = "urn:" + sk.Name
+	}
+	if sk.YangVersion == "" {
+		sk.YangVersion = "1.1"
+	}
+	if sk.Revision == "" {
+		sk.Revision = now.Format("2006-01-02")
+	}
+	if sk.RevisionDescription == "" {
+		sk.RevisionDescription = "Initial revision."
+	}
+	if sk.Description == "" {
+		sk.Description = "The " + sk.Name + " module."
+	}
+	for i, imp := range sk.Imports {
+		if m, p, ok := strings.Cut(imp.Module, ":"); ok && imp.Prefix == "" {
+			imp.Module, imp.Prefix = m, p
+		}
+		if imp.Prefix == "" {
+			imp.Prefix = standardImports[imp.Module]
+		}
+		if imp.Prefix == "" {
+			return "", fmt.Errorf("no prefix for import of %s, use %s:PREFIX", imp.Module, imp.Module)
+		}
+		sk.Imports[i] = imp
+	}
+	for _, id := range []string{sk.Name, sk.Prefix} {
+		if !skeletonIdentifierRE.MatchString(id) {
+			return "", fmt.Errorf("%q is not a valid identifier", id)
+		}
+	}
+	if _, err := time.Parse("2006-01-02", sk.Revision); err != nil {
+		return "", fmt.Errorf("revision %q is not a date", sk.Revision)
+	}
+
+	t, err := template.New("module").Funcs(skeletonFuncs).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, sk); err != nil {
+		return "", err
+	}
+	if err := yang.NewModules().Parse(b.String(), sk.Name+".yang"); err != nil {
+		return "", fmt.Errorf("template does not write a valid module: %v", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format is the registry of the output formats of the goyang
+// command.  A program that registers its own formatters, and then runs the
+// command's main, or one like it, adds formats to it without changing goyang.
+// The Template formatter writes the output of a text/template, for formats
+// that need no Go code at all.
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+// A Formatter writes the entry trees of the modules read by goyang in a
+// format.
+type Formatter struct {
+	// Name is the name of the format, as given to --format.
+	Name string
+	// Help is a one line description of the format.
+	Help string
+	// Flags are the flags of the format, if any, which are only accepted
+	// after --format selects it.  By convention each is named
+	// --NAME_FLAG.
+	Flags *getopt.Set
+	// Format writes entries, the module entries of the modules read, to w.
+	Format func(w io.Writer, entries []*yang.Entry) error
+}
+
+var (
+	mu         sync.Mutex
+	formatters = map[string]*Formatter{}
+)
+
+// Register adds f to the registry.  It panics if a formatter with the same
+// name is already registered.
+func Register(f *Formatter) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := formatters[f.Name]; ok {
+		panic(fmt.Sprintf("format: formatter %s registered twice", f.Name))
+	}
+	formatters[f.Name] = f
+}
+
+// Lookup returns the formatter registered as name, or nil.
+func Lookup(name string) *Formatter {
+	mu.Lock()
+	defer mu.Unlock()
+	return formatters[name]
+}
+
+// Names returns the names of the registered formatters, sorted.
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
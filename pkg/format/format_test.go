@@ -0,0 +1,128 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestRegister(t *testing.T) {
+	f := &Formatter{
+		Name:   "test-register",
+		Format: func(io.Writer, []*yang.Entry) error { return nil },
+	}
+	Register(f)
+	if got := Lookup("test-register"); got != f {
+		t.Errorf("Lookup returned %v, want %v", got, f)
+	}
+	if got := Lookup("test-missing"); got != nil {
+		t.Errorf("Lookup of a missing formatter returned %v", got)
+	}
+	found := false
+	for _, n := range Names() {
+		found = found || n == "test-register"
+	}
+	if !found {
+		t.Errorf("Names() = %v, missing test-register", Names())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering twice did not panic")
+		}
+	}()
+	Register(f)
+}
+
+func testEntries(t *testing.T) []*yang.Entry {
+	t.Helper()
+	ms := yang.NewModules()
+	if err := ms.Parse(`module m {
+  namespace "urn:m";
+  prefix "m";
+  container c {
+    description "The
+      container.";
+    leaf b { type string; }
+    choice ch {
+      leaf a { type int8; }
+    }
+  }
+}`, "m.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return []*yang.Entry{yang.ToEntry(ms.Modules["m"])}
+}
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		want    string
+		wantErr string
+	}{{
+		desc: "outline",
+		in: `{{range .Entries}}{{.Name}}
+{{range descendants .}}{{repeat "  " (depth .)}}{{.Name}} {{kind .}}{{with type .}} {{.}}{{end}}
+{{end}}{{end}}`,
+		want: `m
+  c container
+    b leaf string
+    ch choice
+      a case
+        a leaf int8
+`,
+	}, {
+		desc: "entry functions",
+		in:   `{{range .Entries}}{{range children .}}{{path .}}: {{upper (description .)}}{{end}}{{end}} {{len .Modules.Modules}}`,
+		want: `/m/c: THE CONTAINER. 1`,
+	}, {
+		desc:    "parse error",
+		in:      `{{range}}`,
+		wantErr: "missing value for range",
+	}, {
+		desc:    "execute error",
+		in:      `{{.Missing}}`,
+		wantErr: "can't evaluate field Missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var b strings.Builder
+			f, err := Template("test", "", tt.in)
+			if err == nil {
+				err = f.Format(&b, testEntries(t))
+			}
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("got no error, want %q", tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("Template (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
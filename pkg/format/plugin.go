@@ -0,0 +1,151 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+// PluginPrefix is the prefix of the name of the executable of a plugin, the
+// plugin for the format NAME is the executable goyang-format-NAME.
+const PluginPrefix = "goyang-format-"
+
+// A plugin is a program that formats the entries it is sent.  goyang runs the
+// plugin with no arguments, writes a PluginRequest as JSON to its standard
+// input, closes it, and reads a PluginResponse as JSON from its standard
+// output.  The standard error of the plugin is that of goyang.  A plugin
+// reports an error in the Error of its response rather than by its exit
+// status, which is only used to detect plugins that fail to respond.
+
+// A PluginRequest is the request sent to a plugin.
+type PluginRequest struct {
+	// Format is the name of the format.
+	Format string `json:"format"`
+	// Parameter is the value of the --NAME_param flag, which the plugin
+	// may interpret as it pleases.
+	Parameter string `json:"parameter,omitempty"`
+	// Modules are the module entries of the modules read, as encoded by
+	// encoding/json.
+	Modules []*yang.Entry `json:"modules"`
+}
+
+// A PluginResponse is the response of a plugin.
+type PluginResponse struct {
+	// Output is written to standard output.
+	Output string `json:"output,omitempty"`
+	// Files are written to the --NAME_out directory.
+	Files []PluginFile `json:"files,omitempty"`
+	// Error, if not empty, reports that the plugin failed.  Nothing is
+	// written when Error is set.
+	Error string `json:"error,omitempty"`
+}
+
+// A PluginFile is a file written by a plugin.
+type PluginFile struct {
+	// Name is the slash separated path of the file relative to the
+	// output directory.  It may not be absolute or contain "..".
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// FindPlugin returns the formatter of the plugin for the format name, the
+// executable goyang-format-NAME found in the directories of $PATH.
+func FindPlugin(name string) (*Formatter, error) {
+	path, err := exec.LookPath(PluginPrefix + name)
+	if err != nil {
+		return nil, err
+	}
+	return Plugin(name, path), nil
+}
+
+// Plugin returns a formatter for the format name that runs the plugin at
+// path.  The formatter has the flags --NAME_param, the parameter passed to
+// the plugin, and --NAME_out, the directory the files of the plugin are
+// written to.
+func Plugin(name, path string) *Formatter {
+	var param string
+	out := "."
+	flags := getopt.New()
+	flags.StringVarLong(&param, name+"_param", 0, "parameter passed to the plugin", "PARAM")
+	flags.StringVarLong(&out, name+"_out", 0, "directory the files of the plugin are written to", "DIR")
+	return &Formatter{
+		Name:  name,
+		Help:  "run the plugin " + path,
+		Flags: flags,
+		Format: func(w io.Writer, entries []*yang.Entry) error {
+			resp, err := runPlugin(path, &PluginRequest{Format: name, Parameter: param, Modules: entries})
+			if err != nil {
+				return fmt.Errorf("plugin %s: %v", name, err)
+			}
+			return resp.write(w, out)
+		},
+	}
+}
+
+// runPlugin returns the response of the plugin at path to req.
+func runPlugin(path string, req *PluginRequest) (*PluginResponse, error) {
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+	var resp PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %v", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+	return &resp, nil
+}
+
+// write writes the output of r to w and its files to the directory dir.  No
+// file is written if any of their names is not valid.
+func (r *PluginResponse) write(w io.Writer, dir string) error {
+	for _, f := range r.Files {
+		if f.Name == "" || strings.HasPrefix(f.Name, "/") || strings.Contains("/"+f.Name+"/", "/../") {
+			return fmt.Errorf("plugin file name %q is not a relative path", f.Name)
+		}
+	}
+	for _, f := range r.Files {
+		path := filepath.Join(dir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, r.Output)
+	return err
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// pluginEnv, when set, makes the test binary act as a plugin with the
+// behavior its value names.
+const pluginEnv = "GOYANG_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(pluginEnv); mode != "" {
+		testPlugin(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testPlugin responds to the request on standard input as the plugin mode.
+func testPlugin(mode string) {
+	var req PluginRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		os.Exit(2)
+	}
+	var resp PluginResponse
+	switch mode {
+	case "ok":
+		var names []string
+		for _, e := range req.Modules {
+			for _, c := range e.SortedDir() {
+				names = append(names, c.Name)
+			}
+		}
+		resp.Output = req.Format + " " + req.Parameter + ": " + strings.Join(names, ",") + "\n"
+		resp.Files = []PluginFile{{Name: "sub/out.txt", Content: "file"}}
+	case "error":
+		resp.Error = "cannot format"
+	case "escape":
+		resp.Files = []PluginFile{{Name: "../out.txt", Content: "file"}}
+	case "garbage":
+		os.Stdout.WriteString("not json")
+		return
+	case "exit":
+		os.Exit(3)
+	}
+	json.NewEncoder(os.Stdout).Encode(&resp)
+}
+
+func TestPlugin(t *testing.T) {
+	tests := []struct {
+		mode      string
+		want      string
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{mode: "ok", want: "test p: c\n", wantFiles: map[string]string{"sub/out.txt": "file"}},
+		{mode: "error", wantErr: "plugin test: cannot format"},
+		{mode: "escape", wantErr: "not a relative path"},
+		{mode: "garbage", wantErr: "invalid response"},
+		{mode: "exit", wantErr: "exit status 3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Setenv(pluginEnv, tt.mode)
+			dir := t.TempDir()
+			f := Plugin("test", os.Args[0])
+			if err := f.Flags.Getopt([]string{"goyang", "--test_param=p", "--test_out=" + dir}, nil); err != nil {
+				t.Fatal(err)
+			}
+			var b strings.Builder
+			err := f.Format(&b, testEntries(t))
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("got no error, want %q", tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("output (-want, +got):\n%s", diff)
+			}
+			for name, want := range tt.wantFiles {
+				got, err := ioutil.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != want {
+					t.Errorf("%s: got %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindPlugin(t *testing.T) {
+	if _, err := FindPlugin("no-such-format"); err == nil {
+		t.Errorf("FindPlugin of a missing plugin did not fail")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/openconfig/goyang/pkg/indent"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// TemplateData is the data a template of Template is executed with.
+type TemplateData struct {
+	// Entries are the module entries of the modules read.
+	Entries []*yang.Entry
+	// Modules are the modules the entries were built from, or nil if
+	// there are no entries.
+	Modules *yang.Modules
+}
+
+// TemplateFuncs are the functions available to the templates of Template, in
+// addition to the standard ones:
+//
+//	children ENTRY       the children of ENTRY, sorted by name
+//	descendants ENTRY    the descendants of ENTRY, depth first
+//	depth ENTRY          the number of ancestors of ENTRY, 0 for a module
+//	path ENTRY           the schema path of ENTRY
+//	kind ENTRY           the kind of node of ENTRY, e.g., "container"
+//	type ENTRY           the name of the type of a leaf or leaf-list, or ""
+//	description ENTRY    the description of ENTRY with whitespace normalized
+//	indent PREFIX TEXT   TEXT with each line prefixed by PREFIX
+//	repeat TEXT N        TEXT repeated N times
+//	join LIST SEP        the strings of LIST separated by SEP
+//	lower TEXT, upper TEXT
+//	replace TEXT OLD NEW TEXT with each OLD replaced by NEW
+var TemplateFuncs = template.FuncMap{
+	"children": func(e *yang.Entry) []*yang.Entry { return e.SortedDir() },
+	"descendants": func(e *yang.Entry) []*yang.Entry {
+		var all []*yang.Entry
+		var walk func(*yang.Entry)
+		walk = func(e *yang.Entry) {
+			for _, c := range e.SortedDir() {
+				all = append(all, c)
+				walk(c)
+			}
+		}
+		walk(e)
+		return all
+	},
+	"depth": func(e *yang.Entry) int {
+		d := 0
+		for ; e.Parent != nil; e = e.Parent {
+			d++
+		}
+		return d
+	},
+	"path": func(e *yang.Entry) string { return e.Path() },
+	"kind": func(e *yang.Entry) string {
+		if e.Node == nil {
+			return ""
+		}
+		return e.Node.Kind()
+	},
+	"type": func(e *yang.Entry) string {
+		if e.Type == nil {
+			return ""
+		}
+		return e.Type.Name
+	},
+	"description": func(e *yang.Entry) string { return e.DescriptionText().Normalized() },
+	"indent":      indent.String,
+	"repeat":      strings.Repeat,
+	"join":        strings.Join,
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"replace":     func(s, old, new string) string { return strings.ReplaceAll(s, old, new) },
+}
+
+// Template returns a formatter called name that writes the output of the
+// text/template text, executed with a TemplateData and with the functions of
+// TemplateFuncs.  An error is returned if text cannot be parsed.
+func Template(name, help, text string) (*Formatter, error) {
+	t, err := template.New(name).Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Formatter{
+		Name: name,
+		Help: help,
+		Format: func(w io.Writer, entries []*yang.Entry) error {
+			data := TemplateData{Entries: entries}
+			if len(entries) > 0 {
+				data.Modules = entries[0].Modules()
+			}
+			return t.Execute(w, data)
+		},
+	}, nil
+}
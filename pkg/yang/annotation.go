@@ -0,0 +1,145 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// looseAnnotationMu protects the Annotation maps of the entries that are not
+// part of a Modules, such as those built directly by the caller.
+var looseAnnotationMu sync.RWMutex
+
+// annotationMu returns the lock protecting the Annotation map of e when
+// accessed through the annotation methods, that of the Modules e is part of.
+func (e *Entry) annotationMu() *sync.RWMutex {
+	if m, ok := e.Root().Node.(*Module); ok && m.Modules != nil {
+		return &m.Modules.annotationMu
+	}
+	return &looseAnnotationMu
+}
+
+// SetAnnotation sets the annotation key of e to value.  Keys should be
+// namespaced by the tool setting them, e.g., "ygot/name", as the annotations
+// of an Entry are shared by all tools.  SetAnnotation, like the other
+// annotation methods, is safe for concurrent use, but direct access to the
+// Annotation map is not.
+func (e *Entry) SetAnnotation(key string, value interface{}) {
+	mu := e.annotationMu()
+	mu.Lock()
+	defer mu.Unlock()
+	if e.Annotation == nil {
+		e.Annotation = map[string]interface{}{}
+	}
+	e.Annotation[key] = value
+}
+
+// GetAnnotation returns the annotation key of e and whether it is set.
+func (e *Entry) GetAnnotation(key string) (interface{}, bool) {
+	mu := e.annotationMu()
+	mu.RLock()
+	defer mu.RUnlock()
+	v, ok := e.Annotation[key]
+	return v, ok
+}
+
+// DeleteAnnotation removes the annotation key from e.
+func (e *Entry) DeleteAnnotation(key string) {
+	mu := e.annotationMu()
+	mu.Lock()
+	defer mu.Unlock()
+	delete(e.Annotation, key)
+}
+
+// An AnnotationKey is the key of an annotation whose values are of type T.
+// For example:
+//
+//	var goName = yang.NewAnnotationKey[string]("ygot", "name")
+//
+//	goName.Set(e, "Interface")
+//	name, ok := goName.Get(e)
+type AnnotationKey[T any] struct {
+	key string
+}
+
+// NewAnnotationKey returns the key of the annotation name of the tool
+// namespace.  The annotation is stored in Entry.Annotation as
+// "namespace/name".
+func NewAnnotationKey[T any](namespace, name string) AnnotationKey[T] {
+	return AnnotationKey[T]{key: namespace + "/" + name}
+}
+
+// String returns the key the annotation is stored under.
+func (k AnnotationKey[T]) String() string { return k.key }
+
+// Set sets the annotation k of e to v.
+func (k AnnotationKey[T]) Set(e *Entry, v T) {
+	e.SetAnnotation(k.key, v)
+}
+
+// Get returns the annotation k of e, and whether it is set with a value of
+// type T.  An annotation decoded from JSON, such as a struct decoded as a
+// map[string]interface{}, is converted to T through JSON.
+func (k AnnotationKey[T]) Get(e *Entry) (T, bool) {
+	return AnnotationValue[T](e, k.key)
+}
+
+// Delete removes the annotation k from e.
+func (k AnnotationKey[T]) Delete(e *Entry) {
+	e.DeleteAnnotation(k.key)
+}
+
+// AnnotationValue returns the annotation key of e, and whether it is set
+// with a value of type T.  An annotation decoded from JSON, such as a struct
+// decoded as a map[string]interface{}, is converted to T through JSON.
+func AnnotationValue[T any](e *Entry, key string) (T, bool) {
+	var t T
+	v, ok := e.GetAnnotation(key)
+	if !ok {
+		return t, false
+	}
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, float64, string, bool, nil:
+		// The value may have been decoded from JSON.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return t, false
+		}
+		if err := json.Unmarshal(data, &t); err != nil {
+			return t, false
+		}
+		return t, true
+	}
+	return t, false
+}
+
+// copyAnnotations returns a copy of the annotations of e.
+func copyAnnotations(e *Entry) map[string]interface{} {
+	mu := e.annotationMu()
+	mu.RLock()
+	defer mu.RUnlock()
+	if e.Annotation == nil {
+		return nil
+	}
+	c := make(map[string]interface{}, len(e.Annotation))
+	for k, v := range e.Annotation {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+type testAnnotation struct {
+	Name  string
+	Count int
+}
+
+func TestAnnotations(t *testing.T) {
+	var (
+		name   = NewAnnotationKey[string]("test", "name")
+		detail = NewAnnotationKey[testAnnotation]("test", "detail")
+		count  = NewAnnotationKey[int]("test", "count")
+	)
+	if got, want := name.String(), "test/name"; got != want {
+		t.Errorf("String() got %q, want %q", got, want)
+	}
+
+	e := &Entry{Name: "e"}
+	if _, ok := name.Get(e); ok {
+		t.Errorf("Get() of unset annotation got ok")
+	}
+	name.Set(e, "one")
+	detail.Set(e, testAnnotation{Name: "x", Count: 2})
+	if got, ok := name.Get(e); !ok || got != "one" {
+		t.Errorf("Get() got %q, %v, want one", got, ok)
+	}
+	if _, ok := count.Get(&Entry{Annotation: map[string]interface{}{"test/count": "not an int"}}); ok {
+		t.Errorf("Get() of annotation of another type got ok")
+	}
+
+	// Duplicates have their own annotations.
+	d := e.dup()
+	name.Set(d, "two")
+	if got, _ := name.Get(e); got != "one" {
+		t.Errorf("setting annotation of duplicate changed the original to %q", got)
+	}
+	if got, ok := detail.Get(d); !ok || got.Count != 2 {
+		t.Errorf("duplicate annotation got %+v, %v", got, ok)
+	}
+
+	// Annotations survive encoding to, and decoding from, JSON.
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Entry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := detail.Get(&decoded); !ok || got != (testAnnotation{Name: "x", Count: 2}) {
+		t.Errorf("decoded annotation got %+v, %v", got, ok)
+	}
+	if got, ok := AnnotationValue[string](&decoded, "test/name"); !ok || got != "one" {
+		t.Errorf("decoded annotation got %q, %v", got, ok)
+	}
+
+	name.Delete(e)
+	if _, ok := e.GetAnnotation("test/name"); ok {
+		t.Errorf("Delete() did not remove the annotation")
+	}
+
+	// Concurrent use is safe, as checked by the race detector.
+	var wg sync.WaitGroup
+	c := &Entry{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			count.Set(c, i)
+			count.Get(c)
+			c.dup()
+		}(i)
+	}
+	wg.Wait()
+	if _, ok := count.Get(c); !ok {
+		t.Errorf("concurrent Set() did not set annotation")
+	}
+
+	// The entries of a Modules are locked by the Modules.
+	ms := NewModules()
+	if err := ms.Parse(`module a { prefix a; namespace urn:a; leaf x { type string; } leaf y { type string; } }`, "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	a := ToEntry(ms.Modules["a"])
+	if got, want := a.Dir["x"].annotationMu(), &ms.annotationMu; got != want {
+		t.Errorf("annotationMu() is not the lock of the Modules")
+	}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			count.Set(a.Dir["x"], i)
+			count.Get(a.Dir["y"])
+			count.Set(a.Dir["y"], i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// processWithArena parses and processes src using a, returning the paths of
+// the entries of the module bench.
+func processWithArena(t testing.TB, src string, a *Arena) []string {
+	t.Helper()
+	ms := NewModules()
+	ms.ParseOptions.Arena = a
+	if err := ms.Parse(src, "bench.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	e, errs := ms.GetModule("bench")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+	var paths []string
+	var walk func(e *Entry)
+	walk = func(e *Entry) {
+		paths = append(paths, e.Path())
+		for _, c := range e.children() {
+			walk(c)
+		}
+	}
+	walk(e)
+	return paths
+}
+
+func TestArena(t *testing.T) {
+	src := benchmarkModule(10)
+	want := processWithArena(t, src, nil)
+
+	a := NewArena()
+	for i := 0; i < 2; i++ {
+		if diff := cmp.Diff(want, processWithArena(t, src, a)); diff != "" {
+			t.Errorf("pass %d: entries built with an arena differ (-want, +got):\n%s", i, diff)
+		}
+		statements, entries := a.Allocated()
+		if statements == 0 || entries == 0 {
+			t.Errorf("pass %d: Allocated() got %d statements and %d entries, want both allocated", i, statements, entries)
+		}
+		a.Release()
+		if statements, entries := a.Allocated(); statements != 0 || entries != 0 {
+			t.Errorf("pass %d: after Release, Allocated() got %d statements and %d entries, want 0", i, statements, entries)
+		}
+	}
+}
+
+func BenchmarkArena(b *testing.B) {
+	src := benchmarkModule(200)
+	for _, bb := range []struct {
+		name  string
+		arena *Arena
+	}{
+		{"heap", nil},
+		{"arena", NewArena()},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				processWithArena(b, src, bb.arena)
+				if bb.arena != nil {
+					bb.arena.Release()
+				}
+			}
+		})
+	}
+}
@@ -24,7 +24,6 @@ package yang
 // an AST.
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -39,31 +38,69 @@ func init() {
 }
 
 // A yangStatement contains all information needed to build a particular
-// type of statement into an AST node.
+// type of statement into an AST node.  It is computed once per type by
+// initTypes so that building a node needs no reflection over the struct
+// definition.
 type yangStatement struct {
-	// funcs is the map of YANG field names to the function that populates
+	// typ is the type of node built, a pointer to a struct.
+	typ reflect.Type
+	// funcs is the map of YANG field names to the field that populates
 	// the statement into the AST node.
-	funcs map[string]func(*Statement, reflect.Value, reflect.Value, *typeDictionary) error
+	funcs map[string]*yangField
+	// name, statement and parent are the indexes of the Name, Statement
+	// and Parent fields of the struct, or -1 if the struct has no such
+	// field.
+	name, statement, parent int
 	// required is a list of fields that must be present in the statement.
 	required []string
+	// requiredMask has the bits of the fields in required set.
+	requiredMask uint64
 	// sRequired maps a statement name to a list of required sub-field
 	// names. The statement name can be an alias of the primary field type.
 	//    e.g. If a field is required by statement type foo, then only foo
 	//    should have the field. If bar is an alias of foo, it must not
 	//    have this field.
 	sRequired map[string][]string
+	// typedefer is true if the node built implements Typedefer.
+	typedefer bool
 	// addext is the function to handle possible extensions.
 	addext func(*Statement, reflect.Value, reflect.Value) error
+	// more maps the name of a pointer field to the index of the slice
+	// field holding the instances of its keyword after the first.
+	more map[string]int
 }
 
-// newYangStatement creates a new yangStatement.
-func newYangStatement() *yangStatement {
+// A yangField builds a substatement into a field of an AST node.
+type yangField struct {
+	// build populates the substatement into the AST node.
+	build func(*Statement, reflect.Value, reflect.Value, *typeDictionary) error
+	// index is the index of the field in the struct.
+	index int
+	// bit is the bit recording the presence of the field in a statement,
+	// or 0 if the field is not required by any statement.
+	bit uint64
+}
+
+// newYangStatement creates a new yangStatement for the type at.
+func newYangStatement(at reflect.Type) *yangStatement {
 	return &yangStatement{
-		funcs:     make(map[string]func(*Statement, reflect.Value, reflect.Value, *typeDictionary) error),
+		typ:       at,
+		funcs:     make(map[string]*yangField),
+		name:      -1,
+		statement: -1,
+		parent:    -1,
 		sRequired: make(map[string][]string),
+		more:      make(map[string]int),
+		typedefer: at.Implements(typedeferType),
 	}
 }
 
+// has returns true if the field name is recorded in found.
+func (y *yangStatement) has(found uint64, name string) bool {
+	f := y.funcs[name]
+	return f != nil && found&f.bit != 0
+}
+
 var (
 	// The following maps are built up at init time.
 	// typeMap provides a lookup from a Node type to the corresponding
@@ -78,6 +115,8 @@ var (
 	nilValue      = reflect.ValueOf(nil)
 	// nodeType is the reflect.Type of the Node interface.
 	nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+	// typedeferType is the reflect.Type of the Typedefer interface.
+	typedeferType = reflect.TypeOf((*Typedefer)(nil)).Elem()
 )
 
 // meta is a collection of top-level statements.  There is no actual
@@ -115,70 +154,58 @@ func buildASTWithTypeDict(stmt *Statement, types *typeDictionary) (Node, error)
 // encountered typedefs within the statement are cached. The type of value
 // returned depends on the keyword in stmt (see yang.go). It returns an error
 // if it cannot build the statement into its corresponding Node type.
-func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (v reflect.Value, err error) {
-	defer func() {
-		// If we are returning a real Node then call addTypedefs
-		// if the node possibly contains typedefs.
-		// Cache these in the typedef cache for look-ups.
-		if err != nil || v == nilValue {
-			return
-		}
-		if t, ok := v.Interface().(Typedefer); ok {
-			types.addTypedefs(t)
-		}
-	}()
+func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (reflect.Value, error) {
 	keyword := stmt.Keyword
 	if k, ok := aliases[stmt.Keyword]; ok {
 		keyword = k
 	}
-	t := nameMap[keyword]
-	y := typeMap[t]
-	// Keep track of which substatements are present in the statement.
-	found := map[string]bool{}
+	y := typeMap[nameMap[keyword]]
+	if y == nil {
+		return nilValue, codeErrorf("GY0005", "%s: unexpected statement: %s", stmt.Location(), stmt.Keyword)
+	}
+	return buildStatement(y, stmt, parent, types)
+}
 
-	// Get the struct type we are pointing to.
-	t = t.Elem()
+// buildStatement builds and returns an AST node described by y from the
+// statement stmt, as build does.  Fields holding substatements call
+// buildStatement directly with the yangStatement of the field's type.
+func buildStatement(y *yangStatement, stmt *Statement, parent reflect.Value, types *typeDictionary) (reflect.Value, error) {
 	// v is a pointer to the instantiated structure we are building.
-	v = reflect.New(t)
+	v := reflect.New(y.typ.Elem())
+	e := v.Elem()
 
 	// Handle special cases that are not actually substatements:
 
-	if fn := y.funcs["Name"]; fn != nil {
+	if y.name >= 0 {
 		// Name uses stmt directly.
-		if err := fn(stmt, v, parent, types); err != nil {
-			return nilValue, err
-		}
+		e.Field(y.name).SetString(stmt.Argument)
 	}
-	if fn := y.funcs["Statement"]; fn != nil {
+	if y.statement >= 0 {
 		// Statement uses stmt directly.
-		if err := fn(stmt, v, parent, types); err != nil {
-			return nilValue, err
-		}
+		e.Field(y.statement).Set(reflect.ValueOf(stmt))
 	}
-	if fn := y.funcs["Parent"]; fn != nil {
-		// parent is the parent node, which is nilValue (reflect.ValueOf(nil)) if there is none.
-		// parent.IsValid will return false when parent is a nil interface
-		// parent.IsValid will true if parent references a concrete type
-		// (even if it is nil).
-		if parent.IsValid() {
-			if err := fn(stmt, v, parent, types); err != nil {
-				return nilValue, err
-			}
-		}
+	// parent is the parent node, which is nilValue (reflect.ValueOf(nil)) if there is none.
+	// parent.IsValid will return false when parent is a nil interface
+	// parent.IsValid will true if parent references a concrete type
+	// (even if it is nil).  Assigning through a *Node avoids reflect
+	// checking that the parent implements Node each time it is set.
+	if y.parent >= 0 && parent.IsValid() {
+		*e.Field(y.parent).Addr().Interface().(*Node) = parent.Interface().(Node)
 	}
 
-	// Now handle the substatements
-
+	// Now handle the substatements, keeping track of which required
+	// substatements are present in the statement.
+	var found uint64
 	for _, ss := range stmt.statements {
-		found[ss.Keyword] = true
-		fn := y.funcs[ss.Keyword]
+		f := y.funcs[ss.Keyword]
 		switch {
-		case fn != nil:
+		case f != nil:
 			// Normal case, the keyword is known.
-			if err := fn(ss, v, parent, types); err != nil {
+			found |= f.bit
+			if err := f.build(ss, v, parent, types); err != nil {
 				return nilValue, err
 			}
-		case len(strings.Split(ss.Keyword, ":")) == 2:
+		case strings.Count(ss.Keyword, ":") == 1:
 			// Keyword is not known but it has a prefix so it might
 			// be an extension.
 			if y.addext == nil {
@@ -186,21 +213,23 @@ func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (v refl
 			}
 			y.addext(ss, v, parent)
 		default:
-			return nilValue, fmt.Errorf("%s: unknown %s field: %s", ss.Location(), stmt.Keyword, ss.Keyword)
+			return nilValue, codeErrorf("GY0005", "%s: unknown %s field: %s", ss.Location(), stmt.Keyword, ss.Keyword)
 		}
 	}
 
 	// Make sure all of our required field are there.
-	for _, r := range y.required {
-		if !found[r] {
-			return nilValue, fmt.Errorf("%s: missing required %s field: %s", stmt.Location(), stmt.Keyword, r)
+	if found&y.requiredMask != y.requiredMask {
+		for _, r := range y.required {
+			if !y.has(found, r) {
+				return nilValue, codeErrorf("GY0006", "%s: missing required %s field: %s", stmt.Location(), stmt.Keyword, r)
+			}
 		}
 	}
 
 	// Make sure required fields based on our keyword are there (module vs submodule)
 	for _, r := range y.sRequired[stmt.Keyword] {
-		if !found[r] {
-			return nilValue, fmt.Errorf("%s: missing required %s field: %s", stmt.Location(), stmt.Keyword, r)
+		if !y.has(found, r) {
+			return nilValue, codeErrorf("GY0006", "%s: missing required %s field: %s", stmt.Location(), stmt.Keyword, r)
 		}
 	}
 
@@ -210,11 +239,17 @@ func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (v refl
 			continue
 		}
 		for _, r := range or {
-			if found[r] {
-				return nilValue, fmt.Errorf("%s: unknown %s field: %s", stmt.Location(), stmt.Keyword, r)
+			if y.has(found, r) {
+				return nilValue, codeErrorf("GY0005", "%s: unknown %s field: %s", stmt.Location(), stmt.Keyword, r)
 			}
 		}
 	}
+
+	// If the node possibly contains typedefs, cache these in the
+	// typedef cache for look-ups.
+	if y.typedefer {
+		types.addTypedefs(v.Interface().(Typedefer))
+	}
 	return v, nil
 }
 
@@ -254,24 +289,20 @@ func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (v refl
 //	var vInc = reflect.ValueOf(inc)
 //	var tInc = reflect.TypeOf(inc)
 //
-// Functions are created for each fields and named Name, Statement, Parent, Ext,
-// and revision-date.
+// A function is created for revision-date, and an extension function for Ext.
 //
 // The function built for RevisionDate will be called for any substatement,
 // ds, of stmt that has the keyword "revision-date" along with the value of
 // vInc and its parent:
 //
-//	typeMap[tInc]["revision-date"](ss, vInc, parent, types)
+//	typeMap[tInc].funcs["revision-date"].build(ss, vInc, parent, types)
 //
 // Normal fields are all processed this same way.
 //
-// The other 4 fields are special.  In the case of Name, Statement, and Parent,
-// the function is passed stmt, rather than ss, as these fields are not filled in
-// by substatements.
-//
-// The Name command must set its field to the Statement's argument.  The
-// Statement command must set its field to the Statement itself.  The
-// Parent command must set its field with the Node of its parent (the
+// The other 4 fields are special.  Name, Statement, and Parent are not filled
+// in by substatements, so only the index of their field is recorded.  The Name
+// field is set to the Statement's argument, the Statement field to the
+// Statement itself, and the Parent field to the Node of its parent (the
 // parent parameter).
 //
 // The Ext command is unique and must decode into a []*Statement.  This is a
@@ -282,6 +313,9 @@ func build(stmt *Statement, parent reflect.Value, types *typeDictionary) (v refl
 // supported attributes are:
 //
 //	nomerge:       Do not merge this field
+//	more:          This slice field holds the instances of the keyword
+//	               after the first, which is held by the pointer field
+//	               with the same keyword.
 //	required:      This field must be populated
 //	required=KIND: This field must be populated if the keyword is KIND
 //	               otherwise this field must not be present.
@@ -296,7 +330,7 @@ func initTypes(at reflect.Type) {
 		return // we already defined this type
 	}
 
-	y := newYangStatement()
+	y := newYangStatement(at)
 	typeMap[at] = y
 	t := at.Elem()
 	for i := 0; i != t.NumField(); i++ {
@@ -313,9 +347,12 @@ func initTypes(at reflect.Type) {
 		}
 
 		const reqe = "required="
+		more := false
 		for _, p := range parts[1:] {
 			switch {
 			case p == "nomerge":
+			case p == "more":
+				more = true
 			case p == "required":
 				y.required = append(y.required, name)
 			case strings.HasPrefix(p, reqe):
@@ -326,6 +363,14 @@ func initTypes(at reflect.Type) {
 			}
 		}
 
+		if more {
+			if f.Type.Kind() != reflect.Slice {
+				panic(fmt.Sprintf("more field %s is not a slice", f.Name))
+			}
+			y.more[name] = i
+			continue
+		}
+
 		// Ext means this is where we squirrel away extensions
 		if name == "Ext" {
 			// stmt is the extension to put into v at for field f.
@@ -340,8 +385,9 @@ func initTypes(at reflect.Type) {
 			continue
 		}
 
-		// descend runs initType on dt if it has not already done so.
-		descend := func(name string, dt reflect.Type) {
+		// descend runs initType on dt if it has not already done so and
+		// returns the yangStatement of dt.
+		descend := func(name string, dt reflect.Type) *yangStatement {
 			switch nameMap[name] {
 			case nil:
 				nameMap[name] = dt
@@ -350,6 +396,7 @@ func initTypes(at reflect.Type) {
 			default:
 				panic("redeclared type " + name)
 			}
+			return typeMap[dt]
 		}
 
 		// Create a function, fn, that will build the field from a
@@ -367,30 +414,18 @@ func initTypes(at reflect.Type) {
 			if name != "Parent" {
 				panic(fmt.Sprintf("interface field is %s, not Parent", name))
 			}
-			fn = func(stmt *Statement, v, p reflect.Value, types *typeDictionary) error {
-				if !p.Type().Implements(nodeType) {
-					panic(fmt.Sprintf("invalid interface: %v", f.Type.Kind()))
-				}
-				v.Elem().Field(i).Set(p)
-				return nil
+			if f.Type != nodeType {
+				panic(fmt.Sprintf("Parent field is %v, not Node", f.Type))
 			}
+			y.parent = i
+			continue
 		case reflect.String:
 			// The only case of this should be the "Name" field
 			if name != "Name" {
 				panic(fmt.Sprintf("string field is %s, not Name", name))
 			}
-			fn = func(stmt *Statement, v, _ reflect.Value, types *typeDictionary) error {
-				if v.Type() != at {
-					panic(fmt.Sprintf("got type %v, want %v", v.Type(), at))
-				}
-				fv := v.Elem().Field(i)
-				if fv.String() != "" {
-					return errors.New(stmt.Keyword + ": already set")
-				}
-
-				v.Elem().Field(i).SetString(stmt.Argument)
-				return nil
-			}
+			y.name = i
+			continue
 
 		case reflect.Ptr:
 			if f.Type == statementType {
@@ -399,34 +434,34 @@ func initTypes(at reflect.Type) {
 				if name != "Statement" {
 					panic(fmt.Sprintf("string field is %s, not Statement", name))
 				}
-				fn = func(stmt *Statement, v, _ reflect.Value, types *typeDictionary) error {
-					if v.Type() != at {
-						panic(fmt.Sprintf("got type %v, want %v", v.Type(), at))
-					}
-					v.Elem().Field(i).Set(reflect.ValueOf(stmt))
-					return nil
-				}
-				break
+				y.statement = i
+				continue
 			}
 
 			// Make sure our field type is also setup.
-			descend(name, f.Type)
+			fy := descend(name, f.Type)
 
 			fn = func(stmt *Statement, v, p reflect.Value, types *typeDictionary) error {
 				if v.Type() != at {
 					panic(fmt.Sprintf("given type %s, need type %s", v.Type(), at))
 				}
 				fv := v.Elem().Field(i)
-				if !fv.IsNil() {
-					return errors.New(stmt.Keyword + ": already set")
+				mi, more := y.more[name]
+				if !fv.IsNil() && !more {
+					return codeErrorf("GY0007", "%s: already set", stmt.Keyword)
 				}
 
-				// Use build to build the value for this field.
-				sv, err := build(stmt, v, types)
+				// Use buildStatement to build the value for this field.
+				sv, err := buildStatement(fy, stmt, v, types)
 				if err != nil {
 					return err
 				}
-				v.Elem().Field(i).Set(sv)
+				if !fv.IsNil() {
+					mv := v.Elem().Field(mi)
+					mv.Set(reflect.Append(mv, sv))
+					return nil
+				}
+				fv.Set(sv)
 				return nil
 			}
 
@@ -440,12 +475,12 @@ func initTypes(at reflect.Type) {
 			default:
 				panic(fmt.Sprintf("invalid type: %v", st.Kind()))
 			case reflect.Ptr:
-				descend(name, st)
+				fy := descend(name, st)
 				fn = func(stmt *Statement, v, p reflect.Value, types *typeDictionary) error {
 					if v.Type() != at {
 						panic(fmt.Sprintf("given type %s, need type %s", v.Type(), at))
 					}
-					sv, err := build(stmt, v, types)
+					sv, err := buildStatement(fy, stmt, v, types)
 					if err != nil {
 						return err
 					}
@@ -456,6 +491,32 @@ func initTypes(at reflect.Type) {
 				}
 			}
 		}
-		y.funcs[name] = fn
+		y.funcs[name] = &yangField{build: fn, index: i}
+	}
+
+	// Give each field required by some statement a bit to record its
+	// presence in.
+	var bit uint64 = 1
+	track := func(name string) uint64 {
+		f := y.funcs[name]
+		if f == nil {
+			panic(fmt.Sprintf("required field %s of %v is not a substatement", name, at))
+		}
+		if f.bit == 0 {
+			if bit == 0 {
+				panic(fmt.Sprintf("too many required fields in %v", at))
+			}
+			f.bit = bit
+			bit <<= 1
+		}
+		return f.bit
+	}
+	for _, name := range y.required {
+		y.requiredMask |= track(name)
+	}
+	for _, names := range y.sRequired {
+		for _, name := range names {
+			track(name)
+		}
 	}
 }
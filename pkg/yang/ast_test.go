@@ -536,3 +536,36 @@ alt_node the_node {
 
 	aliases = old_aliases
 }
+
+// benchmarkModule returns the source of a module with n containers, each
+// holding a typedef, a list and a few leaves.
+func benchmarkModule(n int) string {
+	var b bytes.Buffer
+	b.WriteString("module bench {\n\tprefix b;\n\tnamespace \"urn:b\";\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `	container c%d {
+		description "container %d";
+		typedef t%d { type string { length 1..8; } }
+		leaf name { type t%d; mandatory true; }
+		leaf count { type uint32 { range 1..100; } default 1; }
+		list l { key k; leaf k { type string; } leaf v { type int8; } }
+	}
+`, i, i, i, i)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func BenchmarkBuildAST(b *testing.B) {
+	ss, err := Parse(benchmarkModule(1000), "bench.yang")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := buildASTWithTypeDict(ss[0], newTypeDictionary()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// A value of type binary is written in the base64 encoding of RFC 4648
+// section 4, while the length restriction of a binary type limits the number
+// of octets of the decoded value (RFC 7950 section 9.8.1), not the number of
+// characters of its encoding.
+
+// BinaryLength returns the lengths, in octets of decoded data, allowed for
+// values of y, a binary type.  nil is returned if y is not a binary type.  The
+// lengths are those of the length restriction of y, if it has any, and
+// otherwise any length.
+func (y *YangType) BinaryLength() YangRange {
+	if y == nil || y.Kind != Ybinary {
+		return nil
+	}
+	if len(y.Length) == 0 {
+		return Uint64Range
+	}
+	return y.Length
+}
+
+// checkBinaryLength returns an error if n octets is not a valid length of a
+// value of the binary type y.
+func (y *YangType) checkBinaryLength(n int) error {
+	l := FromInt(int64(n))
+	if r := y.BinaryLength(); !r.Contains(YangRange{{Min: l, Max: l}}) {
+		return fmt.Errorf("length %d octets outside of %s", n, r)
+	}
+	return nil
+}
+
+// EncodeBinary returns the base64 encoding of b as a value of y, a binary
+// type.  An error is returned if y is not a binary type or the length of b is
+// not allowed by it.
+func EncodeBinary(y *YangType, b []byte) (string, error) {
+	if y == nil || y.Kind != Ybinary {
+		return "", fmt.Errorf("type %s is not binary", y.kindName())
+	}
+	if err := y.checkBinaryLength(len(b)); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecodeBinary validates s, a value of y, a binary type, and returns the
+// octets it encodes.  An error is returned if y is not a binary type, s is
+// not valid base64, or the length of the decoded value is not allowed by y.
+// Line breaks in s are ignored.
+func DecodeBinary(y *YangType, s string) ([]byte, error) {
+	if y == nil || y.Kind != Ybinary {
+		return nil, fmt.Errorf("type %s is not binary", y.kindName())
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 value %q: %v", s, err)
+	}
+	if err := y.checkBinaryLength(len(b)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// kindName returns the name of the kind of y, or "nil" if y is nil.
+func (y *YangType) kindName() string {
+	if y == nil {
+		return "nil"
+	}
+	return y.Kind.String()
+}
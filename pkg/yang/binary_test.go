@@ -0,0 +1,126 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestBinary(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`
+module binary {
+	prefix "b";
+	namespace "urn:b";
+
+	typedef octets { type binary { length 2..4; } }
+	leaf any { type binary; }
+	leaf bounded { type octets; }
+	leaf s { type string; }
+}`, "binary.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	e, errs := ms.GetModule("binary")
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	any, bounded, s := e.Dir["any"].Type, e.Dir["bounded"].Type, e.Dir["s"].Type
+
+	if got := bounded.BinaryLength().String(); got != "2..4" {
+		t.Errorf("bounded BinaryLength() = %s, want 2..4", got)
+	}
+	if got := any.BinaryLength().String(); got != Uint64Range.String() {
+		t.Errorf("any BinaryLength() = %s, want %s", got, Uint64Range)
+	}
+	if got := s.BinaryLength(); got != nil {
+		t.Errorf("string BinaryLength() = %s, want nil", got)
+	}
+
+	tests := []struct {
+		desc    string
+		t       *YangType
+		in      string
+		want    []byte
+		wantErr string
+	}{{
+		desc: "unbounded",
+		t:    any,
+		in:   "",
+		want: []byte{},
+	}, {
+		desc: "in range",
+		t:    bounded,
+		in:   "AAECAw==",
+		want: []byte{0, 1, 2, 3},
+	}, {
+		// The encoding is 8 characters long, which a string length
+		// check would wrongly reject.
+		desc: "line breaks ignored",
+		t:    bounded,
+		in:   "AAEC\nAw==",
+		want: []byte{0, 1, 2, 3},
+	}, {
+		desc:    "too short",
+		t:       bounded,
+		in:      "AA==",
+		wantErr: "length 1 octets outside of 2..4",
+	}, {
+		desc:    "too long",
+		t:       bounded,
+		in:      "AAECAwQ=",
+		wantErr: "length 5 octets outside of 2..4",
+	}, {
+		desc:    "bad base64",
+		t:       any,
+		in:      "A",
+		wantErr: `invalid base64 value "A": illegal base64 data at input byte 0`,
+	}, {
+		desc:    "not binary",
+		t:       s,
+		in:      "AA==",
+		wantErr: "type string is not binary",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := DecodeBinary(tt.t, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("DecodeBinary(): %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DecodeBinary() (-want, +got):\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			enc, err := EncodeBinary(tt.t, got)
+			if err != nil {
+				t.Fatalf("EncodeBinary() got error %v", err)
+			}
+			if dec, _ := DecodeBinary(tt.t, enc); !cmp.Equal(dec, got) {
+				t.Errorf("EncodeBinary() = %q, which decodes to %v, want %v", enc, dec, got)
+			}
+		})
+	}
+
+	if _, err := EncodeBinary(bounded, []byte{1}); err == nil {
+		t.Errorf("EncodeBinary() of 1 octet got no error")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxBundleDepth is the deepest directory, below the vendor directory, that
+// a bundle is searched for in.
+const maxBundleDepth = 4
+
+// A Bundle selects the modules of one platform and OS version of a vendor
+// from a repository laid out like github.com/YangModels/yang, where the
+// modules of each vendor are under vendor/VENDOR and are split into
+// directories by platform and version, e.g., vendor/cisco/xr/721 or
+// vendor/juniper/21.1/21.1R1/junos.
+//
+// A directory component matches the platform or version if it is equal to
+// it, or if one of its "_" or "-" separated words is.  Versions are compared
+// ignoring case and the "." and "-" characters, so the version 7.2.1 matches
+// the directory 721.  The selected directory is the shallowest directory
+// whose path below the vendor directory has components matching both the
+// platform and the version, preferring exact matches to matching words.
+type Bundle struct {
+	Vendor   string // Vendor is the vendor directory, e.g., "cisco".
+	Platform string // Platform is the platform, e.g., "xr", or empty for any.
+	Version  string // Version is the OS version, e.g., "7.2.1", or empty for any.
+}
+
+// ParseBundle returns the Bundle described by s, which has the form
+// VENDOR[/PLATFORM[/VERSION]].
+func ParseBundle(s string) (Bundle, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) > 3 || parts[0] == "" {
+		return Bundle{}, fmt.Errorf("invalid bundle %q: want VENDOR[/PLATFORM[/VERSION]]", s)
+	}
+	parts = append(parts, "", "")
+	return Bundle{Vendor: parts[0], Platform: parts[1], Version: parts[2]}, nil
+}
+
+// String returns b in the form accepted by ParseBundle.
+func (b Bundle) String() string {
+	return strings.TrimRight(b.Vendor+"/"+b.Platform+"/"+b.Version, "/")
+}
+
+// Dir returns the directory of the modules of b in the repository at root.
+func (b Bundle) Dir(root string) (string, error) {
+	vendor := ""
+	for _, dir := range []string{filepath.Join(root, "vendor"), root} {
+		if d := findDir(dir, b.Vendor); d != "" {
+			vendor = d
+			break
+		}
+	}
+	if vendor == "" {
+		return "", fmt.Errorf("bundle %s: vendor %s not found in %s", b, b.Vendor, root)
+	}
+
+	// Search breadth first, so the shallowest matches are found first.
+	type candidate struct {
+		dir   string
+		exact bool
+	}
+	type node struct {
+		dir                        string
+		platform, version, inexact bool
+	}
+	level := []node{{dir: vendor, platform: b.Platform == "", version: b.Version == ""}}
+	for depth := 0; depth <= maxBundleDepth && len(level) > 0; depth++ {
+		var found []candidate
+		for _, n := range level {
+			if n.platform && n.version {
+				found = append(found, candidate{n.dir, !n.inexact})
+			}
+		}
+		if len(found) > 0 {
+			var exact []string
+			var all []string
+			for _, c := range found {
+				all = append(all, c.dir)
+				if c.exact {
+					exact = append(exact, c.dir)
+				}
+			}
+			if len(exact) > 0 {
+				all = exact
+			}
+			if len(all) > 1 {
+				return "", fmt.Errorf("bundle %s is ambiguous, found %s", b, strings.Join(all, ", "))
+			}
+			return all[0], nil
+		}
+
+		var next []node
+		for _, n := range level {
+			for _, sub := range subDirs(n.dir) {
+				c := n
+				c.dir = filepath.Join(n.dir, sub)
+				if !c.platform {
+					if exact, ok := bundleMatch(sub, b.Platform, strings.ToLower); ok {
+						c.platform, c.inexact = true, c.inexact || !exact
+					}
+				}
+				if !c.version {
+					if exact, ok := bundleMatch(sub, b.Version, normalizeVersion); ok {
+						c.version, c.inexact = true, c.inexact || !exact
+					}
+				}
+				next = append(next, c)
+			}
+		}
+		level = next
+	}
+	return "", fmt.Errorf("bundle %s not found in %s", b, vendor)
+}
+
+// AddBundle adds to Path the directories containing .yang files in the
+// directory of b in the repository at root.
+func (ms *Modules) AddBundle(root string, b Bundle) error {
+	dir, err := b.Dir(root)
+	if err != nil {
+		return err
+	}
+	paths, err := PathsWithModulesOptions(dir, ms.ParseOptions.ScanOptions)
+	if err != nil {
+		return err
+	}
+	ms.AddPath(paths...)
+	return nil
+}
+
+// bundleMatch reports whether the directory name dir matches want, after both
+// are normalized by norm, and whether that is an exact match rather than a
+// match of one of the words of dir.
+func bundleMatch(dir, want string, norm func(string) string) (exact, ok bool) {
+	want = norm(want)
+	if norm(dir) == want {
+		return true, true
+	}
+	for _, w := range strings.FieldsFunc(dir, func(r rune) bool { return r == '_' || r == '-' }) {
+		if norm(w) == want {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// normalizeVersion returns v in lower case without any "." or "-" characters.
+func normalizeVersion(v string) string {
+	return strings.NewReplacer(".", "", "-", "").Replace(strings.ToLower(v))
+}
+
+// findDir returns the path of the subdirectory of dir named name, ignoring
+// case, or "" if there is none.
+func findDir(dir, name string) string {
+	for _, sub := range subDirs(dir) {
+		if strings.EqualFold(sub, name) {
+			return filepath.Join(dir, sub)
+		}
+	}
+	return ""
+}
+
+// subDirs returns the sorted names of the subdirectories of dir.
+func subDirs(dir string) []string {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, fi := range fis {
+		if fi.IsDir() || fi.Mode()&os.ModeSymlink != 0 && isDir(filepath.Join(dir, fi.Name())) {
+			dirs = append(dirs, fi.Name())
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// isDir reports whether path is a directory.
+func isDir(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.IsDir()
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestBundleDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "goyang-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	for _, dir := range []string{
+		"standard/ietf/RFC",
+		"vendor/cisco/xr/721",
+		"vendor/cisco/xr/722",
+		"vendor/cisco/nx/9.3-5",
+		"vendor/juniper/21.1/21.1R1/junos",
+		"vendor/juniper/21.1/21.1R1/junos-es",
+		"vendor/juniper/21.1/21.1R2/junos",
+		"vendor/nokia/7x50_YangModels/latest_sros_21.7",
+		"vendor/nokia/7x50_YangModels/latest_sros_21.10",
+		"vendor/nokia/7250_YangModels/latest_sros_21.10",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{{
+		in:   "cisco/xr/7.2.1",
+		want: "vendor/cisco/xr/721",
+	}, {
+		in:   "Cisco/NX/9.3-5",
+		want: "vendor/cisco/nx/9.3-5",
+	}, {
+		in:   "cisco/xr",
+		want: "vendor/cisco/xr",
+	}, {
+		in:   "juniper/junos/21.1R1",
+		want: "vendor/juniper/21.1/21.1R1/junos",
+	}, {
+		in:   "nokia/7x50/21.10",
+		want: "vendor/nokia/7x50_YangModels/latest_sros_21.10",
+	}, {
+		in:      "nokia//21.10",
+		wantErr: "ambiguous",
+	}, {
+		in:      "cisco/xr/9.9.9",
+		wantErr: "not found",
+	}, {
+		in:      "arista/eos/4.20",
+		wantErr: "vendor arista not found",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			b, err := ParseBundle(tt.in)
+			if err != nil {
+				t.Fatalf("ParseBundle(%q) got unexpected error: %v", tt.in, err)
+			}
+			got, err := b.Dir(root)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Dir() %s", diff)
+			}
+			if err == nil && got != filepath.Join(root, tt.want) {
+				t.Errorf("Dir() got %s, want %s", got, filepath.Join(root, tt.want))
+			}
+		})
+	}
+
+	if _, err := ParseBundle("a/b/c/d"); err == nil {
+		t.Errorf("ParseBundle() of too many components got no error")
+	}
+}
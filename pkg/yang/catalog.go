@@ -0,0 +1,232 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A ModuleFetcher retrieves the source of modules that cannot be found
+// locally, see Options.Fetcher.
+type ModuleFetcher interface {
+	// Fetch returns the name of the file holding the module or
+	// submodule name, and its contents.  revision, if not empty, is the
+	// revision date required.
+	Fetch(name, revision string) (file string, data []byte, err error)
+}
+
+// YANGCatalogURL is the URL template of the modules held by the YANG catalog
+// at yangcatalog.org, for use in a CatalogFetcher.  Modules are only held by
+// revision, so only imports with a revision-date can be fetched from it.
+const YANGCatalogURL = "https://www.yangcatalog.org/all_modules/{file}"
+
+// OpenConfigDirs are the directories of the OpenConfig public models
+// repository, github.com/openconfig/public, that modules are searched for in
+// by the URLs of OpenConfigURLs.
+var OpenConfigDirs = []string{
+	"release/models",
+	"release/models/acl",
+	"release/models/aft",
+	"release/models/bfd",
+	"release/models/bgp",
+	"release/models/catalog",
+	"release/models/interfaces",
+	"release/models/isis",
+	"release/models/lacp",
+	"release/models/lldp",
+	"release/models/local-routing",
+	"release/models/macsec",
+	"release/models/mpls",
+	"release/models/multicast",
+	"release/models/network-instance",
+	"release/models/optical-transport",
+	"release/models/ospf",
+	"release/models/platform",
+	"release/models/policy",
+	"release/models/policy-forwarding",
+	"release/models/qos",
+	"release/models/rib",
+	"release/models/sampling",
+	"release/models/segment-routing",
+	"release/models/stp",
+	"release/models/system",
+	"release/models/telemetry",
+	"release/models/types",
+	"release/models/vlan",
+	"release/models/wifi",
+	"third_party/ietf",
+}
+
+// OpenConfigURLs returns the URL templates, for use in a CatalogFetcher, of
+// the modules of the OpenConfig public models repository at the git ref ref,
+// such as "master" or "v4.0.0", served by raw.githubusercontent.com.  The
+// repository keeps each module, without a revision in its file name, in the
+// directory of its area.  A template is returned for each of dirs, in order,
+// or for each of OpenConfigDirs if no dirs are given.  Naming only the
+// directories needed saves searching the others.
+func OpenConfigURLs(ref string, dirs ...string) []string {
+	if len(dirs) == 0 {
+		dirs = OpenConfigDirs
+	}
+	parts := strings.Split(ref, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	base := "https://raw.githubusercontent.com/openconfig/public/" + strings.Join(parts, "/") + "/"
+	urls := make([]string, len(dirs))
+	for i, dir := range dirs {
+		urls[i] = base + strings.Trim(dir, "/") + "/{name}.yang"
+	}
+	return urls
+}
+
+// A CatalogFetcher is a ModuleFetcher that retrieves modules over HTTP from
+// a module catalog, or a repository of modules, and keeps them in a local
+// cache directory.
+type CatalogFetcher struct {
+	// URLs are the templates of the URLs modules are fetched from, tried
+	// in order.  In each, {name} is replaced by the module name,
+	// {revision} by the revision date, and {file} by the file name of
+	// the module, either name@revision.yang or name.yang.  Templates
+	// using {revision} are skipped when no revision is required.
+	URLs []string
+	// CacheDir is the directory fetched modules are written to.  Modules
+	// already in CacheDir are not fetched again.  If empty, fetched
+	// modules are not cached.
+	CacheDir string
+	// Client is the HTTP client to use.  If nil a client with a timeout
+	// of DefaultFetchTimeout is used.
+	Client *http.Client
+	// MaxSize is the largest module, in bytes, accepted.  If 0,
+	// DefaultMaxFetchSize is used.
+	MaxSize int64
+}
+
+const (
+	// DefaultFetchTimeout is the time limit of each request of a
+	// CatalogFetcher without a Client.
+	DefaultFetchTimeout = 30 * time.Second
+	// DefaultMaxFetchSize is the largest module a CatalogFetcher without
+	// a MaxSize accepts.
+	DefaultMaxFetchSize = 16 << 20
+)
+
+// defaultFetchClient is the client of a CatalogFetcher without a Client.
+var defaultFetchClient = &http.Client{Timeout: DefaultFetchTimeout}
+
+// Fetch implements ModuleFetcher.  name must be a YANG identifier, and
+// revision, if not empty, a revision date, so that neither can name a file
+// outside of CacheDir or change the URLs fetched.
+func (c *CatalogFetcher) Fetch(name, revision string) (string, []byte, error) {
+	if !identifierRE.MatchString(name) {
+		return "", nil, fmt.Errorf("cannot fetch %q: not a valid module name", name)
+	}
+	if revision != "" && !revisionDateRegex.MatchString(revision) {
+		return "", nil, fmt.Errorf("cannot fetch %s: %q is not a valid revision date", name, revision)
+	}
+	file := name + ".yang"
+	if revision != "" {
+		file = name + "@" + revision + ".yang"
+	}
+	var path string
+	if c.CacheDir != "" {
+		var err error
+		if path, err = cachePath(c.CacheDir, file); err != nil {
+			return "", nil, err
+		}
+		if data, err := ioutil.ReadFile(path); err == nil {
+			return path, data, nil
+		}
+	}
+
+	client := c.Client
+	if client == nil {
+		client = defaultFetchClient
+	}
+	max := c.MaxSize
+	if max <= 0 {
+		max = DefaultMaxFetchSize
+	}
+	r := strings.NewReplacer(
+		"{name}", url.PathEscape(name),
+		"{revision}", url.PathEscape(revision),
+		"{file}", url.PathEscape(file),
+	)
+	var errs []string
+	for _, tmpl := range c.URLs {
+		if revision == "" && strings.Contains(tmpl, "{revision}") {
+			continue
+		}
+		u := r.Replace(tmpl)
+		data, err := get(client, u, max)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if c.CacheDir == "" {
+			return u, data, nil
+		}
+		if err := os.MkdirAll(c.CacheDir, 0755); err != nil {
+			return "", nil, err
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return "", nil, err
+		}
+		return path, data, nil
+	}
+	if len(errs) == 0 {
+		return "", nil, fmt.Errorf("cannot fetch %s: no URL applies", file)
+	}
+	return "", nil, fmt.Errorf("cannot fetch %s: %s", file, strings.Join(errs, "; "))
+}
+
+// cachePath returns the path of file in the directory dir, or an error if it
+// is not within dir.
+func cachePath(dir, file string) (string, error) {
+	path := filepath.Join(dir, file)
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel != file {
+		return "", fmt.Errorf("cannot fetch %s: not within cache directory %s", file, dir)
+	}
+	return path, nil
+}
+
+// get returns the body of the HTTP GET of u, which may be at most max bytes.
+func get(client *http.Client, u string, max int64) ([]byte, error) {
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("%s: larger than %d bytes", u, max)
+	}
+	return data, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestCatalogFetcher(t *testing.T) {
+	served := map[string]string{
+		"/all_modules/remote@2020-01-01.yang": `
+module remote {
+	prefix "r";
+	namespace "urn:r";
+	revision 2020-01-01;
+	container rc { leaf l { type string; } }
+}`,
+	}
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		data, ok := served[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	defer srv.Close()
+
+	cache, err := ioutil.TempDir("", "goyang-catalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cache)
+
+	const local = `
+module local {
+	prefix "l";
+	namespace "urn:l";
+	import remote { prefix r; revision-date 2020-01-01; }
+	leaf x { type string; }
+}`
+
+	// Without a fetcher the import cannot be satisfied.
+	ms := NewModules()
+	if err := ms.Parse(local, "local.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) == 0 {
+		t.Fatalf("Process() without fetcher got no errors")
+	}
+	if len(requests) != 0 {
+		t.Errorf("Process() without fetcher made requests %v", requests)
+	}
+
+	newModules := func() *Modules {
+		ms := NewModules()
+		ms.ParseOptions.Fetcher = &CatalogFetcher{
+			URLs:     []string{srv.URL + "/{name}.yang", srv.URL + "/all_modules/{file}"},
+			CacheDir: cache,
+			Client:   srv.Client(),
+		}
+		if err := ms.Parse(local, "local.yang"); err != nil {
+			t.Fatal(err)
+		}
+		if errs := ms.Process(); len(errs) > 0 {
+			t.Fatalf("Process() got unexpected errors: %v", errs)
+		}
+		return ms
+	}
+
+	ms = newModules()
+	if ms.Modules["remote"] == nil {
+		t.Errorf("remote module not fetched")
+	}
+	if want := []string{"/remote.yang", "/all_modules/remote@2020-01-01.yang"}; len(requests) != 2 || requests[0] != want[0] || requests[1] != want[1] {
+		t.Errorf("got requests %v, want %v", requests, want)
+	}
+	if _, err := os.Stat(filepath.Join(cache, "remote@2020-01-01.yang")); err != nil {
+		t.Errorf("fetched module not cached: %v", err)
+	}
+
+	// The second time the module is found in the cache.
+	requests = nil
+	newModules()
+	if len(requests) != 0 {
+		t.Errorf("cached module fetched again with requests %v", requests)
+	}
+
+	f := &CatalogFetcher{URLs: []string{srv.URL + "/all_modules/{name}@{revision}.yang"}, Client: srv.Client()}
+	if _, _, err := f.Fetch("remote", ""); err == nil {
+		t.Errorf("Fetch() without revision from revision only catalog got no error")
+	}
+	if _, _, err := f.Fetch("missing", "2020-01-01"); err == nil {
+		t.Errorf("Fetch() of missing module got no error")
+	}
+
+	f.MaxSize = 10
+	if _, _, err := f.Fetch("remote", "2020-01-01"); err == nil || !strings.Contains(err.Error(), "larger than 10 bytes") {
+		t.Errorf("Fetch() of module larger than MaxSize got error %v, want larger than 10 bytes", err)
+	}
+
+	// The error fetching a module is reported along with the missing
+	// import.
+	ms = NewModules()
+	ms.ParseOptions.Fetcher = &CatalogFetcher{URLs: []string{srv.URL + "/{file}"}, Client: srv.Client()}
+	if err := ms.Parse(local, "local.yang"); err != nil {
+		t.Fatal(err)
+	}
+	errs := ms.Process()
+	if len(errs) != 1 {
+		t.Fatalf("Process() with failing fetcher got errors %v, want one", errs)
+	}
+	if diff := errdiff.Substring(errs[0], "no such module: remote: cannot fetch remote@2020-01-01.yang: "+srv.URL+"/remote@2020-01-01.yang: 404 Not Found"); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCatalogFetcherInvalid(t *testing.T) {
+	dir := t.TempDir()
+	cache := filepath.Join(dir, "cache")
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Write([]byte("module escaped { prefix e; namespace urn:e; }"))
+	}))
+	defer srv.Close()
+	f := &CatalogFetcher{URLs: []string{srv.URL + "/{file}"}, CacheDir: cache, Client: srv.Client()}
+
+	for _, tt := range []struct {
+		name, revision string
+		wantErr        string
+	}{
+		{"../../escaped", "", "not a valid module name"},
+		{"a/b", "", "not a valid module name"},
+		{"", "", "not a valid module name"},
+		{"remote", "../../2020-01-01", "not a valid revision date"},
+		{"remote", "latest", "not a valid revision date"},
+	} {
+		_, _, err := f.Fetch(tt.name, tt.revision)
+		if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+			t.Errorf("Fetch(%q, %q): %s", tt.name, tt.revision, diff)
+		}
+	}
+	if len(requests) != 0 {
+		t.Errorf("invalid names fetched with requests %v", requests)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.yang")); len(files) != 0 {
+		t.Errorf("files written outside of the cache: %v", files)
+	}
+}
+
+func TestOpenConfigURLs(t *testing.T) {
+	got := OpenConfigURLs("release/v1", "release/models/interfaces", "/third_party/ietf/")
+	want := []string{
+		"https://raw.githubusercontent.com/openconfig/public/release/v1/release/models/interfaces/{name}.yang",
+		"https://raw.githubusercontent.com/openconfig/public/release/v1/third_party/ietf/{name}.yang",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("OpenConfigURLs (-want, +got):\n%s", diff)
+	}
+	if got := OpenConfigURLs("master"); len(got) != len(OpenConfigDirs) {
+		t.Errorf("OpenConfigURLs(master) got %d URLs, want one for each of the %d OpenConfigDirs", len(got), len(OpenConfigDirs))
+	}
+
+	// The templates use only the name, as the repository holds a single
+	// revision of each module.
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path != "/openconfig/public/master/release/models/types/openconfig-types.yang" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("module openconfig-types { prefix oc-types; namespace urn:oct; }"))
+	}))
+	defer srv.Close()
+	var urls []string
+	for _, u := range OpenConfigURLs("master", "release/models/bgp", "release/models/types") {
+		urls = append(urls, strings.Replace(u, "https://raw.githubusercontent.com", srv.URL, 1))
+	}
+	f := &CatalogFetcher{URLs: urls, Client: srv.Client()}
+	if _, _, err := f.Fetch("openconfig-types", "2019-04-01"); err != nil {
+		t.Fatalf("Fetch(openconfig-types): %v", err)
+	}
+	wantRequests := []string{
+		"/openconfig/public/master/release/models/bgp/openconfig-types.yang",
+		"/openconfig/public/master/release/models/types/openconfig-types.yang",
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests (-want, +got):\n%s", diff)
+	}
+}
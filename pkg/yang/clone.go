@@ -0,0 +1,176 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Clone returns a deep copy of ms, including its modules, the statements they
+// were built from, their types and identities, and the entries built from
+// them, so that either can be changed, e.g., by ApplyDeviate,
+// EnableFeatures or Compact, without affecting the other.  The copy is made
+// in memory, the modules are not parsed or processed again.
+//
+// The built-in types are shared, as are the values of annotations and
+// anything else not defined by this package.  The clone does not use the
+// Arena of ms, if any, and so may still be used after it is released.  ms
+// must not be changed while it is being cloned.
+func (ms *Modules) Clone() *Modules {
+	c := newCloner()
+	if a := ms.ParseOptions.Arena; a != nil {
+		c.share(reflect.ValueOf(a))
+	}
+	nms := c.value(reflect.ValueOf(ms)).Interface().(*Modules)
+	nms.ParseOptions.Arena = nil
+	return nms
+}
+
+// A cloner makes deep copies of the values of this package, keeping the
+// pointers between them.
+type cloner struct {
+	copies map[clonedPtr]reflect.Value // copies of pointers and maps already copied
+	shared map[clonedPtr]bool          // pointers that are not copied
+}
+
+// A clonedPtr identifies a pointer, or map, by its type and address.
+type clonedPtr struct {
+	t reflect.Type
+	p uintptr
+}
+
+// yangPkgPath is the import path of this package.
+var yangPkgPath = reflect.TypeOf(Module{}).PkgPath()
+
+func newCloner() *cloner {
+	c := &cloner{copies: map[clonedPtr]reflect.Value{}, shared: map[clonedPtr]bool{}}
+	for _, td := range BaseTypedefs {
+		c.share(reflect.ValueOf(td))
+		c.share(reflect.ValueOf(td.Type))
+		c.share(reflect.ValueOf(td.YangType))
+		c.share(reflect.ValueOf(td.Source))
+		if td.Type != nil {
+			c.share(reflect.ValueOf(td.Type.YangType))
+			c.share(reflect.ValueOf(td.Type.Source))
+		}
+	}
+	c.share(reflect.ValueOf(ignoreMe))
+	c.share(reflect.ValueOf(isRPCNode))
+	return c
+}
+
+// share marks the pointer v as one that is not copied.
+func (c *cloner) share(v reflect.Value) {
+	if !v.IsNil() {
+		c.shared[clonedPtr{v.Type(), v.Pointer()}] = true
+	}
+}
+
+// exported returns v, which must be addressable, without the restrictions
+// of having been obtained through unexported struct fields.
+func exported(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// value returns a deep copy of v.
+func (c *cloner) value(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type().Elem().PkgPath() != yangPkgPath {
+			return v
+		}
+		k := clonedPtr{v.Type(), v.Pointer()}
+		if c.shared[k] {
+			return v
+		}
+		if n, ok := c.copies[k]; ok {
+			return n
+		}
+		n := reflect.New(v.Type().Elem())
+		c.copies[k] = n
+		c.copyInto(n.Elem(), v.Elem())
+		return n
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.New(v.Type()).Elem()
+		n.Set(c.value(v.Elem()))
+		return n
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		k := clonedPtr{v.Type(), v.Pointer()}
+		if n, ok := c.copies[k]; ok {
+			return n
+		}
+		n := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[k] = n
+		for it := v.MapRange(); it.Next(); {
+			n.SetMapIndex(c.value(it.Key()), c.value(it.Value()))
+		}
+		return n
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		n := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			n.Index(i).Set(c.value(v.Index(i)))
+		}
+		return n
+	case reflect.Array, reflect.Struct:
+		n := reflect.New(v.Type()).Elem()
+		c.copyInto(n, v)
+		return n
+	}
+	return v
+}
+
+// copyInto sets dst, which is addressable, to a deep copy of src, a struct or
+// array.
+func (c *cloner) copyInto(dst, src reflect.Value) {
+	if !src.CanAddr() {
+		a := reflect.New(src.Type()).Elem()
+		a.Set(src)
+		src = a
+	}
+	switch {
+	case isSync(src.Type()):
+		// Locks are not copied, the copy is unlocked.
+	case src.Kind() == reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			exported(dst.Index(i)).Set(c.value(exported(src.Index(i))))
+		}
+	case src.Type().PkgPath() != yangPkgPath:
+		exported(dst).Set(exported(src))
+	default:
+		for i := 0; i < src.NumField(); i++ {
+			exported(dst.Field(i)).Set(c.value(exported(src.Field(i))))
+		}
+	}
+}
+
+// isSync returns true if t is a lock from package sync.
+func isSync(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(sync.Mutex{}), reflect.TypeOf(sync.RWMutex{}):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"a.yang": `module a {
+	prefix a;
+	namespace "urn:a";
+	identity base;
+	identity derived { base base; }
+	container c {
+		leaf x { type string; }
+		leaf y { type identityref { base base; } }
+	}
+}`,
+		"b.yang": `module b {
+	prefix b;
+	namespace "urn:b";
+	import a { prefix a; }
+	augment "/a:c" {
+		leaf z { type int8; }
+	}
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	a, errs := ms.GetModule("a")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module a: %v", errs)
+	}
+	want := ms.Fingerprint()
+
+	clone := ms.Clone()
+	if got := clone.Fingerprint(); got != want {
+		t.Errorf("Clone() has fingerprint %s, want %s", got, want)
+	}
+	ca, errs := clone.GetModule("a")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module a of the clone: %v", errs)
+	}
+	if ca == a || ca.Dir["c"] == a.Dir["c"] || ca.Dir["c"].Dir["x"].Type == a.Dir["c"].Dir["x"].Type {
+		t.Errorf("Clone() shares entries with the original")
+	}
+	if got := RootNode(ca.Dir["c"].Node).Modules; got != clone {
+		t.Errorf("Clone() module belongs to %p, want the clone %p", got, clone)
+	}
+	if ca.Dir["c"].Dir["z"] == nil {
+		t.Errorf("Clone() lost the augmented leaf z")
+	}
+	if base := ca.Dir["c"].Dir["y"].Type.IdentityBase; base == nil || base == a.Dir["c"].Dir["y"].Type.IdentityBase || len(base.Values) != 1 {
+		t.Errorf("Clone() identity base is %v, want a copy with one derived identity", base)
+	}
+
+	// Deviate and change the clone, the original must not change.
+	if err := clone.Parse(`module d {
+	prefix d;
+	namespace "urn:d";
+	import a { prefix a; }
+	deviation "/a:c/a:x" { deviate not-supported; }
+}`, "d.yang"); err != nil {
+		t.Fatalf("cannot parse the deviation: %v", err)
+	}
+	if errs := clone.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process the clone: %v", errs)
+	}
+	clone.EnableFeatures("a", "f")
+	delete(ca.Dir["c"].Dir, "y")
+	ca, _ = clone.GetModule("a")
+	if ca.Dir["c"].Dir["x"] != nil {
+		t.Errorf("deviation of the clone did not remove x")
+	}
+
+	if got := ms.Fingerprint(); got != want {
+		t.Errorf("changing the clone changed the original fingerprint to %s, want %s", got, want)
+	}
+	if a.Dir["c"].Dir["x"] == nil || a.Dir["c"].Dir["y"] == nil {
+		t.Errorf("changing the clone removed entries of the original")
+	}
+	if ms.FeatureEnabled("a", "f") {
+		t.Errorf("enabling a feature of the clone enabled it in the original")
+	}
+	if ms.Modules["d"] != nil {
+		t.Errorf("parsing into the clone added a module to the original")
+	}
+}
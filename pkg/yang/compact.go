@@ -0,0 +1,175 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"reflect"
+	"unicode/utf8"
+)
+
+// CompactOptions specifies which rarely used information is released from
+// processed modules, for programs that only need the structure and types of
+// the schema tree.  The zero value releases nothing.
+//
+// Compacted modules must not be processed again, and no further modules may
+// be added to them.
+type CompactOptions struct {
+	// DropNodes clears the Node field of each Entry other than the Entry
+	// of a module or submodule, and the Uses statements recorded in the
+	// Uses field.  Entry.Modules and Entry.Namespace are unaffected, but
+	// information read from the Node, such as the extensions found by
+	// Entry.MatchingExtensions and the when conditions returned by
+	// Entry.GetWhenXPath, is no longer available.  Entry.Find resolves the
+	// prefixes of an absolute path with the imports of the module of the
+	// entry, and so cannot resolve those only imported by the module that
+	// augments the entry into its tree.
+	DropNodes bool
+	// DropStatements releases the parsed statements from the nodes of
+	// each module, and the source retained for Modules.FormatError.
+	// Locations are then reported as "unknown" by Source, and
+	// Entry.GetWhenXPath, which reads the when statement, finds no
+	// condition.  The statements of extensions, found in the Exts fields,
+	// are retained.
+	DropStatements bool
+	// KeepSources lists the keywords of the nodes, e.g., "leaf" and
+	// "list", that are exempt from DropNodes and DropStatements.  The
+	// entries of those nodes retain their Node, and the nodes their
+	// parsed statement, so that Source and Statement.Range still locate
+	// them.  A retained statement retains its substatements.
+	KeepSources []string
+	// MaxDescription, if positive, truncates each description longer than
+	// MaxDescription bytes, at a UTF-8 character boundary, in both the
+	// nodes and the entries of each module.
+	MaxDescription int
+}
+
+// isZero returns true if o releases nothing.
+func (o CompactOptions) isZero() bool {
+	return !o.DropNodes && !o.DropStatements && o.MaxDescription <= 0
+}
+
+// keepsSource returns true if the source of the nodes with the keyword kind is
+// retained.
+func (o CompactOptions) keepsSource(kind string) bool {
+	for _, k := range o.KeepSources {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Compact releases the information specified by opts from the modules and
+// entries of ms.  It is called by Process when ParseOptions.Compact is set.
+func (ms *Modules) Compact(opts CompactOptions) {
+	if opts.isZero() {
+		return
+	}
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			if e := ms.getEntryCache(m); e != nil {
+				e.compact(opts, map[*Entry]bool{})
+			}
+			compactNode(reflect.ValueOf(m), opts)
+		}
+	}
+	if opts.DropStatements {
+		ms.sources = map[string]string{}
+	}
+}
+
+// compact releases the information specified by opts from e and its
+// descendants.  seen records the entries already compacted.
+func (e *Entry) compact(opts CompactOptions, seen map[*Entry]bool) {
+	if e == nil || seen[e] {
+		return
+	}
+	seen[e] = true
+	if opts.DropNodes {
+		if e.Parent != nil && (e.Node == nil || !opts.keepsSource(e.Node.Kind())) {
+			e.Node = nil
+		}
+		e.Uses = nil
+	}
+	if opts.MaxDescription > 0 {
+		e.Description = truncate(e.Description, opts.MaxDescription)
+	}
+	for _, c := range e.Dir {
+		c.compact(opts, seen)
+	}
+	if e.RPC != nil {
+		e.RPC.Input.compact(opts, seen)
+		e.RPC.Output.compact(opts, seen)
+	}
+	for _, a := range e.Augments {
+		a.compact(opts, seen)
+	}
+	for _, a := range e.Augmented {
+		a.compact(opts, seen)
+	}
+	for _, d := range e.Deviations {
+		d.Entry.compact(opts, seen)
+	}
+	for _, ds := range e.Deviate {
+		for _, d := range ds {
+			d.compact(opts, seen)
+		}
+	}
+}
+
+// compactNode releases the information specified by opts from v, a pointer to
+// an AST node, and its substatements.  The fields to release are found using
+// the yangStatement describing the type of v.
+func compactNode(v reflect.Value, opts CompactOptions) {
+	y := typeMap[v.Type()]
+	if y == nil || v.IsNil() {
+		return
+	}
+	e := v.Elem()
+	n, ok := v.Interface().(Node)
+	if opts.DropStatements && y.statement >= 0 && !(ok && opts.keepsSource(n.Kind())) {
+		e.Field(y.statement).Set(reflect.Zero(statementType))
+	}
+	for name, f := range y.funcs {
+		fv := e.Field(f.index)
+		switch fv.Kind() {
+		case reflect.Ptr:
+			if fv.IsNil() {
+				continue
+			}
+			if d, ok := fv.Interface().(*Value); ok && name == "description" && opts.MaxDescription > 0 {
+				d.Name = truncate(d.Name, opts.MaxDescription)
+			}
+			compactNode(fv, opts)
+		case reflect.Slice:
+			for i := 0; i < fv.Len(); i++ {
+				compactNode(fv.Index(i), opts)
+			}
+		}
+	}
+}
+
+// truncate returns s truncated to at most n bytes without splitting a UTF-8
+// encoded character.  A truncated string is copied so that it does not
+// retain the memory of s.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return string([]byte(s[:n]))
+}
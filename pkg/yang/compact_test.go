@@ -0,0 +1,156 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+)
+
+const compactModule = `
+module compact {
+	prefix "c";
+	namespace "urn:c";
+	description "a module description";
+
+	extension ext { argument value; }
+
+	grouping g {
+		leaf l {
+			type string;
+			description "crème brûlée";
+		}
+	}
+
+	container top {
+		c:ext "value";
+		uses g;
+		leaf w {
+			when "../l = 'x'";
+			type string;
+		}
+	}
+}
+`
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		desc            string
+		opts            CompactOptions
+		wantNode        bool
+		wantSource      string
+		wantLeafSource  string
+		wantDescription string
+		wantWhen        bool
+	}{{
+		desc:            "zero options",
+		wantNode:        true,
+		wantWhen:        true,
+		wantSource:      "compact.yang:2:1",
+		wantLeafSource:  "compact.yang:10:3",
+		wantDescription: "crème brûlée",
+	}, {
+		desc:            "drop nodes",
+		opts:            CompactOptions{DropNodes: true},
+		wantSource:      "compact.yang:2:1",
+		wantDescription: "crème brûlée",
+	}, {
+		desc:            "drop statements",
+		opts:            CompactOptions{DropStatements: true},
+		wantNode:        true,
+		wantSource:      "unknown",
+		wantLeafSource:  "unknown",
+		wantDescription: "crème brûlée",
+	}, {
+		desc:            "keep the sources of leaves",
+		opts:            CompactOptions{DropNodes: true, DropStatements: true, KeepSources: []string{"leaf"}},
+		wantNode:        true,
+		wantSource:      "unknown",
+		wantLeafSource:  "compact.yang:10:3",
+		wantDescription: "crème brûlée",
+	}, {
+		desc:            "keep the sources of other nodes",
+		opts:            CompactOptions{DropNodes: true, DropStatements: true, KeepSources: []string{"list"}},
+		wantSource:      "unknown",
+		wantDescription: "crème brûlée",
+	}, {
+		desc:            "truncate descriptions at a character boundary",
+		opts:            CompactOptions{MaxDescription: 10},
+		wantNode:        true,
+		wantWhen:        true,
+		wantSource:      "compact.yang:2:1",
+		wantLeafSource:  "compact.yang:10:3",
+		wantDescription: "crème br",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.Compact = tt.opts
+			if err := ms.Parse(compactModule, "compact.yang"); err != nil {
+				t.Fatalf("cannot parse module: %v", err)
+			}
+			if errs := ms.Process(); len(errs) > 0 {
+				t.Fatalf("cannot process module: %v", errs)
+			}
+			e, errs := ms.GetModule("compact")
+			if len(errs) > 0 {
+				t.Fatalf("cannot get module: %v", errs)
+			}
+
+			if e.Node == nil {
+				t.Errorf("module Node is nil, want it retained")
+			}
+			if got := e.Modules(); got != ms {
+				t.Errorf("Modules() got %p, want %p", got, ms)
+			}
+			top := e.Dir["top"]
+			if got := top.Namespace().Name; got != "urn:c" {
+				t.Errorf("Namespace() got %q, want urn:c", got)
+			}
+			if got := len(top.Exts); got != 1 {
+				t.Errorf("got %d extensions, want 1", got)
+			}
+			leaf := top.Dir["l"]
+			if got := leaf.Node != nil; got != tt.wantNode {
+				t.Errorf("leaf has Node %v, want %v", got, tt.wantNode)
+			}
+			if leaf.Node != nil {
+				if got := Source(leaf.Node); got != tt.wantLeafSource {
+					t.Errorf("leaf Source() got %q, want %q", got, tt.wantLeafSource)
+				}
+			}
+			if got := Source(ms.Modules["compact"]); got != tt.wantSource {
+				t.Errorf("Source() got %q, want %q", got, tt.wantSource)
+			}
+			if got := leaf.Description; got != tt.wantDescription {
+				t.Errorf("leaf description got %q, want %q", got, tt.wantDescription)
+			}
+			if got := ms.Modules["compact"].Grouping[0].Leaf[0].Description.Name; got != tt.wantDescription {
+				t.Errorf("node description got %q, want %q", got, tt.wantDescription)
+			}
+			// Prefixed absolute paths are resolved with the module
+			// entry once the Node of the entry is dropped.
+			w := top.Dir["w"]
+			if got := leaf.Find("/c:top/w"); got != w {
+				t.Errorf("Find(/c:top/w) got %v, want the entry of w", got)
+			}
+			// The when condition is found in the statement of the
+			// Node.
+			if when, ok := w.GetWhenXPath(); ok != tt.wantWhen || ok && when != "../l = 'x'" {
+				t.Errorf("GetWhenXPath() got %q, %v, want a condition %v", when, ok, tt.wantWhen)
+			}
+		})
+	}
+}
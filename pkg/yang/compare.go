@@ -0,0 +1,196 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// A SchemaComparison compares the schemas of a set of devices, such as
+// those returned by a SchemaRepo, node by node.
+type SchemaComparison struct {
+	// Devices are the names of the devices compared.
+	Devices []string
+	// Paths are the nodes of the schemas of any of the devices, ordered
+	// by path.
+	Paths []*PathSupport
+}
+
+// A PathSupport is the support of the devices of a SchemaComparison for the
+// node at a path.
+type PathSupport struct {
+	// Path is the path of the node, as returned by Entry.Path.
+	Path string
+	// Variants has the variant of the node of each device, in the order
+	// of the Devices of the comparison.  The variant is 0 if the device
+	// does not have the node.  Otherwise it is 1 for the definition of
+	// the first device having the node, 2 for the next device defining
+	// the node differently, and so on.  Devices whose nodes have the same
+	// variant have the same definition of the node, ignoring
+	// documentation as Modules.Fingerprint does, but not necessarily of
+	// its descendants.
+	Variants []int
+}
+
+// Everywhere returns true if every device has the node of p, with the same
+// definition.
+func (p *PathSupport) Everywhere() bool {
+	for _, v := range p.Variants {
+		if v != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// CompareSchemas compares the schemas schemas, the processed modules of the
+// devices devices, which must have the same number of elements.  The nodes
+// compared are those of the most recent revision of each module, including
+// the input and output of RPCs and actions.
+func CompareSchemas(devices []string, schemas []*Modules) (*SchemaComparison, error) {
+	if len(devices) != len(schemas) {
+		return nil, fmt.Errorf("CompareSchemas: %d devices, but %d schemas", len(devices), len(schemas))
+	}
+	// defs has the definition of each path of each device.
+	defs := map[string][]string{}
+	for i, ms := range schemas {
+		for _, m := range ms.SortedModules() {
+			compareEntry(ToEntry(m), i, len(schemas), defs)
+		}
+	}
+	c := &SchemaComparison{Devices: append([]string{}, devices...)}
+	for path, d := range defs {
+		p := &PathSupport{Path: path, Variants: make([]int, len(d))}
+		var variants []string
+		for i, def := range d {
+			if def == "" {
+				continue
+			}
+			v := indexOf(variants, def)
+			if v < 0 {
+				variants = append(variants, def)
+				v = len(variants) - 1
+			}
+			p.Variants[i] = v + 1
+		}
+		c.Paths = append(c.Paths, p)
+	}
+	sort.Slice(c.Paths, func(i, j int) bool { return c.Paths[i].Path < c.Paths[j].Path })
+	return c, nil
+}
+
+// compareEntry records the definitions of e and its descendants, as the
+// schema of device i of n, in defs.
+func compareEntry(e *Entry, i, n int, defs map[string][]string) {
+	path := e.Path()
+	if defs[path] == nil {
+		defs[path] = make([]string, n)
+	}
+	var def strings.Builder
+	fingerprintNode(&def, e)
+	defs[path][i] = def.String()
+	for _, c := range e.children() {
+		compareEntry(c, i, n, defs)
+	}
+}
+
+// Common returns the paths of the nodes that every device has with the same
+// definition, and whose ancestors every device has too, so that they are
+// safe to use with every device.
+func (c *SchemaComparison) Common() []string {
+	var paths []string
+	everywhere := map[string]bool{}
+	for _, p := range c.Paths {
+		if !p.Everywhere() {
+			continue
+		}
+		everywhere[p.Path] = true
+		if parent := p.Path[:strings.LastIndex(p.Path, "/")]; parent == "" || everywhere[parent] {
+			paths = append(paths, p.Path)
+		} else {
+			everywhere[p.Path] = false
+		}
+	}
+	return paths
+}
+
+// Differences returns the nodes that not every device has with the same
+// definition.
+func (c *SchemaComparison) Differences() []*PathSupport {
+	var diffs []*PathSupport
+	for _, p := range c.Paths {
+		if !p.Everywhere() {
+			diffs = append(diffs, p)
+		}
+	}
+	return diffs
+}
+
+// Missing returns the paths of the nodes that device does not have but some
+// other device does.  nil is returned if device is not one of the devices
+// compared.
+func (c *SchemaComparison) Missing(device string) []string {
+	i := indexOf(c.Devices, device)
+	if i < 0 {
+		return nil
+	}
+	var paths []string
+	for _, p := range c.Paths {
+		if p.Variants[i] == 0 {
+			paths = append(paths, p.Path)
+		}
+	}
+	return paths
+}
+
+// WriteMatrix writes c to w as a table with a tab separated line for each
+// path, and a header line naming the devices.  The column of each device is
+// "-" if it does not have the node, otherwise a letter naming the variant of
+// the node, "A" for the first variant, "B" for the second and so on.  If
+// onlyDifferences is true then the nodes every device has with the same
+// definition are not written.
+func (c *SchemaComparison) WriteMatrix(w io.Writer, onlyDifferences bool) error {
+	if _, err := fmt.Fprintf(w, "path\t%s\n", strings.Join(c.Devices, "\t")); err != nil {
+		return err
+	}
+	for _, p := range c.Paths {
+		if onlyDifferences && p.Everywhere() {
+			continue
+		}
+		cells := []string{p.Path}
+		for _, v := range p.Variants {
+			cells = append(cells, variantName(v))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// variantName returns the name of variant v in a matrix.
+func variantName(v int) string {
+	switch {
+	case v == 0:
+		return "-"
+	case v <= 26:
+		return string(rune('A' + v - 1))
+	default:
+		return fmt.Sprintf("%d", v)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompareSchemas(t *testing.T) {
+	schemas := map[string]string{
+		"r1": `module dev { prefix d; namespace "urn:d";
+			container sys {
+				leaf name { type string; }
+				leaf mtu { type uint16; }
+				container extra { leaf on { type boolean; } }
+			}
+		}`,
+		"r2": `module dev { prefix d; namespace "urn:d";
+			container sys {
+				leaf name { type string; description "different text"; }
+				leaf mtu { type uint32; }
+			}
+		}`,
+		"r3": `module dev { prefix d; namespace "urn:d";
+			container sys {
+				leaf name { type string; }
+				leaf mtu { type uint16; }
+				container extra { config false; leaf on { type boolean; } }
+			}
+		}`,
+	}
+	devices := []string{"r1", "r2", "r3"}
+	var mss []*Modules
+	for _, d := range devices {
+		ms := NewModules()
+		if err := ms.Parse(schemas[d], d+".yang"); err != nil {
+			t.Fatal(err)
+		}
+		if errs := ms.Process(); len(errs) > 0 {
+			t.Fatal(errs)
+		}
+		mss = append(mss, ms)
+	}
+
+	if _, err := CompareSchemas(devices[:2], mss); err == nil {
+		t.Error("CompareSchemas with more schemas than devices did not fail")
+	}
+	c, err := CompareSchemas(devices, mss)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"/dev", "/dev/sys", "/dev/sys/name"}, c.Common()); diff != "" {
+		t.Errorf("Common (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/dev/sys/extra", "/dev/sys/extra/on"}, c.Missing("r2")); diff != "" {
+		t.Errorf("Missing(r2) (-want, +got):\n%s", diff)
+	}
+	if got := c.Missing("r9"); got != nil {
+		t.Errorf("Missing(r9) = %v, want nil", got)
+	}
+	var diffs []string
+	for _, p := range c.Differences() {
+		diffs = append(diffs, p.Path)
+	}
+	if diff := cmp.Diff([]string{"/dev/sys/extra", "/dev/sys/extra/on", "/dev/sys/mtu"}, diffs); diff != "" {
+		t.Errorf("Differences (-want, +got):\n%s", diff)
+	}
+
+	var b strings.Builder
+	if err := c.WriteMatrix(&b, false); err != nil {
+		t.Fatal(err)
+	}
+	want := `path	r1	r2	r3
+/dev	A	A	A
+/dev/sys	A	A	A
+/dev/sys/extra	A	-	B
+/dev/sys/extra/on	A	-	A
+/dev/sys/mtu	A	B	A
+/dev/sys/name	A	A	A
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteMatrix (-want, +got):\n%s", diff)
+	}
+	b.Reset()
+	if err := c.WriteMatrix(&b, true); err != nil {
+		t.Fatal(err)
+	}
+	want = `path	r1	r2	r3
+/dev/sys/extra	A	-	B
+/dev/sys/extra/on	A	-	A
+/dev/sys/mtu	A	B	A
+`
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("WriteMatrix only differences (-want, +got):\n%s", diff)
+	}
+}
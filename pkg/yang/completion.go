@@ -0,0 +1,160 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"sort"
+)
+
+// A CompletionNode is a data node of a CompletionTree, holding what an
+// interactive CLI needs to complete the paths to, and values of, the node.
+// It is designed to be encoded as JSON.
+type CompletionNode struct {
+	// Name is the name of the node, prefixed by the name of its module,
+	// as in RFC 7951, if the node is at the top level or in a different
+	// module than its parent.  The names of the nodes from the root form
+	// the path to the node.
+	Name string `json:"name"`
+	// Kind is the keyword of the node: container, list, leaf, leaf-list,
+	// anydata or anyxml.
+	Kind string `json:"kind"`
+	// Keys are the names of the keys of a list.
+	Keys []string `json:"keys,omitempty"`
+	// Type is the built-in type of a leaf or leaf-list, such as "uint32"
+	// or "union".
+	Type string `json:"type,omitempty"`
+	// Typedef is the name of the typedef of a leaf or leaf-list, if any.
+	Typedef string `json:"typedef,omitempty"`
+	// Values are the values a leaf or leaf-list may be completed with:
+	// the names of enums and bits, the module qualified names of the
+	// identities of an identityref, and true and false for booleans,
+	// including those of the members of a union, sorted.
+	Values []string `json:"values,omitempty"`
+	// State is true if the node is config false.
+	State    bool              `json:"state,omitempty"`
+	Children []*CompletionNode `json:"children,omitempty"`
+}
+
+// CompletionTree returns the data nodes below e, which is normally a module
+// entry, looking through choice and case entries, as CompletionNodes ordered
+// by name.  The state data nodes are omitted if configOnly is true.  RPCs,
+// actions and notifications are omitted.
+func (e *Entry) CompletionTree(configOnly bool) []*CompletionNode {
+	children := dataChildren(e)
+	sort.SliceStable(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	var nodes []*CompletionNode
+	for _, c := range children {
+		kind := dataKind(c)
+		if kind == "" || configOnly && c.ReadOnly() {
+			continue
+		}
+		n := &CompletionNode{
+			Name:     c.Name,
+			Kind:     kind,
+			State:    c.ReadOnly(),
+			Children: c.CompletionTree(configOnly),
+		}
+		for _, k := range c.Keys() {
+			n.Keys = append(n.Keys, k.Name)
+		}
+		if p := c.DataParent(); p == nil || p.Parent == nil || !sameNamespace(p, c) {
+			if m, err := c.InstantiatingModule(); err == nil {
+				n.Name = m + ":" + c.Name
+			}
+		}
+		if c.Type != nil {
+			n.Type = c.Type.Kind.String()
+			if c.Type.Name != n.Type {
+				n.Typedef = c.Type.Name
+			}
+			n.Values = completionValues(c.Type)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// dataKind returns the keyword of the data node e, or "" if e is not a data
+// node.
+func dataKind(e *Entry) string {
+	switch {
+	case e.RPC != nil || e.Kind == NotificationEntry:
+		return ""
+	case e.IsList():
+		return "list"
+	case e.IsContainer():
+		return "container"
+	case e.IsLeafList():
+		return "leaf-list"
+	case e.IsLeaf():
+		return "leaf"
+	case e.Kind == AnyDataEntry:
+		return "anydata"
+	case e.Kind == AnyXMLEntry:
+		return "anyxml"
+	}
+	return ""
+}
+
+// sameNamespace returns true if a and b have the same namespace.
+func sameNamespace(a, b *Entry) bool {
+	na, nb := a.Namespace(), b.Namespace()
+	return na != nil && nb != nil && na.Name == nb.Name
+}
+
+// completionValues returns the sorted values of t, and of its members if t is
+// a union, that a CompletionNode may be completed with.
+func completionValues(t *YangType) []string {
+	seen := map[string]bool{}
+	var add func(t *YangType)
+	add = func(t *YangType) {
+		var values []string
+		switch t.Kind {
+		case Yenum:
+			if t.Enum != nil {
+				values = t.Enum.Names()
+			}
+		case Ybits:
+			if t.Bit != nil {
+				values = t.Bit.Names()
+			}
+		case Ybool:
+			values = []string{"false", "true"}
+		case Yidentityref:
+			if t.IdentityBase != nil {
+				for _, v := range t.IdentityBase.Values {
+					values = append(values, v.modulePrefixedName())
+				}
+			}
+		case Yunion:
+			for _, ut := range t.Type {
+				add(ut)
+			}
+		}
+		for _, v := range values {
+			seen[v] = true
+		}
+	}
+	add(t)
+	if len(seen) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(seen))
+	for v := range seen {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
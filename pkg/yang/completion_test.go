@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompletionTree(t *testing.T) {
+	ms := NewModules()
+	for name, in := range map[string]string{
+		"comp.yang": `module comp {
+  namespace "urn:comp";
+  prefix "comp";
+  identity base;
+  identity one { base base; }
+  typedef mode { type enumeration { enum fast; enum slow; } }
+  container interfaces {
+    list interface {
+      key "name";
+      leaf name { type string; }
+      leaf mode { type mode; }
+      leaf kind { type identityref { base base; } }
+      leaf setting {
+        type union {
+          type boolean;
+          type enumeration { enum auto; }
+        }
+      }
+      choice addr {
+        leaf-list ip { type string; }
+      }
+      container state {
+        config false;
+        leaf counter { type uint64; }
+      }
+      action reset;
+    }
+  }
+  rpc restart;
+  notification alarm;
+}`,
+		"comp-ext.yang": `module comp-ext {
+  namespace "urn:comp-ext";
+  prefix "ext";
+  import comp { prefix comp; }
+  identity two { base comp:base; }
+  augment /comp:interfaces/comp:interface {
+    leaf extra { type empty; }
+  }
+}`,
+	} {
+		if err := ms.Parse(in, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	leaves := func(state bool) []*CompletionNode {
+		nodes := []*CompletionNode{
+			{Name: "comp-ext:extra", Kind: "leaf", Type: "empty"},
+			{Name: "ip", Kind: "leaf-list", Type: "string"},
+			{Name: "kind", Kind: "leaf", Type: "identityref", Values: []string{"comp-ext:two", "comp:one"}},
+			{Name: "mode", Kind: "leaf", Type: "enumeration", Typedef: "mode", Values: []string{"fast", "slow"}},
+			{Name: "name", Kind: "leaf", Type: "string"},
+			{Name: "setting", Kind: "leaf", Type: "union", Values: []string{"auto", "false", "true"}},
+		}
+		if state {
+			nodes = append(nodes, &CompletionNode{Name: "state", Kind: "container", State: true, Children: []*CompletionNode{
+				{Name: "counter", Kind: "leaf", Type: "uint64", State: true},
+			}})
+		}
+		return []*CompletionNode{{
+			Name: "comp:interfaces",
+			Kind: "container",
+			Children: []*CompletionNode{{
+				Name:     "interface",
+				Kind:     "list",
+				Keys:     []string{"name"},
+				Children: nodes,
+			}},
+		}}
+	}
+	root := ToEntry(ms.Modules["comp"])
+	if diff := cmp.Diff(leaves(true), root.CompletionTree(false)); diff != "" {
+		t.Errorf("CompletionTree(false) (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(leaves(false), root.CompletionTree(true)); diff != "" {
+		t.Errorf("CompletionTree(true) (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// A ModuleConflict describes a module or submodule revision that was read
+// from two sources with differing content.  A ModuleConflict is also the
+// error returned by Modules.Parse when ms.ParseOptions.DuplicatePolicy is
+// DuplicateError.
+type ModuleConflict struct {
+	Kind         string // Kind is either "module" or "submodule".
+	Name         string // Name is the name, and revision, of the module.
+	FirstSource  string // FirstSource is the location of the module read first.
+	FirstHash    string // FirstHash is the SHA-256 of the source read first.
+	SecondSource string // SecondSource is the location of the module read second.
+	SecondHash   string // SecondHash is the SHA-256 of the source read second.
+}
+
+// Error implements error.
+func (c *ModuleConflict) Error() string {
+	return fmt.Sprintf("duplicate %s %s with differing content at %s (sha256 %.12s) and %s (sha256 %.12s)", c.Kind, c.Name, c.FirstSource, c.FirstHash, c.SecondSource, c.SecondHash)
+}
+
+func (c *ModuleConflict) errorCode() ErrorCode { return "GY0104" }
+
+// Conflicts returns the conflicts found between the modules and submodules
+// read into ms, in the order they were found.
+func (ms *Modules) Conflicts() []*ModuleConflict {
+	return ms.conflicts
+}
+
+// sourceHash returns the hex encoded SHA-256 of data.
+func sourceHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// A ConformanceType is the conformance-type of a module of RFC 8525 section
+// 5.3: whether a server implements the module or only imports it.
+type ConformanceType int
+
+const (
+	// Implement is the conformance-type of a module whose data nodes,
+	// augments and deviations are part of the schema.  It is the
+	// conformance-type of every module not set otherwise.
+	Implement ConformanceType = iota
+	// ImportOnly is the conformance-type of a module that is only
+	// imported: its typedefs, groupings, identities and extensions are
+	// used by other modules but it adds no data nodes, augments or
+	// deviations to the schema.
+	ImportOnly
+)
+
+// String returns the RFC 8525 name of c, "implement" or "import".
+func (c ConformanceType) String() string {
+	if c == ImportOnly {
+		return "import"
+	}
+	return "implement"
+}
+
+// SetConformance sets the conformance-type of the named modules, and of the
+// submodules that belong to them, to c.  It must be called before Process.
+// When processed, the entry of an import-only module has no children, and
+// its augments and deviations are not applied to the other modules.
+func (ms *Modules) SetConformance(c ConformanceType, modules ...string) {
+	if ms.conformance == nil {
+		ms.conformance = map[string]ConformanceType{}
+	}
+	for _, m := range modules {
+		if c == Implement {
+			delete(ms.conformance, m)
+		} else {
+			ms.conformance[m] = c
+		}
+	}
+}
+
+// Conformance returns the conformance-type of the named module, as set by
+// SetConformance.
+func (ms *Modules) Conformance(module string) ConformanceType {
+	return ms.conformance[module]
+}
+
+// importOnly returns true if the module, or submodule, m belongs to an
+// import-only module.
+func (ms *Modules) importOnly(m *Module) bool {
+	if len(ms.conformance) == 0 {
+		return false
+	}
+	name := m.Name
+	if m.Kind() == "submodule" && m.BelongsTo != nil {
+		name = m.BelongsTo.Name
+	}
+	return ms.conformance[name] == ImportOnly
+}
+
+// dropImportOnly removes the data nodes and augments from the entries of the
+// import-only modules and submodules of ms.
+func (ms *Modules) dropImportOnly() {
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range byKey(mods) {
+			if !ms.importOnly(m) {
+				continue
+			}
+			e := ToEntry(m)
+			e.Dir = map[string]*Entry{}
+			e.Augments = nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConformance(t *testing.T) {
+	mods := map[string]string{
+		"app": `
+			module app {
+				prefix a;
+				namespace "urn:a";
+				import lib { prefix l; }
+				container top {
+					uses l:g;
+					leaf t { type l:name; }
+				}
+			}`,
+		"lib": `
+			module lib {
+				prefix l;
+				namespace "urn:l";
+				include lib-sub;
+				import app { prefix a; }
+				typedef name { type string; }
+				grouping g { leaf from-grouping { type string; } }
+				container lib-data { leaf x { type string; } }
+				augment "/a:top" { leaf augmented { type string; } }
+				deviation "/a:top/a:t" { deviate not-supported; }
+			}`,
+		"lib-sub": `
+			submodule lib-sub {
+				belongs-to lib { prefix l; }
+				container sub-data { leaf y { type string; } }
+			}`,
+	}
+
+	tests := []struct {
+		desc       string
+		importOnly []string
+		wantApp    []string
+		wantLib    []string
+	}{{
+		desc:    "all implemented",
+		wantApp: []string{"/app", "/app/top", "/app/top/augmented", "/app/top/from-grouping"},
+		wantLib: []string{"/lib", "/lib/lib-data", "/lib/lib-data/x", "/lib/sub-data", "/lib/sub-data/y"},
+	}, {
+		desc:       "lib import-only",
+		importOnly: []string{"lib"},
+		wantApp:    []string{"/app", "/app/top", "/app/top/from-grouping", "/app/top/t"},
+		wantLib:    []string{"/lib"},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.AllowImportCycles = true
+			ms.SetConformance(ImportOnly, tt.importOnly...)
+			for name, src := range mods {
+				if err := ms.Parse(src, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			if errs := ms.Process(); len(errs) > 0 {
+				t.Fatalf("cannot process modules: %v", errs)
+			}
+			for _, m := range []struct {
+				name string
+				want []string
+			}{{"app", tt.wantApp}, {"lib", tt.wantLib}} {
+				e, errs := ms.GetModule(m.name)
+				if len(errs) > 0 {
+					t.Fatalf("cannot get %s: %v", m.name, errs)
+				}
+				if diff := cmp.Diff(m.want, entryPaths(e)); diff != "" {
+					t.Errorf("%s entries (-want, +got):\n%s", m.name, diff)
+				}
+			}
+			if e := ms.Modules["app"]; e.Container[0].Leaf[0].Type.YangType.Kind != Ystring {
+				t.Errorf("type of t from import-only lib not resolved, got %v", e.Container[0].Leaf[0].Type.YangType.Kind)
+			}
+		})
+	}
+
+	ms := NewModules()
+	ms.SetConformance(ImportOnly, "lib")
+	if got := ms.Conformance("lib"); got != ImportOnly || got.String() != "import" {
+		t.Errorf("Conformance(lib) = %v, want import", got)
+	}
+	ms.SetConformance(Implement, "lib")
+	if got := ms.Conformance("lib"); got != Implement || got.String() != "implement" {
+		t.Errorf("Conformance(lib) = %v, want implement", got)
+	}
+}
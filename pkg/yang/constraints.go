@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// A ConstraintInfo describes a must or when statement found in the schema.
+type ConstraintInfo struct {
+	Kind       string // Kind is "must" or "when".
+	Expression string // Expression is the XPath expression.
+	// Context is the schema path of the context node of the expression,
+	// as given by RFC 7950 sections 7.5.3 and 7.21.5.  The context node of
+	// a when statement of an augment, uses, choice or case statement is
+	// the closest ancestor data node, that of any other statement is the
+	// node the statement applies to.
+	Context string
+	// Module is the name of the module the statement is written in, the
+	// module a submodule belongs to.
+	Module string
+	// Error is the error-app-tag and error-message of a must statement,
+	// if any.
+	Error *ConstraintError
+	// Node is the *Must or *Value of the statement.
+	Node Node
+}
+
+// Constraints returns the must and when statements of the processed modules
+// in ms.  Modules are in the order of their names, and the statements of
+// each in the order of the schema paths they apply to.  A statement that
+// applies to several nodes, such as the when statement of an augment or uses
+// statement, is listed once.
+func (ms *Modules) Constraints() []*ConstraintInfo {
+	var infos []*ConstraintInfo
+	seen := map[Node]bool{}
+	var walk func(e *Entry)
+	walk = func(e *Entry) {
+		for _, m := range e.Musts() {
+			if !seen[m] {
+				seen[m] = true
+				infos = append(infos, &ConstraintInfo{
+					Kind:       "must",
+					Expression: m.Name,
+					Context:    e.Path(),
+					Module:     constraintModule(m),
+					Error:      m.ConstraintError(),
+					Node:       m,
+				})
+			}
+		}
+		for _, x := range e.Extra["when"] {
+			w, ok := x.(*Value)
+			if !ok || seen[w] {
+				continue
+			}
+			seen[w] = true
+			context := e
+			switch w.Parent.(type) {
+			case *Augment, *Uses, *Choice, *Case:
+				context = e.DataParent()
+			}
+			infos = append(infos, &ConstraintInfo{
+				Kind:       "when",
+				Expression: w.Name,
+				Context:    context.Path(),
+				Module:     constraintModule(w),
+				Node:       w,
+			})
+		}
+		for _, c := range e.children() {
+			walk(c)
+		}
+	}
+	for _, m := range ms.SortedModules() {
+		walk(ToEntry(m))
+	}
+	return infos
+}
+
+// constraintModule returns the name of the module n is written in.
+func constraintModule(n Node) string {
+	m := RootNode(n)
+	switch {
+	case m == nil:
+		return ""
+	case m.BelongsTo != nil:
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
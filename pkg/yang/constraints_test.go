@@ -0,0 +1,77 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConstraints(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"a.yang": `module a {
+	prefix a;
+	namespace "urn:a";
+	container c {
+		must "x > 0" { error-app-tag "x-zero"; }
+		leaf x { type int8; }
+		leaf y {
+			when "../x > 1";
+			type int8;
+		}
+		choice ch {
+			when "x > 2";
+			leaf z { type string; }
+		}
+	}
+}`,
+		"b.yang": `module b {
+	prefix b;
+	namespace "urn:b";
+	import a { prefix a; }
+	grouping g {
+		leaf g1 { type string; }
+		leaf g2 { type string; }
+	}
+	augment "/a:c" {
+		when "a:x = 3";
+		uses g;
+	}
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process: %v", errs)
+	}
+	var got []string
+	for _, c := range ms.Constraints() {
+		got = append(got, fmt.Sprintf("%s %s %s %q at %s %v", Source(c.Node), c.Module, c.Kind, c.Expression, c.Context, c.Error))
+	}
+	want := []string{
+		`a.yang:5:3 a must "x > 0" at /a/c &{x-zero }`,
+		`a.yang:12:4 a when "x > 2" at /a/c <nil>`,
+		`b.yang:10:3 b when "a:x = 3" at /a/c <nil>`,
+		`a.yang:8:4 a when "../x > 1" at /a/c/y <nil>`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Constraints() (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"sort"
+	"strings"
+)
+
+// A TelemetryCoverage is the coverage of the schema of a set of modules by
+// the concrete paths of the telemetry received from devices, as returned by
+// Modules.TelemetryCoverage.
+type TelemetryCoverage struct {
+	// Unknown are the paths received that are not data nodes of the
+	// schema, sorted and without duplicates.
+	Unknown []string
+	// Deprecated are the deprecated and obsolete nodes that the paths
+	// received are, or are within, in the order of StatusReport.
+	Deprecated []*StatusInfo
+	// Total is the coverage of the whole schema, whose Path is "/".
+	Total *SubtreeCoverage
+	// Subtrees are the coverage of each container and list of the schema,
+	// down to the depth requested, in depth first order of their names.
+	Subtrees []*SubtreeCoverage
+}
+
+// A SubtreeCoverage is the coverage of the leaves and leaf-lists of a
+// subtree of the schema.
+type SubtreeCoverage struct {
+	// Path is the data path of the root of the subtree, qualified by
+	// module names as in gNMI.
+	Path string
+	// Depth is the depth of the root of the subtree, 1 for the top level
+	// data nodes of a module.
+	Depth int
+	// Leaves is the number of leaves and leaf-lists of the subtree.
+	Leaves int
+	// Covered is the number of those that are a path received, or are
+	// within one.
+	Covered int
+	Entry   *Entry
+}
+
+// Percent returns the percentage of the leaves of s that are covered, 100
+// if s has no leaves.
+func (s *SubtreeCoverage) Percent() float64 {
+	if s.Leaves == 0 {
+		return 100
+	}
+	return 100 * float64(s.Covered) / float64(s.Leaves)
+}
+
+// TelemetryCoverage maps paths, the concrete paths of the telemetry received
+// from devices such as "/interfaces/interface[name=eth0]/state/counters/in-octets",
+// onto the data nodes of the processed modules of ms and returns the schema
+// coverage they give.  Each step of a path may be qualified by a module name
+// or prefix, which selects the module of a top level node and is otherwise
+// ignored, and the values of key predicates are ignored.  A path to a container or list
+// covers all of its leaves.  The coverage of each container and list, down
+// to depth levels of data nodes, is reported, or of every one if depth is 0.
+// The inputs and outputs of RPCs and notifications are not part of the
+// schema that is covered.
+func (ms *Modules) TelemetryCoverage(paths []string, depth int) *TelemetryCoverage {
+	roots := ms.telemetryRoots()
+	covered := map[*Entry]bool{}
+	reached := map[*Entry]bool{}
+	unknown := map[string]bool{}
+	for _, p := range paths {
+		e := telemetryEntry(roots, p)
+		if e == nil {
+			unknown[p] = true
+			continue
+		}
+		for a := e; a != nil && a.Parent != nil; a = a.Parent {
+			reached[a] = true
+		}
+		var cover func(e *Entry)
+		cover = func(e *Entry) {
+			covered[e] = true
+			for _, c := range telemetryChildren(e) {
+				cover(c)
+			}
+		}
+		cover(e)
+	}
+
+	c := &TelemetryCoverage{Total: &SubtreeCoverage{Path: "/"}}
+	for p := range unknown {
+		c.Unknown = append(c.Unknown, p)
+	}
+	sort.Strings(c.Unknown)
+	for _, si := range ms.StatusReport() {
+		if reached[si.Entry] || covered[si.Entry] {
+			c.Deprecated = append(c.Deprecated, si)
+		}
+	}
+
+	// walk adds the leaves of the subtree rooted at e, at depth d, to the
+	// coverage of its ancestors.
+	var walk func(e *Entry, d int, ancestors []*SubtreeCoverage)
+	walk = func(e *Entry, d int, ancestors []*SubtreeCoverage) {
+		if e.IsLeaf() || e.IsLeafList() {
+			for _, s := range ancestors {
+				s.Leaves++
+				if covered[e] {
+					s.Covered++
+				}
+			}
+			return
+		}
+		if depth == 0 || d <= depth {
+			s := &SubtreeCoverage{Path: e.PathString(PathOpts{Qualifier: ModuleNames}), Depth: d, Entry: e}
+			c.Subtrees = append(c.Subtrees, s)
+			ancestors = append(ancestors, s)
+		}
+		for _, ch := range telemetryChildren(e) {
+			walk(ch, d+1, ancestors)
+		}
+	}
+	for _, e := range roots {
+		walk(e, 1, []*SubtreeCoverage{c.Total})
+	}
+	return c
+}
+
+// telemetryRoots returns the top level data nodes of the processed modules of
+// ms, ordered by module and name.
+func (ms *Modules) telemetryRoots() []*Entry {
+	var roots []*Entry
+	for _, m := range ms.SortedModules() {
+		roots = append(roots, telemetryChildren(ToEntry(m))...)
+	}
+	return roots
+}
+
+// telemetryChildren returns the data node children of e, other than RPCs,
+// actions and notifications, ordered by name.
+func telemetryChildren(e *Entry) []*Entry {
+	var children []*Entry
+	for _, c := range dataChildren(e) {
+		if c.RPC == nil && c.Kind != NotificationEntry {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+// telemetryEntry returns the data node at the concrete path p below roots,
+// the top level data nodes, or nil if there is none.
+func telemetryEntry(roots []*Entry, p string) *Entry {
+	if i := strings.Index(p, ":/"); i >= 0 && !strings.ContainsAny(p[:i], "/[") {
+		// Remove the origin of a gNMI path.
+		p = p[i+1:]
+	}
+	steps := splitPath(strings.TrimPrefix(p, "/"))
+	if len(steps) == 0 {
+		return nil
+	}
+	var e *Entry
+	for i, step := range steps {
+		name, preds, err := splitStep(step)
+		if err != nil {
+			return nil
+		}
+		if i == 0 {
+			e = telemetryRoot(roots, step, name)
+		} else {
+			e = e.DataChild(name)
+		}
+		if e == nil || e.RPC != nil || e.Kind == NotificationEntry || checkPredicates(e, preds) != nil {
+			return nil
+		}
+	}
+	return e
+}
+
+// telemetryRoot returns the node of roots named name of the first step step
+// of a path, selected by the module name, or prefix, of step if it has one.
+func telemetryRoot(roots []*Entry, step, name string) *Entry {
+	if i := strings.IndexByte(step, '['); i >= 0 {
+		step = step[:i]
+	}
+	qualifier, _ := getPrefix(step)
+	for _, e := range roots {
+		if e.Name != name {
+			continue
+		}
+		if qualifier == "" {
+			return e
+		}
+		if m, ok := e.Root().Node.(*Module); ok && (m.Name == qualifier || m.GetPrefix() == qualifier) {
+			return e
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTelemetryCoverage(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module tc { prefix t; namespace "urn:t";
+		container interfaces {
+			list interface {
+				key "name";
+				leaf name { type string; }
+				container state {
+					leaf oper-status { type string; }
+					leaf old-status { type string; status deprecated; description "Replaced by oper-status."; }
+					container counters {
+						leaf in-octets { type uint64; }
+						leaf out-octets { type uint64; }
+					}
+				}
+			}
+		}
+		container system { leaf hostname { type string; } }
+		rpc reboot { input { leaf delay { type uint32; } } }
+	}`, "tc.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	paths := []string{
+		"/interfaces/interface[name=eth0]/state/counters",
+		"/tc:interfaces/interface[name=eth1]/state/old-status",
+		"/t:interfaces/interface[name=eth1]/state/counters/in-octets",
+		"/interfaces/interface[name=eth0]/state/admin-status",
+		"/interfaces/interface[id=1]/name",
+		"/other:interfaces/interface/name",
+		"/reboot/delay",
+		"/interfaces/interface[name=eth0]/state/admin-status",
+	}
+	c := ms.TelemetryCoverage(paths, 2)
+
+	if diff := cmp.Diff([]string{
+		"/interfaces/interface[id=1]/name",
+		"/interfaces/interface[name=eth0]/state/admin-status",
+		"/other:interfaces/interface/name",
+		"/reboot/delay",
+	}, c.Unknown); diff != "" {
+		t.Errorf("Unknown (-want, +got):\n%s", diff)
+	}
+	var deprecated []string
+	for _, si := range c.Deprecated {
+		deprecated = append(deprecated, si.Path)
+	}
+	if diff := cmp.Diff([]string{"/tc/interfaces/interface/state/old-status"}, deprecated); diff != "" {
+		t.Errorf("Deprecated (-want, +got):\n%s", diff)
+	}
+
+	type coverage struct {
+		Path                   string
+		Depth, Leaves, Covered int
+	}
+	var got []coverage
+	for _, s := range append([]*SubtreeCoverage{c.Total}, c.Subtrees...) {
+		got = append(got, coverage{s.Path, s.Depth, s.Leaves, s.Covered})
+	}
+	want := []coverage{
+		{"/", 0, 6, 3},
+		{"/tc:interfaces", 1, 5, 3},
+		{"/tc:interfaces/interface", 2, 5, 3},
+		{"/tc:system", 1, 1, 0},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("coverage (-want, +got):\n%s", diff)
+	}
+	if got, want := c.Subtrees[0].Percent(), 60.0; got != want {
+		t.Errorf("Percent() = %v, want %v", got, want)
+	}
+
+	if got := len(ms.TelemetryCoverage(nil, 0).Subtrees); got != 5 {
+		t.Errorf("TelemetryCoverage(nil, 0) has %d subtrees, want 5", got)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A Dependency is an import or include statement of a module or submodule.
+type Dependency struct {
+	Module  string // Module is the name of the importing or including module.
+	Keyword string // Keyword is "import" or "include".
+	Target  string // Target is the name of the imported or included module.
+	Source  string // Source is the location of the statement.
+}
+
+// String returns the location of d followed by the statement, e.g.,
+// "a.yang:4:2: a import b".
+func (d *Dependency) String() string {
+	return fmt.Sprintf("%s: %s %s %s", d.Source, d.Module, d.Keyword, d.Target)
+}
+
+// A DependencyCycle is a chain of import and include statements that leads
+// from a module back to itself: the first statement is in the module the
+// last statement imports or includes.
+type DependencyCycle []*Dependency
+
+// String returns the statements of c separated by " -> ".
+func (c DependencyCycle) String() string {
+	var s []string
+	for _, d := range c {
+		s = append(s, d.String())
+	}
+	return strings.Join(s, " -> ")
+}
+
+// imports returns true if c has an import statement, rather than only
+// the include statements of submodules including each other.
+func (c DependencyCycle) imports() bool {
+	for _, d := range c {
+		if d.Keyword == "import" {
+			return true
+		}
+	}
+	return false
+}
+
+// A CycleError is returned by Modules.Process for a cycle of imports, which
+// RFC 7950 section 5.1 does not allow, unless Options.AllowImportCycles is
+// set.
+type CycleError struct {
+	Cycle DependencyCycle
+}
+
+// Error returns the location of the first statement of the cycle and the
+// statements of the cycle.
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%s: circular import: %s", e.Cycle[0].Source, e.Cycle)
+}
+
+func (e *CycleError) errorCode() ErrorCode { return "GY0110" }
+
+// Cycles returns the cycles of the import and include statements of the
+// modules and submodules of ms, whose statements must have been resolved by
+// Modules.Process.  The statements are followed depth first, from the
+// modules ordered by name and in the order they are written, and a cycle is
+// reported for each statement that leads back to a module being followed,
+// so a cycle is not reported again from each of the modules it passes
+// through.
+func (ms *Modules) Cycles() []DependencyCycle {
+	var mods []*Module
+	for _, m := range byKey(ms.Modules) {
+		mods = append(mods, m)
+	}
+	for _, m := range byKey(ms.SubModules) {
+		mods = append(mods, m)
+	}
+	c := &cycleFinder{state: map[*Module]int{}}
+	for _, m := range mods {
+		c.visit(m)
+	}
+	return c.cycles
+}
+
+// The states of a module visited by a cycleFinder.
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// A cycleFinder finds the cycles of import and include statements by
+// following them depth first.
+type cycleFinder struct {
+	state  map[*Module]int
+	stack  []*Dependency // stack has the statements followed to reach a module.
+	mods   []*Module     // mods has the module of each statement of stack.
+	cycles []DependencyCycle
+}
+
+// visit follows the import and include statements of m.
+func (c *cycleFinder) visit(m *Module) {
+	if m == nil || c.state[m] != unvisited {
+		return
+	}
+	c.state[m] = visiting
+	var deps []Node
+	for _, i := range m.Include {
+		deps = append(deps, i)
+	}
+	for _, i := range m.Import {
+		deps = append(deps, i)
+	}
+	for _, n := range deps {
+		var target *Module
+		d := &Dependency{Module: m.Name, Keyword: n.Kind(), Target: n.NName(), Source: Source(n)}
+		switch n := n.(type) {
+		case *Include:
+			target = n.Module
+		case *Import:
+			target = n.Module
+		}
+		if target == nil {
+			continue
+		}
+		switch c.state[target] {
+		case visiting:
+			cycle := DependencyCycle{d}
+			for i := len(c.mods) - 1; i >= 0; i-- {
+				cycle = append(DependencyCycle{c.stack[i]}, cycle...)
+				if c.mods[i] == target {
+					break
+				}
+			}
+			c.cycles = append(c.cycles, cycle)
+		case unvisited:
+			c.stack = append(c.stack, d)
+			c.mods = append(c.mods, m)
+			c.visit(target)
+			c.stack = c.stack[:len(c.stack)-1]
+			c.mods = c.mods[:len(c.mods)-1]
+		}
+	}
+	c.state[m] = visited
+}
+
+// importCycles returns a CycleError for each cycle of ms that has an import
+// statement, unless import cycles are allowed.
+func (ms *Modules) importCycles() []error {
+	if ms.ParseOptions.AllowImportCycles {
+		return nil
+	}
+	var errs []error
+	for _, c := range ms.Cycles() {
+		if c.imports() {
+			errs = append(errs, &CycleError{Cycle: c})
+		}
+	}
+	return errs
+}
+
+// includeCycle returns the cycle through the include statement of m
+// including sub, or nil if none is known.
+func (ms *Modules) includeCycle(m, sub string) DependencyCycle {
+	for _, c := range ms.Cycles() {
+		for _, d := range c {
+			if d.Keyword == "include" && d.Module == m && d.Target == sub {
+				return c
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCycles(t *testing.T) {
+	tests := []struct {
+		name        string
+		inModules   map[string]string
+		allowCycles bool
+		wantErrs    []string
+		wantCycles  []string
+	}{{
+		name: "no cycle",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix b; } }`,
+			"b": `module b { prefix b; namespace "urn:b"; }`,
+		},
+	}, {
+		name: "import cycle",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix b; } }`,
+			"b": `module b { prefix b; namespace "urn:b"; import c { prefix c; } }`,
+			"c": `module c { prefix c; namespace "urn:c"; import a { prefix a; } }`,
+		},
+		wantErrs: []string{
+			"a:1:41: circular import: a:1:41: a import b -> b:1:41: b import c -> c:1:41: c import a",
+		},
+		wantCycles: []string{
+			"a:1:41: a import b -> b:1:41: b import c -> c:1:41: c import a",
+		},
+	}, {
+		name: "import cycle allowed",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix b; } }`,
+			"b": `module b { prefix b; namespace "urn:b"; import a { prefix a; } }`,
+		},
+		allowCycles: true,
+		wantCycles: []string{
+			"a:1:41: a import b -> b:1:41: b import a",
+		},
+	}, {
+		name: "include cycle",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; include x; include y; }`,
+			"x": `submodule x { belongs-to a { prefix a; } include y; }`,
+			"y": `submodule y { belongs-to a { prefix a; } include x; }`,
+		},
+		wantErrs: []string{
+			"y: has a circular dependency, importing x: x:1:42: x include y -> y:1:42: y include x",
+		},
+		wantCycles: []string{
+			"x:1:42: x include y -> y:1:42: y include x",
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.AllowImportCycles = tt.allowCycles
+			for name, src := range tt.inModules {
+				if err := ms.Parse(src, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			var gotErrs []string
+			for _, err := range ms.Process() {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, gotErrs); diff != "" {
+				t.Errorf("Process errors (-want, +got):\n%s", diff)
+			}
+			var gotCycles []string
+			for _, c := range ms.Cycles() {
+				gotCycles = append(gotCycles, fmt.Sprint(c))
+			}
+			if diff := cmp.Diff(tt.wantCycles, gotCycles); diff != "" {
+				t.Errorf("Cycles (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// This file has functions that report where the effective default and units
+// of a leaf or leaf-list come from.  The precedence, from RFC 7950 sections
+// 7.3.4, 7.6.1 and 7.7.4, is:
+//
+//   - a default or units statement of the leaf or leaf-list, or one added or
+//     replaced by a deviation of it, and otherwise
+//   - that of the typedef the type of the leaf names, and otherwise that of
+//     the typedef it is derived from, and so on, the nearest typedef in the
+//     chain winning.
+//
+// The default of a typedef is not used by a mandatory leaf, or a leaf-list
+// with min-elements greater than 0.  A deviation that deletes the default of
+// a leaf makes the default of its type the effective one.
+
+// A ValueLevel is the level of the schema that supplied a value.
+type ValueLevel int
+
+const (
+	// NoValue is the level of a value that is not set.
+	NoValue ValueLevel = iota
+	// LeafValue is the level of a value supplied by the leaf or
+	// leaf-list itself.
+	LeafValue
+	// DeviationValue is the level of a value supplied by a deviation of
+	// the leaf or leaf-list.
+	DeviationValue
+	// TypedefValue is the level of a value supplied by a typedef.
+	TypedefValue
+)
+
+func (l ValueLevel) String() string {
+	switch l {
+	case NoValue:
+		return "none"
+	case LeafValue:
+		return "leaf"
+	case DeviationValue:
+		return "deviation"
+	case TypedefValue:
+		return "typedef"
+	}
+	return "unknown"
+}
+
+// A ValueSource describes where the effective value of a default or units
+// of a leaf or leaf-list entry came from.
+type ValueSource struct {
+	Level ValueLevel
+	// Typedef is the typedef that supplied the value, for TypedefValue.
+	Typedef *Typedef
+	// Depth is the position of Typedef in the chain of typedefs of the
+	// type of the entry, 1 for the typedef the type names.
+	Depth int
+}
+
+// typedefChain returns the typedefs of the chain of t, starting with the one
+// the type of t names, and ending before the builtin type.
+func typedefChain(t *YangType) []*Typedef {
+	var chain []*Typedef
+	for seen := map[*YangType]bool{}; t != nil && t.Base != nil && !seen[t]; {
+		seen[t] = true
+		td, ok := t.Base.Parent.(*Typedef)
+		if !ok || td.Parent == nil {
+			break
+		}
+		chain = append(chain, td)
+		t = td.Type.YangType
+	}
+	return chain
+}
+
+// statementValues returns the arguments of the substatements of n with
+// keyword.
+func statementValues(n Node, keyword string) []string {
+	if n == nil || n.Statement() == nil {
+		return nil
+	}
+	var values []string
+	for _, s := range n.Statement().SubStatements() {
+		if s.Keyword == keyword {
+			values = append(values, s.Argument)
+		}
+	}
+	return values
+}
+
+// entrySource returns the level of values, the values of an entry whose node
+// is n, given the arguments of its own statements of keyword.
+func entrySource(n Node, keyword string, values []string) ValueSource {
+	own := statementValues(n, keyword)
+	if len(own) != len(values) {
+		return ValueSource{Level: DeviationValue}
+	}
+	for i, v := range own {
+		if v != values[i] {
+			return ValueSource{Level: DeviationValue}
+		}
+	}
+	return ValueSource{Level: LeafValue}
+}
+
+// DefaultSource returns the effective default values of e, as returned by
+// DefaultValues, and where they came from.
+func (e *Entry) DefaultSource() ([]string, ValueSource) {
+	values := e.DefaultValues()
+	switch {
+	case len(values) == 0:
+		return nil, ValueSource{}
+	case len(e.Default) > 0:
+		return values, entrySource(e.Node, "default", e.Default)
+	}
+	for i, td := range typedefChain(e.Type) {
+		if td.Default != nil {
+			return values, ValueSource{Level: TypedefValue, Typedef: td, Depth: i + 1}
+		}
+	}
+	return values, ValueSource{}
+}
+
+// EffectiveUnits returns the units of the values of e, which are those of e
+// itself or, if e has none, those of its type, and where they came from.
+func (e *Entry) EffectiveUnits() (string, ValueSource) {
+	if e.Units != "" {
+		return e.Units, entrySource(e.Node, "units", []string{e.Units})
+	}
+	for i, td := range typedefChain(e.Type) {
+		if td.Units != nil {
+			return td.Units.Name, ValueSource{Level: TypedefValue, Typedef: td, Depth: i + 1}
+		}
+	}
+	return "", ValueSource{}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValueSources(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module m {
+  namespace "urn:m";
+  prefix m;
+  typedef base { type uint32; default 1; units seconds; }
+  typedef middle { type base; units milliseconds; }
+  typedef top { type middle; }
+  typedef redefault { type top; default 5; }
+
+  container c {
+    leaf plain { type string; }
+    leaf own { type top; default 2; units minutes; }
+    leaf deep { type top; }
+    leaf redefaulted { type redefault; }
+    leaf mandatory { type top; mandatory true; }
+    leaf-list list { type top; default 3; default 4; }
+    leaf-list inherit { type top; }
+    leaf-list required { type top; min-elements 1; }
+    leaf added { type string; }
+    leaf replaced { type top; default 6; }
+    leaf deleted { type top; default 7; }
+    leaf unitless { type top; units hours; }
+  }
+
+  deviation /m:c/m:added { deviate add { default x; units bytes; } }
+  deviation /m:c/m:replaced { deviate replace { default 8; } }
+  deviation /m:c/m:deleted { deviate delete { default 7; } }
+  deviation /m:c/m:unitless { deviate delete { units hours; } }
+}`, "m.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	// source describes a ValueSource by its level, typedef and depth.
+	type source struct {
+		Level   ValueLevel
+		Typedef string
+		Depth   int
+	}
+	describe := func(vs ValueSource) source {
+		s := source{Level: vs.Level, Depth: vs.Depth}
+		if vs.Typedef != nil {
+			s.Typedef = vs.Typedef.Name
+		}
+		return s
+	}
+	for _, tt := range []struct {
+		name          string
+		wantDefault   []string
+		wantDefSource source
+		wantUnits     string
+		wantUnSource  source
+	}{
+		{name: "plain"},
+		{
+			name:          "own",
+			wantDefault:   []string{"2"},
+			wantDefSource: source{Level: LeafValue},
+			wantUnits:     "minutes",
+			wantUnSource:  source{Level: LeafValue},
+		},
+		{
+			name:          "deep",
+			wantDefault:   []string{"1"},
+			wantDefSource: source{TypedefValue, "base", 3},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "redefaulted",
+			wantDefault:   []string{"5"},
+			wantDefSource: source{TypedefValue, "redefault", 1},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 3},
+		},
+		{
+			name:         "mandatory",
+			wantUnits:    "milliseconds",
+			wantUnSource: source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "list",
+			wantDefault:   []string{"3", "4"},
+			wantDefSource: source{Level: LeafValue},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "inherit",
+			wantDefault:   []string{"1"},
+			wantDefSource: source{TypedefValue, "base", 3},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+		{
+			name:         "required",
+			wantUnits:    "milliseconds",
+			wantUnSource: source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "added",
+			wantDefault:   []string{"x"},
+			wantDefSource: source{Level: DeviationValue},
+			wantUnits:     "bytes",
+			wantUnSource:  source{Level: DeviationValue},
+		},
+		{
+			name:          "replaced",
+			wantDefault:   []string{"8"},
+			wantDefSource: source{Level: DeviationValue},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "deleted",
+			wantDefault:   []string{"1"},
+			wantDefSource: source{TypedefValue, "base", 3},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+		{
+			name:          "unitless",
+			wantDefault:   []string{"1"},
+			wantDefSource: source{TypedefValue, "base", 3},
+			wantUnits:     "milliseconds",
+			wantUnSource:  source{TypedefValue, "middle", 2},
+		},
+	} {
+		e := ToEntry(ms.Modules["m"]).Dir["c"].Dir[tt.name]
+		def, defSource := e.DefaultSource()
+		if diff := cmp.Diff(tt.wantDefault, def); diff != "" {
+			t.Errorf("%s: DefaultSource values (-want, +got):\n%s", tt.name, diff)
+		}
+		if got := describe(defSource); got != tt.wantDefSource {
+			t.Errorf("%s: DefaultSource source got %+v, want %+v", tt.name, got, tt.wantDefSource)
+		}
+		units, unSource := e.EffectiveUnits()
+		if units != tt.wantUnits {
+			t.Errorf("%s: EffectiveUnits got %q, want %q", tt.name, units, tt.wantUnits)
+		}
+		if got := describe(unSource); got != tt.wantUnSource {
+			t.Errorf("%s: EffectiveUnits source got %+v, want %+v", tt.name, got, tt.wantUnSource)
+		}
+		// The effective units are always those of the type when the
+		// entry has none.
+		if e.Units == "" && e.Type.Units != units {
+			t.Errorf("%s: type units %q, effective units %q", tt.name, e.Type.Units, units)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A DerivationStep is one of the statements that placed, or modified, an
+// entry.
+type DerivationStep struct {
+	// Kind is "defined", "grouping", "uses", "refine", "augment" or
+	// "deviate".
+	Kind string
+	// Node is the statement of the step.
+	Node Node
+	// Detail is the argument of the statement, e.g., the name of the
+	// grouping or the target of the augment.  For a deviate statement it
+	// is its argument followed by the properties it changes.
+	Detail string
+}
+
+// String returns the location, kind and detail of s.
+func (s DerivationStep) String() string {
+	return fmt.Sprintf("%s: %s %s", Source(s.Node), s.Kind, s.Detail)
+}
+
+// Derivation returns the statements that placed e in its entry tree, and
+// that modified it, in the order they apply: the statement defining e, the
+// grouping it is defined in and the uses statement expanding that grouping,
+// with the refine statements of the uses that target e, and so on out to the
+// module, including the augments that placed e, or an ancestor defined with
+// it, followed by the deviate statements of the deviations of e.  Only the
+// ancestors defined in the same grouping or augment as e are followed, the
+// derivation of an augment's target is the derivation of that entry.
+func (e *Entry) Derivation() []DerivationStep {
+	if e == nil || e.Node == nil {
+		return nil
+	}
+	steps := []DerivationStep{{Kind: "defined", Node: e.Node, Detail: e.Node.Kind() + " " + e.Node.NName()}}
+
+	// a is the entry that n is merged into.  For a data definition
+	// statement it is its own entry, for a uses or augment it is the
+	// entry that has their children.
+	n, a := e.Node, e
+	for a != nil {
+		owner := a
+		if a.Node == n {
+			owner = a.Parent
+		}
+		switch p := n.ParentNode().(type) {
+		case nil, *Module:
+			a = nil
+		case *Grouping:
+			steps = append(steps, DerivationStep{Kind: "grouping", Node: p, Detail: p.Name})
+			u := owner.findUses(func(u *Uses) bool { return usesGrouping(u) == p })
+			if u == nil {
+				a = nil
+				break
+			}
+			steps = e.appendUses(steps, u, owner)
+			n, a = u, owner
+		case *Augment:
+			steps = append(steps, DerivationStep{Kind: "augment", Node: p, Detail: p.Name})
+			n, a = p, owner
+		default:
+			// Skip the entries, such as shorthand cases, that n
+			// has no statement for.
+			for a != nil && a.Node != p {
+				a = a.Parent
+			}
+			n = p
+		}
+	}
+
+	if m, ok := e.Root().Node.(*Module); ok && m.Modules != nil {
+		path := e.Path()
+		for _, d := range m.Modules.Deviations() {
+			if deviationPath(d.Deviate, d.Path) != path {
+				continue
+			}
+			detail := d.Type.String()
+			if len(d.Properties) > 0 {
+				detail += " (" + strings.Join(d.Properties, ", ") + ")"
+			}
+			steps = append(steps, DerivationStep{Kind: "deviate", Node: d.Deviate, Detail: detail})
+		}
+	}
+	return steps
+}
+
+// appendUses appends the steps of u, a uses statement whose children are
+// merged into owner, to steps: u and its refine statements that target e.
+func (e *Entry) appendUses(steps []DerivationStep, u *Uses, owner *Entry) []DerivationStep {
+	steps = append(steps, DerivationStep{Kind: "uses", Node: u, Detail: u.Name})
+	var names []string
+	for d := e; d != nil && d != owner; d = d.Parent {
+		names = append([]string{d.Name}, names...)
+	}
+	rel := strings.Join(names, "/")
+	for _, r := range u.Refine {
+		if stripPrefixes(r.Name) == rel {
+			steps = append(steps, DerivationStep{Kind: "refine", Node: r, Detail: r.Name})
+		}
+	}
+	return steps
+}
+
+// findUses returns the uses statement for which match returns true among
+// those whose children are merged into e: the uses statements of the node of
+// e and of the augments of e, and those of the groupings they expand, or nil
+// if there is none.
+func (e *Entry) findUses(match func(*Uses) bool) *Uses {
+	if e == nil {
+		return nil
+	}
+	seen := map[*Grouping]bool{}
+	nodes := []Node{e.Node}
+	for _, a := range e.Augmented {
+		nodes = append(nodes, a.Node)
+	}
+	for _, n := range nodes {
+		if u := findUses(n, match, seen); u != nil {
+			return u
+		}
+	}
+	return nil
+}
+
+// findUses returns the uses statement of n, or of the groupings they expand,
+// for which match returns true.  seen records the groupings already searched.
+func findUses(n Node, match func(*Uses) bool, seen map[*Grouping]bool) *Uses {
+	for _, u := range nodeUses(n) {
+		if match(u) {
+			return u
+		}
+		g := usesGrouping(u)
+		if g == nil || seen[g] {
+			continue
+		}
+		seen[g] = true
+		if u := findUses(g, match, seen); u != nil {
+			return u
+		}
+	}
+	return nil
+}
+
+// nodeUses returns the uses statements of n.
+func nodeUses(n Node) []*Uses {
+	if n == nil || reflect.ValueOf(n).IsNil() {
+		return nil
+	}
+	v := reflect.ValueOf(n).Elem().FieldByName("Uses")
+	if !v.IsValid() {
+		return nil
+	}
+	uses, _ := v.Interface().([]*Uses)
+	return uses
+}
+
+// usesGrouping returns the grouping expanded by u, or nil if it cannot be
+// found.
+func usesGrouping(u *Uses) *Grouping {
+	return FindGrouping(u, u.Name, map[string]bool{})
+}
+
+// deviationPath returns the entry path, e.g., "/mod/c/x", of path, the
+// target of the deviation of d.
+func deviationPath(d Node, path string) string {
+	var names []string
+	for i, p := range strings.Split(strings.Trim(path, "/"), "/") {
+		prefix, name := getPrefix(p)
+		if i == 0 {
+			m := FindModuleByPrefix(d, prefix)
+			if m == nil {
+				return ""
+			}
+			names = append(names, module(m).Name)
+		}
+		names = append(names, name)
+	}
+	return "/" + strings.Join(names, "/")
+}
+
+// stripPrefixes returns the descendant schema node identifier path without
+// the prefixes of its nodes.
+func stripPrefixes(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range parts {
+		_, parts[i] = getPrefix(p)
+	}
+	return strings.Join(parts, "/")
+}
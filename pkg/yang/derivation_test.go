@@ -0,0 +1,142 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testDerivationModule = `
+module dv {
+	prefix "d";
+	namespace "urn:d";
+
+	grouping inner {
+		leaf x { type string; }
+	}
+	grouping outer {
+		container d {
+			uses inner;
+		}
+		uses inner;
+	}
+	container c {
+		uses outer {
+			refine "d/x" { description "refined"; }
+		}
+		choice ch {
+			leaf short { type string; }
+		}
+	}
+	container t {}
+	augment "/t" {
+		uses inner {
+			refine x { default "a"; }
+		}
+	}
+}
+`
+
+const testDerivationDeviationModule = `
+module dv-dev {
+	prefix "dd";
+	namespace "urn:dd";
+	import dv { prefix d; }
+
+	deviation /d:c/d:d/d:x {
+		deviate replace { type int32; }
+	}
+}
+`
+
+func TestDerivation(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"dv.yang":     testDerivationModule,
+		"dv-dev.yang": testDerivationDeviationModule,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	e, errs := ms.GetModule("dv")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{{
+		path: "c",
+		want: []string{
+			"dv.yang:15:2: defined container c",
+		},
+	}, {
+		path: "c/x",
+		want: []string{
+			"dv.yang:7:3: defined leaf x",
+			"dv.yang:6:2: grouping inner",
+			"dv.yang:13:3: uses inner",
+			"dv.yang:9:2: grouping outer",
+			"dv.yang:16:3: uses outer",
+		},
+	}, {
+		path: "c/d/x",
+		want: []string{
+			"dv.yang:7:3: defined leaf x",
+			"dv.yang:6:2: grouping inner",
+			"dv.yang:11:4: uses inner",
+			"dv.yang:9:2: grouping outer",
+			"dv.yang:16:3: uses outer",
+			"dv.yang:17:4: refine d/x",
+			"dv-dev.yang:8:3: deviate replace (type)",
+		},
+	}, {
+		path: "c/ch/short/short",
+		want: []string{
+			"dv.yang:20:4: defined leaf short",
+		},
+	}, {
+		path: "t/x",
+		want: []string{
+			"dv.yang:7:3: defined leaf x",
+			"dv.yang:6:2: grouping inner",
+			"dv.yang:25:3: uses inner",
+			"dv.yang:26:4: refine x",
+			"dv.yang:24:2: augment /t",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			c := e.Find(tt.path)
+			if c == nil {
+				t.Fatalf("cannot find %s", tt.path)
+			}
+			var got []string
+			for _, s := range c.Derivation() {
+				got = append(got, s.String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Derivation (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
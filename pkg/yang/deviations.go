@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// A DeviationInfo describes a single deviate statement found in a module.
+type DeviationInfo struct {
+	Module string        // Module is the name of the module making the deviation.
+	Path   string        // Path is the target of the deviation.
+	Type   deviationType // Type is the kind of deviate statement.
+	// Properties lists the keywords of the properties the deviate
+	// statement changes, such as "config" or "default".  It is empty for
+	// not-supported deviations.
+	Properties []string
+	Source     string   // Source is the location of the deviate statement.
+	Deviate    *Deviate // Deviate is the deviate statement itself.
+}
+
+// Deviations returns every deviate statement in the modules of ms, including
+// those of the submodules they include.  Modules are ordered by name, and the
+// deviations of each module are in the order they are written.
+func (ms *Modules) Deviations() []*DeviationInfo {
+	var devs []*DeviationInfo
+	for _, m := range ms.SortedModules() {
+		devs = appendDeviations(devs, m.Name, m, map[*Module]bool{})
+	}
+	return devs
+}
+
+// appendDeviations appends the deviations of m, and then those of the
+// submodules m includes, to devs.  name is the name of the module the
+// deviations belong to.  seen records the submodules already visited.
+func appendDeviations(devs []*DeviationInfo, name string, m *Module, seen map[*Module]bool) []*DeviationInfo {
+	if m == nil || seen[m] {
+		return devs
+	}
+	seen[m] = true
+	for _, d := range m.Deviation {
+		for _, dv := range d.Deviate {
+			devs = append(devs, &DeviationInfo{
+				Module:     name,
+				Path:       d.Name,
+				Type:       toDeviation[dv.Name],
+				Properties: dv.properties(),
+				Source:     Source(dv),
+				Deviate:    dv,
+			})
+		}
+	}
+	for _, in := range m.Include {
+		devs = appendDeviations(devs, name, in.Module, seen)
+	}
+	return devs
+}
+
+// properties returns the keywords of the properties set in d.
+func (d *Deviate) properties() []string {
+	var props []string
+	add := func(keyword string, set bool) {
+		if set {
+			props = append(props, keyword)
+		}
+	}
+	add("config", d.Config != nil)
+	add("default", d.Default != nil)
+	add("mandatory", d.Mandatory != nil)
+	add("max-elements", d.MaxElements != nil)
+	add("min-elements", d.MinElements != nil)
+	add("must", len(d.Must) > 0)
+	add("type", d.Type != nil)
+	add("unique", len(d.Unique) > 0)
+	add("units", d.Units != nil)
+	return props
+}
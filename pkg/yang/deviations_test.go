@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDeviations(t *testing.T) {
+	modules := map[string]string{
+		"target.yang": `
+			module target {
+				prefix "t";
+				namespace "urn:t";
+
+				leaf a { type string; }
+				leaf-list b { type string; }
+				container c {}
+			}`,
+		"vendor.yang": `
+			module vendor {
+				prefix "v";
+				namespace "urn:v";
+
+				import target { prefix t; }
+				include vendor-sub;
+
+				deviation /t:a {
+					deviate replace { type uint8; default 1; }
+					deviate add { config false; }
+				}
+				deviation /t:c {
+					deviate not-supported;
+				}
+			}`,
+		"vendor-sub.yang": `
+			submodule vendor-sub {
+				belongs-to vendor { prefix v; }
+
+				import target { prefix t; }
+
+				deviation /t:b {
+					deviate add { max-elements 10; must "count(.) > 0"; }
+				}
+			}`,
+	}
+	ms := NewModules()
+	for name, mod := range modules {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+
+	want := []*DeviationInfo{{
+		Module:     "vendor",
+		Path:       "/t:a",
+		Type:       DeviationReplace,
+		Properties: []string{"default", "type"},
+		Source:     "vendor.yang:10:6",
+	}, {
+		Module:     "vendor",
+		Path:       "/t:a",
+		Type:       DeviationAdd,
+		Properties: []string{"config"},
+		Source:     "vendor.yang:11:6",
+	}, {
+		Module: "vendor",
+		Path:   "/t:c",
+		Type:   DeviationNotSupported,
+		Source: "vendor.yang:14:6",
+	}, {
+		Module:     "vendor",
+		Path:       "/t:b",
+		Type:       DeviationAdd,
+		Properties: []string{"max-elements", "must"},
+		Source:     "vendor-sub.yang:8:6",
+	}}
+	if diff := cmp.Diff(want, ms.Deviations(), cmpopts.IgnoreFields(DeviationInfo{}, "Deviate"), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Deviations() (-want, +got):\n%s", diff)
+	}
+}
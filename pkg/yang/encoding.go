@@ -0,0 +1,74 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	utf8BOM    = "\xef\xbb\xbf"
+	utf16BEBOM = "\xfe\xff"
+	utf16LEBOM = "\xff\xfe"
+)
+
+// decodeSource returns input, read from path, as the UTF-8 source with LF
+// line endings expected by the lexer.  A leading byte order mark is removed,
+// input starting with a UTF-16 byte order mark is transcoded to UTF-8, and
+// CRLF line endings are replaced by LF.  An error is returned if input is not
+// valid UTF-8, giving the location of the first invalid byte.
+func decodeSource(input, path string) (string, error) {
+	switch {
+	case strings.HasPrefix(input, utf8BOM):
+		input = input[len(utf8BOM):]
+	case strings.HasPrefix(input, utf16BEBOM):
+		input = decodeUTF16(input[len(utf16BEBOM):], func(b []byte) uint16 { return uint16(b[0])<<8 | uint16(b[1]) })
+	case strings.HasPrefix(input, utf16LEBOM):
+		input = decodeUTF16(input[len(utf16LEBOM):], func(b []byte) uint16 { return uint16(b[1])<<8 | uint16(b[0]) })
+	}
+	if !utf8.ValidString(input) {
+		line, col := 1, 1
+		for i := 0; i < len(input); {
+			r, w := utf8.DecodeRuneInString(input[i:])
+			if r == utf8.RuneError && w == 1 {
+				break
+			}
+			if r == '\n' {
+				line, col = line+1, 1
+			} else {
+				col++
+			}
+			i += w
+		}
+		return "", codeErrorf("GY0009", "%s:%d:%d: invalid UTF-8 encoding, the source must be encoded as UTF-8", path, line, col)
+	}
+	if strings.Contains(input, "\r\n") {
+		input = strings.ReplaceAll(input, "\r\n", "\n")
+	}
+	return input, nil
+}
+
+// decodeUTF16 returns the UTF-8 encoding of the UTF-16 encoded input, using
+// unit to read each 16 bit code unit.  A trailing odd byte is dropped.
+func decodeUTF16(input string, unit func([]byte) uint16) string {
+	b := []byte(input)
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, unit(b[i:i+2]))
+	}
+	return string(utf16.Decode(units))
+}
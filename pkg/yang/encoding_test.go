@@ -0,0 +1,82 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+// utf16Bytes returns s encoded as UTF-16 with a byte order mark.
+func utf16Bytes(s string, bigEndian bool) string {
+	var b []byte
+	for _, u := range utf16.Encode([]rune("\ufeff" + s)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return string(b)
+}
+
+func TestDecodeSource(t *testing.T) {
+	const src = "a \"b\r\n c\" {\r\n\td;\r\n}\r\n"
+	for _, tt := range []struct {
+		desc string
+		in   string
+		err  string
+	}{{
+		desc: "CRLF line endings",
+		in:   src,
+	}, {
+		desc: "UTF-8 byte order mark",
+		in:   utf8BOM + src,
+	}, {
+		desc: "UTF-16 big endian",
+		in:   utf16Bytes(src, true),
+	}, {
+		desc: "UTF-16 little endian",
+		in:   utf16Bytes(src, false),
+	}, {
+		desc: "invalid UTF-8",
+		in:   "a {\n  b \"caf\xe9\";\n}\n",
+		err:  "enc.yang:2:9: invalid UTF-8 encoding, the source must be encoded as UTF-8",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ss, err := Parse(tt.in, "enc.yang")
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.err {
+				t.Fatalf("Parse() got error %q, want %q", gotErr, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			a := ss[0]
+			if got, want := a.Argument, "b\nc"; got != want {
+				t.Errorf("argument got %q, want %q", got, want)
+			}
+			if got, want := a.Location(), "enc.yang:1:1"; got != want {
+				t.Errorf("location of a got %s, want %s", got, want)
+			}
+			if got, want := a.statements[0].Location(), "enc.yang:3:2"; got != want {
+				t.Errorf("location of d got %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -94,6 +94,11 @@ type Entry struct {
 	Prefix    *Value    `json:",omitempty"` // prefix to use from this point down
 	Mandatory TriState  `json:",omitempty"` // whether this entry is mandatory in the tree
 
+	// Provenance records where the statement defining the entry is found.
+	// It is that of the statement within the grouping for entries placed
+	// in the tree by a uses statement, and is kept when Node is not.
+	Provenance Provenance `json:"-"`
+
 	// Fields associated with directory nodes
 	Dir map[string]*Entry `json:",omitempty"`
 	Key string            `json:",omitempty"` // Optional key name for lists (i.e., maps)
@@ -101,7 +106,8 @@ type Entry struct {
 	// Fields associated with leaf nodes
 	Type *YangType `json:",omitempty"`
 
-	// Extensions found
+	// Extensions found, including those of the uses and augment statements
+	// that placed the entry in the tree, ordered by their source position.
 	Exts []*Statement `json:",omitempty"`
 
 	// Fields associated with list nodes (both lists and leaf-lists)
@@ -127,7 +133,10 @@ type Entry struct {
 
 	// Annotation stores annotated values, and is not populated by this
 	// library but rather can be used by calling code where additional
-	// information should be stored alongside the Entry.
+	// information should be stored alongside the Entry.  Annotations are
+	// copied when the Entry is duplicated, and are included when the
+	// Entry is encoded as JSON.  Use SetAnnotation and GetAnnotation, or
+	// an AnnotationKey, for concurrent access.
 	Annotation map[string]interface{} `json:",omitempty"`
 
 	// namespace stores the namespace of the Entry if it overrides the
@@ -136,6 +145,14 @@ type Entry struct {
 	// the augmenting entity per RFC6020 Section 7.15.2. The namespace
 	// of the Entry should be accessed using the Namespace function.
 	namespace *Value
+
+	// description is the description statement Description is the
+	// argument of, if any.
+	description *Value
+
+	// anySchema is the schema attached to an anydata or anyxml Entry that
+	// describes its content. It is set using AttachSchema.
+	anySchema *Entry
 }
 
 // An RPCEntry contains information related to an RPC Node.
@@ -170,7 +187,7 @@ func (l *ListAttr) parseOrderedBy(s *Value) error {
 		l.OrderedByUser = true
 	case "system":
 	default:
-		return fmt.Errorf("%s: ordered-by has invalid argument: %q", Source(s), s.Name)
+		return codeErrorf("GY0802", "%s: ordered-by has invalid argument: %q", Source(s), s.Name)
 	}
 	return nil
 }
@@ -193,10 +210,7 @@ type UsesStmt struct {
 // Modules returns the Modules structure that e is part of.  This is needed
 // when looking for rooted nodes not part of this Entry tree.
 func (e *Entry) Modules() *Modules {
-	for e.Parent != nil {
-		e = e.Parent
-	}
-	return e.Node.(*Module).Modules
+	return e.Root().Node.(*Module).Modules
 }
 
 // IsDir returns true if e is a directory.
@@ -235,6 +249,87 @@ func (e *Entry) IsCase() bool {
 	return e.Kind == CaseEntry
 }
 
+// IsAny returns true if e is an anydata or anyxml node.
+func (e *Entry) IsAny() bool {
+	return e.Kind == AnyDataEntry || e.Kind == AnyXMLEntry
+}
+
+// IsPresenceContainer returns true if e is a container with a presence
+// statement, including one added by a refine.
+func (e *Entry) IsPresenceContainer() bool {
+	return e.IsContainer() && len(e.Extra["presence"]) > 0
+}
+
+// Keys returns the key leaves of the list e, in the order they are named by
+// its key statement.  Keys returns nil if e is not a list or has no keys.
+// Keys that are not children of e are omitted.
+func (e *Entry) Keys() []*Entry {
+	if !e.IsList() {
+		return nil
+	}
+	var keys []*Entry
+	for _, k := range strings.Fields(e.Key) {
+		if c := e.Dir[k]; c != nil {
+			keys = append(keys, c)
+		}
+	}
+	return keys
+}
+
+// SortedDir returns the children of the directory e ordered by name.
+func (e *Entry) SortedDir() []*Entry {
+	names := make([]string, 0, len(e.Dir))
+	for name := range e.Dir {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	children := make([]*Entry, len(names))
+	for i, name := range names {
+		children[i] = e.Dir[name]
+	}
+	return children
+}
+
+// MinElements returns the min-elements of the list or leaf-list e.  It
+// returns 0, the default, if e is not a list or leaf-list.
+func (e *Entry) MinElements() uint64 {
+	if e.ListAttr == nil {
+		return 0
+	}
+	return e.ListAttr.MinElements
+}
+
+// MaxElements returns the max-elements of the list or leaf-list e, which is
+// math.MaxUint64 if it is unbounded.  It returns math.MaxUint64, the default,
+// if e is not a list or leaf-list.
+func (e *Entry) MaxElements() uint64 {
+	if e.ListAttr == nil {
+		return math.MaxUint64
+	}
+	return e.ListAttr.MaxElements
+}
+
+// AttachSchema attaches the schema rooted at schema to the anydata or anyxml
+// entry e.  The content of anydata and anyxml nodes is not modelled by the
+// module defining them, but some uses, such as the content of a NETCONF
+// notification or a mounted schema, are modelled elsewhere.  Attaching a schema
+// allows consumers that understand it to descend into the content of e rather
+// than treating it as opaque.  The children of schema are not reparented.  A
+// nil schema removes any attached schema.
+func (e *Entry) AttachSchema(schema *Entry) error {
+	if !e.IsAny() {
+		return fmt.Errorf("%s: cannot attach a schema to %s entry %s", Source(e.Node), e.Kind, e.Name)
+	}
+	e.anySchema = schema
+	return nil
+}
+
+// AttachedSchema returns the schema attached to e by AttachSchema, or nil if
+// there is none.
+func (e *Entry) AttachedSchema() *Entry {
+	return e.anySchema
+}
+
 // Print prints e to w in human readable form.
 func (e *Entry) Print(w io.Writer) {
 	if e.Description != "" {
@@ -313,25 +408,57 @@ func (k EntryKind) String() string {
 	return fmt.Sprintf("unknown-entry-%d", k)
 }
 
-// newDirectory returns an empty directory Entry.
-func newDirectory(n Node) *Entry {
-	return &Entry{
-		Kind:  DirectoryEntry,
-		Dir:   make(map[string]*Entry),
-		Node:  n,
-		Name:  n.NName(),
-		Extra: map[string][]interface{}{},
+// A Provenance records the module, and submodule, that a statement appears
+// in and its range in the source.
+type Provenance struct {
+	Module    string // the module, or the module the submodule belongs to
+	Submodule string // the submodule, if the statement is in a submodule
+	Range     SourceRange
+}
+
+// provenanceOf returns the Provenance of the statement of n.
+func provenanceOf(n Node) Provenance {
+	var p Provenance
+	if n == nil {
+		return p
 	}
+	if s := n.Statement(); s != nil {
+		p.Range = s.Range()
+	}
+	if m := RootNode(n); m != nil {
+		p.Module = m.Name
+		if m.BelongsTo != nil {
+			p.Module, p.Submodule = m.BelongsTo.Name, m.Name
+		}
+	}
+	return p
 }
 
-// newLeaf returns an empty leaf Entry.
-func newLeaf(n Node) *Entry {
-	return &Entry{
-		Kind:  LeafEntry,
-		Node:  n,
-		Name:  n.NName(),
-		Extra: map[string][]interface{}{},
+// newDirectory returns an empty directory Entry allocated from a, if not nil.
+func newDirectory(a *Arena, n Node) *Entry {
+	e := a.newEntry()
+	*e = Entry{
+		Kind:       DirectoryEntry,
+		Dir:        make(map[string]*Entry),
+		Node:       n,
+		Name:       n.NName(),
+		Extra:      map[string][]interface{}{},
+		Provenance: provenanceOf(n),
 	}
+	return e
+}
+
+// newLeaf returns an empty leaf Entry allocated from a, if not nil.
+func newLeaf(a *Arena, n Node) *Entry {
+	e := a.newEntry()
+	*e = Entry{
+		Kind:       LeafEntry,
+		Node:       n,
+		Name:       n.NName(),
+		Extra:      map[string][]interface{}{},
+		Provenance: provenanceOf(n),
+	}
+	return e
 }
 
 // newError returns an error Entry using format and v to create the error
@@ -410,7 +537,7 @@ func (e *Entry) GetErrors() []error {
 func (e *Entry) add(key string, value *Entry) *Entry {
 	value.Parent = e
 	if e.Dir[key] != nil {
-		e.errorf("%s: duplicate key from %s: %s", Source(e.Node), Source(value.Node), key)
+		e.addError(codeErrorf("GY0803", "%s: duplicate key from %s: %s", Source(e.Node), Source(value.Node), key))
 		return e
 	}
 	e.Dir[key] = value
@@ -425,6 +552,19 @@ func (e *Entry) delete(key string) {
 	delete(e.Dir, key)
 }
 
+// Musts returns the must statements of e, including those added by refine and
+// deviate statements, in the order they were added.  The error-app-tag and
+// error-message of each are returned by its ConstraintError method.
+func (e *Entry) Musts() []*Must {
+	var musts []*Must
+	for _, x := range e.Extra["must"] {
+		if m, ok := x.(*Must); ok {
+			musts = append(musts, m)
+		}
+	}
+	return musts
+}
+
 // GetWhenXPath returns the when XPath statement of e if able.
 func (e *Entry) GetWhenXPath() (string, bool) {
 	switch n := e.Node.(type) {
@@ -524,10 +664,10 @@ func semCheckMaxElements(v *Value) (uint64, error) {
 	}
 	val, err := strconv.ParseUint(v.Name, 10, 64)
 	if err != nil {
-		return val, fmt.Errorf(`%s: invalid max-elements value %q (expect "unbounded" or a positive integer): %v`, Source(v), v.Name, err)
+		return val, codeErrorf("GY0804", `%s: invalid max-elements value %q (expect "unbounded" or a positive integer): %v`, Source(v), v.Name, err)
 	}
 	if val == 0 {
-		return val, fmt.Errorf(`%s: invalid max-elements value 0 (expect "unbounded" or a positive integer)`, Source(v))
+		return val, codeErrorf("GY0804", `%s: invalid max-elements value 0 (expect "unbounded" or a positive integer)`, Source(v))
 	}
 	return val, nil
 }
@@ -539,7 +679,7 @@ func semCheckMinElements(v *Value) (uint64, error) {
 	}
 	val, err := strconv.ParseUint(v.Name, 10, 64)
 	if err != nil {
-		return val, fmt.Errorf(`%s: invalid min-elements value %q (expect a non-negative integer): %v`, Source(v), v.Name, err)
+		return val, codeErrorf("GY0804", `%s: invalid min-elements value %q (expect a non-negative integer): %v`, Source(v), v.Name, err)
 	}
 	return val, nil
 }
@@ -569,7 +709,7 @@ func ToEntry(n Node) (e *Entry) {
 	// Copy in the extensions from our Node, if any.
 	defer func(n Node) {
 		if e != nil {
-			e.Exts = append(e.Exts, n.Exts()...)
+			e.Exts = addExts(e.Exts, n.Exts())
 		}
 	}(n)
 
@@ -585,7 +725,7 @@ func ToEntry(n Node) (e *Entry) {
 			case "false":
 				return TSFalse, nil
 			default:
-				return TSUnset, fmt.Errorf("%s: invalid config value: %s", Source(n), v.Name)
+				return TSUnset, codeErrorf("GY0801", "%s: invalid config value: %s", Source(n), v.Name)
 			}
 		}
 		return TSUnset, nil
@@ -595,16 +735,19 @@ func ToEntry(n Node) (e *Entry) {
 	// Handle non-directory nodes (leaf, leafref, and oddly enough, uses).
 	switch s := n.(type) {
 	case *Leaf:
-		e := newLeaf(n)
+		e := newLeaf(ms.ParseOptions.Arena, n)
 		if errs := s.Type.resolve(ms.typeDict); errs != nil {
 			e.Errors = errs
 		}
 		if s.Description != nil {
-			e.Description = s.Description.Name
+			e.Description, e.description = s.Description.Name, s.Description
 		}
 		if s.Default != nil {
 			e.Default = []string{s.Default.Name}
 		}
+		if s.Units != nil {
+			e.Units = s.Units.Name
+		}
 		e.Type = s.Type.YangType
 		e.Config, err = tristateValue(s.Config)
 		e.addError(err)
@@ -654,17 +797,25 @@ func ToEntry(n Node) (e *Entry) {
 	case *Uses:
 		g := FindGrouping(s, s.Name, map[string]bool{})
 		if g == nil {
-			return newError(n, "unknown group: %s", s.Name)
+			e := &Entry{Node: n}
+			e.addError(missingImportErrorf("GY0401", s, s.Name, "%s: unknown group: %s", Source(n), s.Name))
+			return e
+		}
+		if ms.logging() {
+			ms.debug("expanded grouping", "uses", Source(s), "grouping", s.Name, "source", Source(g))
 		}
 		// We need to return a duplicate so we resolve properly
 		// when the group is used in multiple locations and the
 		// grouping has a leafref that references outside the group.
 		e = ToEntry(g).dup()
 		addExtraKeywordsToLeafEntry(n, e)
+		for _, r := range s.Refine {
+			e.refine(r)
+		}
 		return e
 	}
 
-	e = newDirectory(n)
+	e = newDirectory(ms.ParseOptions.Arena, n)
 
 	// Special handling for individual Node types.  Lists are like any other
 	// node except a List has a ListAttr.
@@ -725,7 +876,7 @@ func ToEntry(n Node) (e *Entry) {
 			e.addError(err)
 		case "description":
 			if v := fv.Interface().(*Value); v != nil {
-				e.Description = v.Name
+				e.Description, e.description = v.Name, v
 			}
 		case "prefix":
 			if v := fv.Interface().(*Value); v != nil {
@@ -733,7 +884,12 @@ func ToEntry(n Node) (e *Entry) {
 			}
 		case "action":
 			for _, r := range fv.Interface().([]*Action) {
-				e.add(r.Name, ToEntry(r))
+				action := ToEntry(r)
+				if action.RPC == nil {
+					// When "action" has no "input" or "output" children
+					action.RPC = &RPCEntry{}
+				}
+				e.add(r.Name, action)
 			}
 		case "augment":
 			for _, a := range fv.Interface().([]*Augment) {
@@ -810,7 +966,11 @@ func ToEntry(n Node) (e *Entry) {
 				case ms.ParseOptions.IgnoreSubmoduleCircularDependencies:
 					continue
 				default:
-					e.addError(fmt.Errorf("%s: has a circular dependency, importing %s", n.NName(), a.Module.NName()))
+					err := codeErrorf("GY0106", "%s: has a circular dependency, importing %s", n.NName(), a.Module.NName())
+					if c := ms.includeCycle(n.NName(), a.Module.NName()); c != nil {
+						err = codeErrorf("GY0106", "%v: %s", err, c)
+					}
+					e.addError(err)
 				}
 			}
 		case "leaf":
@@ -893,7 +1053,7 @@ func ToEntry(n Node) (e *Entry) {
 
 			if n.Type != nil {
 				if errs := n.Type.resolve(ms.typeDict); errs != nil {
-					e.addError(fmt.Errorf("deviation has unresolvable type, %v", errs))
+					e.addError(codeErrorf("GY0605", "deviation has unresolvable type, %v", errs))
 					continue
 				}
 				e.Type = n.Type.YangType
@@ -906,16 +1066,20 @@ func ToEntry(n Node) (e *Entry) {
 			case LeafEntry, ChoiceEntry:
 				// default is handled separately for leaf, leaf-list and choice
 			case DeviateEntry:
-				// handle deviate statements.
-				// TODO(wenovus): support refine statement's default substatement.
-				d, ok := fv.Interface().(*Value)
+				// handle deviate statements, which may have multiple
+				// default substatements for leaf-list types (YANG1.1).
+				// All of them are added for the Default field, the
+				// MoreDefault field holds the same statements.
+				d, ok := n.(*Deviate)
 				if !ok {
 					e.addError(fmt.Errorf("%s: unexpected default type in %s:%s", Source(n), n.Kind(), n.NName()))
+					break
 				}
-				// TODO(wenovus): deviate statement and refine statement should
-				// allow multiple default substatements for leaf-list types (YANG1.1).
-				if d != nil {
-					e.Default = []string{d.asString()}
+				if fv.Kind() != reflect.Ptr {
+					break
+				}
+				for _, v := range d.Defaults() {
+					e.Default = append(e.Default, v.asString())
 				}
 			}
 		case "typedef":
@@ -944,7 +1108,7 @@ func ToEntry(n Node) (e *Entry) {
 
 					dt, ok := toDeviation[d.Statement().Argument]
 					if !ok {
-						e.addError(fmt.Errorf("%s: unknown deviation type in %s:%s", Source(n), n.Kind(), n.NName()))
+						e.addError(codeErrorf("GY0605", "%s: unknown deviation type in %s:%s", Source(n), n.Kind(), n.NName()))
 						continue
 					}
 
@@ -1027,7 +1191,7 @@ func ToEntry(n Node) (e *Entry) {
 			// These are meta-keywords used internally
 			continue
 		default:
-			e.addError(fmt.Errorf("%s: unexpected statement: %s", Source(n), name))
+			e.addError(codeErrorf("GY0005", "%s: unexpected statement: %s", Source(n), name))
 			continue
 
 		}
@@ -1071,6 +1235,128 @@ func addExtraKeywordsToLeafEntry(n Node, e *Entry) {
 	}
 }
 
+// refine applies the refine statement r to its target within e, the
+// expansion of the grouping named by a uses statement.  Properties that
+// cannot be refined on the kind of node targeted, and refinements that leave
+// the target in a conflicting state, are recorded as errors in e.
+func (e *Entry) refine(r *Refine) {
+	t := e.Find(r.Name)
+	if t == nil || t == e {
+		e.addError(codeErrorf("GY0402", "%s: cannot find refine target %q", Source(r), r.Name))
+		return
+	}
+	illegal := func(keyword string) {
+		e.addError(codeErrorf("GY0403", "%s: %s cannot be refined on %s %s", Source(r), keyword, t.Node.Kind(), r.Name))
+	}
+	boolValue := func(keyword string, v *Value) TriState {
+		switch v.Name {
+		case "true":
+			return TSTrue
+		case "false":
+			return TSFalse
+		}
+		e.addError(codeErrorf("GY0804", "%s: invalid %s value: %s", Source(v), keyword, v.Name))
+		return TSUnset
+	}
+	// The slices in Extra are shared with the grouping, they are
+	// copied before being appended to.
+	addExtra := func(keyword string, v interface{}) {
+		t.Extra[keyword] = append(append([]interface{}{}, t.Extra[keyword]...), v)
+	}
+	isData := t.IsLeaf() || t.IsLeafList() || t.IsList() || t.IsContainer() || t.IsAny()
+
+	if r.Default != nil {
+		switch {
+		case t.IsLeafList():
+			t.Default = nil
+			for _, d := range r.Defaults() {
+				t.Default = append(t.Default, d.Name)
+			}
+		case !t.IsLeaf() && !t.IsChoice():
+			illegal("default")
+		case len(r.MoreDefault) > 0:
+			e.addError(codeErrorf("GY0404", "%s: refine of %s %s has more than one default", Source(r), t.Node.Kind(), r.Name))
+		case t.IsChoice() && t.Dir[r.Default.Name] == nil:
+			e.addError(codeErrorf("GY0404", "%s: refined default case %q not found in choice %s", Source(r.Default), r.Default.Name, r.Name))
+		default:
+			t.Default = []string{r.Default.Name}
+		}
+	}
+	if r.Description != nil {
+		t.Description, t.description = r.Description.Name, r.Description
+	}
+	if r.Reference != nil {
+		t.Extra["reference"] = []interface{}{r.Reference}
+	}
+	if r.Config != nil {
+		if isData {
+			t.Config = boolValue("config", r.Config)
+		} else {
+			illegal("config")
+		}
+	}
+	if r.Mandatory != nil {
+		if t.IsLeaf() || t.IsChoice() || t.IsAny() {
+			t.Mandatory = boolValue("mandatory", r.Mandatory)
+		} else {
+			illegal("mandatory")
+		}
+	}
+	if r.Presence != nil {
+		if t.IsContainer() {
+			t.Extra["presence"] = []interface{}{r.Presence}
+		} else {
+			illegal("presence")
+		}
+	}
+	for _, m := range r.Must {
+		if !isData {
+			illegal("must")
+			break
+		}
+		addExtra("must", m)
+	}
+	if r.MinElements != nil || r.MaxElements != nil {
+		if t.IsList() || t.IsLeafList() {
+			// The ListAttr is also shared with the grouping.
+			la := *t.ListAttr
+			t.ListAttr = &la
+			var err error
+			if r.MinElements != nil {
+				if la.MinElements, err = semCheckMinElements(r.MinElements); err != nil {
+					e.addError(err)
+				}
+			}
+			if r.MaxElements != nil {
+				if la.MaxElements, err = semCheckMaxElements(r.MaxElements); err != nil {
+					e.addError(err)
+				}
+			}
+			if la.MinElements > la.MaxElements {
+				e.addError(codeErrorf("GY0404", "%s: refined min-elements %d of %s is greater than max-elements %d", Source(r), la.MinElements, r.Name, la.MaxElements))
+			}
+		} else {
+			if r.MinElements != nil {
+				illegal("min-elements")
+			}
+			if r.MaxElements != nil {
+				illegal("max-elements")
+			}
+		}
+	}
+	for _, f := range r.IfFeature {
+		addExtra("if-feature", f)
+	}
+	if len(r.Extensions) > 0 {
+		t.Exts = addExts(t.Exts, r.Extensions)
+	}
+
+	// A leaf or choice with a default must not be mandatory.
+	if (r.Default != nil || r.Mandatory != nil) && len(t.Default) > 0 && t.Mandatory == TSTrue {
+		e.addError(codeErrorf("GY0404", "%s: refine leaves mandatory %s %s with a default", Source(r), t.Node.Kind(), r.Name))
+	}
+}
+
 func addToExtrasSlice(fv reflect.Value, name string, e *Entry) {
 	if fv.Kind() == reflect.Slice {
 		for j := 0; j < fv.Len(); j++ {
@@ -1102,11 +1388,12 @@ func (e *Entry) Augment(addErrors bool) (processed, skipped int) {
 	// order the augments (or just keep trying until we can make no further
 	// progress)
 	var unapplied []*Entry
+	ms := nodeModules(e.Node)
 	for _, a := range e.Augments {
 		target := a.Find(a.Name)
 		if target == nil {
 			if addErrors {
-				e.errorf("%s: augment %s not found", Source(a.Node), a.Name)
+				e.addError(missingImportErrorf("GY0501", a.Node, a.Name, "%s: augment %s not found", Source(a.Node), a.Name))
 			}
 			skipped++
 			unapplied = append(unapplied, a)
@@ -1119,6 +1406,9 @@ func (e *Entry) Augment(addErrors bool) (processed, skipped int) {
 		processed++
 		target.merge(nil, a.Namespace(), a)
 		target.Augmented = append(target.Augmented, a.shallowDup())
+		if ms.logging() {
+			ms.debug("applied augment", "augment", a.Name, "target", target.Path(), "source", Source(a.Node))
+		}
 	}
 	e.Augments = unapplied
 	return processed, skipped
@@ -1129,15 +1419,19 @@ func (e *Entry) Augment(addErrors bool) (processed, skipped int) {
 func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 	var errs []error
 	appendErr := func(err error) { errs = append(errs, err) }
+	ms := nodeModules(e.Node)
 	for _, d := range e.Deviations {
 		deviatedNode := e.Find(d.DeviatedPath)
 		if deviatedNode == nil {
-			appendErr(fmt.Errorf("cannot find target node to deviate, %s", d.DeviatedPath))
+			appendErr(missingImportErrorf("GY0601", e.Node, d.DeviatedPath, "cannot find target node to deviate, %s", d.DeviatedPath))
 			continue
 		}
 
 		for dt, dv := range d.Deviate {
 			for _, devSpec := range dv {
+				if ms.logging() {
+					ms.debug("applying deviation", "deviate", dt.String(), "target", deviatedNode.Path(), "source", Source(devSpec.Node))
+				}
 				switch dt {
 				case DeviationAdd, DeviationReplace:
 					if devSpec.Config != TSUnset {
@@ -1151,17 +1445,36 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 							case deviatedNode.IsLeafList():
 								deviatedNode.Default = append(deviatedNode.Default, devSpec.Default...)
 							case len(devSpec.Default) > 1:
-								appendErr(fmt.Errorf("%s: tried to add more than one default to a non-leaflist entry at deviation", Source(e.Node)))
+								appendErr(codeErrorf("GY0604", "%s: tried to add more than one default to a non-leaflist entry at deviation", Source(e.Node)))
 							case len(deviatedNode.Default) != 0:
-								appendErr(fmt.Errorf("%s: tried to add a default value to an entry that already has a default value", Source(e.Node)))
+								appendErr(codeErrorf("GY0604", "%s: tried to add a default value to an entry that already has a default value", Source(e.Node)))
 							case len(devSpec.Default) == 1 && len(deviatedNode.Default) == 0:
 								deviatedNode.Default = append([]string{}, devSpec.Default[0])
 							}
 						case DeviationReplace:
+							if len(devSpec.Default) > 1 && !deviatedNode.IsLeafList() {
+								appendErr(codeErrorf("GY0604", "%s: tried to replace the default of a non-leaflist entry with more than one value at deviation", Source(e.Node)))
+								break
+							}
 							deviatedNode.Default = append([]string{}, devSpec.Default...)
 						}
 					}
 
+					for _, keyword := range []string{"must", "unique"} {
+						switch {
+						case len(devSpec.Extra[keyword]) == 0:
+						case dt == DeviationReplace:
+							appendErr(codeErrorf("GY0602", "%s: %s statements of %s can only be added or deleted by a deviation", Source(devSpec.Node), keyword, d.DeviatedPath))
+						case keyword == "unique" && !deviatedNode.IsList():
+							appendErr(codeErrorf("GY0602", "%s: tried to deviate unique on non-list entry %s", Source(devSpec.Node), d.DeviatedPath))
+						default:
+							// Extra is shared with other uses of a
+							// grouping, so it is copied before being
+							// appended to.
+							deviatedNode.Extra[keyword] = append(append([]interface{}{}, deviatedNode.Extra[keyword]...), devSpec.Extra[keyword]...)
+						}
+					}
+
 					if devSpec.Mandatory != TSUnset {
 						deviatedNode.Mandatory = devSpec.Mandatory
 					}
@@ -1187,13 +1500,29 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 					}
 
 					if devSpec.Type != nil {
-						deviatedNode.Type = devSpec.Type
+						switch {
+						case dt != DeviationReplace:
+							appendErr(codeErrorf("GY0602", "%s: the type of %s can only be replaced by a deviation", Source(devSpec.Node), d.DeviatedPath))
+						case !deviatedNode.IsLeaf() && !deviatedNode.IsLeafList():
+							appendErr(codeErrorf("GY0602", "%s: tried to deviate the type of non-leaf entry %s", Source(devSpec.Node), d.DeviatedPath))
+						default:
+							// The replacement type was resolved in the
+							// context of the deviating module.  Any
+							// default of the deviated node must remain
+							// valid for it.
+							deviatedNode.Type = devSpec.Type
+							for _, dv := range deviatedNode.Default {
+								if err := devSpec.Type.checkValue(dv); err != nil {
+									appendErr(codeErrorf("GY0604", "%s: default %q of %s is invalid for its replacement type %s: %v", Source(devSpec.Node), dv, d.DeviatedPath, devSpec.Type.Name, err))
+								}
+							}
+						}
 					}
 
 				case DeviationNotSupported:
 					dp := deviatedNode.Parent
 					if dp == nil {
-						appendErr(fmt.Errorf("%s: node %s does not have a valid parent, but deviate not-supported references one", Source(e.Node), e.Name))
+						appendErr(codeErrorf("GY0602", "%s: node %s does not have a valid parent, but deviate not-supported references one", Source(e.Node), e.Name))
 						continue
 					}
 					if !hasIgnoreDeviateNotSupported(deviateOpts) {
@@ -1207,14 +1536,24 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 					if len(devSpec.Default) > 0 {
 						switch {
 						case deviatedNode.IsLeafList():
-							// It is unclear from RFC7950 on how deviate delete works
-							// when there are duplicate leaf-list values in config-false leafs.
-							// TODO(wenbli): Add support for deleting default values when the leaf-list is a config leaf (duplicates are not allowed).
-							appendErr(fmt.Errorf("%s: deviate delete on default statements unsupported for leaf-lists, please use replace instead", Source(e.Node)))
+							// Each default deleted removes a single
+							// matching default value from the leaf-list,
+							// duplicates (only possible in config false
+							// leaf-lists) need deleting once each.
+							defaults := append([]string{}, deviatedNode.Default...)
+							for _, dv := range devSpec.Default {
+								i := indexOf(defaults, dv)
+								if i < 0 {
+									appendErr(codeErrorf("GY0603", "%s: tried to deviate delete default %q of %s that doesn't exist", Source(e.Node), dv, d.DeviatedPath))
+									continue
+								}
+								defaults = append(defaults[:i], defaults[i+1:]...)
+							}
+							deviatedNode.Default = defaults
 						case len(deviatedNode.Default) == 0:
-							appendErr(fmt.Errorf("%s: tried to deviate delete a default statement that doesn't exist", Source(e.Node)))
+							appendErr(codeErrorf("GY0603", "%s: tried to deviate delete a default statement that doesn't exist", Source(e.Node)))
 						case devSpec.Default[0] != deviatedNode.Default[0]:
-							appendErr(fmt.Errorf("%s: tried to deviate delete a default statement with a non-matching keyword", Source(e.Node)))
+							appendErr(codeErrorf("GY0603", "%s: tried to deviate delete a default statement with a non-matching keyword", Source(e.Node)))
 						default:
 							deviatedNode.Default = nil
 						}
@@ -1224,6 +1563,39 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 						deviatedNode.Mandatory = TSUnset
 					}
 
+					if devSpec.Units != "" {
+						switch deviatedNode.Units {
+						case "":
+							appendErr(codeErrorf("GY0603", "%s: tried to deviate delete a units statement that doesn't exist", Source(e.Node)))
+						case devSpec.Units:
+							deviatedNode.Units = ""
+						default:
+							appendErr(codeErrorf("GY0603", "%s: tried to deviate delete a units statement with a non-matching keyword", Source(e.Node)))
+						}
+					}
+
+					for _, keyword := range []string{"must", "unique"} {
+						if len(devSpec.Extra[keyword]) == 0 {
+							continue
+						}
+						remaining := append([]interface{}{}, deviatedNode.Extra[keyword]...)
+						for _, dv := range devSpec.Extra[keyword] {
+							i := -1
+							for j, v := range remaining {
+								if extraArgument(v) == extraArgument(dv) {
+									i = j
+									break
+								}
+							}
+							if i < 0 {
+								appendErr(codeErrorf("GY0603", "%s: tried to deviate delete %s %q of %s that doesn't exist", Source(devSpec.Node), keyword, extraArgument(dv), d.DeviatedPath))
+								continue
+							}
+							remaining = append(remaining[:i], remaining[i+1:]...)
+						}
+						deviatedNode.Extra[keyword] = remaining
+					}
+
 					if devSpec.deviatePresence.hasMinElements {
 						if !deviatedNode.IsList() && !deviatedNode.IsLeafList() {
 							appendErr(fmt.Errorf("tried to deviate min-elements on a non-list type %s", deviatedNode.Kind))
@@ -1232,7 +1604,7 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 						if deviatedNode.ListAttr.MinElements != devSpec.ListAttr.MinElements {
 							// Argument value must match:
 							// https://tools.ietf.org/html/rfc7950#section-7.20.3.2
-							appendErr(fmt.Errorf("min-element value %d differs from deviation's min-element value %d for entry %v", devSpec.ListAttr.MinElements, deviatedNode.ListAttr.MinElements, d.DeviatedPath))
+							appendErr(codeErrorf("GY0603", "min-element value %d differs from deviation's min-element value %d for entry %v", devSpec.ListAttr.MinElements, deviatedNode.ListAttr.MinElements, d.DeviatedPath))
 						}
 						deviatedNode.ListAttr.MinElements = 0
 					}
@@ -1243,13 +1615,13 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 							continue
 						}
 						if deviatedNode.ListAttr.MaxElements != devSpec.ListAttr.MaxElements {
-							appendErr(fmt.Errorf("max-element value %d differs from deviation's max-element value %d for entry %v", devSpec.ListAttr.MaxElements, deviatedNode.ListAttr.MaxElements, d.DeviatedPath))
+							appendErr(codeErrorf("GY0603", "max-element value %d differs from deviation's max-element value %d for entry %v", devSpec.ListAttr.MaxElements, deviatedNode.ListAttr.MaxElements, d.DeviatedPath))
 						}
 						deviatedNode.ListAttr.MaxElements = math.MaxUint64
 					}
 
 				default:
-					appendErr(fmt.Errorf("invalid deviation type %s", dt))
+					appendErr(codeErrorf("GY0605", "invalid deviation type %s", dt))
 				}
 			}
 		}
@@ -1258,6 +1630,31 @@ func (e *Entry) ApplyDeviate(deviateOpts ...DeviateOpt) []error {
 	return errs
 }
 
+// indexOf returns the index of the first instance of s in ss, or -1 if s is
+// not in ss.
+func indexOf(ss []string, s string) int {
+	for i, v := range ss {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// extraArgument returns the argument of the must or unique statement v, as
+// stored in the Extra field of an Entry.  The whitespace separating the
+// descendant paths of a unique statement is not significant and is
+// normalized.
+func extraArgument(v interface{}) string {
+	switch v := v.(type) {
+	case *Must:
+		return v.Name
+	case *Value:
+		return strings.Join(strings.Fields(v.Name), " ")
+	}
+	return ""
+}
+
 // FixChoice inserts missing Case entries for non-case entries within a choice
 // entry.
 func (e *Entry) FixChoice() {
@@ -1278,6 +1675,8 @@ func (e *Entry) FixChoice() {
 					Prefix: ce.Prefix,
 					Dir:    map[string]*Entry{ce.Name: ce},
 					Extra:  map[string][]interface{}{},
+
+					Provenance: ce.Provenance,
 				}
 				ce.Parent = ne
 				e.Dir[k] = ne
@@ -1306,8 +1705,23 @@ func (e *Entry) ReadOnly() bool {
 	}
 }
 
-// Find finds the Entry named by name relative to e.
+// Find finds the Entry named by name relative to e.  name is a schema node
+// identifier, as used by augment and deviation statements, which names each
+// choice and case along the path.
 func (e *Entry) Find(name string) *Entry {
+	return e.find(name, false)
+}
+
+// FindData finds the Entry named by the data path name relative to e.  Choice
+// and case entries do not appear in the data tree, so FindData looks through
+// them: a step names a data node within nested choices and cases, and ".."
+// moves to the data parent.  Steps naming a choice or case are also accepted.
+func (e *Entry) FindData(name string) *Entry {
+	return e.find(name, true)
+}
+
+// find is Find, or FindData if data is true.
+func (e *Entry) find(name string, data bool) *Entry {
 	if e == nil || name == "" {
 		return nil
 	}
@@ -1317,14 +1731,18 @@ func (e *Entry) Find(name string) *Entry {
 	// and we need to find our parent.
 	if parts[0] == "" {
 		parts = parts[1:]
-		contextNode := e.Node
-		for e.Parent != nil {
-			e = e.Parent
+		// The prefix is that of an import of the module of e.  The
+		// nodes of entries may be dropped, see CompactOptions, but the
+		// module entry retains its node.
+		var contextNode Node
+		for p := e; p != nil && contextNode == nil; p = p.Parent {
+			contextNode = p.Node
 		}
+		e = e.Root()
 		if prefix, _ := getPrefix(parts[0]); prefix != "" {
 			mod := FindModuleByPrefix(contextNode, prefix)
 			if mod == nil {
-				e.addError(fmt.Errorf("cannot find module giving prefix %q within context entry %q", prefix, e.Path()))
+				e.addError(codeErrorf("GY0108", "cannot find module giving prefix %q within context entry %q", prefix, e.Path()))
 				return nil
 			}
 			m := module(mod)
@@ -1344,6 +1762,10 @@ func (e *Entry) Find(name string) *Entry {
 		case e == nil:
 			return nil
 		case part == ".":
+		case part == ".." && data:
+			// choice and case nodes do not appear in the data tree
+			// and are skipped when moving up the tree.
+			e = e.DataParent()
 		case part == "..":
 			e = e.Parent
 		case e.RPC != nil:
@@ -1351,20 +1773,23 @@ func (e *Entry) Find(name string) *Entry {
 			switch part {
 			case "input":
 				if e.RPC.Input == nil {
-					e.RPC.Input = &Entry{
-						Name: "input",
-						Kind: InputEntry,
-						Dir:  make(map[string]*Entry),
-					}
+					// The input is implicitly defined, create
+					// it so that it can be the target of an
+					// augment.
+					in := newDirectory(arenaOf(e.Node), &Input{Name: "input", Parent: e.Node})
+					in.Parent = e
+					in.Kind = InputEntry
+					in.Prefix = e.Prefix
+					e.RPC.Input = in
 				}
 				e = e.RPC.Input
 			case "output":
 				if e.RPC.Output == nil {
-					e.RPC.Output = &Entry{
-						Name: "output",
-						Kind: OutputEntry,
-						Dir:  make(map[string]*Entry),
-					}
+					out := newDirectory(arenaOf(e.Node), &Output{Name: "output", Parent: e.Node})
+					out.Parent = e
+					out.Kind = OutputEntry
+					out.Prefix = e.Prefix
+					e.RPC.Output = out
 				}
 				e = e.RPC.Output
 			}
@@ -1375,19 +1800,80 @@ func (e *Entry) Find(name string) *Entry {
 			case "", "..":
 				return nil
 			default:
-				e = e.Dir[part]
+				if c := e.Dir[part]; c != nil || !data {
+					e = c
+				} else {
+					e = e.DataChild(part)
+				}
+			}
+		}
+	}
+	return e
+}
+
+// DataChild returns the child of e named name as it appears in the data tree.
+// Choice and case entries do not appear in the data tree, so DataChild looks
+// through them, including shorthand cases that have not yet had their case
+// entry inserted by FixChoice.  nil is returned if e has no such child.
+func (e *Entry) DataChild(name string) *Entry {
+	if e == nil {
+		return nil
+	}
+	if c := e.Dir[name]; c != nil && !c.IsChoice() && !c.IsCase() {
+		return c
+	}
+	for _, c := range e.Dir {
+		if c.IsChoice() || c.IsCase() {
+			if dc := c.DataChild(name); dc != nil {
+				return dc
 			}
 		}
 	}
+	return nil
+}
+
+// DataParent returns the parent of e as it appears in the data tree, i.e.,
+// the nearest ancestor of e that is not a choice or case entry.  nil is
+// returned if e has no such ancestor.
+func (e *Entry) DataParent() *Entry {
+	if e == nil {
+		return nil
+	}
+	for e = e.Parent; e != nil && (e.IsChoice() || e.IsCase()); e = e.Parent {
+	}
+	return e
+}
+
+// Ancestors returns the ancestors of e as they appear in the data tree,
+// starting with its data parent and ending with the module entry.  Choice and
+// case entries are not included.
+func (e *Entry) Ancestors() []*Entry {
+	var ancestors []*Entry
+	for p := e.DataParent(); p != nil; p = p.DataParent() {
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// Root returns the root of the tree containing e, normally the entry of a
+// module.  The root of a nil Entry is nil.
+func (e *Entry) Root() *Entry {
+	if e == nil {
+		return nil
+	}
+	for e.Parent != nil {
+		e = e.Parent
+	}
 	return e
 }
 
-// Path returns the path to e. A nil Entry returns "".
+// Path returns the path to e, starting with the name of its module and
+// including choice and case entries. A nil Entry returns "".
 func (e *Entry) Path() string {
 	if e == nil {
 		return ""
 	}
-	return e.Parent.Path() + "/" + e.Name
+	return e.PathString(PathOpts{Module: true, ChoiceCase: true})
 }
 
 // Namespace returns the YANG/XML namespace Value for e as mounted in the Entry
@@ -1397,8 +1883,14 @@ func (e *Entry) Path() string {
 // "uses" statement is that of the where the uses statement occurs, i.e., the
 // user, rather than creator (grouping) of those elements, so we follow the
 // usage (Entry) tree up to the parent before obtaining the (then adjacent) root
-// node for its namespace Value.
+// node for its namespace Value.  Nodes merged into the tree by an augment
+// have the namespace of the module defining the augment, as do their
+// descendants, including those that are themselves from a grouping.  A nil
+// Entry returns an empty Value.
 func (e *Entry) Namespace() *Value {
+	if e == nil {
+		return new(Value)
+	}
 	// Make e the root parent entry
 	for ; e.Parent != nil; e = e.Parent {
 		if e.namespace != nil {
@@ -1464,30 +1956,52 @@ func (e *Entry) shallowDup() *Entry {
 
 // dup makes a deep duplicate of e.
 func (e *Entry) dup() *Entry {
+	return e.dupIn(arenaOf(e.Node))
+}
+
+// dupIn makes a deep duplicate of e, as dup does, allocating the new entries
+// from a, if not nil.
+func (e *Entry) dupIn(a *Arena) *Entry {
 	// Warning: if we add any elements to Entry that should not be
 	// copied we will have to explicitly uncopy them.
 	// It is possible we may want to do a deep copy on some other fields,
 	// such as Exts, Choice and Case, but it is not clear that we need
 	// to do that.
-	ne := *e
+	ne := a.newEntry()
+	*ne = *e
 
 	// Now recurse down to all of our children, fixing up Parent
 	// pointers as we go.
 	if e.Dir != nil {
 		ne.Dir = make(map[string]*Entry, len(e.Dir))
 		for k, v := range e.Dir {
-			de := v.dup()
-			de.Parent = &ne
+			de := v.dupIn(a)
+			de.Parent = ne
 			ne.Dir[k] = de
 		}
 	}
 
+	// The input and output of an RPC or action are not part of Dir and
+	// must be duplicated separately so they reference the new parent.
+	if e.RPC != nil {
+		ne.RPC = &RPCEntry{}
+		if e.RPC.Input != nil {
+			ne.RPC.Input = e.RPC.Input.dupIn(a)
+			ne.RPC.Input.Parent = ne
+		}
+		if e.RPC.Output != nil {
+			ne.RPC.Output = e.RPC.Output.dupIn(a)
+			ne.RPC.Output.Parent = ne
+		}
+	}
+
 	ne.Extra = make(map[string][]interface{})
 	for k, v := range e.Extra {
 		ne.Extra[k] = v
 	}
+	ne.Annotation = copyAnnotations(e)
 
-	return &ne
+	return ne
 }
 
 // merge merges a duplicate of oe.Dir into e.Dir, setting the prefix of each
@@ -1504,13 +2018,12 @@ func (e *Entry) merge(prefix *Value, namespace *Value, oe *Entry) {
 			v.namespace = namespace
 		}
 		if se := e.Dir[k]; se != nil {
-			er := newError(oe.Node, `Duplicate node %q in %q from:
+			e.addError(codeErrorf("GY0803", `%s: Duplicate node %q in %q from:
    %s: %s
-   %s: %s`, k, e.Name, Source(v.Node), v.Name, Source(se.Node), se.Name)
-			e.addError(er.Errors[0])
+   %s: %s`, Source(oe.Node), k, e.Name, Source(v.Node), v.Name, Source(se.Node), se.Name))
 		} else {
 			v.Parent = e
-			v.Exts = append(v.Exts, oe.Exts...)
+			v.Exts = addExts(v.Exts, oe.Exts)
 			for lk := range oe.Extra {
 				v.Extra[lk] = append(v.Extra[lk], oe.Extra[lk]...)
 			}
@@ -1642,13 +2155,50 @@ func (e *Entry) DefaultValues() []string {
 	}
 
 	if typ := e.Type; typ != nil && typ.HasDefault {
-		switch leaf := e.Node.(type) {
+		switch e.Node.(type) {
 		case *Leaf:
 			switch {
-			case e.IsLeaf() && (leaf.Mandatory == nil || leaf.Mandatory.Name == "false"), e.IsLeafList() && e.ListAttr.MinElements == 0:
+			case e.IsLeaf() && e.Mandatory != TSTrue, e.IsLeafList() && e.ListAttr.MinElements == 0:
 				return []string{typ.Default}
 			}
 		}
 	}
 	return nil
 }
+
+// addExts returns a new slice of the extension statements of exts and add,
+// without duplicates and ordered by their source position.  The entries
+// duplicated by dup share the Exts of the original, so they are never
+// appended to in place.
+func addExts(exts, add []*Statement) []*Statement {
+	if len(add) == 0 {
+		return exts
+	}
+	out := make([]*Statement, 0, len(exts)+len(add))
+	seen := map[*Statement]bool{}
+	for _, ss := range [][]*Statement{exts, add} {
+		for _, s := range ss {
+			if !seen[s] {
+				seen[s] = true
+				out = append(out, s)
+			}
+		}
+	}
+	sortByPosition(out)
+	return out
+}
+
+// sortByPosition sorts ss by the file, line and column they are found at.
+// Statements without a position, such as those created by goyang, sort first.
+func sortByPosition(ss []*Statement) {
+	sort.SliceStable(ss, func(i, j int) bool {
+		a, b := ss[i], ss[j]
+		switch {
+		case a.file != b.file:
+			return a.file < b.file
+		case a.line != b.line:
+			return a.line < b.line
+		}
+		return a.col < b.col
+	})
+}
@@ -700,6 +700,8 @@ module groupings {
 
 func TestAugmentedEntry(t *testing.T) {
 	ms := NewModules()
+	// original and groupings import each other.
+	ms.ParseOptions.AllowImportCycles = true
 	for _, tt := range testAugmentAndUsesModules {
 		if err := ms.Parse(tt.in, tt.name); err != nil {
 			t.Fatalf("could not parse module %s: %v", tt.name, err)
@@ -772,8 +774,76 @@ func TestAugmentedEntry(t *testing.T) {
 	}
 }
 
+func TestAugmentOperationsAndNotifications(t *testing.T) {
+	modules := map[string]string{
+		"a.yang": `
+			module a {
+				prefix a;
+				namespace "urn:a";
+
+				rpc r { input { leaf x { type string; } } }
+				notification n { leaf y { type string; } }
+				container c {
+					action act { input { leaf z { type string; } } }
+					notification cn { leaf w { type string; } }
+				}
+			}`,
+		"b.yang": `
+			module b {
+				prefix b;
+				namespace "urn:b";
+
+				import a { prefix a; }
+
+				augment "/a:r/a:input" { leaf bx { type string; } }
+				augment "/a:r/a:output" { leaf bo { type string; } }
+				augment "/a:n" { leaf by { type string; } }
+				augment "/a:c/a:act/a:input" { leaf bz { type string; } }
+				augment "/a:c/a:act/a:output" { leaf bzo { type string; } }
+				augment "/a:c/a:cn" { leaf bw { type string; } }
+			}`,
+	}
+	ms := NewModules()
+	for name, mod := range modules {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("could not parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("could not process modules: %v", errs)
+	}
+	a, _ := ms.GetModule("a")
+
+	for _, path := range []string{
+		"/r/input/bx",
+		"/r/output/bo",
+		"/n/by",
+		"/c/act/input/bz",
+		"/c/act/output/bzo",
+		"/c/cn/bw",
+	} {
+		t.Run(path, func(t *testing.T) {
+			e := a.Find(path)
+			if e == nil {
+				t.Fatalf("Find(%s): got nil, want augmented entry", path)
+			}
+			if got, want := e.Path(), "/a"+path; got != want {
+				t.Errorf("Find(%s): got entry at %s, want %s", path, got, want)
+			}
+			if got := e.Namespace().Name; got != "urn:b" {
+				t.Errorf("Find(%s): got namespace %q, want urn:b", path, got)
+			}
+			if got := e.Parent.Prefix; got == nil || got.Name != "a" {
+				t.Errorf("Find(%s): parent has prefix %v, want a", path, got)
+			}
+		})
+	}
+}
+
 func TestUsesEntry(t *testing.T) {
 	ms := NewModules()
+	// original and groupings import each other.
+	ms.ParseOptions.AllowImportCycles = true
 	ms.ParseOptions.StoreUses = true
 	for _, tt := range testAugmentAndUsesModules {
 		if err := ms.Parse(tt.in, tt.name); err != nil {
@@ -867,6 +937,226 @@ func TestUsesEntry(t *testing.T) {
 	}
 }
 
+func TestRefine(t *testing.T) {
+	const refineModule = `
+module refine {
+  prefix "r";
+  namespace "urn:r";
+
+  extension ext { argument arg; }
+
+  grouping g {
+    leaf l { type string; }
+    leaf-list ll { type string; }
+    list ls {
+      key "k";
+      leaf k { type string; }
+    }
+    container c {
+      leaf cl { type string; }
+    }
+    choice ch {
+      leaf a { type string; }
+      leaf b { type string; }
+    }
+    anydata any;
+  }
+
+  container refined {
+    uses g {
+      refine l {
+        default "dflt";
+        description "refined leaf";
+        reference "RFC 7950";
+        config false;
+        must "../c";
+        if-feature f;
+        r:ext "x";
+      }
+      refine ll {
+        default one;
+        default two;
+      }
+      refine ls {
+        min-elements 1;
+        max-elements 4;
+      }
+      refine c {
+        presence "enabled";
+      }
+      refine "c/cl" {
+        mandatory true;
+      }
+      refine ch {
+        default b;
+      }
+      refine any {
+        mandatory true;
+      }
+    }
+  }
+
+  container plain {
+    uses g;
+  }
+}
+`
+	ms := NewModules()
+	if err := ms.Parse(refineModule, "refine.yang"); err != nil {
+		t.Fatalf("could not parse module: %v", err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("could not process module: %v", errs)
+	}
+	mod, _ := ms.GetModule("refine")
+	refined, plain := mod.Dir["refined"], mod.Dir["plain"]
+
+	l := refined.Dir["l"]
+	if got, want := l.Default, []string{"dflt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("leaf l: got default %v, want %v", got, want)
+	}
+	if got, want := l.Description, "refined leaf"; got != want {
+		t.Errorf("leaf l: got description %q, want %q", got, want)
+	}
+	if ref := l.Extra["reference"]; len(ref) != 1 || ref[0].(*Value).Name != "RFC 7950" {
+		t.Errorf("leaf l: got reference %v, want RFC 7950", ref)
+	}
+	if l.Config != TSFalse {
+		t.Errorf("leaf l: got config %v, want false", l.Config)
+	}
+	if must := l.Extra["must"]; len(must) != 1 || must[0].(*Must).Name != "../c" {
+		t.Errorf("leaf l: got must %v, want ../c", must)
+	}
+	if f := l.Extra["if-feature"]; len(f) != 1 || f[0].(*Value).Name != "f" {
+		t.Errorf("leaf l: got if-feature %v, want f", f)
+	}
+	if len(l.Exts) != 1 || l.Exts[0].Keyword != "r:ext" {
+		t.Errorf("leaf l: got extensions %v, want r:ext", l.Exts)
+	}
+	if got, want := refined.Dir["ll"].Default, []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("leaf-list ll: got default %v, want %v", got, want)
+	}
+	if la := refined.Dir["ls"].ListAttr; la.MinElements != 1 || la.MaxElements != 4 {
+		t.Errorf("list ls: got min-elements %d max-elements %d, want 1 and 4", la.MinElements, la.MaxElements)
+	}
+	if p := refined.Dir["c"].Extra["presence"]; len(p) != 1 || p[0].(*Value).Name != "enabled" {
+		t.Errorf("container c: got presence %v, want enabled", p)
+	}
+	if refined.Dir["c"].Dir["cl"].Mandatory != TSTrue {
+		t.Errorf("leaf c/cl: not refined to be mandatory")
+	}
+	if got, want := refined.Dir["ch"].Default, []string{"b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("choice ch: got default %v, want %v", got, want)
+	}
+	if refined.Dir["any"].Mandatory != TSTrue {
+		t.Errorf("anydata any: not refined to be mandatory")
+	}
+
+	// The refines must not change other uses of the grouping.
+	pl := plain.Dir["l"]
+	if len(pl.Default) != 0 || pl.Config != TSUnset || pl.Description != "" || len(pl.Extra["must"]) != 0 || len(pl.Exts) != 0 {
+		t.Errorf("leaf plain/l: changed by refine of refined/l: %v", pl)
+	}
+	if la := plain.Dir["ls"].ListAttr; la.MinElements != 0 || la.MaxElements != math.MaxUint64 {
+		t.Errorf("list plain/ls: changed by refine, got min-elements %d max-elements %d", la.MinElements, la.MaxElements)
+	}
+	if len(plain.Dir["c"].Extra["presence"]) != 0 {
+		t.Errorf("container plain/c: changed by refine, got presence %v", plain.Dir["c"].Extra["presence"])
+	}
+}
+
+func TestRefineErrors(t *testing.T) {
+	tests := []struct {
+		desc          string
+		refine        string
+		wantErrSubstr string
+	}{{
+		desc:          "missing target",
+		refine:        `refine missing { description "d"; }`,
+		wantErrSubstr: `cannot find refine target "missing"`,
+	}, {
+		desc:          "presence on leaf",
+		refine:        `refine l { presence "p"; }`,
+		wantErrSubstr: "presence cannot be refined on leaf l",
+	}, {
+		desc:          "default on container",
+		refine:        `refine c { default "d"; }`,
+		wantErrSubstr: "default cannot be refined on container c",
+	}, {
+		desc:          "mandatory on list",
+		refine:        `refine ls { mandatory true; }`,
+		wantErrSubstr: "mandatory cannot be refined on list ls",
+	}, {
+		desc:          "min-elements on leaf",
+		refine:        `refine l { min-elements 1; }`,
+		wantErrSubstr: "min-elements cannot be refined on leaf l",
+	}, {
+		desc:          "config on choice",
+		refine:        `refine ch { config false; }`,
+		wantErrSubstr: "config cannot be refined on choice ch",
+	}, {
+		desc:          "must on choice",
+		refine:        `refine ch { must "a"; }`,
+		wantErrSubstr: "must cannot be refined on choice ch",
+	}, {
+		desc:          "multiple defaults on leaf",
+		refine:        `refine l { default a; default b; }`,
+		wantErrSubstr: "refine of leaf l has more than one default",
+	}, {
+		desc:          "unknown default case",
+		refine:        `refine ch { default c; }`,
+		wantErrSubstr: `refined default case "c" not found in choice ch`,
+	}, {
+		desc:          "min-elements greater than max-elements",
+		refine:        `refine ls { min-elements 5; max-elements 2; }`,
+		wantErrSubstr: "refined min-elements 5 of ls is greater than max-elements 2",
+	}, {
+		desc:          "mandatory leaf with default",
+		refine:        `refine l { default a; mandatory true; }`,
+		wantErrSubstr: "refine leaves mandatory leaf l with a default",
+	}, {
+		desc:          "invalid config value",
+		refine:        `refine l { config maybe; }`,
+		wantErrSubstr: "invalid config value: maybe",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			if err := ms.Parse(fmt.Sprintf(`
+module refine {
+  prefix "r";
+  namespace "urn:r";
+
+  grouping g {
+    leaf l { type string; }
+    list ls {
+      key "k";
+      leaf k { type string; }
+    }
+    container c {}
+    choice ch {
+      leaf a { type string; }
+      leaf b { type string; }
+    }
+  }
+
+  uses g { %s }
+}
+`, tt.refine), "refine.yang"); err != nil {
+				t.Fatalf("could not parse module: %v", err)
+			}
+			errs := ms.Process()
+			if len(errs) == 0 {
+				t.Fatalf("Process: got no errors, want %q", tt.wantErrSubstr)
+			}
+			if diff := errdiff.Substring(errs[0], tt.wantErrSubstr); diff != "" {
+				t.Errorf("Process: %s", diff)
+			}
+		})
+	}
+}
+
 func TestShallowDup(t *testing.T) {
 	testModule := struct {
 		name string
@@ -1790,6 +2080,17 @@ func TestAnyDataAnyXML(t *testing.T) {
 		if got := data.Description; got != tt.wantNodeKind {
 			t.Errorf("%s: want data.Description: %q, got: %q", tt.name, tt.wantNodeKind, got)
 		}
+		if !data.IsAny() {
+			t.Errorf("%s: want data.IsAny() true, got false", tt.name)
+		}
+		if err := data.AttachSchema(c); err != nil {
+			t.Errorf("%s: data.AttachSchema(): got unexpected error: %v", tt.name, err)
+		} else if got := data.AttachedSchema(); got != c {
+			t.Errorf("%s: data.AttachedSchema(): got %v, want %v", tt.name, got, c)
+		}
+		if err := c.AttachSchema(data); err == nil {
+			t.Errorf("%s: c.AttachSchema(): got no error attaching a schema to a container", tt.name)
+		}
 	}
 }
 
@@ -1882,6 +2183,44 @@ func TestActionRPC(t *testing.T) {
 }`,
 		},
 
+		{
+			name:          "minimal action",
+			wantNodeKind:  "action",
+			operationPath: []string{"c", "operation"},
+			inModule: `module test {
+  namespace "urn:test";
+  prefix "test";
+  container c {
+    action operation {
+      description "action";
+    }
+  }
+}`,
+			noInput:  true,
+			noOutput: true,
+		},
+
+		{
+			name:          "test action in nested container via grouping used twice",
+			wantNodeKind:  "action",
+			operationPath: []string{"b", "inner", "operation"},
+			inModule: `module test {
+  namespace "urn:test";
+  prefix "test";
+  grouping g {
+    container inner {
+      action operation {
+        description "action";
+        input { leaf string { type string; } }
+        output { leaf string { type string; } }
+      }
+    }
+  }
+  container a { uses g; }
+  container b { uses g; }
+}`,
+		},
+
 		{
 			name:          "test rpc",
 			wantNodeKind:  "rpc",
@@ -2026,6 +2365,19 @@ func TestActionRPC(t *testing.T) {
 		} else if !tt.noOutput && e.RPC.Output == nil {
 			t.Errorf("%s: RPCEntry has nil Output, want: non-nil. Entry: %#v", tt.name, e.RPC)
 		}
+		// confirm the input and output are parented by the entry and can be
+		// found by path from it.
+		if e.RPC != nil && e.RPC.Input != nil {
+			if e.RPC.Input.Parent != e {
+				t.Errorf("%s: Input has parent %s, want: %s", tt.name, e.RPC.Input.Parent.Path(), e.Path())
+			}
+			if got := e.Find("input/string"); got == nil || got.Path() != e.Path()+"/input/string" {
+				t.Errorf("%s: Find(input/string) got %v, want: %s", tt.name, got.Path(), e.Path()+"/input/string")
+			}
+		}
+		if e.RPC != nil && e.RPC.Output != nil && e.RPC.Output.Parent != e {
+			t.Errorf("%s: Output has parent %s, want: %s", tt.name, e.RPC.Output.Parent.Path(), e.Path())
+		}
 	}
 }
 
@@ -2130,7 +2482,7 @@ var testIfFeatureModules = []struct {
 
   uses g {
     if-feature ft-uses;
-    refine rf {
+    refine gc {
       if-feature ft-refine;
     }
   }
@@ -2277,10 +2629,11 @@ func TestIfFeature(t *testing.T) {
 			wantIfFeatures: []string{"ft-uses"},
 		},
 		{
-			// Verify that if-feature field defined in "uses" is correctly propagated to container
+			// Verify that if-feature field defined in "uses" is correctly propagated to container,
+			// following the if-feature added by the refine of the container.
 			name:           "uses",
 			inIfFeatures:   entryIfFeatures(mod.Dir["gc"]),
-			wantIfFeatures: []string{"ft-uses"},
+			wantIfFeatures: []string{"ft-refine", "ft-uses"},
 		},
 		{
 			// Verify that if-feature field defined in "augment" and in "augment > uses" is correctly propagated to container
@@ -2318,6 +2671,11 @@ var testNotificationModules = []struct {
 
   grouping g {
     notification g-n {}
+    container g-cont {
+      notification g-cont-n {
+        leaf l { type string; }
+      }
+    }
   }
 
   container cont {
@@ -2371,6 +2729,10 @@ func TestNotification(t *testing.T) {
 			name:     "grouping",
 			wantPath: []string{"ls", "g-n"},
 		},
+		{
+			name:     "nested in grouping",
+			wantPath: []string{"ls", "g-cont", "g-cont-n"},
+		},
 		{
 			name:     "augment",
 			wantPath: []string{"cont", "aug-n"},
@@ -2403,6 +2765,7 @@ func TestEntryFind(t *testing.T) {
 		name            string
 		inModules       map[string]string
 		inBaseEntryPath string
+		inFindData      bool              // use FindData rather than Find.
 		wantEntryPath   map[string]string // keyed on path to find, with path expected as value.
 		wantError       string
 	}{{
@@ -2530,6 +2893,84 @@ func TestEntryFind(t *testing.T) {
 			"/t:e/t:operation/t:input":  "/test/e/operation/input",
 			"/t:e/t:operation/t:output": "/test/e/operation/output",
 		},
+	}, {
+		name: "schema node identifiers name choice and case",
+		inModules: map[string]string{
+			"test.yang": `
+				module test {
+					prefix "t";
+					namespace "urn:t";
+
+					container c {
+						choice ch {
+							case one {
+								leaf a { type string; }
+							}
+							leaf b { type string; }
+							case three {
+								choice inner {
+									leaf d { type string; }
+								}
+							}
+						}
+						leaf e { type string; }
+					}
+				}
+			`,
+		},
+		inBaseEntryPath: "/test/c/ch/one/a",
+		wantEntryPath: map[string]string{
+			"/c/ch/one/a": "/test/c/ch/one/a",
+			"/c/ch/b/b":   "/test/c/ch/b/b",
+			"../../../e":  "/test/c/e",
+			"../../b/b":   "/test/c/ch/b/b",
+			// Data paths, which skip choice and case, are not schema
+			// node identifiers.
+			"/c/a":        "",
+			"/c/d":        "",
+			"../e":        "",
+			"../../c/t:a": "",
+		},
+	}, {
+		name: "data paths skip choice and case",
+		inModules: map[string]string{
+			"test.yang": `
+				module test {
+					prefix "t";
+					namespace "urn:t";
+
+					container c {
+						choice ch {
+							case one {
+								leaf a { type string; }
+							}
+							leaf b { type string; }
+							case three {
+								choice inner {
+									leaf d { type string; }
+								}
+							}
+						}
+						leaf e { type string; }
+					}
+				}
+			`,
+		},
+		inBaseEntryPath: "/test/c/ch/one/a",
+		inFindData:      true,
+		wantEntryPath: map[string]string{
+			// Schema paths naming the choice and case.
+			"/c/ch/one/a": "/test/c/ch/one/a",
+			"/c/ch/b/b":   "/test/c/ch/b/b",
+			// Data paths that skip choice and case.
+			"/c/a":        "/test/c/ch/one/a",
+			"/c/b":        "/test/c/ch/b/b",
+			"/c/d":        "/test/c/ch/three/inner/d/d",
+			"../e":        "/test/c/e",
+			"../b":        "/test/c/ch/b/b",
+			"../d":        "/test/c/ch/three/inner/d/d",
+			"../../c/t:a": "/test/c/ch/one/a",
+		},
 	}, {
 		name: "inter-module find",
 		inModules: map[string]string{
@@ -2616,7 +3057,11 @@ func TestEntryFind(t *testing.T) {
 		}
 
 		for path, want := range tt.wantEntryPath {
-			got := dir[tt.inBaseEntryPath].Find(path)
+			find := dir[tt.inBaseEntryPath].Find
+			if tt.inFindData {
+				find = dir[tt.inBaseEntryPath].FindData
+			}
+			got := find(path)
 			if got.Path() != want {
 				t.Errorf("%s: (entry %s).Find(%s), did not find path, got: %v, want: %v, errors: %v", tt.name, dir[tt.inBaseEntryPath].Path(), path, got.Path(), want, dir[tt.inBaseEntryPath].Errors)
 			}
@@ -2968,6 +3413,163 @@ func mustReadFile(path string) string {
 	return string(s)
 }
 
+func TestDeviateReplaceTypeDefaultValues(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`
+		module deviate {
+			prefix "d";
+			namespace "urn:d";
+
+			typedef with-default { type string; default "fish"; }
+			typedef port { type uint16; default 22; }
+
+			leaf a { type with-default; }
+			leaf b { type string; }
+			leaf c { type string; }
+
+			deviation /a {
+				deviate replace { type uint16; }
+			}
+			deviation /b {
+				deviate replace { type port; }
+			}
+			deviation /c {
+				deviate replace { type port; }
+				deviate add { mandatory true; }
+			}
+		}`, "deviate.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	m, _ := ms.GetModule("deviate")
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "/a"},
+		{path: "/b", want: []string{"22"}},
+		{path: "/c"},
+	}
+	for _, tt := range tests {
+		if got := m.Find(tt.path).DefaultValues(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: DefaultValues() got %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDeviateMustAndUnique(t *testing.T) {
+	args := func(e *Entry, keyword string) []string {
+		var a []string
+		for _, v := range e.Extra[keyword] {
+			a = append(a, extraArgument(v))
+		}
+		return a
+	}
+
+	tests := []struct {
+		desc                    string
+		in                      string
+		wantMust                []string
+		wantUnique              []string
+		wantProcessErrSubstring string
+	}{{
+		desc: "add must and unique",
+		in: `
+			deviation /l {
+				deviate add {
+					must "count(v) > 0";
+					unique "v  w";
+				}
+			}`,
+		wantMust:   []string{"k != 'x'", "count(v) > 0"},
+		wantUnique: []string{"v", "v w"},
+	}, {
+		desc: "delete must and unique",
+		in: `
+			deviation /l {
+				deviate delete {
+					must "k != 'x'";
+					unique "v";
+				}
+			}`,
+	}, {
+		desc: "delete must that doesn't exist",
+		in: `
+			deviation /l {
+				deviate delete { must "k = 'x'"; }
+			}`,
+		wantProcessErrSubstring: `tried to deviate delete must "k = 'x'" of /l that doesn't exist`,
+	}, {
+		desc: "delete unique that doesn't exist",
+		in: `
+			deviation /l {
+				deviate delete { unique "w"; }
+			}`,
+		wantProcessErrSubstring: `tried to deviate delete unique "w" of /l that doesn't exist`,
+	}, {
+		desc: "replace must",
+		in: `
+			deviation /l {
+				deviate replace { must "k = 'x'"; }
+			}`,
+		wantProcessErrSubstring: "must statements of /l can only be added or deleted by a deviation",
+	}, {
+		desc: "add unique to a leaf",
+		in: `
+			deviation /l/v {
+				deviate add { unique "w"; }
+			}`,
+		wantProcessErrSubstring: "tried to deviate unique on non-list entry /l/v",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			if err := ms.Parse(`
+				module deviate {
+					prefix "d";
+					namespace "urn:d";
+
+					list l {
+						key "k";
+						must "k != 'x'";
+						unique "v";
+						leaf k { type string; }
+						leaf v { type string; }
+						leaf w { type string; }
+					}
+					`+tt.in+`
+				}`, "deviate.yang"); err != nil {
+				t.Fatalf("cannot parse module: %v", err)
+			}
+			errs := ms.Process()
+			if tt.wantProcessErrSubstring != "" {
+				if len(errs) == 0 {
+					t.Fatalf("got no errors, want %q", tt.wantProcessErrSubstring)
+				}
+				if diff := errdiff.Substring(errs[0], tt.wantProcessErrSubstring); diff != "" {
+					t.Fatalf("%s", diff)
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("cannot process module: %v", errs)
+			}
+			m, _ := ms.GetModule("deviate")
+			l := m.Dir["l"]
+			if diff := cmp.Diff(tt.wantMust, args(l, "must"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("must (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantUnique, args(l, "unique"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unique (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestDeviation(t *testing.T) {
 	type deviationTest struct {
 		path  string
@@ -3002,7 +3604,7 @@ func TestDeviation(t *testing.T) {
 			}, {
 				path: "/target/add/default-list",
 				entry: &Entry{
-					Default: []string{"foo", "bar", "foo"},
+					Default: []string{"foo", "bar", "foo", "baz"},
 				},
 			}, {
 				path: "/target/add/default-list-typedef-default",
@@ -3336,6 +3938,11 @@ func TestDeviation(t *testing.T) {
 			}, {
 				path:  "/target/delete/default",
 				entry: &Entry{},
+			}, {
+				path: "/target/delete/default-list",
+				entry: &Entry{
+					Default: []string{"sticks"},
+				},
 			}, {
 				path: "/target/delete/mandatory",
 				entry: &Entry{
@@ -3381,9 +3988,7 @@ func TestDeviation(t *testing.T) {
 			}},
 		},
 	}, {
-		// TODO(wenovus): Support deviate delete for leaf-lists for config-false leafs once its semantics are clear.
-		// https://github.com/mbj4668/pyang/issues/756
-		desc: "error case - deviation delete on a leaf-list",
+		desc: "error case - deviation delete of a leaf-list default that doesn't exist",
 		inFiles: map[string]string{
 			"deviate": `
 				module deviate {
@@ -3402,7 +4007,7 @@ func TestDeviation(t *testing.T) {
 					}
 				}`,
 		},
-		wantProcessErrSubstring: "deviate delete on default statements unsupported for leaf-lists",
+		wantProcessErrSubstring: `tried to deviate delete default "fishsticks" of /a that doesn't exist`,
 	}, {
 		desc: "error case - deviation delete of default has different keyword value",
 		inFiles: map[string]string{
@@ -3568,6 +4173,117 @@ func TestDeviation(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		desc: "replace type with a union of typedefs of the deviating module",
+		inFiles: map[string]string{
+			"deviator": `
+				module deviator {
+					prefix "d";
+					namespace "urn:d";
+
+					import source { prefix s; }
+
+					typedef port { type uint16 { range "1..1024"; } }
+					typedef name-or-port {
+						type union {
+							type port;
+							type string { pattern "[a-z]+"; }
+						}
+					}
+
+					deviation /s:a {
+						deviate replace { type d:name-or-port; }
+					}
+				}
+			`,
+			"source": `
+				module source {
+					prefix "s";
+					namespace "urn:s";
+
+					leaf a { type string; default "ssh"; }
+				}
+			`,
+		},
+		wants: map[string][]deviationTest{
+			"source": {{
+				path: "/a",
+				entry: &Entry{
+					Default: []string{"ssh"},
+					Type: &YangType{
+						Name: "name-or-port",
+						Kind: Yunion,
+					},
+				},
+			}},
+		},
+	}, {
+		desc: "error case - deviation add of type",
+		inFiles: map[string]string{
+			"deviate": `
+				module deviate {
+					prefix "d";
+					namespace "urn:d";
+
+					leaf a { type string; }
+
+					deviation /a {
+						deviate add { type uint8; }
+					}
+				}`,
+		},
+		wantProcessErrSubstring: "can only be replaced by a deviation",
+	}, {
+		desc: "error case - deviation replace of type on a container",
+		inFiles: map[string]string{
+			"deviate": `
+				module deviate {
+					prefix "d";
+					namespace "urn:d";
+
+					container a {}
+
+					deviation /a {
+						deviate replace { type uint8; }
+					}
+				}`,
+		},
+		wantProcessErrSubstring: "tried to deviate the type of non-leaf entry /a",
+	}, {
+		desc: "error case - default invalid for replacement type",
+		inFiles: map[string]string{
+			"deviate": `
+				module deviate {
+					prefix "d";
+					namespace "urn:d";
+
+					leaf a { type string; default "fish"; }
+
+					deviation /a {
+						deviate replace { type uint8; }
+					}
+				}`,
+		},
+		wantProcessErrSubstring: `default "fish" of /a is invalid for its replacement type uint8`,
+	}, {
+		desc: "error case - replaced default out of range of replacement type",
+		inFiles: map[string]string{
+			"deviate": `
+				module deviate {
+					prefix "d";
+					namespace "urn:d";
+
+					leaf a { type string; }
+
+					deviation /a {
+						deviate replace {
+							type uint8 { range "1..10"; }
+							default 11;
+						}
+					}
+				}`,
+		},
+		wantProcessErrSubstring: "value 11 outside of range 1..10",
 	}}
 
 	for _, tt := range tests {
@@ -4139,3 +4855,352 @@ func TestOrderedBy(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryGetters(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"a.yang": `module a {
+	prefix a;
+	namespace "urn:a";
+	container top {
+		presence "enabled";
+		list l {
+			key "k1 k2";
+			min-elements 1;
+			leaf k1 { type string; }
+			leaf k2 { type string; }
+			leaf v { type string; }
+		}
+		leaf-list ll { type string; max-elements 3; }
+		container np;
+	}
+	grouping ag {
+		container ac;
+	}
+	container at {
+		uses ag;
+	}
+}`,
+		"b.yang": `module b {
+	prefix b;
+	namespace "urn:b";
+	import a { prefix a; }
+	grouping bg {
+		leaf bl { type string; }
+	}
+	augment "/a:top" {
+		container bc {
+			uses bg;
+		}
+	}
+}`,
+		"c.yang": `module c {
+	prefix c;
+	namespace "urn:c";
+	import a { prefix a; }
+	import b { prefix b; }
+	augment "/a:top/b:bc" {
+		leaf cl { type string; }
+	}
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	a, err := ms.GetModule("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := a.Dir["top"]
+	l := top.Dir["l"]
+	ll := top.Dir["ll"]
+
+	if !l.IsList() || top.IsList() {
+		t.Errorf("IsList() got %v for l and %v for top, want true and false", l.IsList(), top.IsList())
+	}
+	var keys []string
+	for _, k := range l.Keys() {
+		keys = append(keys, k.Name)
+	}
+	if diff := cmp.Diff([]string{"k1", "k2"}, keys); diff != "" {
+		t.Errorf("Keys() (-want, +got):\n%s", diff)
+	}
+	if keys := top.Keys(); keys != nil {
+		t.Errorf("Keys() of a container got %v, want nil", keys)
+	}
+
+	for _, tt := range []struct {
+		e        *Entry
+		min, max uint64
+	}{
+		{l, 1, math.MaxUint64},
+		{ll, 0, 3},
+		{top, 0, math.MaxUint64},
+	} {
+		if got := tt.e.MinElements(); got != tt.min {
+			t.Errorf("%s: MinElements() got %d, want %d", tt.e.Path(), got, tt.min)
+		}
+		if got := tt.e.MaxElements(); got != tt.max {
+			t.Errorf("%s: MaxElements() got %d, want %d", tt.e.Path(), got, tt.max)
+		}
+	}
+
+	if !top.IsPresenceContainer() {
+		t.Errorf("%s: IsPresenceContainer() got false, want true", top.Path())
+	}
+	for _, e := range []*Entry{top.Dir["np"], l} {
+		if e.IsPresenceContainer() {
+			t.Errorf("%s: IsPresenceContainer() got true, want false", e.Path())
+		}
+	}
+
+	for _, tt := range []struct {
+		desc string
+		e    *Entry
+		want string
+	}{{
+		desc: "module",
+		e:    a,
+		want: "urn:a",
+	}, {
+		desc: "augmented container",
+		e:    top.Dir["bc"],
+		want: "urn:b",
+	}, {
+		desc: "grouping used in an augment",
+		e:    top.Dir["bc"].Dir["bl"],
+		want: "urn:b",
+	}, {
+		desc: "augment of an augment",
+		e:    top.Dir["bc"].Dir["cl"],
+		want: "urn:c",
+	}, {
+		desc: "grouping used in the module",
+		e:    a.Dir["at"].Dir["ac"],
+		want: "urn:a",
+	}, {
+		desc: "nil entry",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.e == nil && tt.want != "" {
+				t.Fatalf("entry not found")
+			}
+			if got := tt.e.Namespace().Name; got != tt.want {
+				t.Errorf("Namespace() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryAncestors(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module a {
+	prefix a;
+	namespace "urn:a";
+	container top {
+		choice ch {
+			case cs {
+				container inner {
+					leaf l { type string; }
+				}
+			}
+			leaf short { type string; }
+		}
+	}
+}`, "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	a, err := ms.GetModule("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := a.Dir["top"]
+	ch := top.Dir["ch"]
+	inner := ch.Dir["cs"].Dir["inner"]
+	short := ch.Dir["short"].Dir["short"]
+
+	for _, tt := range []struct {
+		desc string
+		e    *Entry
+		want *Entry
+	}{
+		{"child of a case", inner, top},
+		{"shorthand case", short, top},
+		{"leaf", inner.Dir["l"], inner},
+		{"choice", ch, top},
+		{"module", a, nil},
+		{"nil entry", nil, nil},
+	} {
+		if got := tt.e.DataParent(); got != tt.want {
+			t.Errorf("%s: DataParent() got %s, want %s", tt.desc, got.Path(), tt.want.Path())
+		}
+	}
+
+	var got []string
+	for _, e := range inner.Dir["l"].Ancestors() {
+		got = append(got, e.Name)
+	}
+	if diff := cmp.Diff([]string{"inner", "top", "a"}, got); diff != "" {
+		t.Errorf("Ancestors() (-want, +got):\n%s", diff)
+	}
+
+	if got := inner.Dir["l"].Root(); got != a {
+		t.Errorf("Root() got %s, want %s", got.Path(), a.Path())
+	}
+	if got := (*Entry)(nil).Root(); got != nil {
+		t.Errorf("Root() of nil got %s, want nil", got.Path())
+	}
+}
+
+func TestEntryProvenance(t *testing.T) {
+	ms := NewModules()
+	for name, in := range map[string]string{
+		"a.yang": `module a {
+  prefix a;
+  namespace "urn:a";
+  include sub;
+  import b { prefix b; }
+  container top {
+    uses b:g;
+    uses sg;
+  }
+}`,
+		"sub.yang": `submodule sub {
+  belongs-to a { prefix a; }
+  grouping sg {
+    leaf s { type string; }
+  }
+}`,
+		"b.yang": `module b {
+  prefix b;
+  namespace "urn:b";
+  grouping g {
+    leaf l {
+      type string;
+    }
+  }
+}`,
+	} {
+		if err := ms.Parse(in, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	a, err := ms.GetModule("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	top := a.Dir["top"]
+	for _, tt := range []struct {
+		desc string
+		e    *Entry
+		want Provenance
+	}{{
+		desc: "container",
+		e:    top,
+		want: Provenance{Module: "a", Range: SourceRange{File: "a.yang", StartLine: 6, StartCol: 3, EndLine: 9, EndCol: 3, Start: 86, End: 132}},
+	}, {
+		desc: "grouping of another module",
+		e:    top.Dir["l"],
+		want: Provenance{Module: "b", Range: SourceRange{File: "b.yang", StartLine: 5, StartCol: 5, EndLine: 7, EndCol: 5, Start: 63, End: 96}},
+	}, {
+		desc: "grouping of a submodule",
+		e:    top.Dir["s"],
+		want: Provenance{Module: "a", Submodule: "sub", Range: SourceRange{File: "sub.yang", StartLine: 4, StartCol: 5, EndLine: 4, EndCol: 27, Start: 65, End: 88}},
+	}} {
+		if tt.e == nil {
+			t.Fatalf("%s: entry not found", tt.desc)
+		}
+		if diff := cmp.Diff(tt.want, tt.e.Provenance); diff != "" {
+			t.Errorf("%s: Provenance (-want, +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestEntryOrdering(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module a {
+	prefix a;
+	namespace "urn:a";
+	extension e { argument a; }
+	container c {
+		uses g { a:e "uses"; }
+		leaf z { type string; }
+		leaf m { type string; }
+	}
+	grouping g {
+		leaf l {
+			type string;
+			a:e "leaf";
+		}
+	}
+}`, "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	a, err := ms.GetModule("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := a.Dir["c"]
+
+	var names []string
+	for _, e := range c.SortedDir() {
+		names = append(names, e.Name)
+	}
+	if diff := cmp.Diff([]string{"l", "m", "z"}, names); diff != "" {
+		t.Errorf("SortedDir() (-want, +got):\n%s", diff)
+	}
+
+	var exts []string
+	for _, s := range c.Dir["l"].Exts {
+		exts = append(exts, s.Argument)
+	}
+	if diff := cmp.Diff([]string{"uses", "leaf"}, exts); diff != "" {
+		t.Errorf("Exts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestTargetsNameChoiceAndCase(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		stmt    string
+		wantErr bool
+	}{
+		{"augment naming choice and case", `augment /t:c/t:ch/t:one { leaf x { type string; } }`, false},
+		{"augment skipping choice and case", `augment /t:c/t:a { leaf x { type string; } }`, true},
+		{"deviation naming choice and case", `deviation /t:c/t:ch/t:one/t:a { deviate not-supported; }`, false},
+		{"deviation skipping choice and case", `deviation /t:c/t:a { deviate not-supported; }`, true},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			if err := ms.Parse(`
+				module test {
+					prefix "t";
+					namespace "urn:t";
+					container c {
+						choice ch {
+							case one { container a { leaf l { type string; } } }
+						}
+					}
+					`+tt.stmt+`
+				}`, "test.yang"); err != nil {
+				t.Fatal(err)
+			}
+			if errs := ms.Process(); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("Process() got errors %v, want errors %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// An ErrorCode is a stable code, such as GY0201, identifying a class of the
+// errors found parsing and processing modules.  Codes are never reused for a
+// different class of error, so they can be used to suppress or escalate
+// specific errors.
+type ErrorCode string
+
+// An Explanation describes a class of errors.
+type Explanation struct {
+	Code        ErrorCode // Code is the code of the class of errors.
+	Title       string    // Title is a short summary of the class.
+	Description string    // Description describes the cause of the errors.
+	Reference   string    // Reference cites the specification, if any.
+}
+
+// errorClasses are the classes of errors.
+var errorClasses = []*Explanation{
+	// Syntax.
+	{"GY0001", "unterminated string or comment",
+		"A quoted string or block comment is not closed before the end of the file.",
+		"RFC 7950, section 6.1.3"},
+	{"GY0002", "invalid escape sequence",
+		`Only \n, \t, \" and \\ may be escaped within a double quoted string.`,
+		"RFC 7950, section 6.1.3"},
+	{"GY0003", "unbalanced braces",
+		"The braces enclosing the substatements of a statement do not balance.",
+		"RFC 7950, section 6.3"},
+	{"GY0004", "malformed statement",
+		"A statement is not a keyword, an optional argument, and either ';' or a block of substatements.",
+		"RFC 7950, section 6.3"},
+	{"GY0005", "unknown or misplaced statement",
+		"A statement is not allowed as a substatement of its parent statement.",
+		"RFC 7950, section 7"},
+	{"GY0006", "missing required statement",
+		"A statement is missing a substatement that is mandatory for it.",
+		"RFC 7950, section 7"},
+	{"GY0007", "repeated statement",
+		"A substatement that may appear at most once appears more than once.",
+		"RFC 7950, section 7"},
+	{"GY0008", "parse limit exceeded",
+		"The input exceeds a limit set by ParseLimits, on the nesting depth, number of statements or argument length.",
+		""},
+	{"GY0009", "invalid character encoding",
+		"The source is not valid UTF-8.  Only UTF-8, and UTF-16 starting with a byte order mark, are accepted.",
+		"RFC 7950, section 6"},
+	{"GY0010", "unsafe statement dropped",
+		"StatementOptions drops a statement, such as key, type or import, that the rest of the schema depends on.  Keep the statement, or set AllowUnsafe to drop it anyway.",
+		""},
+
+	// Modules, imports and includes.
+	{"GY0101", "placeholder for missing import",
+		"An imported module could not be found and AllowMissingImports replaced it with an empty placeholder.",
+		"RFC 7950, section 7.1.5"},
+	{"GY0102", "module not found",
+		"An imported, or requested, module could not be found in the search path.",
+		"RFC 7950, section 7.1.5"},
+	{"GY0103", "submodule not found",
+		"An included submodule could not be found in the search path.",
+		"RFC 7950, section 7.1.6"},
+	{"GY0104", "conflicting module sources",
+		"The same module revision was read from two sources with differing content.",
+		""},
+	{"GY0105", "duplicate module",
+		"The same module or submodule revision was read more than once.",
+		"RFC 7950, section 5.6.5"},
+	{"GY0106", "circular include",
+		"Submodules include each other, which is not allowed.",
+		"RFC 7950, section 5.1"},
+	{"GY0107", "namespace not found or ambiguous",
+		"No module, or more than one module, has the namespace.",
+		"RFC 7950, section 7.1.3"},
+	{"GY0108", "unknown prefix",
+		"A prefix is not the prefix of the module or of one of its imports.",
+		"RFC 7950, section 7.1.4"},
+	{"GY0109", "invalid revision",
+		"A revision date is not a valid YYYY-MM-DD date, is repeated or the revisions are not listed most recent first.",
+		"RFC 7950, section 7.1.9"},
+	{"GY0110", "circular import",
+		"Modules import each other, directly or through other modules, which is not allowed unless AllowImportCycles is set.",
+		"RFC 7950, section 5.1"},
+	{"GY0111", "prefix conflict",
+		"An import uses the prefix of the module itself, or of another import.",
+		"RFC 7950, section 7.1.4"},
+	{"GY0112", "invalid include",
+		"An included submodule belongs to another module, is included by another module or is not the revision given by revision-date.",
+		"RFC 7950, section 7.1.6"},
+	{"GY0113", "yang-version mismatch",
+		"A YANG version 1 module uses a YANG version 1.1 construct, the yang-version is unknown, or modules of differing versions include or import each other.",
+		"RFC 7950, sections 1.1 and 12"},
+
+	// Types.
+	{"GY0201", "unknown type",
+		"A type is neither a built-in type nor a typedef in scope.",
+		"RFC 7950, section 7.3"},
+	{"GY0202", "invalid range",
+		"A range restriction is malformed, out of order, or not a subset of the range of its base type.",
+		"RFC 7950, section 9.2.4"},
+	{"GY0203", "invalid length",
+		"A length restriction is malformed or not a subset of the length of its base type.",
+		"RFC 7950, section 9.4.4"},
+	{"GY0204", "invalid pattern",
+		"A pattern restriction is not a valid regular expression.",
+		"RFC 7950, section 9.4.5"},
+	{"GY0205", "invalid fraction-digits",
+		"The fraction-digits statement is missing, out of range, or used with a type other than decimal64.",
+		"RFC 7950, section 9.3.4"},
+	{"GY0206", "invalid enum or bit",
+		"An enum or bit name, enum value or bit position is used twice, or a value or position is missing where it cannot be assigned automatically.",
+		"RFC 7950, sections 9.6.4.2 and 9.7.4.2"},
+	{"GY0207", "identityref without base",
+		"An identityref type does not specify the identity it is derived from.",
+		"RFC 7950, section 9.10.2"},
+
+	// Identities.
+	{"GY0301", "unknown base identity",
+		"The base of an identity is not an identity defined in the module or one of its imports.",
+		"RFC 7950, section 7.18.2"},
+
+	// Groupings and refinements.
+	{"GY0401", "unknown grouping",
+		"A uses statement names a grouping that is not in scope.",
+		"RFC 7950, section 7.13"},
+	{"GY0402", "unknown refine target",
+		"The target of a refine statement is not a node of the grouping.",
+		"RFC 7950, section 7.13.2"},
+	{"GY0403", "property cannot be refined",
+		"A refine statement sets a property that the kind of its target node does not have.",
+		"RFC 7950, section 7.13.2"},
+	{"GY0404", "invalid refinement",
+		"A refine statement leaves its target in an invalid state, such as mandatory with a default.",
+		"RFC 7950, section 7.13.2"},
+
+	// Augments.
+	{"GY0501", "unknown augment target",
+		"The target of an augment statement is not a node of the schema.",
+		"RFC 7950, section 7.17"},
+
+	// Deviations.
+	{"GY0601", "unknown deviation target",
+		"The target of a deviation statement is not a node of the schema.",
+		"RFC 7950, section 7.20.3"},
+	{"GY0602", "property cannot be deviated this way",
+		"A deviate statement adds, replaces or deletes a property in a way the property, or the target node, does not allow.",
+		"RFC 7950, section 7.20.3.2"},
+	{"GY0603", "deleted property does not exist",
+		"A deviate delete statement deletes a property value the target node does not have.",
+		"RFC 7950, section 7.20.3.2"},
+	{"GY0604", "invalid default after deviation",
+		"A deviation adds a default to a node that has one, adds more than one default to a node that is not a leaf-list, or leaves a default that is invalid for the type.",
+		"RFC 7950, section 7.20.3.2"},
+	{"GY0605", "invalid deviate statement",
+		"The argument of a deviate statement is not one of not-supported, add, replace and delete.",
+		"RFC 7950, section 7.20.3.2"},
+
+	// Extensions.
+	{"GY0701", "unknown extension",
+		"An extension statement is not defined by the module its prefix refers to.",
+		"RFC 7950, section 7.19"},
+	{"GY0702", "invalid extension argument",
+		"An extension statement has an argument when its definition has none, or has none when its definition has one.",
+		"RFC 7950, section 7.19.2"},
+
+	// Data definitions.
+	{"GY0801", "invalid config",
+		"The argument of a config statement is not true or false.",
+		"RFC 7950, section 7.21.1"},
+	{"GY0802", "invalid ordered-by",
+		"The argument of an ordered-by statement is not system or user.",
+		"RFC 7950, section 7.7.7"},
+	{"GY0803", "duplicate node name",
+		"Two sibling data nodes, possibly from different groupings or augments, have the same name.",
+		"RFC 7950, section 6.2.1"},
+	{"GY0804", "invalid value",
+		"A value, such as a default, is not valid for its type.",
+		"RFC 7950, section 9"},
+
+	// Modeling guidelines, reported by Modules.Lint.
+	{"GY0901", "schema too deep",
+		"A data node is nested more deeply than LintOptions.MaxDepth allows.",
+		""},
+	{"GY0902", "too many children",
+		"A container, list or other node has more child data nodes than LintOptions.MaxChildren allows.",
+		""},
+	{"GY0903", "enumeration too large",
+		"An enumeration has more enums than LintOptions.MaxEnums allows.",
+		""},
+	{"GY0904", "description too short",
+		"A node has no description, or one shorter than LintOptions.MinDescription allows.",
+		""},
+}
+
+// A codedError is an error of the class with code.  The code is attached
+// where the error is constructed, rather than recovered from its message.
+type codedError struct {
+	code ErrorCode
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *codedError) Unwrap() error { return e.err }
+
+func (e *codedError) errorCode() ErrorCode { return e.code }
+
+// codeErrorf returns an error of the class with code, with the message
+// formatted as by fmt.Errorf.
+func codeErrorf(code ErrorCode, format string, v ...interface{}) error {
+	return &codedError{code: code, err: fmt.Errorf(format, v...)}
+}
+
+// withCode returns err as an error of the class with code.  It returns err
+// if err is nil or code is "".
+func withCode(code ErrorCode, err error) error {
+	if err == nil || code == "" {
+		return err
+	}
+	return &codedError{code: code, err: err}
+}
+
+// findErrorClass returns the class with code.
+func findErrorClass(code ErrorCode) *Explanation {
+	for _, c := range errorClasses {
+		if c.Code == code {
+			return c
+		}
+	}
+	return nil
+}
+
+// Code returns the code of the class of err, or "" if err is not an error
+// returned by this package or is of no known class.  Errors wrapping an
+// error with a code, as by fmt.Errorf with %w, have the code of the error
+// they wrap.
+func Code(err error) ErrorCode {
+	var c interface{ errorCode() ErrorCode }
+	if errors.As(err, &c) {
+		return c.errorCode()
+	}
+	return ""
+}
+
+// Explain returns the explanation of code, or nil if code is unknown.
+func Explain(code ErrorCode) *Explanation {
+	if c := findErrorClass(ErrorCode(strings.ToUpper(string(code)))); c != nil {
+		e := *c
+		return &e
+	}
+	return nil
+}
+
+// ErrorCodes returns all the known codes, sorted.
+func ErrorCodes() []ErrorCode {
+	var codes []ErrorCode
+	for _, c := range errorClasses {
+		codes = append(codes, c.Code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	return codes
+}
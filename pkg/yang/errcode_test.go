@@ -0,0 +1,361 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	lint := func(opts LintOptions) func(*Modules) []error {
+		return func(ms *Modules) []error {
+			if errs := ms.Process(); len(errs) > 0 {
+				return errs
+			}
+			return ms.Lint(opts)
+		}
+	}
+
+	tests := []struct {
+		desc string
+		in   []string
+		// opts, if not nil, sets the options of the Modules.
+		opts func(*Modules)
+		// errs returns the errors of the Modules, ms.Process if nil.
+		errs func(*Modules) []error
+		want ErrorCode
+	}{{
+		desc: "unterminated string",
+		in:   []string{`module a { prefix "a; }`},
+		want: "GY0001",
+	}, {
+		desc: "invalid escape sequence",
+		in:   []string{`module a { prefix a; namespace urn:a; description "\q"; }`},
+		opts: func(ms *Modules) { ms.ParseOptions.StrictStrings = true },
+		want: "GY0002",
+	}, {
+		desc: "missing brace",
+		in:   []string{`module a { prefix a; namespace urn:a;`},
+		want: "GY0003",
+	}, {
+		desc: "unexpected brace",
+		in:   []string{`module a { prefix a; namespace urn:a; } }`},
+		want: "GY0003",
+	}, {
+		desc: "missing semicolon",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string } }`},
+		want: "GY0004",
+	}, {
+		desc: "unknown statement",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; bogus x; } }`},
+		want: "GY0005",
+	}, {
+		desc: "missing namespace",
+		in:   []string{`module a { prefix a; }`},
+		want: "GY0006",
+	}, {
+		desc: "repeated type",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; type int8; } }`},
+		want: "GY0007",
+	}, {
+		desc: "nested too deep",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { leaf l { type string; } } }`},
+		opts: func(ms *Modules) { ms.ParseOptions.Limits.MaxDepth = 2 },
+		want: "GY0008",
+	}, {
+		desc: "invalid UTF-8",
+		in:   []string{"module a { prefix a; namespace urn:a; description \"\xff\"; }"},
+		want: "GY0009",
+	}, {
+		desc: "unsafe statement dropped",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } }`},
+		opts: func(ms *Modules) { ms.ParseOptions.StatementOptions.ExcludeStatements = []string{"type"} },
+		want: "GY0010",
+	}, {
+		desc: "placeholder for missing import",
+		in:   []string{`module a { prefix a; namespace urn:a; import missing { prefix m; } }`},
+		opts: func(ms *Modules) { ms.ParseOptions.AllowMissingImports = true },
+		errs: func(ms *Modules) []error {
+			if errs := ms.Process(); len(errs) > 0 {
+				return errs
+			}
+			return ms.Warnings()
+		},
+		want: "GY0101",
+	}, {
+		desc: "missing import",
+		in:   []string{`module a { prefix a; namespace urn:a; import missing { prefix m; } }`},
+		want: "GY0102",
+	}, {
+		desc: "missing include",
+		in:   []string{`module a { prefix a; namespace urn:a; include missing; }`},
+		want: "GY0103",
+	}, {
+		desc: "conflicting module sources",
+		in: []string{
+			`module a { prefix a; namespace urn:a; }`,
+			`module a { prefix a; namespace urn:b; }`,
+		},
+		want: "GY0104",
+	}, {
+		desc: "duplicate module",
+		in: []string{
+			`module a { prefix a; namespace urn:a; }`,
+			`module a { prefix a; namespace urn:a; }`,
+		},
+		want: "GY0105",
+	}, {
+		desc: "circular include",
+		in: []string{
+			`module a { prefix a; namespace urn:a; include x; include y; }`,
+			`submodule x { belongs-to a { prefix a; } include y; }`,
+			`submodule y { belongs-to a { prefix a; } include x; }`,
+		},
+		want: "GY0106",
+	}, {
+		desc: "unknown namespace",
+		in:   []string{`module a { prefix a; namespace urn:a; }`},
+		errs: func(ms *Modules) []error {
+			_, err := ms.FindModuleByNamespace("urn:b")
+			return []error{err}
+		},
+		want: "GY0107",
+	}, {
+		desc: "unknown prefix",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type x:string; } }`},
+		want: "GY0108",
+	}, {
+		desc: "invalid revision",
+		in:   []string{`module a { prefix a; namespace urn:a; revision 2020-13-01; }`},
+		errs: func(ms *Modules) []error { return ms.Modules["a"].CheckRevisions() },
+		want: "GY0109",
+	}, {
+		desc: "circular import",
+		in: []string{
+			`module a { prefix a; namespace urn:a; import b { prefix b; } }`,
+			`module b { prefix b; namespace urn:b; import a { prefix a; } }`,
+		},
+		want: "GY0110",
+	}, {
+		desc: "prefix conflict",
+		in: []string{
+			`module a { prefix a; namespace urn:a; import b { prefix a; } }`,
+			`module b { prefix b; namespace urn:b; }`,
+		},
+		want: "GY0111",
+	}, {
+		desc: "invalid include",
+		in: []string{
+			`module a { prefix a; namespace urn:a; include s; }`,
+			`submodule s { belongs-to b { prefix b; } }`,
+		},
+		want: "GY0112",
+	}, {
+		desc: "yang-version mismatch",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { action x; } }`},
+		opts: func(ms *Modules) { ms.ParseOptions.StrictYANGVersion = true },
+		want: "GY0113",
+	}, {
+		desc: "unknown type",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type bogus; } }`},
+		want: "GY0201",
+	}, {
+		desc: "bad range",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type uint8 { range 1..1000; } } }`},
+		want: "GY0202",
+	}, {
+		desc: "bad length",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string { length 2..1; } } }`},
+		want: "GY0203",
+	}, {
+		desc: "bad pattern",
+		in: []string{
+			`module a { prefix a; namespace urn:a; import openconfig-extensions { prefix oc-ext; } leaf l { type string { oc-ext:posix-pattern "["; } } }`,
+			`module openconfig-extensions { prefix oc-ext; namespace urn:oc-ext; extension posix-pattern { argument pattern; } }`,
+		},
+		want: "GY0204",
+	}, {
+		desc: "missing fraction-digits",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type decimal64; } }`},
+		want: "GY0205",
+	}, {
+		desc: "repeated enum value",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type enumeration { enum x { value 1; } enum y { value 1; } } } }`},
+		want: "GY0206",
+	}, {
+		desc: "identityref without base",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type identityref; } }`},
+		want: "GY0207",
+	}, {
+		desc: "unknown base identity",
+		in:   []string{`module a { prefix a; namespace urn:a; identity i { base bogus; } }`},
+		want: "GY0301",
+	}, {
+		desc: "unknown grouping",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { uses g; } }`},
+		want: "GY0401",
+	}, {
+		desc: "unknown refine target",
+		in:   []string{`module a { prefix a; namespace urn:a; grouping g { leaf l { type string; } } container c { uses g { refine x { description d; } } } }`},
+		want: "GY0402",
+	}, {
+		desc: "property cannot be refined",
+		in:   []string{`module a { prefix a; namespace urn:a; grouping g { leaf l { type string; } } container c { uses g { refine l { max-elements 3; } } } }`},
+		want: "GY0403",
+	}, {
+		desc: "invalid refinement",
+		in:   []string{`module a { prefix a; namespace urn:a; grouping g { leaf l { type string; default x; } } container c { uses g { refine l { mandatory true; } } } }`},
+		want: "GY0404",
+	}, {
+		desc: "unknown augment target",
+		in:   []string{`module a { prefix a; namespace urn:a; augment /x { leaf l { type string; } } }`},
+		want: "GY0501",
+	}, {
+		desc: "unknown deviation target",
+		in:   []string{`module a { prefix a; namespace urn:a; deviation /x { deviate not-supported; } }`},
+		want: "GY0601",
+	}, {
+		desc: "unique deviated on a leaf",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } deviation /l { deviate add { unique x; } } }`},
+		want: "GY0602",
+	}, {
+		desc: "deleted default does not exist",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } deviation /l { deviate delete { default x; } } }`},
+		want: "GY0603",
+	}, {
+		desc: "default added to a leaf with a default",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; default x; } deviation /l { deviate add { default y; } } }`},
+		want: "GY0604",
+	}, {
+		desc: "invalid deviate",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } deviation /l { deviate bogus; } }`},
+		want: "GY0605",
+	}, {
+		desc: "unknown extension",
+		in:   []string{`module a { prefix a; namespace urn:a; a:bogus; }`},
+		want: "GY0701",
+	}, {
+		desc: "missing extension argument",
+		in:   []string{`module a { prefix a; namespace urn:a; extension e { argument x; } a:e; }`},
+		want: "GY0702",
+	}, {
+		desc: "invalid config",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { config maybe; } }`},
+		errs: func(ms *Modules) []error { return ToEntry(ms.Modules["a"]).GetErrors() },
+		want: "GY0801",
+	}, {
+		desc: "invalid ordered-by",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf-list l { type string; ordered-by bogus; } }`},
+		want: "GY0802",
+	}, {
+		desc: "duplicate node name",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } leaf l { type string; } }`},
+		want: "GY0803",
+	}, {
+		desc: "invalid max-elements",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf-list l { type string; max-elements bogus; } }`},
+		want: "GY0804",
+	}, {
+		desc: "schema too deep",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { leaf l { type string; } } }`},
+		errs: lint(LintOptions{MaxDepth: 1}),
+		want: "GY0901",
+	}, {
+		desc: "too many children",
+		in:   []string{`module a { prefix a; namespace urn:a; container c { leaf x { type string; } leaf y { type string; } } }`},
+		errs: lint(LintOptions{MaxChildren: 1}),
+		want: "GY0902",
+	}, {
+		desc: "enumeration too large",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type enumeration { enum x; enum y; } } }`},
+		errs: lint(LintOptions{MaxEnums: 1}),
+		want: "GY0903",
+	}, {
+		desc: "description too short",
+		in:   []string{`module a { prefix a; namespace urn:a; leaf l { type string; } }`},
+		errs: lint(LintOptions{MinDescription: 1}),
+		want: "GY0904",
+	}}
+
+	tested := map[ErrorCode]bool{}
+	for _, tt := range tests {
+		tested[tt.want] = true
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			if tt.opts != nil {
+				tt.opts(ms)
+			}
+			var errs []error
+			for i, in := range tt.in {
+				if err := ms.Parse(in, fmt.Sprintf("%d.yang", i)); err != nil {
+					errs = []error{err}
+					break
+				}
+			}
+			if errs == nil {
+				if tt.errs != nil {
+					errs = tt.errs(ms)
+				} else {
+					errs = ms.Process()
+				}
+			}
+			if len(errs) == 0 {
+				t.Fatalf("no errors for %q", tt.in)
+			}
+			if got := Code(errs[0]); got != tt.want {
+				t.Errorf("Code(%q) got %q, want %q", errs[0], got, tt.want)
+			}
+		})
+	}
+	for _, code := range ErrorCodes() {
+		if !tested[code] {
+			t.Errorf("no test for %s", code)
+		}
+	}
+
+	wrapped := fmt.Errorf("wrapped: %w", &ModuleConflict{Kind: "module", Name: "a"})
+	if got := Code(wrapped); got != "GY0104" {
+		t.Errorf("Code(%q) got %q, want GY0104", wrapped, got)
+	}
+	// A message alone does not give an error a code.
+	if got := Code(errors.New("a.yang:1:1: unknown type bogus")); got != "" {
+		t.Errorf("Code() of unknown error got %q", got)
+	}
+	if got := Code(nil); got != "" {
+		t.Errorf("Code(nil) got %q", got)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	seen := map[ErrorCode]bool{}
+	for _, code := range ErrorCodes() {
+		if seen[code] {
+			t.Errorf("code %s used more than once", code)
+		}
+		seen[code] = true
+		e := Explain(code)
+		if e == nil || e.Code != code || e.Title == "" || e.Description == "" {
+			t.Errorf("Explain(%s) got %+v", code, e)
+		}
+	}
+	if e := Explain("gy0201"); e == nil || e.Reference != "RFC 7950, section 7.3" {
+		t.Errorf("Explain(gy0201) got %+v", e)
+	}
+	if e := Explain("GY9999"); e != nil {
+		t.Errorf("Explain(GY9999) got %+v, want nil", e)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A DataFilter selects data nodes by whether they are configuration or state.
+type DataFilter int
+
+const (
+	// AllData selects all data nodes.
+	AllData DataFilter = iota
+	// ConfigData selects the config true data nodes.
+	ConfigData
+	// StateData selects the config false data nodes and those with
+	// config false descendants.
+	StateData
+)
+
+// selects returns true if f selects e.
+func (f DataFilter) selects(e *Entry) bool {
+	switch f {
+	case ConfigData:
+		return !e.ReadOnly()
+	case StateData:
+		if e.ReadOnly() {
+			return true
+		}
+		for _, c := range e.Dir {
+			if f.selects(c) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ExpandPath returns the data nodes below e that match path, ordered by
+// their data paths and filtered by filter.  path is a "/" separated list of
+// data node names, each optionally with a prefix, which is ignored.  The name
+// "*" matches any data node and "..." matches any number of levels of data
+// nodes, including none, as in gNMI.  The list entries a step selects may be
+// given by predicates of the form [key=value] or [key=*], or [*], as in
+// /interfaces/interface[*]/state/counters/*.  Predicates do not change the
+// schema nodes matched but their keys must be keys of the list.  An error is
+// returned if path is invalid, a path that matches nothing returns no
+// entries.
+func (e *Entry) ExpandPath(path string, filter DataFilter) ([]*Entry, error) {
+	nodes := []*Entry{e}
+	for _, step := range splitPath(strings.TrimPrefix(path, "/")) {
+		var next []*Entry
+		seen := map[*Entry]bool{}
+		add := func(n *Entry) {
+			if !seen[n] {
+				seen[n] = true
+				next = append(next, n)
+			}
+		}
+		if step == "..." {
+			var descend func(n *Entry)
+			descend = func(n *Entry) {
+				add(n)
+				for _, c := range dataChildren(n) {
+					descend(c)
+				}
+			}
+			for _, n := range nodes {
+				descend(n)
+			}
+			nodes = next
+			continue
+		}
+		name, preds, err := splitStep(step)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %v", path, err)
+		}
+		for _, n := range nodes {
+			for _, c := range dataChildren(n) {
+				if name != "*" && c.Name != name {
+					continue
+				}
+				if err := checkPredicates(c, preds); err != nil {
+					return nil, fmt.Errorf("path %s: %v", path, err)
+				}
+				add(c)
+			}
+		}
+		nodes = next
+	}
+	var matched []*Entry
+	for _, n := range nodes {
+		if n != e && filter.selects(n) {
+			matched = append(matched, n)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].PathString(PathOpts{}) < matched[j].PathString(PathOpts{})
+	})
+	return matched, nil
+}
+
+// dataChildren returns the data node children of e, looking through choice
+// and case entries, ordered by name.
+func dataChildren(e *Entry) []*Entry {
+	var children []*Entry
+	for _, c := range e.SortedDir() {
+		if c.IsChoice() || c.IsCase() {
+			children = append(children, dataChildren(c)...)
+			continue
+		}
+		children = append(children, c)
+	}
+	return children
+}
+
+// splitPath splits path at each "/" that is not within a predicate.
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	var steps []string
+	depth, start := 0, 0
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && depth > 0:
+			i++
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			steps = append(steps, path[start:i])
+			start = i + 1
+		}
+	}
+	return append(steps, path[start:])
+}
+
+// splitStep returns the unprefixed name and the predicates of the path step
+// step.  The predicate [*] is returned as a predicate named "*".
+func splitStep(step string) (string, []KeyPredicate, error) {
+	name, rest := step, ""
+	if i := strings.Index(step, "["); i >= 0 {
+		name, rest = step[:i], step[i:]
+	}
+	_, name = getPrefix(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("empty element in %q", step)
+	}
+	if rest == "[*]" {
+		return name, []KeyPredicate{{Name: "*"}}, nil
+	}
+	preds, err := SplitKeyPredicates(rest, GNMIKeys)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid predicate in %q", step)
+	}
+	return name, preds, nil
+}
+
+// checkPredicates returns an error if the predicates preds are not valid
+// for e.
+func checkPredicates(e *Entry, preds []KeyPredicate) error {
+	if len(preds) == 0 {
+		return nil
+	}
+	if !e.IsList() {
+		return fmt.Errorf("%s is not a list and cannot have predicates", e.Name)
+	}
+	keys := map[string]bool{}
+	for _, k := range e.KeyNames() {
+		keys[k] = true
+	}
+	for _, p := range preds {
+		if p.Name == "*" {
+			continue
+		}
+		if _, k := getPrefix(p.Name); !keys[k] {
+			return fmt.Errorf("%s is not a key of list %s", k, e.Name)
+		}
+	}
+	return nil
+}
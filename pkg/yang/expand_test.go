@@ -0,0 +1,138 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpandPath(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module if {
+	prefix if;
+	namespace "urn:if";
+	container interfaces {
+		list interface {
+			key "name";
+			leaf name { type string; }
+			container config {
+				leaf mtu { type uint16; }
+			}
+			container state {
+				config false;
+				leaf oper-status { type string; }
+				container counters {
+					leaf in-octets { type uint64; }
+					leaf out-octets { type uint64; }
+				}
+			}
+			choice kind {
+				leaf vlan { type uint16; }
+			}
+		}
+	}
+}`, "if.yang"); err != nil {
+		t.Fatalf("cannot parse module: %v", err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process module: %v", errs)
+	}
+	e, errs := ms.GetModule("if")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+
+	tests := []struct {
+		desc    string
+		path    string
+		filter  DataFilter
+		want    []string
+		wantErr string
+	}{{
+		desc: "list wildcard and wildcard leaves",
+		path: "/interfaces/interface[*]/state/counters/*",
+		want: []string{
+			"/interfaces/interface/state/counters/in-octets",
+			"/interfaces/interface/state/counters/out-octets",
+		},
+	}, {
+		desc: "prefixes, keys and choices",
+		path: "/if:interfaces/if:interface[name=eth0]/vlan",
+		want: []string{"/interfaces/interface/vlan"},
+	}, {
+		desc: "escaped key value",
+		path: `/interfaces/interface[name=a\]/b]/vlan`,
+		want: []string{"/interfaces/interface/vlan"},
+	}, {
+		desc: "any number of levels",
+		path: "/interfaces/.../in-octets",
+		want: []string{"/interfaces/interface/state/counters/in-octets"},
+	}, {
+		desc:   "config data",
+		path:   "/interfaces/interface/...",
+		filter: ConfigData,
+		want: []string{
+			"/interfaces/interface",
+			"/interfaces/interface/config",
+			"/interfaces/interface/config/mtu",
+			"/interfaces/interface/name",
+			"/interfaces/interface/vlan",
+		},
+	}, {
+		desc:   "state data",
+		path:   "/interfaces/*/*",
+		filter: StateData,
+		want:   []string{"/interfaces/interface/state"},
+	}, {
+		desc: "no match",
+		path: "/interfaces/bogus/*",
+	}, {
+		desc:    "predicate on a container",
+		path:    "/interfaces[name=x]",
+		wantErr: "path /interfaces[name=x]: interfaces is not a list and cannot have predicates",
+	}, {
+		desc:    "unknown key",
+		path:    "/interfaces/interface[id=1]",
+		wantErr: "path /interfaces/interface[id=1]: id is not a key of list interface",
+	}, {
+		desc:    "invalid predicate",
+		path:    "/interfaces/interface[name=x",
+		wantErr: `path /interfaces/interface[name=x: invalid predicate in "interface[name=x"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := e.ExpandPath(tt.path, tt.filter)
+			if err != nil {
+				if err.Error() != tt.wantErr {
+					t.Fatalf("ExpandPath() got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("ExpandPath() got no error, want %q", tt.wantErr)
+			}
+			var paths []string
+			for _, m := range got {
+				paths = append(paths, m.PathString(PathOpts{}))
+			}
+			if diff := cmp.Diff(tt.want, paths); diff != "" {
+				t.Errorf("ExpandPath() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
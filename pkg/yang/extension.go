@@ -0,0 +1,247 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkExtensions checks each extension statement used within mods, and the
+// submodules they include, against the extension's definition.  An
+// extension defined with an argument statement must be given an argument,
+// and one defined without must not be.  The substatements of an extension
+// statement are not constrained by YANG and are not checked, other than the
+// extension statements among them.
+func checkExtensions(mods []*Module) []error {
+	var errs []error
+	seen := map[*Module]bool{}
+	var check func(m *Module)
+	check = func(m *Module) {
+		if m == nil || seen[m] {
+			return
+		}
+		seen[m] = true
+		errs = append(errs, checkStatementExtensions(m, m.Source)...)
+		for _, in := range m.Include {
+			check(in.Module)
+		}
+	}
+	for _, m := range mods {
+		check(m)
+	}
+	return errs
+}
+
+// checkStatementExtensions checks the extension statements found within s,
+// which is within the module or submodule m.
+func checkStatementExtensions(m *Module, s *Statement) []error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	for _, ss := range s.SubStatements() {
+		if i := strings.Index(ss.Keyword, ":"); i >= 0 {
+			if err := checkExtension(m, ss, ss.Keyword[:i], ss.Keyword[i+1:]); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		errs = append(errs, checkStatementExtensions(m, ss)...)
+	}
+	return errs
+}
+
+// checkExtension checks the use s, within m, of the extension name defined by
+// the module with the prefix prefix.
+func checkExtension(m *Module, s *Statement, prefix, name string) error {
+	mod := FindModuleByPrefix(m, prefix)
+	if mod == nil {
+		// Unresolvable prefixes are reported by the consumers of the
+		// extension, such as MatchingExtensions.
+		return nil
+	}
+	ext := findExtension(mod, name, map[*Module]bool{})
+	switch {
+	case ext == nil:
+		return missingImportErrorf("GY0701", m, prefix+":"+name, "%s: extension %s is not defined in module %s", s.Location(), name, mod.Name)
+	case ext.Argument != nil && !s.HasArgument:
+		return codeErrorf("GY0702", "%s: extension %s requires argument %s", s.Location(), s.Keyword, ext.Argument.Name)
+	case ext.Argument == nil && s.HasArgument:
+		return codeErrorf("GY0702", "%s: extension %s does not take an argument, got %q", s.Location(), s.Keyword, s.Argument)
+	}
+	return nil
+}
+
+// findExtension returns the definition of the extension name within the
+// module or submodule m, or within the submodules m includes.  nil is
+// returned if there is no such extension.
+func findExtension(m *Module, name string, seen map[*Module]bool) *Extension {
+	if m == nil || seen[m] {
+		return nil
+	}
+	seen[m] = true
+	for _, e := range m.Extension {
+		if e.Name == name {
+			return e
+		}
+	}
+	for _, in := range m.Include {
+		if e := findExtension(in.Module, name, seen); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// A ResolvedKeyword is the definition of the extension used by an extension
+// statement.
+type ResolvedKeyword struct {
+	// Module is the name of the module defining the extension.
+	Module string
+	// Name is the name of the extension, the keyword without its prefix.
+	Name string
+	// Definition is the extension statement defining the extension.
+	Definition *Statement
+	// Extension is the definition of the extension.  It is nil when the
+	// extension is defined by a module statement being parsed, see
+	// ResolveKeyword.
+	Extension *Extension
+}
+
+// ResolveKeyword resolves keyword, the prefixed keyword of an extension
+// statement such as "oc-ext:openconfig-version", to the module and extension
+// statement defining the extension.  The prefix is resolved relative to ctx,
+// as it is by FindModuleByPrefix, so for the statements of the Exts of a node
+// ctx is that node.
+//
+// ResolveKeyword may also be used from a StatementHook, before the modules
+// are built, by passing the module or submodule statement, the statement the
+// hook is called with without a parent, as ctx.  The prefixes are then
+// resolved using its prefix, belongs-to and import statements, and imported
+// modules that have not yet been read are read as they are by Process.  An
+// extension defined by the statement itself has no Extension.
+func (ms *Modules) ResolveKeyword(ctx Node, keyword string) (*ResolvedKeyword, error) {
+	prefix, name := getPrefix(keyword)
+	if prefix == "" {
+		return nil, fmt.Errorf("%s is not an extension keyword, it has no prefix", keyword)
+	}
+	if s, ok := ctx.(*Statement); ok {
+		return ms.resolveStatementKeyword(s, prefix, name)
+	}
+	mod := FindModuleByPrefix(ctx, prefix)
+	if mod == nil {
+		return nil, codeErrorf("GY0108", "%s: unknown prefix %q of extension %s", Source(ctx), prefix, keyword)
+	}
+	return resolveExtension(mod, keyword, name)
+}
+
+// resolveExtension returns the definition of the extension name, used as
+// keyword, of the module or submodule mod, which may be defined by any of
+// the submodules of its module.
+func resolveExtension(mod *Module, keyword, name string) (*ResolvedKeyword, error) {
+	modName := mod.Name
+	ext := findExtension(mod, name, map[*Module]bool{})
+	if m := module(mod); m != nil && m != mod {
+		modName = m.Name
+		if ext == nil {
+			ext = findExtension(m, name, map[*Module]bool{})
+		}
+	}
+	if ext == nil {
+		return nil, codeErrorf("GY0701", "extension %s is not defined in module %s", keyword, modName)
+	}
+	return &ResolvedKeyword{
+		Module:     modName,
+		Name:       name,
+		Definition: ext.Source,
+		Extension:  ext,
+	}, nil
+}
+
+// resolveStatementKeyword resolves the extension name, with the prefix
+// prefix, used within s, the statement of the module or submodule being
+// parsed.
+func (ms *Modules) resolveStatementKeyword(s *Statement, prefix, name string) (*ResolvedKeyword, error) {
+	keyword := prefix + ":" + name
+	if s.Keyword != "module" && s.Keyword != "submodule" {
+		return nil, fmt.Errorf("%s: cannot resolve extension %s from a %s statement, want a module or submodule", s.Location(), keyword, s.Keyword)
+	}
+	own := s.Argument
+	for _, ss := range s.SubStatements() {
+		switch ss.Keyword {
+		case "prefix":
+			if ss.Argument == prefix {
+				return ms.resolveOwnKeyword(s, own, keyword, name)
+			}
+		case "belongs-to":
+			if p := subStatement(ss, "prefix"); p != nil && p.Argument == prefix {
+				return ms.resolveOwnKeyword(s, ss.Argument, keyword, name)
+			}
+		}
+	}
+	for _, ss := range s.SubStatements() {
+		if ss.Keyword != "import" {
+			continue
+		}
+		if p := subStatement(ss, "prefix"); p == nil || p.Argument != prefix {
+			continue
+		}
+		i := &Import{Name: ss.Argument}
+		if r := subStatement(ss, "revision-date"); r != nil {
+			i.RevisionDate = &Value{Name: r.Argument}
+		}
+		mod := ms.FindModule(i)
+		if mod == nil {
+			return nil, codeErrorf("GY0102", "%s: no such module: %s", ss.Location(), ss.Argument)
+		}
+		return resolveExtension(mod, keyword, name)
+	}
+	return nil, codeErrorf("GY0108", "%s: unknown prefix %q of extension %s", s.Location(), prefix, keyword)
+}
+
+// resolveOwnKeyword resolves the extension name, used as keyword, defined by
+// s, the statement of a module or submodule being parsed, or by the module
+// modName s is, or belongs to.
+func (ms *Modules) resolveOwnKeyword(s *Statement, modName, keyword, name string) (*ResolvedKeyword, error) {
+	for _, ss := range s.SubStatements() {
+		if ss.Keyword == "extension" && ss.Argument == name {
+			return &ResolvedKeyword{Module: modName, Name: name, Definition: ss}, nil
+		}
+	}
+	if mod := ms.Modules[modName]; mod != nil {
+		return resolveExtension(mod, keyword, name)
+	}
+	for _, ss := range s.SubStatements() {
+		if ss.Keyword != "include" {
+			continue
+		}
+		if sub := ms.FindModule(&Include{Name: ss.Argument}); sub != nil {
+			if ext := findExtension(sub, name, map[*Module]bool{}); ext != nil {
+				return &ResolvedKeyword{Module: modName, Name: name, Definition: ext.Source, Extension: ext}, nil
+			}
+		}
+	}
+	return nil, codeErrorf("GY0701", "extension %s is not defined in module %s", keyword, modName)
+}
+
+// subStatement returns the first substatement of s with keyword, or nil.
+func subStatement(s *Statement, keyword string) *Statement {
+	for _, ss := range s.SubStatements() {
+		if ss.Keyword == keyword {
+			return ss
+		}
+	}
+	return nil
+}
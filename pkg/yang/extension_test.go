@@ -0,0 +1,244 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestCheckExtensions(t *testing.T) {
+	const extModule = `
+		module ext {
+			prefix "e";
+			namespace "urn:e";
+
+			include ext-sub;
+
+			extension flag;
+			extension label { argument "text"; }
+			extension element {
+				argument "value" { yin-element true; }
+			}
+		}`
+	const extSubModule = `
+		submodule ext-sub {
+			belongs-to ext { prefix e; }
+
+			extension sub-flag;
+		}`
+
+	tests := []struct {
+		desc          string
+		in            string
+		wantErrSubstr string
+	}{{
+		desc: "valid uses",
+		in: `
+			e:flag;
+			e:sub-flag;
+			leaf l {
+				e:label "x";
+				e:element "y" {
+					e:flag;
+				}
+				type string;
+			}`,
+	}, {
+		desc:          "missing argument",
+		in:            `leaf l { e:label; type string; }`,
+		wantErrSubstr: "extension e:label requires argument text",
+	}, {
+		desc:          "missing yin-element argument",
+		in:            `e:element;`,
+		wantErrSubstr: "extension e:element requires argument value",
+	}, {
+		desc:          "unexpected argument",
+		in:            `container c { e:flag "x"; }`,
+		wantErrSubstr: `extension e:flag does not take an argument, got "x"`,
+	}, {
+		desc:          "undefined extension",
+		in:            `e:missing;`,
+		wantErrSubstr: "extension missing is not defined in module ext",
+	}, {
+		desc:          "nested extension statement",
+		in:            `e:label "x" { e:sub-flag "y"; }`,
+		wantErrSubstr: "extension e:sub-flag does not take an argument",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			for name, mod := range map[string]string{
+				"ext.yang":     extModule,
+				"ext-sub.yang": extSubModule,
+				"test.yang": `
+					module test {
+						prefix "t";
+						namespace "urn:t";
+
+						import ext { prefix e; }
+						` + tt.in + `
+					}`,
+			} {
+				if err := ms.Parse(mod, name); err != nil {
+					t.Fatalf("cannot parse module %s: %v", name, err)
+				}
+			}
+			var err error
+			if errs := ms.Process(); len(errs) > 0 {
+				err = errs[0]
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Errorf("Process: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveKeyword(t *testing.T) {
+	const extModule = `
+		module ext {
+			prefix "e";
+			namespace "urn:e";
+
+			include ext-sub;
+
+			extension label { argument "text"; }
+		}`
+	const extSubModule = `
+		submodule ext-sub {
+			belongs-to ext { prefix e; }
+
+			extension sub-flag;
+		}`
+	const userModule = `
+		module user {
+			prefix "u";
+			namespace "urn:u";
+			import ext { prefix x; }
+
+			extension own;
+
+			leaf l {
+				x:label "a";
+				type string;
+			}
+		}`
+
+	ms := NewModules()
+	var root *Statement
+	type result struct {
+		module, name, definition string
+		built                    bool
+	}
+	hookResults := map[string]result{}
+	ms.ParseOptions.StatementHook = func(s, parent *Statement) error {
+		if parent == nil {
+			root = s
+		}
+		if i := strings.Index(s.Keyword, ":"); i < 0 || root.Argument != "user" {
+			return nil
+		}
+		r, err := ms.ResolveKeyword(root, s.Keyword)
+		if err != nil {
+			return err
+		}
+		hookResults[s.Keyword] = result{r.Module, r.Name, r.Definition.Location(), r.Extension != nil}
+		return nil
+	}
+	for name, src := range map[string]string{"ext.yang": extModule, "ext-sub.yang": extSubModule} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if err := ms.Parse(userModule+"\n", "user.yang"); err != nil {
+		t.Fatalf("cannot parse user.yang: %v", err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	if diff := cmp.Diff(map[string]result{
+		"x:label": {"ext", "label", "ext.yang:8:4", true},
+	}, hookResults, cmp.AllowUnexported(result{})); diff != "" {
+		t.Errorf("ResolveKeyword from StatementHook (-want, +got):\n%s", diff)
+	}
+	if r, err := ms.ResolveKeyword(root, "u:own"); err != nil {
+		t.Errorf("ResolveKeyword(module statement, u:own): %v", err)
+	} else if got, want := (result{r.Module, r.Name, r.Definition.Location(), r.Extension != nil}), (result{"user", "own", "user.yang:7:4", false}); got != want {
+		t.Errorf("ResolveKeyword(module statement, u:own) got %+v, want %+v", got, want)
+	}
+
+	leaf := ms.Modules["user"].Leaf[0]
+	tests := []struct {
+		desc          string
+		ctx           Node
+		keyword       string
+		want          result
+		wantErrSubstr string
+	}{{
+		desc:    "imported",
+		ctx:     leaf,
+		keyword: "x:label",
+		want:    result{"ext", "label", "ext.yang:8:4", true},
+	}, {
+		desc:    "defined by submodule",
+		ctx:     leaf,
+		keyword: "x:sub-flag",
+		want:    result{"ext", "sub-flag", "ext-sub.yang:5:4", true},
+	}, {
+		desc:    "own",
+		ctx:     leaf,
+		keyword: "u:own",
+		want:    result{"user", "own", "user.yang:7:4", true},
+	}, {
+		desc:          "no prefix",
+		ctx:           leaf,
+		keyword:       "own",
+		wantErrSubstr: "has no prefix",
+	}, {
+		desc:          "unknown prefix",
+		ctx:           leaf,
+		keyword:       "y:label",
+		wantErrSubstr: `unknown prefix "y"`,
+	}, {
+		desc:          "not defined",
+		ctx:           leaf,
+		keyword:       "x:missing",
+		wantErrSubstr: "extension x:missing is not defined in module ext",
+	}, {
+		desc:          "statement is not a module",
+		ctx:           leaf.Source,
+		keyword:       "x:label",
+		wantErrSubstr: "want a module or submodule",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r, err := ms.ResolveKeyword(tt.ctx, tt.keyword)
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Fatal(diff)
+			}
+			if err != nil {
+				return
+			}
+			if got := (result{r.Module, r.Name, r.Definition.Location(), r.Extension != nil}); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
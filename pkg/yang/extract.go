@@ -0,0 +1,396 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// extractKeywords are the keywords of the statements that ExtractGrouping
+// extracts.
+var extractKeywords = map[string]bool{
+	"anydata":   true,
+	"anyxml":    true,
+	"choice":    true,
+	"container": true,
+	"leaf":      true,
+	"leaf-list": true,
+	"list":      true,
+}
+
+// prefixedKeywords are the keywords of the statements whose arguments may
+// contain prefixed identifiers.
+var prefixedKeywords = map[string]bool{
+	"augment":    true,
+	"base":       true,
+	"default":    true,
+	"if-feature": true,
+	"must":       true,
+	"path":       true,
+	"refine":     true,
+	"type":       true,
+	"unique":     true,
+	"uses":       true,
+	"when":       true,
+}
+
+// definitionKeywords maps the keywords of the statements that refer to a
+// definition by name to the keyword of the definition.
+var definitionKeywords = map[string]string{
+	"base":       "identity",
+	"if-feature": "feature",
+	"type":       "typedef",
+	"uses":       "grouping",
+}
+
+// prefixRE matches a prefix, and its colon, in an argument.
+var prefixRE = regexp.MustCompile(`(?:^|[^A-Za-z0-9_.\-])([A-Za-z_][A-Za-z0-9_.\-]*):`)
+
+// ExtractGrouping moves the data definition statement of the first of defs,
+// such as a container, into a new grouping called name defined at the top of
+// into, and replaces each of defs with a uses of the grouping.  Each of defs
+// must be the same as the first once the prefixes they use are those of
+// into, which deduplicates copies of a subtree.  If into is nil the grouping
+// is defined in the module or submodule of the first of defs.  The edited
+// source text of each file changed is returned by the name it was parsed
+// with, ms is not changed.
+//
+// When into is not the module defs are defined in, the prefixes of the
+// extracted statements are changed to the prefixes into uses for the same
+// modules, and each module of defs must import into.  An error is returned if
+// a statement of defs refers to a typedef, grouping, identity or feature
+// without a prefix and it is neither defined within the statement nor, when
+// into is the module of defs, at the top of that module.  The contents of
+// single-quoted multi-line strings are reindented along with the rest of the
+// subtree.
+func (ms *Modules) ExtractGrouping(name string, into *Module, defs ...Node) (map[string]string, error) {
+	if !identifierRE.MatchString(name) {
+		return nil, fmt.Errorf("%q is not a valid identifier", name)
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no statements to extract")
+	}
+	if into == nil {
+		into = RootNode(defs[0])
+	}
+	if into == nil || into.Source == nil {
+		return nil, fmt.Errorf("no module to define grouping %s in", name)
+	}
+	for _, m := range modulesNamed(ms, moduleName(into)) {
+		for _, g := range m.Grouping {
+			if g.Name == name {
+				return nil, fmt.Errorf("%s: grouping %s already defined", g.Source.Location(), name)
+			}
+		}
+	}
+
+	x := &extractor{ms: ms, into: into, splices: map[string][]splice{}}
+	var body, canon string
+	srcs := map[string]string{} // the sources of the files spliced
+	for i, def := range defs {
+		s := def.Statement()
+		if s == nil || !extractKeywords[s.Keyword] {
+			return nil, fmt.Errorf("%s is not a data definition statement", NodePath(def))
+		}
+		src, ok := ms.source(s.file)
+		if !ok || s.endOffset > len(src) || !strings.HasPrefix(src[s.offset:], s.Keyword) {
+			return nil, fmt.Errorf("%s: source not retained", s.Location())
+		}
+		srcs[s.file] = src
+		text, c, err := x.translate(def, src)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			body, canon = reindent(text, lineIndent(src, s.offset), ""), c
+		} else if c != canon {
+			return nil, fmt.Errorf("%s: %s differs from %s", s.Location(), NodePath(def), NodePath(defs[0]))
+		}
+		ref := name
+		if moduleName(RootNode(def)) != moduleName(into) {
+			prefix := importPrefix(RootNode(def), moduleName(into))
+			if prefix == "" {
+				return nil, fmt.Errorf("%s: %s does not import %s", s.Location(), RootNode(def).Name, moduleName(into))
+			}
+			ref = prefix + ":" + name
+		}
+		x.splices[s.file] = append(x.splices[s.file], splice{s.offset, s.endOffset, "uses " + ref + ";"})
+	}
+
+	// The grouping is added before the closing brace of into.
+	ts := into.Source
+	src, ok := ms.source(ts.file)
+	if !ok || ts.endOffset < 1 || ts.endOffset > len(src) || src[ts.endOffset-1] != '}' {
+		return nil, fmt.Errorf("%s: source not retained", ts.Location())
+	}
+	srcs[ts.file] = src
+	indent := "  "
+	if len(ts.statements) > 0 {
+		if i := lineIndent(src, ts.statements[0].offset); i != "" {
+			indent = i
+		}
+	}
+	at := ts.endOffset - 1
+	at -= len(lineIndent(src, at))
+	var b strings.Builder
+	if at > 0 && src[at-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "\n%sgrouping %s {\n", indent, name)
+	b.WriteString(reindent(body, "", indent+indent))
+	fmt.Fprintf(&b, "\n%s}\n", indent)
+	x.splices[ts.file] = append(x.splices[ts.file], splice{at, at, b.String()})
+
+	files := map[string]string{}
+	for file, sps := range x.splices {
+		sort.Slice(sps, func(i, j int) bool { return sps[i].start > sps[j].start })
+		src := srcs[file]
+		for i, sp := range sps {
+			if i > 0 && sp.end > sps[i-1].start {
+				return nil, fmt.Errorf("%s: statements to extract overlap", file)
+			}
+			src = src[:sp.start] + sp.text + src[sp.end:]
+		}
+		files[file] = src
+	}
+	return files, nil
+}
+
+// A splice replaces the bytes from start to end of a source with text.
+type splice struct {
+	start, end int
+	text       string
+}
+
+// An extractor collects the edits of ExtractGrouping.
+type extractor struct {
+	ms      *Modules
+	into    *Module
+	splices map[string][]splice
+}
+
+// translate returns the source text of def, whose file holds src, with its
+// prefixes changed to those of x.into, along with a canonical form of the
+// translated statements that is the same for the same definitions.
+func (x *extractor) translate(def Node, src string) (string, string, error) {
+	s := def.Statement()
+	from := RootNode(def)
+	local := map[string]map[string]bool{}
+	visitStatements(s, nil, func(t, _ *Statement) error {
+		switch t.Keyword {
+		case "feature", "grouping", "identity", "typedef":
+			if local[t.Keyword] == nil {
+				local[t.Keyword] = map[string]bool{}
+			}
+			local[t.Keyword][t.Argument] = true
+		}
+		return nil
+	})
+
+	var sps []splice
+	var canon strings.Builder
+	var err error
+	rewrite := func(t *Statement, text string) string {
+		out, e := x.rewrite(from, t, text)
+		if e != nil && err == nil {
+			err = e
+		}
+		return out
+	}
+	var walk func(t *Statement, depth int)
+	walk = func(t *Statement, depth int) {
+		kw := t.Keyword
+		if strings.Contains(kw, ":") {
+			if kw = rewrite(t, kw); kw != t.Keyword {
+				sps = append(sps, splice{t.offset, t.offset + len(t.Keyword), kw})
+			}
+		}
+		arg := t.Argument
+		if prefixedKeywords[t.Keyword] && t.HasArgument {
+			if e := x.checkUnprefixed(from, t, local); e != nil && err == nil {
+				err = e
+			}
+			arg = rewrite(t, t.Argument)
+			if raw := rewrite(t, t.rawArgument); raw != t.rawArgument {
+				if !strings.HasPrefix(src[t.argOffset:], t.rawArgument) && err == nil {
+					err = fmt.Errorf("%s: cannot find the argument of %s in the source", t.Location(), t.Keyword)
+				}
+				sps = append(sps, splice{t.argOffset, t.argOffset + len(t.rawArgument), raw})
+			}
+		}
+		fmt.Fprintf(&canon, "%s%s %q\n", strings.Repeat(" ", depth), kw, arg)
+		for _, ss := range t.statements {
+			walk(ss, depth+1)
+		}
+	}
+	walk(s, 0)
+	if err != nil {
+		return "", "", err
+	}
+
+	sort.Slice(sps, func(i, j int) bool { return sps[i].start > sps[j].start })
+	text := src[s.offset:s.endOffset]
+	for _, sp := range sps {
+		start, end := sp.start-s.offset, sp.end-s.offset
+		text = text[:start] + sp.text + text[end:]
+	}
+	return text, canon.String(), nil
+}
+
+// rewrite returns text, a keyword or argument of t in the module or submodule
+// from, with each prefix of a module known to from replaced by the prefix
+// x.into uses for that module.
+func (x *extractor) rewrite(from *Module, t *Statement, text string) (string, error) {
+	if moduleName(from) == moduleName(x.into) {
+		return text, nil
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range prefixRE.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[2], m[3]
+		if end+1 < len(text) && text[end+1] == ':' {
+			// An XPath axis, such as ancestor::.
+			continue
+		}
+		pm := FindModuleByPrefix(from, text[start:end])
+		if pm == nil {
+			continue
+		}
+		prefix := importPrefix(x.into, moduleName(pm))
+		if prefix == "" {
+			return text, fmt.Errorf("%s: %s does not import %s", t.Location(), x.into.Name, moduleName(pm))
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(prefix)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+// checkUnprefixed returns an error if t, in the module or submodule from,
+// refers without a prefix to a definition that will not be visible from the
+// grouping in x.into.  local has the names of the definitions of each
+// keyword within the extracted statement.
+func (x *extractor) checkUnprefixed(from *Module, t *Statement, local map[string]map[string]bool) error {
+	kind, ok := definitionKeywords[t.Keyword]
+	if !ok {
+		return nil
+	}
+	names := []string{t.Argument}
+	if t.Keyword == "if-feature" {
+		names = strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(t.Argument))
+	}
+	for _, n := range names {
+		switch {
+		case strings.Contains(n, ":"), local[kind][n]:
+			continue
+		case t.Keyword == "if-feature" && (n == "and" || n == "or" || n == "not"):
+			continue
+		case kind == "typedef" && BaseTypedefs[n] != nil:
+			continue
+		case moduleName(from) == moduleName(x.into) && topLevel(x.ms, moduleName(from), kind, n):
+			continue
+		}
+		return fmt.Errorf("%s: %s %s is not visible from grouping in %s", t.Location(), kind, n, x.into.Name)
+	}
+	return nil
+}
+
+// moduleName returns the name of m, or of the module m belongs to if m is a
+// submodule.
+func moduleName(m *Module) string {
+	if m == nil {
+		return ""
+	}
+	if m.BelongsTo != nil {
+		return m.BelongsTo.Name
+	}
+	return m.Name
+}
+
+// modulesNamed returns the modules and submodules of ms that are, or belong
+// to, the module name.
+func modulesNamed(ms *Modules, name string) []*Module {
+	var mods []*Module
+	for _, all := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(all) {
+			if moduleName(m) == name {
+				mods = append(mods, m)
+			}
+		}
+	}
+	return mods
+}
+
+// topLevel returns true if a statement with keyword kind and argument name is
+// a substatement of the module, or a submodule of the module, module.
+func topLevel(ms *Modules, module, kind, name string) bool {
+	for _, m := range modulesNamed(ms, module) {
+		if m.Source == nil {
+			continue
+		}
+		for _, s := range m.Source.statements {
+			if s.Keyword == kind && s.Argument == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// importPrefix returns the prefix m uses to refer to the module name, or ""
+// if there is none.
+func importPrefix(m *Module, name string) string {
+	if moduleName(m) == name {
+		return m.GetPrefix()
+	}
+	for _, i := range m.Import {
+		if i.Name == name && i.Prefix != nil {
+			return i.Prefix.Name
+		}
+	}
+	return ""
+}
+
+// lineIndent returns the whitespace before offset on its line in src, or ""
+// if anything else precedes offset on the line.
+func lineIndent(src string, offset int) string {
+	start := strings.LastIndexByte(src[:offset], '\n') + 1
+	if strings.TrimLeft(src[start:offset], " \t") != "" {
+		return ""
+	}
+	return src[start:offset]
+}
+
+// reindent returns text with the indentation old removed from the start of
+// each line but the first, and indent added to the start of each non-empty
+// line.
+func reindent(text, old, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if i > 0 {
+			line = strings.TrimPrefix(line, old)
+		}
+		if strings.TrimSpace(line) != "" {
+			line = indent + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
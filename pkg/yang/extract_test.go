@@ -0,0 +1,262 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testExtractModules = map[string]string{
+	"types.yang": `module types {
+  namespace "urn:types";
+  prefix "types";
+  typedef counter { type uint32; }
+  extension note { argument text; }
+}`,
+	"common.yang": `module common {
+  namespace "urn:common";
+  prefix "c";
+  import types { prefix ty; }
+}`,
+	"unused.yang": `module unused {
+  namespace "urn:unused";
+  prefix "u";
+}`,
+	"vendor.yang": `module vendor {
+  namespace "urn:vendor";
+  prefix "v";
+  import types { prefix t; }
+  import common { prefix cmn; }
+  typedef local { type string; }
+  container a {
+    container stats {
+      t:note "copied";
+      leaf in { type t:counter; }
+      leaf out { type t:counter; }
+    }
+  }
+  container b {
+        container stats {
+          t:note "copied";
+          leaf in { type t:counter; }
+          leaf out {
+            type t:counter;
+          }
+        }
+  }
+  container c {
+    container stats {
+      leaf in { type local; }
+    }
+  }
+}`,
+}
+
+func extractModules(t *testing.T) *Modules {
+	t.Helper()
+	ms := NewModules()
+	ms.ParseOptions.RetainSources = true
+	for _, name := range []string{"types.yang", "common.yang", "unused.yang", "vendor.yang"} {
+		if err := ms.Parse(testExtractModules[name], name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	return ms
+}
+
+func stats(ms *Modules, parent string) Node {
+	return ToEntry(ms.Modules["vendor"]).Dir[parent].Dir["stats"].Node
+}
+
+func TestExtractGrouping(t *testing.T) {
+	tests := []struct {
+		desc    string
+		into    string
+		parents []string
+		want    map[string]string
+	}{{
+		desc:    "same module",
+		parents: []string{"a", "b"},
+		want: map[string]string{
+			"vendor.yang": `module vendor {
+  namespace "urn:vendor";
+  prefix "v";
+  import types { prefix t; }
+  import common { prefix cmn; }
+  typedef local { type string; }
+  container a {
+    uses stats;
+  }
+  container b {
+        uses stats;
+  }
+  container c {
+    container stats {
+      leaf in { type local; }
+    }
+  }
+
+  grouping stats {
+    container stats {
+      t:note "copied";
+      leaf in { type t:counter; }
+      leaf out { type t:counter; }
+    }
+  }
+}`,
+		},
+	}, {
+		desc:    "other module",
+		into:    "common",
+		parents: []string{"b", "a"},
+		want: map[string]string{
+			"vendor.yang": `module vendor {
+  namespace "urn:vendor";
+  prefix "v";
+  import types { prefix t; }
+  import common { prefix cmn; }
+  typedef local { type string; }
+  container a {
+    uses cmn:stats;
+  }
+  container b {
+        uses cmn:stats;
+  }
+  container c {
+    container stats {
+      leaf in { type local; }
+    }
+  }
+}`,
+			"common.yang": `module common {
+  namespace "urn:common";
+  prefix "c";
+  import types { prefix ty; }
+
+  grouping stats {
+    container stats {
+      ty:note "copied";
+      leaf in { type ty:counter; }
+      leaf out {
+        type ty:counter;
+      }
+    }
+  }
+}`,
+		},
+	}, {
+		desc:    "local typedef",
+		parents: []string{"c"},
+		want: map[string]string{
+			"vendor.yang": `module vendor {
+  namespace "urn:vendor";
+  prefix "v";
+  import types { prefix t; }
+  import common { prefix cmn; }
+  typedef local { type string; }
+  container a {
+    container stats {
+      t:note "copied";
+      leaf in { type t:counter; }
+      leaf out { type t:counter; }
+    }
+  }
+  container b {
+        container stats {
+          t:note "copied";
+          leaf in { type t:counter; }
+          leaf out {
+            type t:counter;
+          }
+        }
+  }
+  container c {
+    uses stats;
+  }
+
+  grouping stats {
+    container stats {
+      leaf in { type local; }
+    }
+  }
+}`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := extractModules(t)
+			var into *Module
+			if tt.into != "" {
+				into = ms.Modules[tt.into]
+			}
+			var defs []Node
+			for _, p := range tt.parents {
+				defs = append(defs, stats(ms, p))
+			}
+			got, err := ms.ExtractGrouping("stats", into, defs...)
+			if err != nil {
+				t.Fatalf("ExtractGrouping: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ExtractGrouping (-want, +got):\n%s", diff)
+			}
+
+			// The edited modules must process.
+			nms := NewModules()
+			for _, name := range []string{"types.yang", "common.yang", "unused.yang", "vendor.yang"} {
+				src, ok := got[name]
+				if !ok {
+					src = testExtractModules[name]
+				}
+				if err := nms.Parse(src, name); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if errs := nms.Process(); len(errs) > 0 {
+				t.Errorf("edited modules do not process: %v", errs)
+			}
+		})
+	}
+}
+
+func TestExtractGroupingErrors(t *testing.T) {
+	ms := extractModules(t)
+	for _, tt := range []struct {
+		desc    string
+		name    string
+		into    *Module
+		defs    []Node
+		wantErr string
+	}{
+		{"invalid name", "1bad", nil, []Node{stats(ms, "a")}, "not a valid identifier"},
+		{"no statements", "g", nil, nil, "no statements"},
+		{"not data definition", "g", nil, []Node{ms.Modules["vendor"].Typedef[0]}, "not a data definition"},
+		{"different", "g", nil, []Node{stats(ms, "a"), stats(ms, "c")}, "differs from"},
+		{"not visible", "g", ms.Modules["common"], []Node{stats(ms, "c")}, "typedef local is not visible"},
+		{"not imported", "g", ms.Modules["unused"], []Node{stats(ms, "a")}, "unused does not import types"},
+		{"overlap", "g", nil, []Node{stats(ms, "a"), stats(ms, "a")}, "overlap"},
+	} {
+		_, err := ms.ExtractGrouping(tt.name, tt.into, tt.defs...)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: got error %v, want %q", tt.desc, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A FeatureGraph is the graph of the features defined by a set of modules,
+// whose edges are the references of their if-feature statements, as
+// returned by Modules.FeatureGraph.
+type FeatureGraph struct {
+	// Features are the features defined by the modules and their
+	// submodules, ordered by module and name.
+	Features []*FeatureNode
+	// Cycles are the circular dependencies of the features, which RFC
+	// 7950 section 7.20.1 does not allow.
+	Cycles []FeatureCycle
+}
+
+// A FeatureNode is a feature of a FeatureGraph.
+type FeatureNode struct {
+	Module  string   // Module is the name of the module defining the feature.
+	Name    string   // Name is the name of the feature.
+	Feature *Feature // Feature is the feature statement.
+	// IfFeature are the arguments of the if-feature statements of the
+	// feature, as written.
+	IfFeature []string
+	// Requires are the features referenced by IfFeature, in the order
+	// written, without duplicates.
+	Requires []*FeatureNode
+	// RequiredBy are the features whose Requires have this feature,
+	// ordered by module and name.
+	RequiredBy []*FeatureNode
+	// Unknown are the references of IfFeature that are not features of
+	// the modules, as written.
+	Unknown []string
+}
+
+// String returns the module and name of f, e.g., "ietf-interfaces:if-mib".
+func (f *FeatureNode) String() string {
+	return f.Module + ":" + f.Name
+}
+
+// AllRequired returns the features f requires, directly or through the
+// features it requires, ordered by module and name.  They are the features
+// to enable, along with f, to enable f when its if-feature expressions do
+// not use "or" or "not".
+func (f *FeatureNode) AllRequired() []*FeatureNode {
+	seen := map[*FeatureNode]bool{f: true}
+	var all []*FeatureNode
+	var visit func(f *FeatureNode)
+	visit = func(f *FeatureNode) {
+		for _, r := range f.Requires {
+			if !seen[r] {
+				seen[r] = true
+				all = append(all, r)
+				visit(r)
+			}
+		}
+	}
+	visit(f)
+	sortFeatures(all)
+	return all
+}
+
+// A FeatureCycle is a chain of features, each referenced by the if-feature
+// statements of the one before it, that leads back to the first: the last
+// feature references the first.
+type FeatureCycle []*FeatureNode
+
+// String returns the features of c separated by " -> ", ending with the
+// first, e.g., "m:a -> m:b -> m:a".
+func (c FeatureCycle) String() string {
+	var s []string
+	for _, f := range c {
+		s = append(s, f.String())
+	}
+	if len(c) > 0 {
+		s = append(s, c[0].String())
+	}
+	return strings.Join(s, " -> ")
+}
+
+// Find returns the feature name of the module named module, or nil if g has
+// no such feature.
+func (g *FeatureGraph) Find(module, name string) *FeatureNode {
+	i := sort.Search(len(g.Features), func(i int) bool {
+		f := g.Features[i]
+		return f.Module > module || f.Module == module && f.Name >= name
+	})
+	if i < len(g.Features) && g.Features[i].Module == module && g.Features[i].Name == name {
+		return g.Features[i]
+	}
+	return nil
+}
+
+// FeatureGraph returns the graph of the features defined by the modules and
+// submodules of ms, whose imports must have been resolved by
+// Modules.Process.  The prefixes of the if-feature statements of a feature
+// are resolved in the module or submodule defining it.  A cycle is reported
+// for each reference that leads back to a feature being followed, following
+// the features in order and their references as written, so a cycle is not
+// reported again from each of the features it passes through.
+func (ms *Modules) FeatureGraph() *FeatureGraph {
+	g := &FeatureGraph{}
+	type defined struct {
+		node *FeatureNode
+		mod  *Module // the module or submodule defining the feature
+	}
+	var features []defined
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			name := m.Name
+			if mod := moduleOf(m); mod != nil {
+				name = mod.Name
+			}
+			for _, f := range m.Feature {
+				features = append(features, defined{&FeatureNode{Module: name, Name: f.Name, Feature: f}, m})
+			}
+		}
+	}
+	for _, d := range features {
+		g.Features = append(g.Features, d.node)
+	}
+	sortFeatures(g.Features)
+
+	for _, d := range features {
+		f := d.node
+		seen := map[*FeatureNode]bool{}
+		for _, v := range f.Feature.IfFeature {
+			f.IfFeature = append(f.IfFeature, v.Name)
+			for _, ref := range featureReferences(v.Name) {
+				var r *FeatureNode
+				prefix, name := getPrefix(ref)
+				if mod := moduleOf(FindModuleByPrefix(d.mod, prefix)); mod != nil {
+					r = g.Find(mod.Name, name)
+				}
+				switch {
+				case r == nil:
+					f.Unknown = append(f.Unknown, ref)
+				case !seen[r]:
+					seen[r] = true
+					f.Requires = append(f.Requires, r)
+					r.RequiredBy = append(r.RequiredBy, f)
+				}
+			}
+		}
+	}
+	for _, f := range g.Features {
+		sortFeatures(f.RequiredBy)
+	}
+
+	state := map[*FeatureNode]int{}
+	var stack []*FeatureNode
+	var visit func(f *FeatureNode)
+	visit = func(f *FeatureNode) {
+		state[f] = visiting
+		stack = append(stack, f)
+		for _, r := range f.Requires {
+			switch state[r] {
+			case visiting:
+				i := len(stack) - 1
+				for stack[i] != r {
+					i--
+				}
+				g.Cycles = append(g.Cycles, append(FeatureCycle{}, stack[i:]...))
+			case unvisited:
+				visit(r)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[f] = visited
+	}
+	for _, f := range g.Features {
+		if state[f] == unvisited {
+			visit(f)
+		}
+	}
+	return g
+}
+
+// featureReferences returns the feature names, possibly prefixed, that the
+// if-feature expression expr references, in the order written.
+func featureReferences(expr string) []string {
+	var refs []string
+	for _, t := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expr)) {
+		switch t {
+		case "and", "or", "not":
+		default:
+			refs = append(refs, t)
+		}
+	}
+	return refs
+}
+
+// sortFeatures sorts fs by module and name.
+func sortFeatures(fs []*FeatureNode) {
+	sort.Slice(fs, func(i, j int) bool {
+		if fs[i].Module != fs[j].Module {
+			return fs[i].Module < fs[j].Module
+		}
+		return fs[i].Name < fs[j].Name
+	})
+}
+
+// featuresEnabled returns true if the if-feature expression expr, written in
+// the module or submodule mod, is true for the features enabled by
+// EnableFeatures.  A feature of a module EnableFeatures was not called for is
+// taken to be enabled.
+func (ms *Modules) featuresEnabled(mod *Module, expr string) (bool, error) {
+	p := &ifFeatureParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))}
+	p.enabled = func(ref string) bool {
+		prefix, name := getPrefix(ref)
+		m := moduleOf(FindModuleByPrefix(mod, prefix))
+		if m == nil {
+			return false
+		}
+		enabled, ok := ms.features[m.Name]
+		return !ok || enabled[name]
+	}
+	v, err := p.expr()
+	switch {
+	case err != nil:
+		return false, err
+	case len(p.tokens) > 0:
+		return false, fmt.Errorf("unexpected %q", p.tokens[0])
+	}
+	return v, nil
+}
+
+// An ifFeatureParser evaluates the if-feature expression held in tokens, as
+// defined by RFC 7950 section 7.20.2, while parsing it.
+type ifFeatureParser struct {
+	tokens  []string
+	enabled func(ref string) bool
+}
+
+// next removes and returns the next token, or "" at the end of the
+// expression.
+func (p *ifFeatureParser) next() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+	t := p.tokens[0]
+	p.tokens = p.tokens[1:]
+	return t
+}
+
+// peek returns the next token, or "" at the end of the expression.
+func (p *ifFeatureParser) peek() string {
+	if len(p.tokens) == 0 {
+		return ""
+	}
+	return p.tokens[0]
+}
+
+// expr parses: if-feature-term ["or" if-feature-expr]
+func (p *ifFeatureParser) expr() (bool, error) {
+	v, err := p.term()
+	for err == nil && p.peek() == "or" {
+		p.next()
+		var w bool
+		w, err = p.term()
+		v = v || w
+	}
+	return v, err
+}
+
+// term parses: if-feature-factor ["and" if-feature-term]
+func (p *ifFeatureParser) term() (bool, error) {
+	v, err := p.factor()
+	for err == nil && p.peek() == "and" {
+		p.next()
+		var w bool
+		w, err = p.factor()
+		v = v && w
+	}
+	return v, err
+}
+
+// factor parses: "not" if-feature-factor | "(" if-feature-expr ")" |
+// identifier-ref
+func (p *ifFeatureParser) factor() (bool, error) {
+	switch t := p.next(); t {
+	case "":
+		return false, errors.New("unexpected end of expression")
+	case "not":
+		v, err := p.factor()
+		return !v, err
+	case "(":
+		v, err := p.expr()
+		if err != nil {
+			return false, err
+		}
+		if t := p.next(); t != ")" {
+			return false, errors.New("missing )")
+		}
+		return v, nil
+	case ")", "and", "or":
+		return false, fmt.Errorf("unexpected %q", t)
+	default:
+		return p.enabled(t), nil
+	}
+}
+
+// pruneFeatures removes from the entries of ms the nodes that have an
+// if-feature statement that is false for the features enabled by
+// EnableFeatures.  Nothing is removed if EnableFeatures has not been called.
+func (ms *Modules) pruneFeatures() []error {
+	if ms.features == nil {
+		return nil
+	}
+	var errs []error
+	keep := func(e *Entry) bool {
+		for _, x := range e.Extra["if-feature"] {
+			v, ok := x.(*Value)
+			if !ok {
+				continue
+			}
+			n := Node(v)
+			if v.Parent == nil {
+				n = e.Node
+			}
+			enabled, err := ms.featuresEnabled(RootNode(n), v.Name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid if-feature %q: %v", Source(v), v.Name, err))
+				continue
+			}
+			if !enabled {
+				if ms.logging() {
+					ms.debug("removed disabled node", "path", e.Path(), "if-feature", v.Name)
+				}
+				return false
+			}
+		}
+		return true
+	}
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			e := ToEntry(m)
+			prune(e, e, keep)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,279 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFeatureGraph(t *testing.T) {
+	ms := NewModules()
+	for name, mod := range map[string]string{
+		"a.yang": `
+			module a {
+				prefix "a";
+				namespace "urn:a";
+				yang-version 1.1;
+
+				import b { prefix bb; }
+				include a-sub;
+
+				feature x;
+				feature y { if-feature x; }
+				feature z { if-feature "(y or bb:w) and not a:x"; if-feature "missing"; }
+			}`,
+		"a-sub.yang": `
+			submodule a-sub {
+				belongs-to a { prefix s; }
+				yang-version 1.1;
+
+				feature sub { if-feature s:z; }
+			}`,
+		"b.yang": `
+			module b {
+				prefix "b";
+				namespace "urn:b";
+
+				feature w;
+				feature v { if-feature v2; }
+				feature v2 { if-feature v; }
+			}`,
+	} {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	g := ms.FeatureGraph()
+
+	names := func(fs []*FeatureNode) []string {
+		var s []string
+		for _, f := range fs {
+			s = append(s, f.String())
+		}
+		return s
+	}
+	if diff := cmp.Diff([]string{"a:sub", "a:x", "a:y", "a:z", "b:v", "b:v2", "b:w"}, names(g.Features)); diff != "" {
+		t.Errorf("Features (-want, +got):\n%s", diff)
+	}
+	var cycles []string
+	for _, c := range g.Cycles {
+		cycles = append(cycles, c.String())
+	}
+	if diff := cmp.Diff([]string{"b:v -> b:v2 -> b:v"}, cycles); diff != "" {
+		t.Errorf("Cycles (-want, +got):\n%s", diff)
+	}
+
+	for _, tt := range []struct {
+		feature        string
+		wantIfFeature  []string
+		wantRequires   []string
+		wantRequiredBy []string
+		wantUnknown    []string
+		wantAll        []string
+	}{{
+		feature:        "x",
+		wantRequiredBy: []string{"a:y", "a:z"},
+	}, {
+		feature:        "y",
+		wantIfFeature:  []string{"x"},
+		wantRequires:   []string{"a:x"},
+		wantRequiredBy: []string{"a:z"},
+		wantAll:        []string{"a:x"},
+	}, {
+		feature:        "z",
+		wantIfFeature:  []string{"(y or bb:w) and not a:x", "missing"},
+		wantRequires:   []string{"a:y", "b:w", "a:x"},
+		wantRequiredBy: []string{"a:sub"},
+		wantUnknown:    []string{"missing"},
+		wantAll:        []string{"a:x", "a:y", "b:w"},
+	}, {
+		feature:       "sub",
+		wantIfFeature: []string{"s:z"},
+		wantRequires:  []string{"a:z"},
+		wantAll:       []string{"a:x", "a:y", "a:z", "b:w"},
+	}} {
+		t.Run(tt.feature, func(t *testing.T) {
+			f := g.Find("a", tt.feature)
+			if f == nil {
+				t.Fatalf("Find(a, %s) = nil", tt.feature)
+			}
+			if diff := cmp.Diff(tt.wantIfFeature, f.IfFeature); diff != "" {
+				t.Errorf("IfFeature (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRequires, names(f.Requires)); diff != "" {
+				t.Errorf("Requires (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRequiredBy, names(f.RequiredBy)); diff != "" {
+				t.Errorf("RequiredBy (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantUnknown, f.Unknown); diff != "" {
+				t.Errorf("Unknown (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantAll, names(f.AllRequired())); diff != "" {
+				t.Errorf("AllRequired (-want, +got):\n%s", diff)
+			}
+		})
+	}
+	if f := g.Find("b", "x"); f != nil {
+		t.Errorf("Find(b, x) = %v, want nil", f)
+	}
+}
+
+func TestEnableFeaturesPrunes(t *testing.T) {
+	const a = `
+		module a {
+			prefix "a";
+			namespace "urn:a";
+			yang-version 1.1;
+
+			import b { prefix bb; }
+
+			feature x;
+			feature y;
+
+			leaf plain { type string; }
+			leaf on-x { if-feature x; type string; }
+			leaf on-y { if-feature y; type string; }
+			leaf not-y { if-feature "not a:y"; type string; }
+			leaf x-and-y { if-feature "x and y"; type string; }
+			leaf y-or-x { if-feature "y or x"; type string; }
+			leaf nested { if-feature "not (y or not x) and bb:w"; type string; }
+			leaf both { if-feature x; if-feature y; type string; }
+			container only-y {
+				leaf on-y { if-feature y; type string; }
+			}
+			container presence-y {
+				presence "kept when empty";
+				leaf on-y { if-feature y; type string; }
+			}
+			list l {
+				key "k";
+				leaf k { type string; }
+				leaf on-y { if-feature y; type string; }
+			}
+			rpc r {
+				input { leaf on-y { if-feature y; type string; } }
+			}
+		}`
+	const b = `
+		module b {
+			prefix "b";
+			namespace "urn:b";
+
+			feature w;
+		}`
+
+	tests := []struct {
+		desc     string
+		enable   map[string][]string
+		want     []string
+		wantList []string
+		wantRPC  []string
+	}{{
+		desc:     "no features enabled keeps every node",
+		want:     []string{"both", "l", "nested", "not-y", "on-x", "on-y", "only-y", "plain", "presence-y", "x-and-y", "y-or-x"},
+		wantList: []string{"k", "on-y"},
+		wantRPC:  []string{"on-y"},
+	}, {
+		desc:     "x enabled, the features of b taken as enabled",
+		enable:   map[string][]string{"a": {"x"}},
+		want:     []string{"l", "nested", "not-y", "on-x", "plain", "presence-y", "y-or-x"},
+		wantList: []string{"k"},
+	}, {
+		desc:     "x enabled, w of b not enabled",
+		enable:   map[string][]string{"a": {"x"}, "b": nil},
+		want:     []string{"l", "not-y", "on-x", "plain", "presence-y", "y-or-x"},
+		wantList: []string{"k"},
+	}, {
+		desc:     "x and y enabled",
+		enable:   map[string][]string{"a": {"x", "y"}},
+		want:     []string{"both", "l", "on-x", "on-y", "only-y", "plain", "presence-y", "x-and-y", "y-or-x"},
+		wantList: []string{"k", "on-y"},
+		wantRPC:  []string{"on-y"},
+	}, {
+		desc:     "no features of a enabled",
+		enable:   map[string][]string{"a": nil},
+		want:     []string{"l", "not-y", "plain", "presence-y"},
+		wantList: []string{"k"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			for name, mod := range map[string]string{"a.yang": a, "b.yang": b} {
+				if err := ms.Parse(mod, name); err != nil {
+					t.Fatalf("cannot parse module %s: %v", name, err)
+				}
+			}
+			for m, fs := range tt.enable {
+				ms.EnableFeatures(m, fs...)
+			}
+			if errs := ms.Process(); len(errs) > 0 {
+				t.Fatalf("cannot process modules: %v", errs)
+			}
+			e, err := ms.GetModule("a")
+			if err != nil {
+				t.Fatalf("GetModule(a): %v", err)
+			}
+			names := func(e *Entry) []string {
+				var s []string
+				for _, c := range e.SortedDir() {
+					if c.RPC == nil {
+						s = append(s, c.Name)
+					}
+				}
+				return s
+			}
+			if diff := cmp.Diff(tt.want, names(e)); diff != "" {
+				t.Errorf("data nodes (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantList, names(e.Dir["l"])); diff != "" {
+				t.Errorf("/l (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantRPC, names(e.Dir["r"].RPC.Input)); diff != "" {
+				t.Errorf("/r/input (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnableFeaturesInvalidExpression(t *testing.T) {
+	for _, expr := range []string{"x and", "(x", "x y", "or x", "x)"} {
+		t.Run(expr, func(t *testing.T) {
+			ms := NewModules()
+			if err := ms.Parse(`
+				module a {
+					prefix "a";
+					namespace "urn:a";
+					yang-version 1.1;
+
+					feature x;
+					leaf l { if-feature "`+expr+`"; type string; }
+				}`, "a.yang"); err != nil {
+				t.Fatalf("cannot parse module: %v", err)
+			}
+			ms.EnableFeatures("a", "x")
+			errs := ms.Process()
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), "invalid if-feature") {
+				t.Errorf("Process: got errors %v, want an invalid if-feature error", errs)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -34,25 +35,129 @@ var (
 // root containing files with a ".yang" extension, as well as
 // any error encountered
 func PathsWithModules(root string) (paths []string, err error) {
-	pm := map[string]bool{}
-	filepath.Walk(root, func(p string, info os.FileInfo, e error) error {
-		err = e
-		if err == nil {
-			if info == nil {
-				return nil
+	return PathsWithModulesOptions(root, ScanOptions{})
+}
+
+// PathsWithModulesOptions is PathsWithModules, searching the paths under root
+// as specified by opts.
+func PathsWithModulesOptions(root string, opts ScanOptions) (paths []string, err error) {
+	switch fi, err := os.Lstat(root); {
+	case err != nil:
+		return nil, err
+	case !fi.IsDir() && fi.Mode()&os.ModeSymlink == 0:
+		if strings.HasSuffix(root, ".yang") {
+			paths = append(paths, filepath.Dir(root))
+		}
+		return paths, nil
+	}
+	s := newScanner(root, opts)
+	err = s.walk(root, 0, func(dir string, fis []os.FileInfo) {
+		for _, fi := range fis {
+			if !fi.IsDir() && strings.HasSuffix(fi.Name(), ".yang") {
+				paths = append(paths, dir)
+				return
 			}
-			if !info.IsDir() && strings.HasSuffix(p, ".yang") {
-				dir := filepath.Dir(p)
-				if !pm[dir] {
-					pm[dir] = true
-					paths = append(paths, dir)
-				}
+		}
+	})
+	return paths, err
+}
+
+// A scanner searches the directories under root as specified by opts.
+type scanner struct {
+	root string
+	opts ScanOptions
+	seen map[string]bool // seen holds the real paths of directories searched.
+}
+
+func newScanner(root string, opts ScanOptions) *scanner {
+	return &scanner{root: root, opts: opts, seen: map[string]bool{}}
+}
+
+// excluded returns true if the path name matches one of the exclude patterns.
+func (s *scanner) excluded(name string) bool {
+	if len(s.opts.Exclude) == 0 {
+		return false
+	}
+	base := filepath.Base(name)
+	rel, err := filepath.Rel(s.root, name)
+	if err != nil {
+		rel = name
+	}
+	rel = filepath.ToSlash(rel)
+	for _, p := range s.opts.Exclude {
+		if ok, _ := filepath.Match(p, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// descend returns true if the directory dir, at depth below root, is to be
+// searched, recording that it has been searched.
+func (s *scanner) descend(dir string, depth int) bool {
+	if s.opts.MaxDepth > 0 && depth > s.opts.MaxDepth {
+		return false
+	}
+	if !s.opts.FollowSymlinks {
+		return true
+	}
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	if s.seen[real] {
+		return false
+	}
+	s.seen[real] = true
+	return true
+}
+
+// readDir returns the files and directories in dir, sorted by name, that are
+// not excluded.  Symbolic links to directories are returned as directories if
+// they are to be followed.
+func (s *scanner) readDir(dir string) ([]os.FileInfo, error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var found []os.FileInfo
+	for _, fi := range fis {
+		p := filepath.Join(dir, fi.Name())
+		if s.excluded(p) {
+			continue
+		}
+		if s.opts.FollowSymlinks && fi.Mode()&os.ModeSymlink != 0 {
+			if target, err := os.Stat(p); err == nil {
+				fi = target
 			}
-			return nil
 		}
+		found = append(found, fi)
+	}
+	return found, nil
+}
+
+// walk calls f with each directory, and the files and directories it
+// contains, at or below dir, which is at depth below root.
+func (s *scanner) walk(dir string, depth int, f func(dir string, fis []os.FileInfo)) error {
+	if !s.descend(dir, depth) {
+		return nil
+	}
+	fis, err := s.readDir(dir)
+	if err != nil {
 		return err
-	})
-	return
+	}
+	f(dir, fis)
+	for _, fi := range fis {
+		if fi.IsDir() {
+			if err := s.walk(filepath.Join(dir, fi.Name()), depth+1, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // AddPath adds the directories specified in p, a colon separated list
@@ -89,10 +194,11 @@ var scanDir = findInDir
 // The current directory (.) is always checked first, no matter the value of
 // Path.
 func (ms *Modules) findFile(name string) (string, string, error) {
+	requested := name
 	slash := strings.Index(name, "/")
 	if slash < 0 && !strings.HasSuffix(name, ".yang") {
 		name += ".yang"
-		if best := scanDir(".", name, false); best != "" {
+		if best := scanDir(".", name, false, ms.ParseOptions.ScanOptions); best != "" {
 			// we found a matching candidate in the local directory
 			name = best
 		}
@@ -101,6 +207,7 @@ func (ms *Modules) findFile(name string) (string, string, error) {
 	switch data, err := readFile(name); true {
 	case err == nil:
 		ms.AddPath(filepath.Dir(name))
+		ms.debug("found module file", "name", requested, "file", name)
 		return name, string(data), nil
 	case slash >= 0:
 		// If there are any /'s in the name then don't search Path.
@@ -110,14 +217,15 @@ func (ms *Modules) findFile(name string) (string, string, error) {
 	for _, dir := range ms.Path {
 		var n string
 		if filepath.Base(dir) == "..." {
-			n = scanDir(filepath.Dir(dir), name, true)
+			n = scanDir(filepath.Dir(dir), name, true, ms.ParseOptions.ScanOptions)
 		} else {
-			n = scanDir(dir, name, false)
+			n = scanDir(dir, name, false, ms.ParseOptions.ScanOptions)
 		}
 		if n == "" {
 			continue
 		}
 		if data, err := readFile(n); err == nil {
+			ms.debug("found module file", "name", requested, "file", n)
 			return n, string(data), nil
 		}
 	}
@@ -126,7 +234,8 @@ func (ms *Modules) findFile(name string) (string, string, error) {
 
 // findInDir looks for a file named name in dir or any of its subdirectories if
 // recurse is true. if recurse is false, scan only the directory dir.
-// If no matching file is found, an empty string is returned.
+// If no matching file is found, an empty string is returned.  The files and
+// subdirectories searched are controlled by opts.
 //
 // The file SHOULD have the following name, per
 // https://tools.ietf.org/html/rfc7950#section-5.2:
@@ -137,8 +246,16 @@ func (ms *Modules) findFile(name string) (string, string, error) {
 // Else if file(s) with otherwise matching names but which contain a
 // revision-date pattern exactly matching the above are found, then path of the
 // one with the latest date is returned.
-func findInDir(dir, name string, recurse bool) string {
-	fis, err := ioutil.ReadDir(dir)
+func findInDir(dir, name string, recurse bool, opts ScanOptions) string {
+	return newScanner(dir, opts).find(dir, name, recurse, 0)
+}
+
+// find implements findInDir for dir, which is at depth below s.root.
+func (s *scanner) find(dir, name string, recurse bool, depth int) string {
+	if !s.descend(dir, depth) {
+		return ""
+	}
+	fis, err := s.readDir(dir)
 	if err != nil {
 		return ""
 	}
@@ -154,7 +271,7 @@ func findInDir(dir, name string, recurse bool) string {
 				revisions = append(revisions, fn)
 			}
 		case recurse:
-			if n := findInDir(filepath.Join(dir, fi.Name()), name, recurse); n != "" {
+			if n := s.find(filepath.Join(dir, fi.Name()), name, recurse, depth+1); n != "" {
 				return n
 			}
 		}
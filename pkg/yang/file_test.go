@@ -20,11 +20,13 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
 )
 
 func TestFindFile(t *testing.T) {
 	sep := string(os.PathSeparator)
+	defer func() { readFile, scanDir = ioutil.ReadFile, findInDir }()
 
 	for _, tt := range []struct {
 		name  string
@@ -56,7 +58,7 @@ func TestFindFile(t *testing.T) {
 			checked = append(checked, path)
 			return nil, errors.New("no such file")
 		}
-		scanDir = func(dir, name string, recurse bool) string {
+		scanDir = func(dir, name string, recurse bool, opts ScanOptions) string {
 			return filepath.Join(dir, name)
 		}
 		if _, _, err := ms.findFile(tt.name); err == nil {
@@ -158,9 +160,104 @@ func TestFindInDir(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
-			if got, want := findInDir(tt.inDir, tt.inName, tt.inRecurse), tt.want; got != want {
+			if got, want := findInDir(tt.inDir, tt.inName, tt.inRecurse, ScanOptions{}), tt.want; got != want {
 				t.Errorf("got: %q, want: %q", got, want)
 			}
 		})
 	}
 }
+
+func TestScanOptions(t *testing.T) {
+	root, err := ioutil.TempDir("", "goyang-scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	for _, f := range []string{
+		"a/a.yang",
+		"a/b/b.yang",
+		"a/b/c/c.yang",
+		"testdata/a.yang",
+		"vendor/old/a.yang",
+		"vendor/new/n.yang",
+		"linked/l.yang",
+	} {
+		p := filepath.Join(root, filepath.FromSlash(f))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	symlinks := true
+	for link, target := range map[string]string{
+		"a/link": filepath.Join(root, "linked"),
+		"a/loop": filepath.Join(root, "a"),
+	} {
+		if err := os.Symlink(target, filepath.Join(root, filepath.FromSlash(link))); err != nil {
+			symlinks = false
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		opts     ScanOptions
+		symlinks bool
+		want     []string
+	}{{
+		desc: "default",
+		want: []string{"a", "a/b", "a/b/c", "linked", "testdata", "vendor/new", "vendor/old"},
+	}, {
+		desc: "exclude base names and relative paths",
+		opts: ScanOptions{Exclude: []string{"testdata", "vendor/o*", "c.yang"}},
+		want: []string{"a", "a/b", "linked", "vendor/new"},
+	}, {
+		desc: "max depth",
+		opts: ScanOptions{MaxDepth: 2},
+		want: []string{"a", "a/b", "linked", "testdata", "vendor/new", "vendor/old"},
+	}, {
+		desc:     "follow symlinks searching each directory once",
+		opts:     ScanOptions{FollowSymlinks: true, Exclude: []string{"testdata", "vendor"}},
+		symlinks: true,
+		want:     []string{"a", "a/b", "a/b/c", "a/link"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if tt.symlinks && !symlinks {
+				t.Skip("symbolic links not supported")
+			}
+			paths, err := PathsWithModulesOptions(root, tt.opts)
+			if err != nil {
+				t.Fatalf("PathsWithModulesOptions() got unexpected error: %v", err)
+			}
+			var got []string
+			for _, p := range paths {
+				rel, err := filepath.Rel(root, p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rel == "." {
+					rel = ""
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PathsWithModulesOptions() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := findInDir(root, "c.yang", true, ScanOptions{MaxDepth: 2}); got != "" {
+		t.Errorf("findInDir() beyond MaxDepth got %s, want none", got)
+	}
+	if got := findInDir(root, "a.yang", true, ScanOptions{Exclude: []string{"a"}}); got != filepath.Join(root, "testdata", "a.yang") {
+		t.Errorf("findInDir() with exclude got %s, want testdata/a.yang", got)
+	}
+	if symlinks {
+		if got := findInDir(filepath.Join(root, "a"), "l.yang", true, ScanOptions{FollowSymlinks: true}); got != filepath.Join(root, "a", "link", "l.yang") {
+			t.Errorf("findInDir() through symlink got %s, want a/link/l.yang", got)
+		}
+	}
+}
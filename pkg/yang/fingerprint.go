@@ -0,0 +1,173 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// fingerprintExtras are the keys of Entry.Extra that are part of the schema
+// described by a fingerprint.
+var fingerprintExtras = []string{"if-feature", "must", "presence", "status", "unique", "when"}
+
+// Fingerprint returns the hex encoded SHA-256 hash of the schema of the
+// processed modules in ms.  The hash is computed from the Entry trees of the
+// most recent revision of each module, and their identities, in the order of
+// their names.  It does not depend on how the modules are formatted, the
+// files they were read from, the order statements are written in or whether
+// nodes are defined by groupings, augments or submodules.  Descriptions,
+// references, revision dates and other documentation are not part of the
+// schema, so two module sets differing only in them have the same
+// fingerprint.
+func (ms *Modules) Fingerprint() string {
+	h := sha256.New()
+	for _, m := range ms.SortedModules() {
+		fmt.Fprintf(h, "module %s\n", m.Name)
+		var ids []string
+		for _, i := range moduleIdentities(m, map[*Module]bool{}) {
+			var values []string
+			for _, v := range i.Values {
+				values = append(values, v.modulePrefixedName())
+			}
+			sort.Strings(values)
+			ids = append(ids, fmt.Sprintf("identity %s %s\n", i.modulePrefixedName(), strings.Join(values, " ")))
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			io.WriteString(h, id)
+		}
+		fingerprintEntry(h, ToEntry(m))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// moduleIdentities returns the identities defined in m and in the submodules
+// it includes.
+func moduleIdentities(m *Module, seen map[*Module]bool) []*Identity {
+	if m == nil || seen[m] {
+		return nil
+	}
+	seen[m] = true
+	ids := append([]*Identity{}, m.Identity...)
+	for _, in := range m.Include {
+		ids = append(ids, moduleIdentities(in.Module, seen)...)
+	}
+	return ids
+}
+
+// fingerprintEntry writes the schema of e and its descendants to w.
+func fingerprintEntry(w io.Writer, e *Entry) {
+	fingerprintNode(w, e)
+	io.WriteString(w, " {\n")
+	for _, c := range e.children() {
+		fingerprintEntry(w, c)
+	}
+	io.WriteString(w, "}\n")
+}
+
+// fingerprintNode writes the schema of e, but not of its descendants, to w.
+func fingerprintNode(w io.Writer, e *Entry) {
+	fmt.Fprintf(w, "%s %s ns=%s config=%s mandatory=%s", e.Kind, e.Name, e.Namespace().Name, e.Config, e.Mandatory)
+	if e.Key != "" {
+		fmt.Fprintf(w, " key=%q", e.Key)
+	}
+	if e.ListAttr != nil {
+		fmt.Fprintf(w, " elements=%d..%d user=%t", e.ListAttr.MinElements, e.ListAttr.MaxElements, e.ListAttr.OrderedByUser)
+	}
+	if len(e.Default) > 0 {
+		fmt.Fprintf(w, " default=%q", e.Default)
+	}
+	if e.Units != "" {
+		fmt.Fprintf(w, " units=%q", e.Units)
+	}
+	for _, k := range fingerprintExtras {
+		for _, x := range e.Extra[k] {
+			if n, ok := x.(Node); ok {
+				fmt.Fprintf(w, " %s=%q", k, n.NName())
+			}
+		}
+	}
+	for _, s := range e.Exts {
+		prefix, name := getPrefix(s.Keyword)
+		if e.Node != nil {
+			if m := FindModuleByPrefix(e.Node, prefix); m != nil {
+				prefix = m.Name
+				if m.BelongsTo != nil {
+					prefix = m.BelongsTo.Name
+				}
+			}
+		}
+		fmt.Fprintf(w, " %s:%s=%q", prefix, name, s.Argument)
+	}
+	if e.Type != nil {
+		io.WriteString(w, " type=")
+		fingerprintType(w, e.Type)
+	}
+}
+
+// fingerprintType writes the restrictions of t, but not the name of the
+// typedef it comes from, to w.
+func fingerprintType(w io.Writer, t *YangType) {
+	fmt.Fprintf(w, "%s", t.Kind)
+	if t.Units != "" {
+		fmt.Fprintf(w, " units=%q", t.Units)
+	}
+	if t.HasDefault {
+		fmt.Fprintf(w, " default=%q", t.Default)
+	}
+	if t.FractionDigits != 0 {
+		fmt.Fprintf(w, " fraction-digits=%d", t.FractionDigits)
+	}
+	if len(t.Length) > 0 {
+		fmt.Fprintf(w, " length=%s", t.Length)
+	}
+	if len(t.Range) > 0 {
+		fmt.Fprintf(w, " range=%s", t.Range)
+	}
+	if t.Kind == Yleafref || t.Kind == YinstanceIdentifier {
+		fmt.Fprintf(w, " path=%q require-instance=%t", t.Path, !t.OptionalInstance)
+	}
+	if len(t.Pattern) > 0 {
+		fmt.Fprintf(w, " pattern=%q", t.Pattern)
+	}
+	if len(t.POSIXPattern) > 0 {
+		fmt.Fprintf(w, " posix-pattern=%q", t.POSIXPattern)
+	}
+	if t.IdentityBase != nil {
+		fmt.Fprintf(w, " base=%s", t.IdentityBase.modulePrefixedName())
+	}
+	for _, et := range []*EnumType{t.Enum, t.Bit} {
+		if et != nil {
+			for _, v := range et.Values() {
+				fmt.Fprintf(w, " %s=%d", et.Name(v), v)
+			}
+		}
+	}
+	if len(t.Type) > 0 {
+		io.WriteString(w, " (")
+		for i, ut := range t.Type {
+			if i > 0 {
+				io.WriteString(w, " | ")
+			}
+			fingerprintType(w, ut)
+		}
+		io.WriteString(w, ")")
+	}
+}
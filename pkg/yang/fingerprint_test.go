@@ -0,0 +1,107 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	const base = `module a {
+	prefix a;
+	namespace "urn:a";
+	identity id;
+	identity id1 { base id; }
+	container c {
+		leaf l { type uint8 { range "1..10"; } }
+		leaf e { type enumeration { enum x; enum y; } }
+	}
+}`
+	fingerprint := func(t *testing.T, srcs ...string) string {
+		t.Helper()
+		ms := NewModules()
+		for _, src := range srcs {
+			if err := ms.Parse(src, "a.yang"); err != nil {
+				t.Fatalf("cannot parse: %v", err)
+			}
+		}
+		if errs := ms.Process(); len(errs) > 0 {
+			t.Fatalf("cannot process: %v", errs)
+		}
+		return ms.Fingerprint()
+	}
+	want := fingerprint(t, base)
+
+	for _, tt := range []struct {
+		desc string
+		src  []string
+		same bool
+	}{{
+		desc: "formatting, order, descriptions and a grouping",
+		src: []string{`module a {
+  namespace "urn:a"; prefix "a";
+  description "a module";
+  revision 2020-01-01 { description "first"; }
+  grouping g {
+    leaf e {
+      type enumeration { enum "x" { value 0; } enum y; }
+    }
+    leaf l { description "l"; type uint8 { range 1..10; } }
+  }
+  container c { uses g; }
+  identity id1 { base a:id; }
+  identity id;
+}`},
+		same: true,
+	}, {
+		desc: "leaf from a submodule",
+		src: []string{`module a {
+	prefix a;
+	namespace "urn:a";
+	include s;
+	identity id;
+	identity id1 { base id; }
+	container c {
+		leaf l { type uint8 { range "1..10"; } }
+	}
+}`, `submodule s {
+	belongs-to a { prefix a; }
+	augment "/a:c" {
+		leaf e { type enumeration { enum x; enum y; } }
+	}
+}`},
+		same: true,
+	}, {
+		desc: "range changed",
+		src:  []string{strings.Replace(base, "1..10", "1..11", 1)},
+	}, {
+		desc: "enum added",
+		src:  []string{strings.Replace(base, "enum y;", "enum y; enum z;", 1)},
+	}, {
+		desc: "identity removed",
+		src:  []string{strings.Replace(base, "identity id1 { base id; }", "", 1)},
+	}, {
+		desc: "leaf made config false",
+		src:  []string{strings.Replace(base, "leaf l {", "leaf l { config false;", 1)},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := fingerprint(t, tt.src...)
+			if same := got == want; same != tt.same {
+				t.Errorf("Fingerprint() got %s, base %s, want same %t", got, want, tt.same)
+			}
+		})
+	}
+}
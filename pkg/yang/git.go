@@ -0,0 +1,197 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// A GitSource is a git repository, at a specific ref, that YANG modules are
+// read from.  The repository is checked out with the git command.
+type GitSource struct {
+	Repo string // Repo is the URL or path of the repository.
+	Ref  string // Ref is the branch, tag or commit to check out.
+	// Dir, if set, is the directory within the repository that modules
+	// are searched for in, otherwise the whole repository is searched.
+	Dir string
+	// CacheDir is the directory checkouts are kept in, which is created
+	// accessible only by the user.  If empty, the goyang-git directory of
+	// os.UserCacheDir() is used.  A checkout of a commit hash found in
+	// CacheDir is used rather than checking it out again, the checkouts of
+	// branches and tags are fetched again to bring them up to date.
+	CacheDir string
+}
+
+// shortRepoRegex matches a GitHub repository given as owner/repository.
+var shortRepoRegex = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+// ParseGitSource returns the GitSource described by s, which has the form
+// REPO@REF[:DIR].  REPO is either a URL, a path, or owner/repository for a
+// repository on GitHub.  For example, "openconfig/public@v4.1.0:release/models"
+// is the release/models directory of tag v4.1.0 of
+// https://github.com/openconfig/public.
+func ParseGitSource(s string) (*GitSource, error) {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 || at == len(s)-1 {
+		return nil, fmt.Errorf("invalid git source %q: want REPO@REF[:DIR]", s)
+	}
+	g := &GitSource{Repo: s[:at], Ref: s[at+1:]}
+	if colon := strings.Index(g.Ref, ":"); colon >= 0 {
+		g.Ref, g.Dir = g.Ref[:colon], g.Ref[colon+1:]
+		if g.Ref == "" {
+			return nil, fmt.Errorf("invalid git source %q: missing ref", s)
+		}
+	}
+	if err := g.check(); err != nil {
+		return nil, err
+	}
+	if shortRepoRegex.MatchString(g.Repo) {
+		if _, err := os.Stat(g.Repo); err != nil {
+			g.Repo = "https://github.com/" + g.Repo
+		}
+	}
+	return g, nil
+}
+
+// String returns g in the form accepted by ParseGitSource.
+func (g *GitSource) String() string {
+	s := g.Repo + "@" + g.Ref
+	if g.Dir != "" {
+		s += ":" + g.Dir
+	}
+	return s
+}
+
+// commitHashRegex matches a full SHA-1 or SHA-256 commit hash.
+var commitHashRegex = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// check returns an error if the repository or ref of g could be taken as an
+// option by git.
+func (g *GitSource) check() error {
+	switch {
+	case g.Repo == "" || strings.HasPrefix(g.Repo, "-"):
+		return fmt.Errorf("invalid git repository %q", g.Repo)
+	case g.Ref == "" || strings.HasPrefix(g.Ref, "-"):
+		return fmt.Errorf("invalid git ref %q", g.Ref)
+	}
+	return nil
+}
+
+// checkoutDir returns the directory g is checked out in.  It is named by a
+// hash, as the repository and ref may contain any characters.
+func (g *GitSource) checkoutDir() (string, error) {
+	cache := g.CacheDir
+	if cache == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		cache = filepath.Join(dir, "goyang-git")
+	}
+	sum := sha256.Sum256([]byte(g.Repo + "\x00" + g.Ref))
+	return filepath.Join(cache, hex.EncodeToString(sum[:])), nil
+}
+
+// Checkout checks out g, if not already checked out, and returns the
+// directory modules are to be searched for in.  A ref that is not a commit
+// hash is fetched again if already checked out, as it may have moved.
+func (g *GitSource) Checkout() (string, error) {
+	if err := g.check(); err != nil {
+		return "", err
+	}
+	dir, err := g.checkoutDir()
+	if err != nil {
+		return "", err
+	}
+	cache, name := filepath.Split(dir)
+	if err := os.MkdirAll(cache, 0700); err != nil {
+		return "", err
+	}
+	fetch := []string{"fetch", "-q", "--depth", "1", "--end-of-options", g.Repo, g.Ref}
+	if _, err := os.Stat(dir); err == nil {
+		if commitHashRegex.MatchString(g.Ref) {
+			return filepath.Join(dir, filepath.FromSlash(g.Dir)), nil
+		}
+		for _, args := range [][]string{
+			fetch,
+			{"checkout", "-q", "--force", "FETCH_HEAD"},
+			{"clean", "-q", "-f", "-d", "-x"},
+		} {
+			if err := runGit(dir, args...); err != nil {
+				return "", fmt.Errorf("%s: %v", g, err)
+			}
+		}
+		return filepath.Join(dir, filepath.FromSlash(g.Dir)), nil
+	}
+	// Check out into a temporary directory so an interrupted checkout is
+	// not mistaken for a complete one.
+	tmp, err := ioutil.TempDir(cache, name+".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		fetch,
+		{"checkout", "-q", "FETCH_HEAD"},
+	} {
+		if err := runGit(tmp, args...); err != nil {
+			return "", fmt.Errorf("%s: %v", g, err)
+		}
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.FromSlash(g.Dir)), nil
+}
+
+// runGit runs the git command with args in dir.
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s: %v", args[0], err)
+	}
+	return nil
+}
+
+// AddGitSource checks out g and adds the directories under it that contain
+// .yang files to Path, searching it as specified by ms.ParseOptions.ScanOptions.
+func (ms *Modules) AddGitSource(g *GitSource) error {
+	dir, err := g.Checkout()
+	if err != nil {
+		return err
+	}
+	paths, err := PathsWithModulesOptions(dir, ms.ParseOptions.ScanOptions)
+	if err != nil {
+		return err
+	}
+	ms.AddPath(paths...)
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGitSource(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    GitSource
+		wantErr bool
+	}{{
+		in:   "openconfig/public@v4.1.0",
+		want: GitSource{Repo: "https://github.com/openconfig/public", Ref: "v4.1.0"},
+	}, {
+		in:   "openconfig/public@v4.1.0:release/models",
+		want: GitSource{Repo: "https://github.com/openconfig/public", Ref: "v4.1.0", Dir: "release/models"},
+	}, {
+		in:   "git@example.com:repo.git@main",
+		want: GitSource{Repo: "git@example.com:repo.git", Ref: "main"},
+	}, {
+		in:   "https://example.com/repo.git@0123abc:yang",
+		want: GitSource{Repo: "https://example.com/repo.git", Ref: "0123abc", Dir: "yang"},
+	}, {
+		in:      "openconfig/public",
+		wantErr: true,
+	}, {
+		in:      "openconfig/public@",
+		wantErr: true,
+	}, {
+		in:      "openconfig/public@:models",
+		wantErr: true,
+	}, {
+		in:      "--upload-pack=touch /tmp/x@main",
+		wantErr: true,
+	}, {
+		in:      "openconfig/public@--output=/tmp/x",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseGitSource(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGitSource(%q) got error %v, want error %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("ParseGitSource(%q) got %+v, want %+v", tt.in, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmp, err := ioutil.TempDir("", "goyang-git-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	repo := filepath.Join(tmp, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, "models", "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, data string) {
+		if err := ioutil.WriteFile(filepath.Join(repo, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(msg string) {
+		for _, args := range [][]string{
+			{"add", "-A"},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", msg},
+		} {
+			if err := runGit(repo, args...); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := runGit(repo, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	write("models/sub/remote.yang", `module remote { prefix "r"; namespace "urn:r"; leaf v1 { type string; } }`)
+	commit("v1")
+	if err := runGit(repo, "tag", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	write("models/sub/remote.yang", `module remote { prefix "r"; namespace "urn:r"; leaf v2 { type string; } }`)
+	commit("v2")
+
+	g := &GitSource{Repo: repo, Ref: "v1", Dir: "models", CacheDir: filepath.Join(tmp, "cache")}
+	for i := 0; i < 2; i++ {
+		ms := NewModules()
+		if err := ms.AddGitSource(g); err != nil {
+			t.Fatalf("AddGitSource() got unexpected error: %v", err)
+		}
+		e, errs := ms.GetModule("remote")
+		if len(errs) > 0 {
+			t.Fatalf("GetModule() got unexpected errors: %v", errs)
+		}
+		if e.Dir["v1"] == nil {
+			t.Errorf("GetModule() did not read the module at ref v1, got leaves %v", e.Dir)
+		}
+	}
+
+	// A branch is fetched again, as it may have moved.
+	if err := runGit(repo, "branch", "dev", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	g = &GitSource{Repo: repo, Ref: "dev", CacheDir: filepath.Join(tmp, "cache")}
+	readLeaf := func(want string) {
+		t.Helper()
+		dir, err := g.Checkout()
+		if err != nil {
+			t.Fatalf("Checkout() got unexpected error: %v", err)
+		}
+		ms := NewModules()
+		ms.AddPath(filepath.Join(dir, "models", "sub"))
+		e, errs := ms.GetModule("remote")
+		if len(errs) > 0 {
+			t.Fatalf("GetModule() got unexpected errors: %v", errs)
+		}
+		if e.Dir[want] == nil {
+			t.Errorf("GetModule() got leaves %v, want %s", e.Dir, want)
+		}
+	}
+	readLeaf("v1")
+	if err := runGit(repo, "branch", "-f", "dev", "HEAD"); err != nil {
+		t.Fatal(err)
+	}
+	readLeaf("v2")
+
+	// A commit hash is not fetched again once checked out.
+	out, err := exec.Command("git", "-C", repo, "rev-parse", "v1").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g = &GitSource{Repo: repo, Ref: strings.TrimSpace(string(out)), CacheDir: filepath.Join(tmp, "cache")}
+	readLeaf("v1")
+	if err := os.Rename(repo, repo+".moved"); err != nil {
+		t.Fatal(err)
+	}
+	readLeaf("v1")
+	if err := os.Rename(repo+".moved", repo); err != nil {
+		t.Fatal(err)
+	}
+
+	// The cache is only accessible by the user, and sources differing only
+	// in characters that are not used in file names are kept apart.
+	if fi, err := os.Stat(filepath.Join(tmp, "cache")); err != nil || fi.Mode().Perm() != 0700 {
+		t.Errorf("cache directory got mode %v, %v, want 0700", fi.Mode().Perm(), err)
+	}
+	a, err := (&GitSource{Repo: repo, Ref: "a/b", CacheDir: "c"}).checkoutDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := (&GitSource{Repo: repo, Ref: "a_b", CacheDir: "c"}).checkoutDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Errorf("refs a/b and a_b share the checkout directory %s", a)
+	}
+
+	for _, g := range []*GitSource{
+		{Repo: "--upload-pack=touch " + filepath.Join(tmp, "pwned"), Ref: "main", CacheDir: filepath.Join(tmp, "cache")},
+		{Repo: repo, Ref: "--upload-pack=touch " + filepath.Join(tmp, "pwned"), CacheDir: filepath.Join(tmp, "cache")},
+	} {
+		if _, err := g.Checkout(); err == nil {
+			t.Errorf("Checkout() of %s got no error", g)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "pwned")); err == nil {
+		t.Errorf("git option given as the repository or ref was run")
+	}
+
+	g = &GitSource{Repo: repo, Ref: "no-such-ref", CacheDir: filepath.Join(tmp, "cache")}
+	if err := NewModules().AddGitSource(g); err == nil {
+		t.Errorf("AddGitSource() of missing ref got no error")
+	}
+}
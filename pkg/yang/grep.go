@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"regexp"
+)
+
+// A StatementQuery describes the statements searched for by
+// Modules.FindStatements.  A statement matches the query if it matches each
+// of the fields set.  The zero value matches every statement.
+type StatementQuery struct {
+	// Keyword, if set, is the keyword of the statement, e.g., "leaf".
+	Keyword string
+	// Argument, if set, matches the argument of the statement.
+	Argument *regexp.Regexp
+	// Extension, if set, is the name of the extension the statement must
+	// be a use of.  The name may be qualified by the prefix used for the
+	// module defining the extension, or by the name of that module, e.g.,
+	// "oc-ext:openconfig-version" or
+	// "openconfig-extensions:openconfig-version".
+	Extension string
+	// Child, if set, must match at least one substatement of the
+	// statement.  For example, the leaves with the type ip-address are
+	// found by:
+	//
+	//	StatementQuery{
+	//		Keyword: "leaf",
+	//		Child: &StatementQuery{
+	//			Keyword:  "type",
+	//			Argument: regexp.MustCompile(`^(.*:)?ip-address$`),
+	//		},
+	//	}
+	Child *StatementQuery
+}
+
+// A StatementMatch is a statement found by Modules.FindStatements.
+type StatementMatch struct {
+	Module    *Module    // Module is the module or submodule containing the statement.
+	Statement *Statement // Statement is the statement found.
+	Parent    *Statement // Parent is the parent of the statement, or nil.
+}
+
+// FindStatements returns the statements of the modules and submodules of ms
+// that match q.  Modules are in the order of their names, and the statements
+// of each module are in the order they are written.
+func (ms *Modules) FindStatements(q StatementQuery) []*StatementMatch {
+	var matches []*StatementMatch
+	var mods []*Module
+	mods = append(mods, uniqueModules(ms.Modules)...)
+	mods = append(mods, uniqueModules(ms.SubModules)...)
+	for _, m := range mods {
+		if m.Source == nil {
+			continue
+		}
+		var walk func(s, parent *Statement)
+		walk = func(s, parent *Statement) {
+			if q.matches(m, s) {
+				matches = append(matches, &StatementMatch{Module: m, Statement: s, Parent: parent})
+			}
+			for _, ss := range s.statements {
+				walk(ss, s)
+			}
+		}
+		walk(m.Source, nil)
+	}
+	return matches
+}
+
+// matches returns true if the statement s, within the module m, matches q.
+func (q *StatementQuery) matches(m *Module, s *Statement) bool {
+	switch {
+	case q.Keyword != "" && s.Keyword != q.Keyword:
+		return false
+	case q.Argument != nil && (!s.HasArgument || !q.Argument.MatchString(s.Argument)):
+		return false
+	case q.Extension != "" && !extensionMatches(m, s.Keyword, q.Extension):
+		return false
+	case q.Child == nil:
+		return true
+	}
+	for _, ss := range s.statements {
+		if q.Child.matches(m, ss) {
+			return true
+		}
+	}
+	return false
+}
+
+// extensionMatches returns true if keyword, used within m, is a use of the
+// extension name, which may be qualified by a prefix or module name.
+func extensionMatches(m *Module, keyword, name string) bool {
+	prefix, id := getPrefix(keyword)
+	if prefix == "" {
+		return false
+	}
+	qualifier, want := getPrefix(name)
+	switch {
+	case id != want:
+		return false
+	case qualifier == "" || qualifier == prefix:
+		return true
+	}
+	mod := FindModuleByPrefix(m, prefix)
+	return mod != nil && (mod.Name == qualifier || mod.BelongsTo != nil && mod.BelongsTo.Name == qualifier)
+}
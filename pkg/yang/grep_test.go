@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindStatements(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"ext.yang": `module ext {
+	prefix e;
+	namespace "urn:e";
+	extension marker;
+}`,
+		"main.yang": `module main {
+	prefix m;
+	namespace "urn:m";
+	import ext { prefix x; }
+	container c {
+		x:marker;
+		leaf address { type inet:ip-address; }
+		leaf local { type ip-address; }
+		leaf name { type string; }
+	}
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		query StatementQuery
+		want  []string
+	}{{
+		desc:  "keyword",
+		query: StatementQuery{Keyword: "leaf"},
+		want:  []string{"main.yang:7:3: leaf address", "main.yang:8:3: leaf local", "main.yang:9:3: leaf name"},
+	}, {
+		desc:  "keyword and argument",
+		query: StatementQuery{Keyword: "prefix", Argument: regexp.MustCompile(`^[ex]$`)},
+		want:  []string{"ext.yang:2:2: prefix e", "main.yang:4:15: prefix x"},
+	}, {
+		desc: "child",
+		query: StatementQuery{
+			Keyword: "leaf",
+			Child:   &StatementQuery{Keyword: "type", Argument: regexp.MustCompile(`^(.*:)?ip-address$`)},
+		},
+		want: []string{"main.yang:7:3: leaf address", "main.yang:8:3: leaf local"},
+	}, {
+		desc:  "unqualified extension",
+		query: StatementQuery{Extension: "marker"},
+		want:  []string{"main.yang:6:3: x:marker "},
+	}, {
+		desc:  "extension qualified by prefix",
+		query: StatementQuery{Extension: "x:marker"},
+		want:  []string{"main.yang:6:3: x:marker "},
+	}, {
+		desc:  "extension qualified by module",
+		query: StatementQuery{Extension: "ext:marker"},
+		want:  []string{"main.yang:6:3: x:marker "},
+	}, {
+		desc:  "extension of another module",
+		query: StatementQuery{Extension: "other:marker"},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, m := range ms.FindStatements(tt.query) {
+				s := m.Statement
+				got = append(got, fmt.Sprintf("%s: %s %s", s.Location(), s.Keyword, s.Argument))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FindStatements() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -96,14 +96,14 @@ func (mod *Module) findIdentityBase(baseStr string) (*resolvedIdentity, []error)
 		keyName := fmt.Sprintf("%s:%s", module(mod).Name, baseName)
 		base, ok = typeDict.identities.dict[keyName]
 		if !ok {
-			errs = append(errs, fmt.Errorf("%s: can't resolve the local base %s as %s", source, baseStr, keyName))
+			errs = append(errs, codeErrorf("GY0301", "%s: can't resolve the local base %s as %s", source, baseStr, keyName))
 		}
 	default:
 		// This is an identity which is defined within another module
 		extmod := FindModuleByPrefix(mod, basePrefix)
 		if extmod == nil {
 			errs = append(errs,
-				fmt.Errorf("%s: can't find external module with prefix %s", source, basePrefix))
+				codeErrorf("GY0108", "%s: can't find external module with prefix %s", source, basePrefix))
 			break
 		}
 		// The identity we are looking for is modulename:basename.
@@ -115,7 +115,7 @@ func (mod *Module) findIdentityBase(baseStr string) (*resolvedIdentity, []error)
 		// Error if we did not find the identity that had the name specified in
 		// the module it was expected to be in.
 		if base.isEmpty() {
-			errs = append(errs, fmt.Errorf("%s: can't resolve remote base %s", source, baseStr))
+			errs = append(errs, missingImportErrorf("GY0301", mod, baseStr, "%s: can't resolve remote base %s", source, baseStr))
 		}
 	}
 	return &base, errs
@@ -131,7 +131,7 @@ func (ms *Modules) resolveIdentities() []error {
 	// from them, and compile them into a "fully resolved" map that means that
 	// we can look them up based on the 'real' prefix of the module and the
 	// name of the identity.
-	for _, mod := range ms.Modules {
+	for _, mod := range byKey(ms.Modules) {
 		for _, i := range mod.Identities() {
 			keyName, r := newResolvedIdentity(mod, i)
 			ms.typeDict.identities.dict[keyName] = *r
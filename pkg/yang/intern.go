@@ -0,0 +1,58 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+const (
+	// maxInternLength is the length of the longest string interned.
+	// Longer strings, such as descriptions, are rarely repeated.
+	maxInternLength = 64
+	// maxInterned is the most strings an interner holds, bounding the
+	// memory used for the modules of one Modules.
+	maxInterned = 1 << 16
+)
+
+// An interner interns the keywords and arguments of the statements parsed
+// into the same Modules, or by a single call to Parse.  It is not safe for
+// concurrent use, as neither is Modules, so parsing into different Modules
+// does not contend for it, and its strings are released along with the
+// Modules.
+type interner struct {
+	m map[string]string
+}
+
+// newInterner returns an empty interner.
+func newInterner() *interner {
+	return &interner{m: map[string]string{}}
+}
+
+// intern returns the interned copy of s.  Keywords and identifiers, such as
+// "config" or "name", are repeated many times in large schemas, and interning
+// them also stops them from holding on to the source they were parsed from.
+// A nil interner returns s.
+func (in *interner) intern(s string) string {
+	if in == nil || len(s) > maxInternLength {
+		return s
+	}
+	if is, ok := in.m[s]; ok {
+		return is
+	}
+	if len(in.m) >= maxInterned {
+		return s
+	}
+	// Copy s so it does not refer to the source it was parsed from.
+	s = string([]byte(s))
+	in.m[s] = s
+	return s
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// stringData returns the address of the data of s.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+func TestIntern(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"a.yang": `module a { prefix a; namespace "urn:a"; leaf config { type string; } }`,
+		"b.yang": `module b { prefix b; namespace "urn:b"; container c { leaf config { type string; } } }`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l1 := ms.Modules["a"].Source.SubStatements()[2]
+	l2 := ms.Modules["b"].Source.SubStatements()[2].SubStatements()[0]
+	if l1.Keyword != "leaf" || l2.Keyword != "leaf" || l1.Argument != "config" || l2.Argument != "config" {
+		t.Fatalf("got statements %s %s and %s %s", l1.Keyword, l1.Argument, l2.Keyword, l2.Argument)
+	}
+	if stringData(l1.Keyword) != stringData(l2.Keyword) {
+		t.Errorf("keywords of different sources are not interned")
+	}
+	if stringData(l1.Argument) != stringData(l2.Argument) {
+		t.Errorf("arguments of different sources are not interned")
+	}
+
+	// Each call to Parse has its own interner.
+	ss, err := Parse(`module d { leaf config { type string; } }`, "d.yang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l3 := ss[0].SubStatements()[0]; stringData(l3.Argument) == stringData(l1.Argument) {
+		t.Errorf("arguments parsed into different Modules are interned together")
+	}
+
+	in := newInterner()
+	long := strings.Repeat("x", maxInternLength+1)
+	if got := in.intern(long); stringData(got) != stringData(long) {
+		t.Errorf("intern() of long string did not return the string")
+	}
+	if len(in.m) != 0 {
+		t.Errorf("intern() of long string interned it")
+	}
+	var nilInterner *interner
+	if got := nilInterner.intern("x"); got != "x" {
+		t.Errorf("intern() of nil interner got %q, want x", got)
+	}
+}
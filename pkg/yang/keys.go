@@ -0,0 +1,257 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// A KeySyntax is a syntax of the key values of a list entry in a path.
+type KeySyntax int
+
+const (
+	// XPathKeys are the predicates of XPath and instance-identifiers
+	// (RFC 7950 section 9.13), e.g., [name='eth0'][unit='0'].  A value is
+	// quoted with ' unless it contains one, and then with ".  XPath has
+	// no escapes, so a value cannot contain both.
+	XPathKeys KeySyntax = iota
+	// GNMIKeys are the predicates of gNMI path strings, e.g.,
+	// [name=eth0][unit=0].  The characters \ and ] of a value are escaped
+	// with \.
+	GNMIKeys
+	// RESTCONFKeys are the key values of a RESTCONF data resource
+	// identifier (RFC 8040 section 3.5.3), e.g., =eth0,0.  The values are
+	// percent-encoded and separated by commas.
+	RESTCONFKeys
+)
+
+// A KeyPredicate is a key and its value in a path.
+type KeyPredicate struct {
+	Name  string // Name is the name of the key, possibly with a prefix.
+	Value string // Value is the unescaped value of the key.
+}
+
+// KeyNames returns the names of the keys of the list e, in the order of its
+// key statement.  nil is returned if e is not a list with keys.
+func (e *Entry) KeyNames() []string {
+	if !e.IsList() {
+		return nil
+	}
+	return strings.Fields(e.Key)
+}
+
+// FormatKeys returns the key predicates, in syntax, of the entry of the list
+// e whose keys have the values values, indexed by key name.  The keys are
+// written in the order of the key statement of e.  An error is returned if e
+// is not a list with keys, if values is missing a key or has a value for a
+// node that is not a key, or if a value cannot be written in syntax.
+func (e *Entry) FormatKeys(values map[string]string, syntax KeySyntax) (string, error) {
+	names := e.KeyNames()
+	if len(names) == 0 {
+		return "", fmt.Errorf("%s is not a list with keys", e.Name)
+	}
+	if err := checkKeyNames(e, names, values); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, k := range names {
+		v := values[k]
+		switch syntax {
+		case XPathKeys:
+			q := "'"
+			if strings.Contains(v, q) {
+				if strings.Contains(v, `"`) {
+					return "", fmt.Errorf("value %q of key %s of list %s cannot be quoted in XPath", v, k, e.Name)
+				}
+				q = `"`
+			}
+			fmt.Fprintf(&b, "[%s=%s%s%s]", k, q, v, q)
+		case GNMIKeys:
+			fmt.Fprintf(&b, "[%s=%s]", k, EscapeGNMIValue(v))
+		case RESTCONFKeys:
+			if i == 0 {
+				b.WriteByte('=')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(EscapeRESTCONFValue(v))
+		default:
+			return "", fmt.Errorf("unknown key syntax %d", syntax)
+		}
+	}
+	return b.String(), nil
+}
+
+// EscapeGNMIValue returns s escaped as a key value of a gNMI path string:
+// the characters \ and ] are escaped with \.
+func EscapeGNMIValue(s string) string {
+	return gnmiKeyEscaper.Replace(s)
+}
+
+// gnmiKeyEscaper escapes the value of a key of a gNMI path string.
+var gnmiKeyEscaper = strings.NewReplacer(`\`, `\\`, `]`, `\]`)
+
+// EscapeRESTCONFValue returns s percent-encoded as a key value of a RESTCONF
+// data resource identifier.  RFC 8040 section 3.5.3 requires the reserved
+// characters of RFC 3986 to be encoded, so all but the unreserved characters,
+// letters, digits and -._~, are, and url.PathUnescape decodes the result.
+func EscapeRESTCONFValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+		}
+	}
+	return b.String()
+}
+
+// ParseKeys returns the values, indexed by key name, of the key predicates s
+// of an entry of the list e, written in syntax.  The prefixes of the key
+// names are removed.  An error is returned if s is not valid, or does not
+// give a value for exactly each key of e.
+func (e *Entry) ParseKeys(s string, syntax KeySyntax) (map[string]string, error) {
+	names := e.KeyNames()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s is not a list with keys", e.Name)
+	}
+	values := map[string]string{}
+	if syntax == RESTCONFKeys {
+		if !strings.HasPrefix(s, "=") {
+			return nil, fmt.Errorf("invalid key values %q of list %s: want =value,...", s, e.Name)
+		}
+		vs := strings.Split(s[1:], ",")
+		if len(vs) != len(names) {
+			return nil, fmt.Errorf("list %s has %d keys, got %d key values in %q", e.Name, len(names), len(vs), s)
+		}
+		for i, v := range vs {
+			uv, err := url.PathUnescape(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q of key %s of list %s: %v", v, names[i], e.Name, err)
+			}
+			values[names[i]] = uv
+		}
+		return values, nil
+	}
+	preds, err := SplitKeyPredicates(s, syntax)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range preds {
+		_, k := getPrefix(p.Name)
+		if _, ok := values[k]; ok {
+			return nil, fmt.Errorf("key %s of list %s is repeated in %q", k, e.Name, s)
+		}
+		values[k] = p.Value
+	}
+	if err := checkKeyNames(e, names, values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// checkKeyNames returns an error if values does not have the value of each
+// of names, the keys of the list e, or has a value of anything else.
+func checkKeyNames(e *Entry, names []string, values map[string]string) error {
+	keys := map[string]bool{}
+	for _, k := range names {
+		if _, ok := values[k]; !ok {
+			return fmt.Errorf("missing key %s of list %s", k, e.Name)
+		}
+		keys[k] = true
+	}
+	for k := range values {
+		if !keys[k] {
+			return fmt.Errorf("%s is not a key of list %s", k, e.Name)
+		}
+	}
+	return nil
+}
+
+// SplitKeyPredicates returns the keys and values of the predicates s, such as
+// "[name='eth0'][unit='0']", written in syntax, which must be XPathKeys or
+// GNMIKeys.  The predicates are returned in the order written, with their
+// values unquoted or unescaped.  White space around the = and around the
+// predicate of XPathKeys is ignored.
+func SplitKeyPredicates(s string, syntax KeySyntax) ([]KeyPredicate, error) {
+	var preds []KeyPredicate
+	rest := s
+	for rest != "" {
+		if rest[0] != '[' {
+			return nil, fmt.Errorf("invalid predicate in %q", s)
+		}
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("invalid predicate in %q", s)
+		}
+		p := KeyPredicate{Name: strings.TrimSpace(rest[1:eq])}
+		rest = rest[eq+1:]
+		var end int
+		switch syntax {
+		case XPathKeys:
+			v := strings.TrimLeft(rest, " \t\n")
+			if v == "" || (v[0] != '\'' && v[0] != '"') {
+				return nil, fmt.Errorf("unquoted value of key %s in %q", p.Name, s)
+			}
+			q := strings.IndexByte(v[1:], v[0])
+			if q < 0 {
+				return nil, fmt.Errorf("unterminated value of key %s in %q", p.Name, s)
+			}
+			p.Value = v[1 : q+1]
+			v = strings.TrimLeft(v[q+2:], " \t\n")
+			if !strings.HasPrefix(v, "]") {
+				return nil, fmt.Errorf("invalid predicate in %q", s)
+			}
+			end = len(rest) - len(v)
+		case GNMIKeys:
+			var b strings.Builder
+			end = -1
+			for i := 0; i < len(rest) && end < 0; i++ {
+				switch c := rest[i]; {
+				case c == '\\' && i+1 < len(rest):
+					i++
+					b.WriteByte(rest[i])
+				case c == ']':
+					end = i
+				default:
+					b.WriteByte(c)
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid predicate in %q", s)
+			}
+			p.Value = b.String()
+		default:
+			return nil, fmt.Errorf("key syntax %d has no predicates", syntax)
+		}
+		if p.Name == "" {
+			return nil, fmt.Errorf("empty key name in %q", s)
+		}
+		preds = append(preds, p)
+		rest = rest[end+1:]
+		if syntax == XPathKeys {
+			rest = strings.TrimLeft(rest, " \t\n")
+		}
+	}
+	return preds, nil
+}
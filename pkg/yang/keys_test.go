@@ -0,0 +1,186 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func keysEntry(t *testing.T) *Entry {
+	t.Helper()
+	ms := NewModules()
+	if err := ms.Parse(`module keys { prefix k; namespace "urn:k";
+		list l { key "name unit"; leaf name { type string; } leaf unit { type uint8; } }
+		container c { leaf x { type string; } }
+	}`, "keys.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	e, errs := ms.GetModule("keys")
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return e
+}
+
+func TestFormatKeys(t *testing.T) {
+	e := keysEntry(t)
+	l := e.Dir["l"]
+	tests := []struct {
+		desc    string
+		e       *Entry
+		values  map[string]string
+		syntax  KeySyntax
+		want    string
+		wantErr string
+	}{{
+		desc:   "xpath",
+		e:      l,
+		values: map[string]string{"unit": "0", "name": "eth0"},
+		syntax: XPathKeys,
+		want:   "[name='eth0'][unit='0']",
+	}, {
+		desc:   "xpath with quote",
+		e:      l,
+		values: map[string]string{"unit": "0", "name": "it's"},
+		syntax: XPathKeys,
+		want:   `[name="it's"][unit='0']`,
+	}, {
+		desc:    "xpath with both quotes",
+		e:       l,
+		values:  map[string]string{"unit": "0", "name": `it's "x"`},
+		syntax:  XPathKeys,
+		wantErr: "cannot be quoted in XPath",
+	}, {
+		desc:   "gnmi",
+		e:      l,
+		values: map[string]string{"unit": "0", "name": `a]b\c`},
+		syntax: GNMIKeys,
+		want:   `[name=a\]b\\c][unit=0]`,
+	}, {
+		desc:   "restconf",
+		e:      l,
+		values: map[string]string{"unit": "0", "name": "a,b/c"},
+		syntax: RESTCONFKeys,
+		want:   "=a%2Cb%2Fc,0",
+	}, {
+		desc:   "restconf reserved characters",
+		e:      l,
+		values: map[string]string{"unit": "0", "name": "a=b%c:d@e+f g"},
+		syntax: RESTCONFKeys,
+		want:   "=a%3Db%25c%3Ad%40e%2Bf%20g,0",
+	}, {
+		desc:    "missing key",
+		e:       l,
+		values:  map[string]string{"name": "eth0"},
+		syntax:  GNMIKeys,
+		wantErr: "missing key unit of list l",
+	}, {
+		desc:    "not a key",
+		e:       l,
+		values:  map[string]string{"name": "eth0", "unit": "0", "mtu": "1500"},
+		syntax:  GNMIKeys,
+		wantErr: "mtu is not a key of list l",
+	}, {
+		desc:    "not a list",
+		e:       e.Dir["c"],
+		values:  map[string]string{"x": "1"},
+		syntax:  GNMIKeys,
+		wantErr: "c is not a list with keys",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tt.e.FormatKeys(tt.values, tt.syntax)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if got != tt.want {
+				t.Errorf("FormatKeys() = %q, want %q", got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			back, err := tt.e.ParseKeys(got, tt.syntax)
+			if err != nil {
+				t.Fatalf("ParseKeys(%q) got error %v", got, err)
+			}
+			if diff := cmp.Diff(tt.values, back); diff != "" {
+				t.Errorf("ParseKeys(FormatKeys()) (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	l := keysEntry(t).Dir["l"]
+	tests := []struct {
+		desc    string
+		in      string
+		syntax  KeySyntax
+		want    map[string]string
+		wantErr string
+	}{{
+		desc:   "xpath with prefixes and white space",
+		in:     `[k:unit = "1"] [k:name='a]b']`,
+		syntax: XPathKeys,
+		want:   map[string]string{"name": "a]b", "unit": "1"},
+	}, {
+		desc:   "gnmi value with =",
+		in:     `[name=a=b][unit=1]`,
+		syntax: GNMIKeys,
+		want:   map[string]string{"name": "a=b", "unit": "1"},
+	}, {
+		desc:    "unquoted xpath",
+		in:      `[name=a][unit='1']`,
+		syntax:  XPathKeys,
+		wantErr: "unquoted value of key name",
+	}, {
+		desc:    "repeated key",
+		in:      `[name=a][name=b]`,
+		syntax:  GNMIKeys,
+		wantErr: "key name of list l is repeated",
+	}, {
+		desc:    "unterminated",
+		in:      `[name=a`,
+		syntax:  GNMIKeys,
+		wantErr: "invalid predicate",
+	}, {
+		desc:    "restconf value count",
+		in:      "=a",
+		syntax:  RESTCONFKeys,
+		wantErr: "list l has 2 keys, got 1 key values",
+	}, {
+		desc:    "restconf without =",
+		in:      "a,1",
+		syntax:  RESTCONFKeys,
+		wantErr: "want =value",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := l.ParseKeys(tt.in, tt.syntax)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseKeys(%q) (-want, +got):\n%s", tt.in, diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ package yang
 //    tEOF         // end-of-file
 //    tString      // A de-quoted string (e.g., "\"bob\"" becomes "bob")
 //    tUnquoted    // An un-quoted string
+//    tComment     // A comment, only if comments is set
 //    '{'
 //    ';'
 //    '}'
@@ -51,6 +52,11 @@ type lexer struct {
 	errout io.Writer // destination for errors, defaults to os.Stderr
 	errcnt int       // number of errors encountered
 
+	// code is the code of the first error written to errout, by the lexer
+	// or the parser, and coded is true once it is set.
+	code  ErrorCode
+	coded bool
+
 	file  string // name of file we are processing
 	input string // contents of the file
 	start int    // start position in input of unconsumed data.
@@ -60,6 +66,9 @@ type lexer struct {
 
 	debug     bool        // set to true to include internal debugging
 	inPattern bool        // set when parsing the argument to a pattern
+	strict    bool        // set to enforce RFC 7950 string rules strictly
+	comments  bool        // set to emit comments as tComment tokens
+	tstart    int         // starting position in input of current token
 	items     chan *token // channel of scanned items.
 	tcol      int         // column with tabs expanded (for multi-line strings)
 	scol      int         // starting col of current token
@@ -77,6 +86,7 @@ const (
 	tError                      // An error
 	tString                     // A dequoted string
 	tUnquoted                   // A non-quoted string
+	tComment                    // A comment
 )
 
 // String returns c as a string.
@@ -88,6 +98,8 @@ func (c code) String() string {
 		return "String"
 	case tUnquoted:
 		return "Unquoted"
+	case tComment:
+		return "Comment"
 	}
 	if c < 0 || c > '~' {
 		return fmt.Sprintf("%d", c)
@@ -103,6 +115,9 @@ type token struct {
 	File string // the source file the token is from
 	Line int    // the source line number the token is from
 	Col  int    // the source column number the token is from (8 space tabs)
+
+	raw    string // the token as written in the source, including quotes
+	offset int    // the position of the token in the source
 }
 
 // Code returns the code of t.  If t is nil, tEOF is returned.
@@ -189,11 +204,13 @@ func (l *lexer) emitText(c code, text string) {
 	}
 	select {
 	case l.items <- &token{
-		code: c,
-		Text: text,
-		File: l.file,
-		Line: l.sline,
-		Col:  l.scol + 1,
+		code:   c,
+		Text:   text,
+		File:   l.file,
+		Line:   l.sline,
+		Col:    l.scol + 1,
+		raw:    l.input[l.tstart:l.pos],
+		offset: l.tstart,
 	}:
 	default:
 	}
@@ -297,10 +314,10 @@ func (l *lexer) updateCursor(n int) {
 	l.col += utf8.RuneCountInString(s[strings.LastIndex(s, "\n")+1:])
 }
 
-// Errorf writes an error on l.errout and increments the error count.
-// If too many errors (8) are encountered then lexing will stop and
-// eof is returned as the next token.
-func (l *lexer) Errorf(f string, v ...interface{}) {
+// Errorf writes an error of the class with code on l.errout and increments
+// the error count.  If too many errors (8) are encountered then lexing will
+// stop and eof is returned as the next token.
+func (l *lexer) Errorf(code ErrorCode, f string, v ...interface{}) {
 	buf := &bytes.Buffer{}
 
 	if l.debug {
@@ -317,16 +334,25 @@ func (l *lexer) Errorf(f string, v ...interface{}) {
 		buf.Write([]byte{'\n'})
 	}
 	l.emit(tError)
+	l.noteError(code)
 	l.adderror(buf.Bytes())
 }
 
-func (l *lexer) ErrorfAt(line, col int, f string, v ...interface{}) {
+func (l *lexer) ErrorfAt(code ErrorCode, line, col int, f string, v ...interface{}) {
 	oline, ocol := l.line, l.col
 	defer func() {
 		l.line, l.col = oline, ocol
 	}()
 	l.line, l.col = line, col
-	l.Errorf(f, v...)
+	l.Errorf(code, f, v...)
+}
+
+// noteError records code as the code of the error about to be written to
+// errout if it is the first.
+func (l *lexer) noteError(code ErrorCode) {
+	if !l.coded {
+		l.code, l.coded = code, true
+	}
 }
 
 // adderror writes out the error string err and increases the error count.
@@ -336,6 +362,7 @@ func (l *lexer) adderror(err []byte) {
 	if l.errcnt == maxErrors {
 		l.pos = 0
 		l.start = 0
+		l.tstart = 0
 		l.input = ""
 		l.errout.Write([]byte(tooMany))
 		l.errcnt++
@@ -357,6 +384,7 @@ func lexGround(l *lexer) stateFn {
 	l.consume()
 	l.sline = l.line
 	l.scol = l.col
+	l.tstart = l.pos
 
 	switch c := l.peek(); c {
 	case eof:
@@ -369,11 +397,12 @@ func lexGround(l *lexer) stateFn {
 		l.next()
 		l.consume() // Toss the leading '
 		if !l.skipTo("'") {
-			l.ErrorfAt(l.line, l.col-1, `missing closing '`)
+			l.ErrorfAt("GY0001", l.line, l.col-1, `missing closing '`)
 			return nil
 		}
-		l.emit(tString)
-		l.next() // Either EOF or the matching '
+		text := l.input[l.start:l.pos]
+		l.next() // the matching '
+		l.emitText(tString, text)
 		return lexGround
 	case '"':
 		l.next()
@@ -386,19 +415,25 @@ func lexGround(l *lexer) stateFn {
 			if !l.skipTo("\n") {
 				// Here "\n" should always be found, since we force all
 				// input to be "\n" terminated.
-				l.ErrorfAt(l.line, l.col-1, `lexer internal error: all lines should be newline-terminated.`)
+				l.ErrorfAt("", l.line, l.col-1, `lexer internal error: all lines should be newline-terminated.`)
 				return nil
 			}
+			if l.comments {
+				l.emit(tComment)
+			}
 			return lexGround
 		case '*':
 			// Start of a /* comment
 			if !l.skipTo("*/") {
-				l.ErrorfAt(l.line, l.col-1, `missing closing */`)
+				l.ErrorfAt("GY0001", l.line, l.col-1, `missing closing */`)
 				return nil
 			}
 			// Now actually skip the */
 			l.next()
 			l.next()
+			if l.comments {
+				l.emit(tComment)
+			}
 			return lexGround
 		default:
 			return lexUnquoted
@@ -440,11 +475,12 @@ func lexQString(l *lexer) stateFn {
 
 	var text []byte
 	for {
+		tcol := l.tcol // the column before c
 		// l.next can return non-8bit unicode code points.
 		// c cannot be treated as only a single byte.
 		switch c := l.next(); c {
 		case eof:
-			l.ErrorfAt(line, col, `missing closing "`)
+			l.ErrorfAt("GY0001", line, col, `missing closing "`)
 			return nil
 		case '"':
 			l.emitText(tString, string(text))
@@ -469,6 +505,13 @@ func lexQString(l *lexer) stateFn {
 			if !over && l.tcol <= indent {
 				break
 			}
+			// Only the part of a tab that crosses our indent is
+			// kept, as spaces.
+			if l.strict && !over && c == '\t' && tcol < indent {
+				over = true
+				text = append(text, strings.Repeat(" ", l.tcol-indent)...)
+				break
+			}
 			over = true
 			text = append(text, []byte(string(c))...)
 		case '\\':
@@ -486,8 +529,12 @@ func lexQString(l *lexer) stateFn {
 				// can either mean to escape the character
 				// (e..g., \{) or to be part of of a special
 				// sequence such as \S.
-				if !l.inPattern {
-					l.ErrorfAt(l.line, l.col-2, `invalid escape sequence: \`+string(c))
+				// RFC 7950 makes these an error in both.
+				switch {
+				case !l.inPattern:
+					l.ErrorfAt("GY0002", l.line, l.col-2, `invalid escape sequence: \`+string(c))
+				case l.strict:
+					l.ErrorfAt("GY0002", l.line, l.col-2, `invalid escape sequence: \`+string(c)+`, use a single quoted string for the pattern`)
 				}
 				text = append(text, '\\')
 			}
@@ -507,6 +554,7 @@ func lexQString(l *lexer) stateFn {
 // single or double quote character, a semicolon (";"), braces ("{" or
 // "}"), or comment sequences ("//", "/*", or "*/").
 func lexUnquoted(l *lexer) stateFn {
+	var last rune // the last rune of the string
 	for {
 		switch c := l.peek(); c {
 		// TODO: Support detection of comment immediately following an
@@ -516,7 +564,10 @@ func lexUnquoted(l *lexer) stateFn {
 			l.emit(tUnquoted)
 			return lexGround
 		default:
-			l.next()
+			if l.strict && (last == '/' && (c == '/' || c == '*') || last == '*' && c == '/') {
+				l.ErrorfAt("", l.line, l.col-1, `comment sequence %c%c in unquoted string`, last, c)
+			}
+			last = l.next()
 		}
 	}
 }
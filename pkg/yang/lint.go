@@ -0,0 +1,88 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"unicode/utf8"
+)
+
+// LintOptions are the thresholds of the modeling guidelines checked by
+// Modules.Lint.  A threshold of 0 is not checked.
+type LintOptions struct {
+	// MaxDepth is the deepest a data node may be nested, counting the
+	// top level data nodes of a module as 1.  Choices, cases, and the
+	// input and output of RPCs and actions are not counted.
+	MaxDepth int
+	// MaxChildren is the most child data nodes an entry may have,
+	// looking through choices and cases.
+	MaxChildren int
+	// MaxEnums is the most enums an enumeration may have.
+	MaxEnums int
+	// MinDescription is the fewest characters the description of an
+	// entry, other than a case or the input or output of an RPC or action,
+	// may have, with its whitespace normalized.  1 requires a description.
+	MinDescription int
+}
+
+// Lint returns an error for each node of the processed modules in ms that
+// does not meet the thresholds of opts.  The errors have the codes GY0901 to
+// GY0904, see Code, and are sorted by location.
+func (ms *Modules) Lint(opts LintOptions) []error {
+	var errs []error
+	seenEnums := map[*EnumType]bool{}
+	var walk func(e *Entry, depth int)
+	walk = func(e *Entry, depth int) {
+		structural := e.IsChoice() || e.IsCase() || e.Kind == InputEntry || e.Kind == OutputEntry
+		if !structural {
+			depth++
+			if max := opts.MaxDepth; max > 0 && depth > max {
+				errs = append(errs, codeErrorf("GY0901", "%s: %s is nested %d deep, more than %d", Source(e.Node), e.Path(), depth, max))
+			}
+			if n := len(dataChildren(e)); opts.MaxChildren > 0 && n > opts.MaxChildren {
+				errs = append(errs, codeErrorf("GY0902", "%s: %s has %d children, more than %d", Source(e.Node), e.Path(), n, opts.MaxChildren))
+			}
+		}
+		if min := opts.MinDescription; min > 0 && !e.IsCase() && e.Kind != InputEntry && e.Kind != OutputEntry {
+			switch n := utf8.RuneCountInString(e.DescriptionText().Normalized()); {
+			case n == 0:
+				errs = append(errs, codeErrorf("GY0904", "%s: %s has no description", Source(e.Node), e.Path()))
+			case n < min:
+				errs = append(errs, codeErrorf("GY0904", "%s: description of %s is %d characters, less than %d", Source(e.Node), e.Path(), n, min))
+			}
+		}
+		if max := opts.MaxEnums; max > 0 {
+			anyType(e.Type, func(t *YangType) bool {
+				if t.Kind == Yenum && t.Enum != nil && !seenEnums[t.Enum] && len(t.Enum.Names()) > max {
+					seenEnums[t.Enum] = true
+					var src Node = e.Node
+					if t.Base != nil {
+						src = t.Base
+					}
+					errs = append(errs, codeErrorf("GY0903", "%s: enumeration of %s has %d enums, more than %d", Source(src), e.Path(), len(t.Enum.Names()), max))
+				}
+				return false
+			})
+		}
+		for _, c := range e.children() {
+			walk(c, depth)
+		}
+	}
+	for _, m := range ms.SortedModules() {
+		for _, c := range ToEntry(m).children() {
+			walk(c, 0)
+		}
+	}
+	return errorSort(errs)
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLint(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module lint {
+  namespace "urn:lint";
+  prefix "lint";
+  typedef colour {
+    type enumeration { enum red; enum green; enum blue; }
+  }
+  container top {
+    description "The top container.";
+    container middle {
+      description "Short";
+      choice ch {
+        leaf bottom {
+          type colour;
+          description "The bottom leaf.";
+        }
+      }
+      leaf other {
+        type colour;
+        description "Another leaf.";
+      }
+      leaf third { type string; }
+    }
+  }
+}`, "lint.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	for _, tt := range []struct {
+		desc string
+		opts LintOptions
+		want []string
+	}{{
+		desc: "no thresholds",
+	}, {
+		desc: "depth",
+		opts: LintOptions{MaxDepth: 2},
+		want: []string{
+			"lint.yang:12:9: /lint/top/middle/ch/bottom/bottom is nested 3 deep, more than 2",
+			"lint.yang:17:7: /lint/top/middle/other is nested 3 deep, more than 2",
+			"lint.yang:21:7: /lint/top/middle/third is nested 3 deep, more than 2",
+		},
+	}, {
+		desc: "children",
+		opts: LintOptions{MaxChildren: 2},
+		want: []string{"lint.yang:9:5: /lint/top/middle has 3 children, more than 2"},
+	}, {
+		desc: "enums reported once per enumeration",
+		opts: LintOptions{MaxEnums: 2},
+		want: []string{"lint.yang:5:5: enumeration of /lint/top/middle/ch/bottom/bottom has 3 enums, more than 2"},
+	}, {
+		desc: "descriptions",
+		opts: LintOptions{MinDescription: 10},
+		want: []string{
+			"lint.yang:9:5: description of /lint/top/middle is 5 characters, less than 10",
+			"lint.yang:11:7: /lint/top/middle/ch has no description",
+			"lint.yang:21:7: /lint/top/middle/third has no description",
+		},
+	}} {
+		var got []string
+		for _, err := range ms.Lint(tt.opts) {
+			got = append(got, err.Error())
+			if code := Code(err); code < "GY0901" || code > "GY0904" {
+				t.Errorf("%s: %v: got code %q, want a lint code", tt.desc, err, code)
+			}
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: Lint (-want, +got):\n%s", tt.desc, diff)
+		}
+	}
+}
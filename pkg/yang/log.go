@@ -0,0 +1,57 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// A Logger receives the trace messages logged while modules are found and
+// processed, see Options.Logger.  Each message is followed by alternating
+// keys and values, as used by the log/slog package, so a *slog.Logger is a
+// Logger.
+//
+// The messages logged are:
+//
+//	found module file      name, file
+//	resolved import        module, import, source
+//	resolved include       module, include, source
+//	expanded grouping      uses, grouping, source
+//	applied augment        augment, target, source
+//	applying deviation     deviate, target, source
+//	removed disabled node  path, if-feature
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// debug logs msg and args to the logger of ms, if any.
+func (ms *Modules) debug(msg string, args ...interface{}) {
+	if ms.logging() {
+		ms.ParseOptions.Logger.Debug(msg, args...)
+	}
+}
+
+// logging returns true if ms has a logger.  Arguments of debug that take
+// time to compute are only computed when logging returns true.
+func (ms *Modules) logging() bool {
+	return ms != nil && ms.ParseOptions.Logger != nil
+}
+
+// nodeModules returns the Modules n belongs to, or nil if it belongs to none.
+func nodeModules(n Node) *Modules {
+	if n == nil {
+		return nil
+	}
+	if m := RootNode(n); m != nil {
+		return m.Modules
+	}
+	return nil
+}
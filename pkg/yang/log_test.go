@@ -0,0 +1,72 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recordingLogger records each message logged as the message followed by its
+// key=value pairs.
+type recordingLogger struct {
+	msgs []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	parts := []string{msg}
+	for i := 0; i+1 < len(args); i += 2 {
+		parts = append(parts, fmt.Sprintf("%v=%v", args[i], args[i+1]))
+	}
+	l.msgs = append(l.msgs, strings.Join(parts, " "))
+}
+
+func TestLogger(t *testing.T) {
+	l := &recordingLogger{}
+	ms := NewModules()
+	ms.ParseOptions.Logger = l
+	ms.AddPath("testdata/modeldata")
+	if err := ms.Parse(`module log {
+  prefix l;
+  namespace "urn:l";
+  import openconfig-extensions { prefix oc-ext; }
+  grouping g { leaf x { type string; } }
+  container c { uses g; }
+  augment /c { leaf y { type string; } }
+  deviation /c/x { deviate not-supported; }
+}`, "log.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("Process() got unexpected errors: %v", errs)
+	}
+
+	want := []string{
+		"applied augment augment=/c target=/log/c source=log.yang:7:3",
+		"applying deviation deviate=not-supported target=/log/c/x source=log.yang:8:20",
+		"expanded grouping uses=log.yang:6:17 grouping=g source=log.yang:5:3",
+		"found module file name=openconfig-extensions file=testdata/modeldata/openconfig-extensions.yang",
+		"resolved import module=log import=openconfig-extensions source=testdata/modeldata/openconfig-extensions.yang:1:1",
+	}
+	got := append([]string{}, l.msgs...)
+	sort.Strings(got)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("logged messages (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// missingImportError is an error caused by a reference into a placeholder
+// module created for a missing import.  Process reports these as warnings.
+type missingImportError struct {
+	err error
+}
+
+func (e *missingImportError) Error() string { return e.err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *missingImportError) Unwrap() error { return e.err }
+
+// isMissingImport returns true if err is caused by a missing import.
+func isMissingImport(err error) bool {
+	_, ok := err.(*missingImportError)
+	return ok
+}
+
+// IsPlaceholder returns true if m is a placeholder module created for a
+// missing import, see Options.AllowMissingImports.
+func (ms *Modules) IsPlaceholder(m *Module) bool {
+	return m != nil && ms.placeholders[m]
+}
+
+// Warnings returns the warnings found when ms was last processed.  Warnings
+// are the errors, such as an unknown type from a missing import, that are
+// caused by the placeholder modules created when
+// Options.AllowMissingImports is set.
+func (ms *Modules) Warnings() []error {
+	return ms.warnings
+}
+
+// addPlaceholder adds, and returns, an empty placeholder module for the
+// missing module imported by i.  The prefix of the module is that used by i,
+// and its namespace is urn:goyang:missing:NAME.
+func (ms *Modules) addPlaceholder(i *Import) (*Module, error) {
+	var src strings.Builder
+	fmt.Fprintf(&src, "module %s {\n", i.Name)
+	fmt.Fprintf(&src, "  namespace %q;\n", "urn:goyang:missing:"+i.Name)
+	prefix := i.Name
+	if i.Prefix != nil {
+		prefix = i.Prefix.Name
+	}
+	fmt.Fprintf(&src, "  prefix %q;\n", prefix)
+	if i.RevisionDate != nil {
+		fmt.Fprintf(&src, "  revision %s;\n", i.RevisionDate.Name)
+	}
+	src.WriteString("}\n")
+
+	ss, err := Parse(src.String(), "<missing "+i.Name+">")
+	if err != nil {
+		return nil, err
+	}
+	n, err := buildASTWithTypeDict(ss[0], ms.typeDict)
+	if err != nil {
+		return nil, err
+	}
+	m := n.(*Module)
+	if err := ms.add(m, ""); err != nil {
+		return nil, err
+	}
+	if ms.placeholders == nil {
+		ms.placeholders = map[*Module]bool{}
+	}
+	ms.placeholders[m] = true
+	ms.warnings = append(ms.warnings, codeErrorf("GY0101", "%s: no such module: %s, using a placeholder", Source(i), i.Name))
+	return m, nil
+}
+
+// refersToPlaceholder returns true if any of the prefixes used in path, a
+// name or a schema node path, refer to a placeholder module from the
+// context of n.
+func refersToPlaceholder(n Node, path string) bool {
+	for _, part := range strings.Split(path, "/") {
+		prefix, _ := getPrefix(part)
+		if prefix == "" {
+			continue
+		}
+		if m := FindModuleByPrefix(n, prefix); m != nil && m.Modules != nil && m.Modules.IsPlaceholder(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingImportErrorf returns the error of the class with code from format
+// and v, marked as caused by a missing import if path, as used by n, refers
+// to a placeholder module.
+func missingImportErrorf(code ErrorCode, n Node, path, format string, v ...interface{}) error {
+	err := codeErrorf(code, format, v...)
+	if refersToPlaceholder(n, path) {
+		return &missingImportError{err}
+	}
+	return err
+}
+
+// splitWarnings returns errs without the errors caused by missing imports,
+// which are added to the warnings of ms if not already present.  The errors
+// of an Entry are collected more than once by Process.
+func (ms *Modules) splitWarnings(errs []error) []error {
+	var kept []error
+	for _, err := range errs {
+		if isMissingImport(err) {
+			if !ms.hasWarning(err) {
+				ms.warnings = append(ms.warnings, err)
+			}
+			continue
+		}
+		kept = append(kept, err)
+	}
+	return kept
+}
+
+// hasWarning returns true if ms already has a warning with the same message
+// as err.
+func (ms *Modules) hasWarning(err error) bool {
+	for _, w := range ms.warnings {
+		if w.Error() == err.Error() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAllowMissingImports(t *testing.T) {
+	const partial = `
+module partial {
+	prefix "p";
+	namespace "urn:p";
+	import vendor-types { prefix vt; revision-date 2020-01-01; }
+
+	identity local { base vt:base-id; }
+
+	container c {
+		leaf speed { type vt:speed; }
+		leaf ok { type string; vt:annotation "x"; }
+		leaf local { type identityref { base local; } }
+		uses vt:common;
+	}
+
+	augment "/vt:root" {
+		leaf extra { type string; }
+	}
+
+	deviation "/vt:root/vt:other" {
+		deviate not-supported;
+	}
+}
+`
+	ms := NewModules()
+	if err := ms.Parse(partial, "partial.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) == 0 || !strings.Contains(errs[0].Error(), "no such module: vendor-types") {
+		t.Errorf("Process() without AllowMissingImports got errors %v, want no such module", errs)
+	}
+
+	ms = NewModules()
+	ms.ParseOptions.AllowMissingImports = true
+	if err := ms.Parse(partial, "partial.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("Process() got unexpected errors: %v", errs)
+	}
+
+	var got []string
+	for _, w := range ms.Warnings() {
+		got = append(got, w.Error())
+	}
+	sort.Strings(got)
+	want := []string{
+		"cannot find target node to deviate, /vt:root/vt:other",
+		"partial.yang:10:16: unknown type vt:speed",
+		"partial.yang:11:26: extension annotation is not defined in module vendor-types",
+		"partial.yang:13:3: unknown group: vt:common",
+		"partial.yang:16:2: augment /vt:root not found",
+		"partial.yang:2:1: can't resolve remote base vt:base-id",
+		"partial.yang:5:2: no such module: vendor-types, using a placeholder",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Warnings() (-want, +got):\n%s", diff)
+	}
+
+	vt := ms.Modules["vendor-types@2020-01-01"]
+	if !ms.IsPlaceholder(vt) || vt.Prefix.Name != "vt" || vt.Namespace.Name != "urn:goyang:missing:vendor-types" {
+		t.Errorf("placeholder module got %v", vt)
+	}
+	if ms.IsPlaceholder(ms.Modules["partial"]) {
+		t.Errorf("IsPlaceholder(partial) got true")
+	}
+
+	e := ToEntry(ms.Modules["partial"])
+	speed := e.Find("c/speed")
+	if speed == nil || speed.Type == nil || speed.Type.Kind != Ynone || speed.Type.Name != "vt:speed" {
+		t.Errorf("leaf speed got type %v, want placeholder vt:speed", speed.Type)
+	}
+	if e.Find("c/ok") == nil || e.Find("c/local") == nil {
+		t.Errorf("leaves not depending on the missing import not found")
+	}
+}
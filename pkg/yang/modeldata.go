@@ -0,0 +1,169 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// revisionDateRegex matches a YANG revision date.
+var revisionDateRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// NewModulesForModels returns a Modules for the modules described by models,
+// the supported models of a gNMI CapabilityResponse, found in the directories
+// of path.  The version of a model is either a revision date or, for
+// OpenConfig modules, an openconfig-version.  A module is read from
+// name@version.yang when its version is a revision date and that file exists,
+// from the file of the module whose openconfig-version is the version when it
+// is not, and otherwise the latest revision of the module found is read.  The
+// models that could not be found, or whose organization or version do not
+// match the module read, are returned as unsatisfied.  The modules read are
+// processed, and any errors found reading or processing them are returned.
+func NewModulesForModels(models []*gpb.ModelData, path ...string) (ms *Modules, unsatisfied []*gpb.ModelData, errs []error) {
+	ms = NewModules()
+	ms.AddPath(path...)
+
+	for _, md := range models {
+		if err := ms.readRevision(md.GetName(), md.GetVersion()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return ms, nil, errs
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		return ms, nil, errs
+	}
+
+	for _, md := range models {
+		m := ms.Modules[md.GetName()+"@"+md.GetVersion()]
+		if m == nil {
+			m = ms.Modules[md.GetName()]
+		}
+		if !modelMatches(md, m) {
+			unsatisfied = append(unsatisfied, md)
+		}
+	}
+	return ms, unsatisfied, nil
+}
+
+// readRevision reads the module name into ms, if it has not already been
+// read.  If version is a revision date and the file name@version.yang is
+// found then it is read, if it is another version then the file of the module
+// with that openconfig-version is read, and otherwise the latest revision
+// found is read.  It is not an error for the module not to be found, an error
+// is only returned if the module's file cannot be parsed.
+func (ms *Modules) readRevision(name, version string) error {
+	if ms.Modules[name] != nil {
+		return nil
+	}
+	var names []string
+	switch {
+	case revisionDateRegex.MatchString(version):
+		names = append(names, name+"@"+version)
+	case version != "":
+		if file, data := ms.findSemVer(name, version); file != "" {
+			return ms.Parse(data, file)
+		}
+	}
+	for _, n := range append(names, name) {
+		if file, data, err := ms.findFile(n); err == nil {
+			return ms.Parse(data, file)
+		}
+	}
+	return nil
+}
+
+// findSemVer returns the name and contents of the first file of the module
+// name, name.yang or name@revision.yang, in the current directory and in the
+// directories of ms.Path whose openconfig-version is version, or "" if there
+// is none.
+func (ms *Modules) findSemVer(name, version string) (string, string) {
+	var files []string
+	add := func(dir string, fis []os.FileInfo) {
+		for _, fi := range fis {
+			fn := fi.Name()
+			if !fi.IsDir() && (fn == name+".yang" || strings.HasPrefix(fn, name) && revisionDateSuffixRegex.MatchString(strings.TrimPrefix(fn, name))) {
+				files = append(files, filepath.Join(dir, fn))
+			}
+		}
+	}
+	for _, dir := range append([]string{"."}, ms.Path...) {
+		if filepath.Base(dir) == "..." {
+			dir = filepath.Dir(dir)
+			newScanner(dir, ms.ParseOptions.ScanOptions).walk(dir, 0, add)
+			continue
+		}
+		if fis, err := newScanner(dir, ms.ParseOptions.ScanOptions).readDir(dir); err == nil {
+			add(dir, fis)
+		}
+	}
+	for _, file := range files {
+		data, err := readFile(file)
+		if err != nil {
+			continue
+		}
+		// Parse the file on its own to find its version.
+		vms := NewModules()
+		if vms.Parse(string(data), file) != nil {
+			continue
+		}
+		if m := vms.Modules[name]; m != nil && semVer(m) == version {
+			ms.AddPath(filepath.Dir(file))
+			return file, string(data)
+		}
+	}
+	return "", ""
+}
+
+// semVer returns the openconfig-version of m, which need not be processed,
+// or "" if it has none.  Unlike ModuleVersion it finds the prefix of the
+// extension from the import of openconfig-extensions, which need not be
+// resolved.
+func semVer(m *Module) string {
+	for _, i := range m.Import {
+		if i.Name != "openconfig-extensions" || i.Prefix == nil {
+			continue
+		}
+		for _, ext := range m.Exts() {
+			if ext.Keyword == i.Prefix.Name+":openconfig-version" {
+				return ext.Argument
+			}
+		}
+	}
+	return ""
+}
+
+// modelMatches returns true if the module m is the module described by md.
+// The organization, if given, is compared ignoring case.  The version, if
+// given, must be either the most recent revision of m or its
+// openconfig-version.
+func modelMatches(md *gpb.ModelData, m *Module) bool {
+	switch {
+	case m == nil:
+		return false
+	case md.GetOrganization() != "" && (m.Organization == nil || !strings.EqualFold(strings.TrimSpace(m.Organization.Name), strings.TrimSpace(md.GetOrganization()))):
+		return false
+	case md.GetVersion() == "":
+		return true
+	}
+	v := m.ModuleVersion()
+	return md.GetVersion() == v.Revision || md.GetVersion() == v.SemVer
+}
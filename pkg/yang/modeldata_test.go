@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+func TestNewModulesForModels(t *testing.T) {
+	path := filepath.Join("testdata", "modeldata")
+	tests := []struct {
+		desc            string
+		in              []*gpb.ModelData
+		wantRevisions   map[string]string
+		wantUnsatisfied []*gpb.ModelData
+	}{{
+		desc: "openconfig version",
+		in: []*gpb.ModelData{{
+			Name:         "openconfig-platform",
+			Organization: "OpenConfig working group",
+			Version:      "0.13.0",
+		}},
+		wantRevisions: map[string]string{"openconfig-platform": "2021-01-18"},
+	}, {
+		desc: "older openconfig version",
+		in: []*gpb.ModelData{{
+			Name:    "openconfig-platform",
+			Version: "0.12.2",
+		}},
+		wantRevisions: map[string]string{"openconfig-platform": "2019-04-16"},
+	}, {
+		desc: "older revision",
+		in: []*gpb.ModelData{{
+			Name:         "ietf-interfaces",
+			Organization: "ietf netmod (network modeling) working group",
+			Version:      "2014-05-08",
+		}},
+		wantRevisions: map[string]string{"ietf-interfaces": "2014-05-08"},
+	}, {
+		desc:          "no version selects the latest revision",
+		in:            []*gpb.ModelData{{Name: "ietf-interfaces"}},
+		wantRevisions: map[string]string{"ietf-interfaces": "2018-02-20"},
+	}, {
+		desc: "unsatisfied models",
+		in: []*gpb.ModelData{
+			{Name: "openconfig-platform", Version: "0.14.0"},
+			{Name: "ietf-interfaces", Organization: "Acme"},
+			{Name: "ietf-interfaces", Version: "2016-01-01"},
+			{Name: "missing"},
+		},
+		wantUnsatisfied: []*gpb.ModelData{
+			{Name: "openconfig-platform", Version: "0.14.0"},
+			{Name: "ietf-interfaces", Organization: "Acme"},
+			{Name: "ietf-interfaces", Version: "2016-01-01"},
+			{Name: "missing"},
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms, unsatisfied, errs := NewModulesForModels(tt.in, path)
+			if len(errs) > 0 {
+				t.Fatalf("NewModulesForModels: unexpected errors: %v", errs)
+			}
+			if diff := cmp.Diff(tt.wantUnsatisfied, unsatisfied, cmpopts.EquateEmpty(), protocmp.Transform()); diff != "" {
+				t.Errorf("NewModulesForModels: unsatisfied models (-want, +got):\n%s", diff)
+			}
+			for name, want := range tt.wantRevisions {
+				m := ms.Modules[name]
+				if m == nil {
+					t.Errorf("NewModulesForModels: module %s not read", name)
+					continue
+				}
+				if got := m.Current(); got != want {
+					t.Errorf("NewModulesForModels: module %s has revision %s, want %s", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -20,23 +20,30 @@ package yang
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"sync"
+	"time"
 )
 
 // Modules contains information about all the top level modules and
 // submodules that are read into it via its Read method.
 type Modules struct {
-	Modules      map[string]*Module // All "module" nodes
-	SubModules   map[string]*Module // All "submodule" nodes
-	includes     map[*Module]bool   // Modules we have already done include on
-	nsMu         sync.Mutex         // nsMu protects the byNS map.
-	byNS         map[string]*Module // Cache of namespace lookup
-	typeDict     *typeDictionary    // Cache for type definitions.
-	entryCacheMu sync.RWMutex       // entryCacheMu protects the entryCache map.
+	Modules      map[string]*Module  // All "module" nodes
+	SubModules   map[string]*Module  // All "submodule" nodes
+	includes     map[*Module]bool    // Modules we have already done include on
+	includedBy   map[*Module]*Module // The module each submodule is included in
+	nsMu         sync.Mutex          // nsMu protects the byNS map.
+	byNS         map[string]*Module  // Cache of namespace lookup
+	typeDict     *typeDictionary     // Cache for type definitions.
+	entryCacheMu sync.RWMutex        // entryCacheMu protects the entryCache map.
 	// entryCache is used to prevent unnecessary recursion into previously
 	// converted nodes. To access the map, use the get/set/ClearEntryCache()
 	// thread-safe functions.
 	entryCache map[Node]*Entry
+	// annotationMu protects the Annotation maps of the entries of ms, see
+	// Entry.SetAnnotation.
+	annotationMu sync.RWMutex
 	// mergedSubmodule is used to prevent re-parsing a submodule that has already
 	// been merged into a particular entity when circular dependencies are being
 	// ignored. The keys of the map are a string that is formed by concatenating
@@ -50,6 +57,25 @@ type Modules struct {
 	Path []string
 	// pathMap is used to prevent adding dups in Path.
 	pathMap map[string]bool
+	// features holds the features enabled by EnableFeatures, keyed by
+	// module name.
+	features map[string]map[string]bool
+	// conformance holds the conformance-types set by SetConformance,
+	// keyed by module name.
+	conformance map[string]ConformanceType
+	// hashes holds the hash of the source of each module and submodule.
+	hashes map[*Module]string
+	// conflicts holds the duplicate modules found with differing content.
+	conflicts []*ModuleConflict
+	// placeholders holds the modules created for missing imports.
+	placeholders map[*Module]bool
+	// warnings holds the warnings found by Process.
+	warnings []error
+	// sources holds the source parsed, keyed by the name of its file, if
+	// ParseOptions.RetainSources is set.
+	sources map[string]string
+	// strings interns the keywords and arguments of the source parsed.
+	strings *interner
 }
 
 // NewModules returns a newly created and initialized Modules.
@@ -58,11 +84,15 @@ func NewModules() *Modules {
 		Modules:         map[string]*Module{},
 		SubModules:      map[string]*Module{},
 		includes:        map[*Module]bool{},
+		includedBy:      map[*Module]*Module{},
 		byNS:            map[string]*Module{},
 		typeDict:        newTypeDictionary(),
 		mergedSubmodule: map[string]bool{},
 		entryCache:      map[Node]*Entry{},
 		pathMap:         map[string]bool{},
+		hashes:          map[*Module]string{},
+		sources:         map[string]string{},
+		strings:         newInterner(),
 	}
 	return ms
 }
@@ -72,7 +102,9 @@ func NewModules() *Modules {
 // e.g., foo.yang is named foo).  An error is returned if the file is not
 // found or there was an error parsing the file.
 func (ms *Modules) Read(name string) error {
+	start := time.Now()
 	name, data, err := ms.findFile(name)
+	ms.timePhase(ScanPhase, name, start)
 	if err != nil {
 		return err
 	}
@@ -84,22 +116,55 @@ func (ms *Modules) Read(name string) error {
 // Note: If an error is returned, valid modules might still have been added to
 // the Modules cache.
 func (ms *Modules) Parse(data, name string) error {
-	ss, err := Parse(data, name)
+	defer ms.timePhase(ParsePhase, name, time.Now())
+	data, err := decodeSource(data, name)
 	if err != nil {
 		return err
 	}
+	if ms.ParseOptions.RetainSources {
+		ms.sources[name] = data
+	}
+	ss, err := parseDecoded(data, name, &ms.ParseOptions, ms.strings)
+	if err != nil {
+		return err
+	}
+	if hook := ms.ParseOptions.StatementHook; hook != nil {
+		for _, s := range ss {
+			if err := visitStatements(s, nil, hook); err != nil {
+				return err
+			}
+		}
+	}
+	hash := sourceHash(data)
 	for _, s := range ss {
 		n, err := buildASTWithTypeDict(s, ms.typeDict)
 		if err != nil {
 			return err
 		}
-		if err := ms.add(n); err != nil {
+		if err := ms.add(n, hash); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// source returns the decoded source of file, as retained by Parse, or read again
+// from file, and true, or false if it is not available.
+func (ms *Modules) source(file string) (string, bool) {
+	if src, ok := ms.sources[file]; ok {
+		return src, true
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	src, err := decodeSource(string(data), file)
+	if err != nil {
+		return "", false
+	}
+	return src, true
+}
+
 // GetModule returns the Entry of the module named by name.  GetModule will
 // search for and read the file named name + ".yang" if it cannot satisfy the
 // request from what it has currently read.
@@ -113,7 +178,7 @@ func (ms *Modules) GetModule(name string) (*Entry, []error) {
 			return nil, []error{err}
 		}
 		if ms.Modules[name] == nil {
-			return nil, []error{fmt.Errorf("module not found: %s", name)}
+			return nil, []error{codeErrorf("GY0102", "module not found: %s", name)}
 		}
 	}
 	// Make sure that the modules have all been processed and have no
@@ -146,10 +211,12 @@ func GetModule(name string, sources ...string) (*Entry, []error) {
 	return ms.GetModule(name)
 }
 
-// add adds Node n to ms.  n must be assignable to *Module (i.e., it is a
-// "module" or "submodule").  An error is returned if n is a duplicate of
-// a name already added, or n is not assignable to *Module.
-func (ms *Modules) add(n Node) error {
+// add adds Node n, whose source has the hash hash, to ms.  n must be
+// assignable to *Module (i.e., it is a "module" or "submodule").  If n is a
+// duplicate of a name already added it is handled as specified by
+// ms.ParseOptions.DuplicatePolicy.  An error is returned if n is not
+// assignable to *Module.
+func (ms *Modules) add(n Node, hash string) error {
 	var m map[string]*Module
 
 	name := n.NName()
@@ -168,9 +235,34 @@ func (ms *Modules) add(n Node) error {
 	mod.Modules = ms
 
 	if o := m[fullName]; o != nil {
-		return fmt.Errorf("duplicate %s %s at %s and %s", kind, fullName, Source(o), Source(n))
+		if ms.hashes[o] == hash {
+			if ms.ParseOptions.DuplicatePolicy == DuplicateError {
+				return codeErrorf("GY0105", "duplicate %s %s at %s and %s", kind, fullName, Source(o), Source(n))
+			}
+			return nil
+		}
+		c := &ModuleConflict{
+			Kind:         kind,
+			Name:         fullName,
+			FirstSource:  Source(o),
+			FirstHash:    ms.hashes[o],
+			SecondSource: Source(n),
+			SecondHash:   hash,
+		}
+		ms.conflicts = append(ms.conflicts, c)
+		switch ms.ParseOptions.DuplicatePolicy {
+		case DuplicateFirst:
+			return nil
+		case DuplicateLast:
+			if m[name] == o {
+				m[name] = mod
+			}
+		default:
+			return c
+		}
 	}
 	m[fullName] = mod
+	ms.hashes[mod] = hash
 	if fullName == name {
 		return nil
 	}
@@ -184,47 +276,97 @@ func (ms *Modules) add(n Node) error {
 	return nil
 }
 
+// SortedModules returns the most recent revision of each module in ms,
+// ordered by module name.
+func (ms *Modules) SortedModules() []*Module {
+	var list []*Module
+	for name, m := range ms.Modules {
+		// Modules are held under both their name and name@revision.
+		if name == m.Name {
+			list = append(list, m)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// byKey returns the modules of m in the order of their keys so that
+// processing does not depend on the iteration order of the map.  A module
+// held under both its name and name@revision is returned twice.
+func byKey(m map[string]*Module) []*Module {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]*Module, len(keys))
+	for i, k := range keys {
+		list[i] = m[k]
+	}
+	return list
+}
+
 // FindModule returns the Module/Submodule specified by n, which must be a
 // *Include or *Import.  If n is a *Include then a submodule is returned.  If n
 // is a *Import then a module is returned.
 func (ms *Modules) FindModule(n Node) *Module {
+	m, _ := ms.findModule(n)
+	return m
+}
+
+// findModule is FindModule, also returning the error of fetching the module
+// with the fetcher of ms, if it was fetched and that failed.
+func (ms *Modules) findModule(n Node) (*Module, error) {
 	name := n.NName()
 	rev := name
 	var m map[string]*Module
 
+	var revision string
 	switch i := n.(type) {
 	case *Include:
 		m = ms.SubModules
 		if i.RevisionDate != nil {
-			rev = name + "@" + i.RevisionDate.Name
+			revision = i.RevisionDate.Name
+			rev = name + "@" + revision
 		}
 		// TODO(borman): we should check the BelongsTo field below?
 	case *Import:
 		m = ms.Modules
 		if i.RevisionDate != nil {
-			rev = name + "@" + i.RevisionDate.Name
+			revision = i.RevisionDate.Name
+			rev = name + "@" + revision
 		}
 	default:
-		return nil
+		return nil, nil
 	}
 	if n := m[rev]; n != nil {
-		return n
+		return n, nil
 	}
 	if n := m[name]; n != nil {
-		return n
+		return n, nil
 	}
 
 	// Try to read first a module by revision
 	if err := ms.Read(rev); err != nil {
 		// if failed, try to read a module by its bare name
 		if err := ms.Read(name); err != nil {
-			return nil
+			// and finally fetch it, if fetching is enabled.
+			if ms.ParseOptions.Fetcher == nil {
+				return nil, nil
+			}
+			file, data, err := ms.ParseOptions.Fetcher.Fetch(name, revision)
+			if err != nil {
+				return nil, err
+			}
+			if err := ms.Parse(string(data), file); err != nil {
+				return nil, err
+			}
 		}
 	}
 	if n := m[rev]; n != nil {
-		return n
+		return n, nil
 	}
-	return m[name]
+	return m[name], nil
 }
 
 // FindModuleByNamespace either returns the Module specified by the namespace
@@ -238,12 +380,12 @@ func (ms *Modules) FindModuleByNamespace(ns string) (*Module, error) {
 		return m, nil
 	}
 	var found *Module
-	for _, m := range ms.Modules {
+	for _, m := range byKey(ms.Modules) {
 		if m.Namespace.Name == ns {
 			switch {
 			case m == found:
 			case found != nil:
-				return nil, fmt.Errorf("namespace %s matches two or more modules (%s, %s)",
+				return nil, codeErrorf("GY0107", "namespace %s matches two or more modules (%s, %s)",
 					ns, found.Name, m.Name)
 			default:
 				found = m
@@ -251,7 +393,7 @@ func (ms *Modules) FindModuleByNamespace(ns string) (*Module, error) {
 		}
 	}
 	if found == nil {
-		return nil, fmt.Errorf("%q: no such namespace", ns)
+		return nil, codeErrorf("GY0107", "%q: no such namespace", ns)
 	}
 	// Don't cache negative results because new modules could be added.
 	ms.byNS[ns] = found
@@ -273,7 +415,7 @@ func (ms *Modules) process() []error {
 	// Collect the list of modules we know about now so when we range
 	// below we don't pick up new modules.  We assume the user tells
 	// us explicitly which modules they are interested in.
-	for _, m := range ms.Modules {
+	for _, m := range byKey(ms.Modules) {
 		mods = append(mods, m)
 	}
 	for _, m := range mods {
@@ -281,6 +423,9 @@ func (ms *Modules) process() []error {
 			errs = append(errs, err)
 		}
 	}
+	if len(errs) == 0 {
+		errs = ms.importCycles()
+	}
 
 	// Resolve identities before resolving typedefs, otherwise when we resolve a
 	// typedef that has an identityref within it, then the identity dictionary
@@ -288,7 +433,36 @@ func (ms *Modules) process() []error {
 	errs = append(errs, ms.resolveIdentities()...)
 	// Append any errors found trying to resolve typedefs
 	errs = append(errs, ms.typeDict.resolveTypedefs()...)
+	// Check that extensions are used as they are defined.
+	errs = append(errs, checkExtensions(mods)...)
+	// Check that the prefixes of each module are unique.
+	errs = append(errs, checkModules(mods, (*Module).CheckPrefixes)...)
+	if ms.ParseOptions.StrictYANGVersion {
+		errs = append(errs, checkModules(mods, (*Module).CheckYANGVersion)...)
+	}
+
+	return errs
+}
 
+// checkModules returns the errors returned by check for mods and the
+// submodules they include.
+func checkModules(mods []*Module, check func(*Module) []error) []error {
+	var errs []error
+	seen := map[*Module]bool{}
+	var visit func(m *Module)
+	visit = func(m *Module) {
+		if m == nil || seen[m] {
+			return
+		}
+		seen[m] = true
+		errs = append(errs, check(m)...)
+		for _, in := range m.Include {
+			visit(in.Module)
+		}
+	}
+	for _, m := range mods {
+		visit(m)
+	}
 	return errs
 }
 
@@ -326,31 +500,39 @@ func (ms *Modules) Process() []error {
 	// made by the same caller.
 	ms.mergedSubmodule = map[string]bool{}
 	ms.ClearEntryCache()
+	ms.warnings = nil
 
-	errs := ms.process()
+	start := time.Now()
+	errs := ms.splitWarnings(ms.process())
+	ms.timePhase(ResolvePhase, "", start)
 	if len(errs) > 0 {
 		return errorSort(errs)
 	}
 
-	for _, m := range ms.Modules {
+	start = time.Now()
+	for _, m := range byKey(ms.Modules) {
 		errs = append(errs, ToEntry(m).GetErrors()...)
 	}
-	for _, m := range ms.SubModules {
+	for _, m := range byKey(ms.SubModules) {
 		errs = append(errs, ToEntry(m).GetErrors()...)
 	}
 
+	errs = ms.splitWarnings(errs)
 	if len(errs) > 0 {
 		return errorSort(errs)
 	}
 
+	// Import-only modules add nothing to the schema.
+	ms.dropImportOnly()
+
 	// Now handle all the augments.  We don't have a good way to know
 	// what order to process them in, so repeat until no progress is made
 
 	mods := make([]*Module, 0, len(ms.Modules)+len(ms.SubModules))
-	for _, m := range ms.Modules {
+	for _, m := range byKey(ms.Modules) {
 		mods = append(mods, m)
 	}
-	for _, m := range ms.SubModules {
+	for _, m := range byKey(ms.SubModules) {
 		mods = append(mods, m)
 	}
 	for len(mods) > 0 {
@@ -373,10 +555,10 @@ func (ms *Modules) Process() []error {
 
 	// Now fix up all the choice statements to add in the missing case
 	// statements.
-	for _, m := range ms.Modules {
+	for _, m := range byKey(ms.Modules) {
 		ToEntry(m).FixChoice()
 	}
-	for _, m := range ms.SubModules {
+	for _, m := range byKey(ms.SubModules) {
 		ToEntry(m).FixChoice()
 	}
 
@@ -386,15 +568,20 @@ func (ms *Modules) Process() []error {
 		ToEntry(m).Augment(true)
 		errs = append(errs, ToEntry(m).GetErrors()...)
 	}
+	ms.timePhase(ToEntryPhase, "", start)
 
 	// The deviation statement is only valid under a module or submodule,
 	// which allows us to avoid having to process it within ToEntry, and
 	// rather we can just walk all modules and submodules *after* entries
 	// are resolved. This means we do not need to concern ourselves that
 	// an entry does not exist.
+	start = time.Now()
 	dvP := map[string]bool{} // cache the modules we've handled since we have both modname and modname@revision-date
 	for _, devmods := range []map[string]*Module{ms.Modules, ms.SubModules} {
-		for _, m := range devmods {
+		for _, m := range byKey(devmods) {
+			if ms.importOnly(m) {
+				continue
+			}
 			e := ToEntry(m)
 			if !dvP[e.Name] {
 				errs = append(errs, e.ApplyDeviate(ms.ParseOptions.DeviateOptions)...)
@@ -402,14 +589,28 @@ func (ms *Modules) Process() []error {
 			}
 		}
 	}
+	ms.timePhase(DeviationPhase, "", start)
 
-	return errorSort(errs)
+	start = time.Now()
+	errs = append(errs, ms.pruneFeatures()...)
+	errs = errorSort(ms.splitWarnings(errs))
+	ms.resolveLeafrefTargets()
+	ms.DropStatus(ms.ParseOptions.DropStatus)
+	ms.Compact(ms.ParseOptions.Compact)
+	ms.timePhase(FinishPhase, "", start)
+	return errs
 }
 
 // include resolves all the include and import statements for m.  It returns
 // an error if m, or recursively, any of the modules it includes or imports,
 // reference a module that cannot be found.
 func (ms *Modules) include(m *Module) error {
+	return ms.includeIn(m, m)
+}
+
+// includeIn is include for m, which is the module owner or one of the
+// submodules it includes.
+func (ms *Modules) includeIn(m, owner *Module) error {
 	if ms.includes[m] {
 		return nil
 	}
@@ -417,12 +618,24 @@ func (ms *Modules) include(m *Module) error {
 
 	// First process any includes in this module.
 	for _, i := range m.Include {
-		im := ms.FindModule(i)
-		if im == nil {
-			return fmt.Errorf("no such submodule: %s", i.Name)
+		im, err := ms.findModule(i)
+		switch {
+		case Code(err) != "":
+			// The submodule was found, but could not be read.
+			return fmt.Errorf("no such submodule: %s: %w", i.Name, err)
+		case err != nil:
+			return codeErrorf("GY0103", "no such submodule: %s: %v", i.Name, err)
+		case im == nil:
+			return codeErrorf("GY0103", "no such submodule: %s", i.Name)
+		}
+		if err := ms.checkInclude(i, im, owner); err != nil {
+			return err
+		}
+		if ms.logging() {
+			ms.debug("resolved include", "module", m.Name, "include", i.Name, "source", Source(im))
 		}
 		// Process the include statements in our included module.
-		if err := ms.include(im); err != nil {
+		if err := ms.includeIn(im, owner); err != nil {
 			return err
 		}
 		i.Module = im
@@ -431,9 +644,24 @@ func (ms *Modules) include(m *Module) error {
 	// Next process any imports in this module.  Imports are used
 	// when searching.
 	for _, i := range m.Import {
-		im := ms.FindModule(i)
-		if im == nil {
-			return fmt.Errorf("no such module: %s", i.Name)
+		im, ferr := ms.findModule(i)
+		if im == nil && ms.ParseOptions.AllowMissingImports {
+			var err error
+			if im, err = ms.addPlaceholder(i); err != nil {
+				return err
+			}
+		}
+		switch {
+		case im == nil && Code(ferr) != "":
+			// The module was found, but could not be read.
+			return fmt.Errorf("no such module: %s: %w", i.Name, ferr)
+		case im == nil && ferr != nil:
+			return codeErrorf("GY0102", "no such module: %s: %v", i.Name, ferr)
+		case im == nil:
+			return codeErrorf("GY0102", "no such module: %s", i.Name)
+		}
+		if ms.logging() {
+			ms.debug("resolved import", "module", m.Name, "import", i.Name, "source", Source(im))
 		}
 		// Process the include statements in our included module.
 		if err := ms.include(im); err != nil {
@@ -445,6 +673,42 @@ func (ms *Modules) include(m *Module) error {
 	return nil
 }
 
+// EnableFeatures records that the named features of module are enabled, for
+// instance because a server advertises support for them.  Once EnableFeatures
+// has been called for a module, with or without features, the features of
+// the module not enabled are disabled, and Process removes from the entries
+// the nodes whose if-feature statements are false (see RFC 7950 section
+// 7.20.2).  The features of modules EnableFeatures is not called for are
+// all taken to be enabled.  EnableFeatures must be called before Process.
+func (ms *Modules) EnableFeatures(module string, features ...string) {
+	if ms.features == nil {
+		ms.features = map[string]map[string]bool{}
+	}
+	if ms.features[module] == nil {
+		ms.features[module] = map[string]bool{}
+	}
+	for _, f := range features {
+		ms.features[module][f] = true
+	}
+}
+
+// FeatureEnabled returns true if feature of module has been enabled by
+// EnableFeatures.
+func (ms *Modules) FeatureEnabled(module, feature string) bool {
+	return ms.features[module][feature]
+}
+
+// EnabledFeatures returns the sorted names of the features of module that
+// have been enabled by EnableFeatures.
+func (ms *Modules) EnabledFeatures(module string) []string {
+	var features []string
+	for f := range ms.features[module] {
+		features = append(features, f)
+	}
+	sort.Strings(features)
+	return features
+}
+
 func (ms *Modules) getEntryCache(n Node) *Entry {
 	ms.entryCacheMu.RLock()
 	defer ms.entryCacheMu.RUnlock()
@@ -464,3 +728,49 @@ func (ms *Modules) ClearEntryCache() {
 	defer ms.entryCacheMu.Unlock()
 	ms.entryCache = map[Node]*Entry{}
 }
+
+// An IncludeError is an include statement that resolved to a submodule that
+// cannot be included by the including module.
+type IncludeError struct {
+	Include   *Include // Include is the include statement.
+	Module    *Module  // Module is the module the include statement is part of.
+	Submodule *Module  // Submodule is the submodule the statement resolved to.
+	// Reason describes why Submodule cannot be included.
+	Reason string
+}
+
+// Error returns the location of the include statement and the reason the
+// submodule cannot be included.
+func (e *IncludeError) Error() string {
+	return fmt.Sprintf("%s: cannot include submodule %s in module %s: %s", Source(e.Include), e.Include.Name, e.Module.FullName(), e.Reason)
+}
+
+func (e *IncludeError) errorCode() ErrorCode { return "GY0112" }
+
+// checkInclude returns an IncludeError if sub, the submodule i resolved to,
+// does not belong to owner, the module i is part of, has been included by
+// another module or, as when the revision of the revision-date of i cannot be
+// found the latest revision is used, has a different revision than that
+// date.  A submodule without a revision is assumed to match its
+// revision-date.
+func (ms *Modules) checkInclude(i *Include, sub, owner *Module) error {
+	ierr := &IncludeError{Include: i, Module: owner, Submodule: sub}
+	switch {
+	case sub.BelongsTo == nil:
+		ierr.Reason = "it has no belongs-to statement"
+	case sub.BelongsTo.Name != owner.Name:
+		ierr.Reason = fmt.Sprintf("it belongs to module %s", sub.BelongsTo.Name)
+	case i.RevisionDate != nil && sub.Current() != "" && i.RevisionDate.Name != sub.Current():
+		ierr.Reason = fmt.Sprintf("revision-date %s does not match revision %s of %s", i.RevisionDate.Name, sub.Current(), Source(sub))
+	}
+	if ierr.Reason == "" {
+		if prev := ms.includedBy[sub]; prev != nil && prev != owner {
+			ierr.Reason = fmt.Sprintf("it is already included by module %s", prev.FullName())
+		}
+	}
+	if ierr.Reason != "" {
+		return ierr
+	}
+	ms.includedBy[sub] = owner
+	return nil
+}
@@ -15,9 +15,11 @@
 package yang
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/openconfig/gnmi/errdiff"
 )
 
@@ -412,3 +414,231 @@ func TestModulesTotalProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestDuplicatePolicy(t *testing.T) {
+	const (
+		first  = `module foo { prefix "foo"; namespace "urn:foo"; revision 2020-01-01; leaf a { type string; } }`
+		second = `module foo { prefix "foo"; namespace "urn:foo"; revision 2020-01-01; leaf b { type string; } }`
+	)
+	tests := []struct {
+		desc          string
+		policy        DuplicatePolicy
+		sources       []string
+		wantErr       string
+		wantLeaf      string
+		wantConflicts int
+	}{{
+		desc:          "error on differing content",
+		policy:        DuplicateError,
+		sources:       []string{first, second},
+		wantErr:       "duplicate module foo@2020-01-01 with differing content at one.yang:1:1",
+		wantLeaf:      "a",
+		wantConflicts: 1,
+	}, {
+		desc:     "error on identical content",
+		policy:   DuplicateError,
+		sources:  []string{first, first},
+		wantErr:  "duplicate module foo@2020-01-01 at one.yang:1:1 and two.yang:1:1",
+		wantLeaf: "a",
+	}, {
+		desc:     "identical content is added once",
+		policy:   DuplicateFirst,
+		sources:  []string{first, first},
+		wantLeaf: "a",
+	}, {
+		desc:          "keep first",
+		policy:        DuplicateFirst,
+		sources:       []string{first, second},
+		wantLeaf:      "a",
+		wantConflicts: 1,
+	}, {
+		desc:          "keep last",
+		policy:        DuplicateLast,
+		sources:       []string{first, second},
+		wantLeaf:      "b",
+		wantConflicts: 1,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.DuplicatePolicy = tt.policy
+			var err error
+			for i, src := range tt.sources {
+				if err = ms.Parse(src, []string{"one", "two"}[i]+".yang"); err != nil {
+					break
+				}
+			}
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatalf("Parse() %s", diff)
+			}
+			for _, name := range []string{"foo", "foo@2020-01-01"} {
+				if m := ms.Modules[name]; m == nil || len(m.Leaf) != 1 || m.Leaf[0].Name != tt.wantLeaf {
+					t.Errorf("Modules[%q] does not have leaf %s", name, tt.wantLeaf)
+				}
+			}
+			conflicts := ms.Conflicts()
+			if len(conflicts) != tt.wantConflicts {
+				t.Fatalf("Conflicts() got %d conflicts, want %d", len(conflicts), tt.wantConflicts)
+			}
+			for _, c := range conflicts {
+				if c.FirstSource != "one.yang:1:1" || c.SecondSource != "two.yang:1:1" || c.FirstHash != sourceHash(first) || c.SecondHash != sourceHash(second) {
+					t.Errorf("Conflicts() got %+v", c)
+				}
+			}
+		})
+	}
+}
+
+func TestStatementHook(t *testing.T) {
+	const src = `module foo {
+  prefix "foo";
+  namespace "urn:foo";
+  import ext { prefix x; }
+  container c {
+    x:banned;
+    leaf l { type string; }
+  }
+}`
+
+	ms := NewModules()
+	var visited []string
+	ms.ParseOptions.StatementHook = func(s, parent *Statement) error {
+		p := "-"
+		if parent != nil {
+			p = parent.Keyword
+		}
+		_, line, col := s.Position()
+		visited = append(visited, fmt.Sprintf("%s(%s) %d:%d", s.Keyword, p, line, col))
+		return nil
+	}
+	if err := ms.Parse(src, "foo.yang"); err != nil {
+		t.Fatalf("Parse() got unexpected error: %v", err)
+	}
+	want := []string{
+		"module(-) 1:1",
+		"prefix(module) 2:3",
+		"namespace(module) 3:3",
+		"import(module) 4:3",
+		"prefix(import) 4:16",
+		"container(module) 5:3",
+		"x:banned(container) 6:5",
+		"leaf(container) 7:5",
+		"type(leaf) 7:14",
+	}
+	if diff := cmp.Diff(want, visited); diff != "" {
+		t.Errorf("StatementHook visited (-want, +got):\n%s", diff)
+	}
+
+	ms = NewModules()
+	visited = nil
+	ms.ParseOptions.StatementHook = func(s, parent *Statement) error {
+		visited = append(visited, s.Keyword)
+		if s.Keyword == "x:banned" {
+			return fmt.Errorf("%s: extension %s is not allowed", s.Location(), s.Keyword)
+		}
+		return nil
+	}
+	err := ms.Parse(src, "foo.yang")
+	if diff := errdiff.Substring(err, "foo.yang:6:5: extension x:banned is not allowed"); diff != "" {
+		t.Errorf("Parse() %s", diff)
+	}
+	if got := visited[len(visited)-1]; got != "x:banned" {
+		t.Errorf("StatementHook called after error for %s", got)
+	}
+	if ms.Modules["foo"] != nil {
+		t.Errorf("module added after StatementHook error")
+	}
+}
+
+func TestSortedModules(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"b.yang":            `module b { prefix b; namespace "urn:b"; }`,
+		"a@2020-01-01.yang": `module a { prefix a; namespace "urn:a"; revision 2020-01-01; }`,
+		"a@2021-01-01.yang": `module a { prefix a; namespace "urn:a"; revision 2021-01-01; }`,
+		"c.yang":            `module c { prefix c; namespace "urn:c"; revision 2019-01-01; }`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	var got []string
+	for _, m := range ms.SortedModules() {
+		got = append(got, m.FullName())
+	}
+	if diff := cmp.Diff([]string{"a@2021-01-01", "b", "c@2019-01-01"}, got); diff != "" {
+		t.Errorf("SortedModules() (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCheckInclude(t *testing.T) {
+	tests := []struct {
+		desc          string
+		inModules     map[string]string
+		wantErrSubstr string
+		wantReason    string
+	}{{
+		desc: "valid include",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; include s { revision-date 2020-01-01; } }`,
+			"s": `submodule s { belongs-to a { prefix a; } revision 2020-01-01; }`,
+		},
+	}, {
+		desc: "belongs to another module",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; include s; }`,
+			"s": `submodule s { belongs-to b { prefix b; } }`,
+		},
+		wantErrSubstr: "a:1:41: cannot include submodule s in module a: it belongs to module b",
+		wantReason:    "it belongs to module b",
+	}, {
+		desc: "revision mismatch",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; include s { revision-date 2019-01-01; } }`,
+			"s": `submodule s { belongs-to a { prefix a; } revision 2020-01-01; }`,
+		},
+		wantErrSubstr: "revision-date 2019-01-01 does not match revision 2020-01-01 of s:1:1",
+		wantReason:    "revision-date 2019-01-01 does not match revision 2020-01-01 of s:1:1",
+	}, {
+		desc: "included by two modules",
+		inModules: map[string]string{
+			"a":  `module a { prefix a; namespace "urn:a"; revision 2020-01-01; include s; }`,
+			"a2": `module a { prefix a; namespace "urn:a2"; revision 2021-01-01; include s; }`,
+			"s":  `submodule s { belongs-to a { prefix a; } }`,
+		},
+		wantErrSubstr: "cannot include submodule s in module a@2020-01-01: it is already included by module a@2021-01-01",
+		wantReason:    "it is already included by module a@2021-01-01",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			for _, name := range []string{"a", "a2", "s"} {
+				if src, ok := tt.inModules[name]; ok {
+					if err := ms.Parse(src, name); err != nil {
+						t.Fatalf("cannot parse %s: %v", name, err)
+					}
+				}
+			}
+			errs := ms.Process()
+			var err error
+			if len(errs) > 0 {
+				err = errs[0]
+			}
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Fatal(diff)
+			}
+			if err == nil {
+				return
+			}
+			ierr, ok := err.(*IncludeError)
+			if !ok {
+				t.Fatalf("got error %T, want *IncludeError", err)
+			}
+			if ierr.Reason != tt.wantReason || ierr.Submodule != ms.SubModules["s"] {
+				t.Errorf("got reason %q, submodule %v, want %q, s", ierr.Reason, ierr.Submodule.Name, tt.wantReason)
+			}
+		})
+	}
+}
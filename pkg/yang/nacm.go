@@ -0,0 +1,54 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// NACMModule is the name of the module defining the NETCONF Access Control
+// Model extensions, see RFC 8341.
+const NACMModule = "ietf-netconf-acm"
+
+// NACMFlags are the access control flags set on a schema node by the
+// extensions of the NETCONF Access Control Model.
+type NACMFlags struct {
+	// DefaultDenyWrite is set by nacm:default-deny-write, write access
+	// to the node is denied unless explicitly permitted.
+	DefaultDenyWrite bool
+	// DefaultDenyAll is set by nacm:default-deny-all, all access to the
+	// node, or execution of the operation, is denied unless explicitly
+	// permitted.
+	DefaultDenyAll bool
+}
+
+// NACM returns the NETCONF Access Control Model flags set on e by extension
+// statements of the ietf-netconf-acm module.  Extension statements whose
+// prefix cannot be resolved are ignored.
+func (e *Entry) NACM() NACMFlags {
+	var f NACMFlags
+	if e == nil || e.Node == nil {
+		return f
+	}
+	for _, ext := range e.Exts {
+		id, ok := extensionIdentifier(e.Node, ext, NACMModule)
+		if !ok {
+			continue
+		}
+		switch id {
+		case "default-deny-write":
+			f.DefaultDenyWrite = true
+		case "default-deny-all":
+			f.DefaultDenyAll = true
+		}
+	}
+	return f
+}
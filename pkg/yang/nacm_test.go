@@ -0,0 +1,88 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import "testing"
+
+func TestNACM(t *testing.T) {
+	modules := map[string]string{
+		"ietf-netconf-acm.yang": `
+			module ietf-netconf-acm {
+				prefix "nacm";
+				namespace "urn:ietf:params:xml:ns:yang:ietf-netconf-acm";
+
+				extension default-deny-write;
+				extension default-deny-all;
+			}`,
+		"other.yang": `
+			module other {
+				prefix "o";
+				namespace "urn:o";
+
+				extension default-deny-all;
+			}`,
+		"dev.yang": `
+			module dev {
+				prefix "d";
+				namespace "urn:d";
+
+				import ietf-netconf-acm { prefix acm; }
+				import other { prefix o; }
+
+				leaf write {
+					acm:default-deny-write;
+					type string;
+				}
+				container all {
+					acm:default-deny-all;
+					acm:default-deny-write;
+				}
+				rpc reboot {
+					acm:default-deny-all;
+				}
+				leaf other {
+					o:default-deny-all;
+					type string;
+				}
+				leaf none { type string; }
+			}`,
+	}
+	ms := NewModules()
+	for name, mod := range modules {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	dev, _ := ms.GetModule("dev")
+
+	tests := []struct {
+		name string
+		want NACMFlags
+	}{
+		{name: "write", want: NACMFlags{DefaultDenyWrite: true}},
+		{name: "all", want: NACMFlags{DefaultDenyWrite: true, DefaultDenyAll: true}},
+		{name: "reboot", want: NACMFlags{DefaultDenyAll: true}},
+		{name: "other"},
+		{name: "none"},
+	}
+	for _, tt := range tests {
+		if got := dev.Dir[tt.name].NACM(); got != tt.want {
+			t.Errorf("%s: NACM() got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
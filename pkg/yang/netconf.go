@@ -0,0 +1,133 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// A Capability is a NETCONF capability advertising support for a YANG
+// module, as described in RFC 6020 section 5.6.4, e.g.,
+//
+//	urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2014-05-08&features=pre-provisioning
+type Capability struct {
+	URI        string   // URI is the capability as advertised.
+	Namespace  string   // Namespace is the namespace of the module.
+	Module     string   // Module is the name of the module.
+	Revision   string   // Revision is the revision of the module, if any.
+	Features   []string // Features are the features of the module supported.
+	Deviations []string // Deviations names the modules deviating the module.
+}
+
+// ParseCapability parses the capability URI uri.  An error is returned if
+// uri does not advertise a YANG module, such as the NETCONF base capability.
+func ParseCapability(uri string) (*Capability, error) {
+	uri = strings.TrimSpace(uri)
+	i := strings.Index(uri, "?")
+	if i < 0 {
+		return nil, fmt.Errorf("capability %s does not name a module", uri)
+	}
+	q, err := url.ParseQuery(uri[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("capability %s: %v", uri, err)
+	}
+	c := &Capability{
+		URI:       uri,
+		Namespace: uri[:i],
+		Module:    q.Get("module"),
+		Revision:  q.Get("revision"),
+	}
+	if c.Module == "" {
+		return nil, fmt.Errorf("capability %s does not name a module", uri)
+	}
+	if f := q.Get("features"); f != "" {
+		c.Features = strings.Split(f, ",")
+	}
+	if d := q.Get("deviations"); d != "" {
+		c.Deviations = strings.Split(d, ",")
+	}
+	return c, nil
+}
+
+// ParseHello returns the capabilities advertising YANG modules found in the
+// NETCONF hello message data.  Other capabilities are ignored.
+func ParseHello(data []byte) ([]*Capability, error) {
+	var hello struct {
+		Capabilities []string `xml:"capabilities>capability"`
+	}
+	if err := xml.Unmarshal(data, &hello); err != nil {
+		return nil, fmt.Errorf("cannot parse hello: %v", err)
+	}
+	var caps []*Capability
+	for _, uri := range hello.Capabilities {
+		if c, err := ParseCapability(uri); err == nil {
+			caps = append(caps, c)
+		}
+	}
+	return caps, nil
+}
+
+// NewModulesForCapabilities returns a Modules for the modules advertised by
+// caps, found in the directories of path.  The revision advertised of each
+// module is read, along with the modules deviating it, and the features
+// advertised are enabled (see EnableFeatures), so the nodes depending on the
+// features not advertised are removed.  Deviations are applied when the
+// modules are processed.  The capabilities whose module, the revision
+// advertised, or a deviating module could not be found are returned as
+// unsatisfied.  Any errors found reading or processing the modules are
+// returned.
+func NewModulesForCapabilities(caps []*Capability, path ...string) (ms *Modules, unsatisfied []*Capability, errs []error) {
+	ms = NewModules()
+	ms.AddPath(path...)
+
+	for _, c := range caps {
+		if err := ms.readRevision(c.Module, c.Revision); err != nil {
+			errs = append(errs, err)
+		}
+		for _, d := range c.Deviations {
+			if err := ms.readRevision(d, ""); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		ms.EnableFeatures(c.Module, c.Features...)
+	}
+	if len(errs) > 0 {
+		return ms, nil, errs
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		return ms, nil, errs
+	}
+
+	for _, c := range caps {
+		m := ms.Modules[c.Module+"@"+c.Revision]
+		if m == nil {
+			m = ms.Modules[c.Module]
+		}
+		if m == nil || (c.Revision != "" && m.Current() != c.Revision) {
+			unsatisfied = append(unsatisfied, c)
+			continue
+		}
+		for _, d := range c.Deviations {
+			if ms.Modules[d] == nil {
+				unsatisfied = append(unsatisfied, c)
+				break
+			}
+		}
+	}
+	return ms, unsatisfied, nil
+}
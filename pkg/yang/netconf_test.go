@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestParseCapability(t *testing.T) {
+	tests := []struct {
+		desc          string
+		in            string
+		want          *Capability
+		wantErrSubstr string
+	}{{
+		desc: "module with features and deviations",
+		in:   "urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2014-05-08&features=arbitrary-names,pre-provisioning&deviations=acme-dev",
+		want: &Capability{
+			URI:        "urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&revision=2014-05-08&features=arbitrary-names,pre-provisioning&deviations=acme-dev",
+			Namespace:  "urn:ietf:params:xml:ns:yang:ietf-interfaces",
+			Module:     "ietf-interfaces",
+			Revision:   "2014-05-08",
+			Features:   []string{"arbitrary-names", "pre-provisioning"},
+			Deviations: []string{"acme-dev"},
+		},
+	}, {
+		desc: "module only",
+		in:   "  http://example.com/m?module=m  ",
+		want: &Capability{
+			URI:       "http://example.com/m?module=m",
+			Namespace: "http://example.com/m",
+			Module:    "m",
+		},
+	}, {
+		desc:          "base capability",
+		in:            "urn:ietf:params:netconf:base:1.1",
+		wantErrSubstr: "does not name a module",
+	}, {
+		desc:          "capability parameters without a module",
+		in:            "urn:ietf:params:netconf:capability:yang-library:1.0?revision=2016-06-21&module-set-id=1",
+		wantErrSubstr: "does not name a module",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseCapability(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+				t.Fatalf("ParseCapability: %s", diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseCapability (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+const testHello = `<?xml version="1.0" encoding="UTF-8"?>
+<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+  <capabilities>
+    <capability>urn:ietf:params:netconf:base:1.1</capability>
+    <capability>
+      urn:ietf:params:xml:ns:yang:ietf-interfaces?module=ietf-interfaces&amp;revision=2014-05-08&amp;features=arbitrary-names&amp;deviations=acme-interfaces-deviations
+    </capability>
+    <capability>urn:acme:missing?module=acme-missing&amp;revision=2020-01-01</capability>
+  </capabilities>
+  <session-id>4</session-id>
+</hello>`
+
+func TestNewModulesForCapabilities(t *testing.T) {
+	caps, err := ParseHello([]byte(testHello))
+	if err != nil {
+		t.Fatalf("ParseHello: unexpected error: %v", err)
+	}
+	if len(caps) != 2 {
+		t.Fatalf("ParseHello: got %d capabilities, want 2", len(caps))
+	}
+
+	ms, unsatisfied, errs := NewModulesForCapabilities(caps, filepath.Join("testdata", "modeldata"))
+	if len(errs) > 0 {
+		t.Fatalf("NewModulesForCapabilities: unexpected errors: %v", errs)
+	}
+	if len(unsatisfied) != 1 || unsatisfied[0].Module != "acme-missing" {
+		t.Errorf("NewModulesForCapabilities: got unsatisfied %v, want acme-missing", unsatisfied)
+	}
+	if got := ms.Modules["ietf-interfaces"].Current(); got != "2014-05-08" {
+		t.Errorf("NewModulesForCapabilities: got ietf-interfaces revision %s, want 2014-05-08", got)
+	}
+	if !ms.FeatureEnabled("ietf-interfaces", "arbitrary-names") {
+		t.Errorf("NewModulesForCapabilities: feature arbitrary-names not enabled")
+	}
+	if got := ms.EnabledFeatures("ietf-interfaces"); !cmp.Equal(got, []string{"arbitrary-names"}) {
+		t.Errorf("EnabledFeatures: got %v, want [arbitrary-names]", got)
+	}
+	e := ToEntry(ms.Modules["ietf-interfaces"])
+	if e.Dir["interfaces"] == nil || e.Dir["interfaces"].Dir["statistics"] != nil {
+		t.Errorf("NewModulesForCapabilities: deviation of /interfaces/statistics not applied")
+	}
+	if e.Dir["interfaces"].Dir["name-pattern"] == nil {
+		t.Errorf("NewModulesForCapabilities: /interfaces/name-pattern of advertised feature arbitrary-names removed")
+	}
+	if e.Dir["interfaces"].Dir["pre-provisioned"] != nil {
+		t.Errorf("NewModulesForCapabilities: /interfaces/pre-provisioned of feature pre-provisioning not advertised not removed")
+	}
+}
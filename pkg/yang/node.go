@@ -125,6 +125,90 @@ func MatchingEntryExtensions(e *Entry, module, identifier string) ([]*Statement,
 	return matchingExtensions(e.Node, e.Exts, module, identifier)
 }
 
+// NearestEntryExtension returns the nearest of e and its ancestors to have
+// extensions that match the given module and identifier, along with those
+// extensions.  Many extensions apply to the whole subtree of the node they
+// are used on, this finds the node whose extension applies to e.  A nil
+// Entry is returned if neither e nor any of its ancestors has a matching
+// extension.
+func NearestEntryExtension(e *Entry, module, identifier string) (*Entry, []*Statement, error) {
+	for ; e != nil; e = e.Parent {
+		exts, err := MatchingEntryExtensions(e, module, identifier)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(exts) > 0 {
+			return e, exts, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// Extensions returns the extension statements of e, in source order, that
+// use the extension keyword defined by the module named module, e.g.,
+//
+//	e.Extensions("openconfig-extensions", "openconfig-version")
+//
+// Unlike MatchingEntryExtensions, the prefix of each statement is resolved
+// in the module or submodule the statement is written in, which differs from
+// the module of e for the extensions of uses and augment statements of other
+// modules.  Statements whose prefix cannot be resolved are not returned.
+func (e *Entry) Extensions(module, keyword string) []*Statement {
+	var exts []*Statement
+	for _, ext := range e.Exts {
+		prefix, name := getPrefix(ext.Keyword)
+		if prefix == "" || name != keyword {
+			continue
+		}
+		mod := FindModuleByPrefix(e.extensionContext(ext), prefix)
+		if m := moduleOf(mod); m != nil && m.Name == module {
+			exts = append(exts, ext)
+		}
+	}
+	return exts
+}
+
+// Extension returns the first of the extension statements returned by
+// Extensions, and true, or false if e has none.
+func (e *Entry) Extension(module, keyword string) (*Statement, bool) {
+	if exts := e.Extensions(module, keyword); len(exts) > 0 {
+		return exts[0], true
+	}
+	return nil, false
+}
+
+// extensionContext returns the node the prefix of ext, one of the Exts of e,
+// is resolved relative to: the module or submodule parsed from the file of
+// ext, or else the node of e or of its module.
+func (e *Entry) extensionContext(ext *Statement) Node {
+	root := e.Root()
+	if m, ok := root.Node.(*Module); ok && m.Modules != nil && ext.file != "" {
+		for _, mods := range []map[string]*Module{m.Modules.Modules, m.Modules.SubModules} {
+			for _, mod := range mods {
+				if mod.Source != nil && mod.Source.file == ext.file {
+					return mod
+				}
+			}
+		}
+	}
+	if e.Node != nil {
+		return e.Node
+	}
+	return root.Node
+}
+
+// moduleOf returns the module mod belongs to, mod itself unless it is a
+// submodule, or nil if mod is nil.
+func moduleOf(mod *Module) *Module {
+	if mod == nil || mod.BelongsTo == nil || mod.Modules == nil {
+		return mod
+	}
+	if m := mod.Modules.Modules[mod.BelongsTo.Name]; m != nil {
+		return m
+	}
+	return mod
+}
+
 // matchingEntryExtensions returns the subset of the given node's extensions
 // that match the given module and identifier.
 func matchingExtensions(n Node, exts []*Statement, module, identifier string) ([]*Statement, error) {
@@ -133,7 +217,7 @@ func matchingExtensions(n Node, exts []*Statement, module, identifier string) ([
 		names := strings.SplitN(ext.Keyword, ":", 2)
 		mod := FindModuleByPrefix(n, names[0])
 		if mod == nil {
-			return nil, fmt.Errorf("matchingExtensions: module prefix %q not found", names[0])
+			return nil, codeErrorf("GY0108", "matchingExtensions: module prefix %q not found", names[0])
 		}
 		if len(names) == 2 && names[1] == identifier && mod.Name == module {
 			matchingExtensions = append(matchingExtensions, ext)
@@ -142,6 +226,20 @@ func matchingExtensions(n Node, exts []*Statement, module, identifier string) ([
 	return matchingExtensions, nil
 }
 
+// extensionIdentifier returns the identifier of the extension used by the
+// extension statement ext, and true if that extension is defined by the
+// module named module.  The prefix of ext is resolved relative to n.
+func extensionIdentifier(n Node, ext *Statement, module string) (string, bool) {
+	names := strings.SplitN(ext.Keyword, ":", 2)
+	if len(names) != 2 {
+		return "", false
+	}
+	if mod := FindModuleByPrefix(n, names[0]); mod == nil || mod.Name != module {
+		return "", false
+	}
+	return names[1], true
+}
+
 // RootNode returns the submodule or module that n was defined in.
 func RootNode(n Node) *Module {
 	for ; n.ParentNode() != nil; n = n.ParentNode() {
@@ -163,7 +261,10 @@ func module(n Node) *Module {
 	return m
 }
 
-// NodePath returns the full path of the node from the module name.
+// NodePath returns the full path of the node from the module name.  The path
+// is that of n in the statements of the module, including groupings and uses;
+// the path of the schema or data node built from n is returned by the
+// PathString method of its Entry.
 func NodePath(n Node) string {
 	var path string
 	for n != nil {
@@ -206,7 +307,7 @@ func FindNode(n Node, path string) (Node, error) {
 		if mod.Kind() == "submodule" {
 			m := mod.Modules.Modules[mod.BelongsTo.Name]
 			if m == nil {
-				return nil, fmt.Errorf("%s: unknown module %s", m.Name, mod.BelongsTo.Name)
+				return nil, codeErrorf("GY0102", "%s: unknown module %s", m.Name, mod.BelongsTo.Name)
 			}
 			if prefix == "" || prefix == mod.BelongsTo.Prefix.Name {
 				goto processing
@@ -225,7 +326,7 @@ func FindNode(n Node, path string) (Node, error) {
 			}
 		}
 		// We didn't find a matching prefix.
-		return nil, fmt.Errorf("unknown prefix: %q", prefix)
+		return nil, codeErrorf("GY0108", "unknown prefix: %q", prefix)
 	processing:
 		// At this point, n should be pointing to the Module node
 		// of module we are rooted in
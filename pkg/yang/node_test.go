@@ -620,3 +620,182 @@ func TestModulesFindByPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestNearestEntryExtension(t *testing.T) {
+	ms := NewModules()
+	for name, mod := range map[string]string{
+		"ext.yang": `
+			module ext {
+				prefix "e";
+				namespace "urn:e";
+
+				extension atomic;
+			}`,
+		"test.yang": `
+			module test {
+				prefix "t";
+				namespace "urn:t";
+
+				import ext { prefix e; }
+
+				container state {
+					e:atomic;
+					container counters {
+						leaf in { type uint64; }
+					}
+					choice c {
+						container nested {
+							e:atomic;
+							leaf x { type string; }
+						}
+					}
+				}
+				leaf other { type string; }
+			}`,
+	} {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	test, _ := ms.GetModule("test")
+
+	tests := []struct {
+		desc     string
+		path     string
+		wantPath string
+	}{
+		{desc: "extension on the entry", path: "/state", wantPath: "/test/state"},
+		{desc: "extension on an ancestor", path: "/state/counters/in", wantPath: "/test/state"},
+		{desc: "nearest extension", path: "/state/nested/x", wantPath: "/test/state/c/nested/nested"},
+		{desc: "no extension", path: "/other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, exts, err := NearestEntryExtension(test.FindData(tt.path), "ext", "atomic")
+			if err != nil {
+				t.Fatalf("NearestEntryExtension: unexpected error: %v", err)
+			}
+			var gotPath string
+			if got != nil {
+				gotPath = got.Path()
+				if len(exts) != 1 || exts[0].Keyword != "e:atomic" {
+					t.Errorf("NearestEntryExtension: got extensions %v, want e:atomic", exts)
+				}
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("NearestEntryExtension: got entry %q, want %q", gotPath, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestEntryExtensions(t *testing.T) {
+	ms := NewModules()
+	for name, mod := range map[string]string{
+		"ext.yang": `
+			module ext {
+				prefix "e";
+				namespace "urn:e";
+
+				extension a { argument value; }
+				extension b { argument value; }
+			}`,
+		"grp.yang": `
+			module grp {
+				prefix "g";
+				namespace "urn:g";
+
+				import ext { prefix y; }
+
+				grouping g2 {
+					leaf k { y:a "k"; type string; }
+				}
+			}`,
+		"test.yang": `
+			module test {
+				prefix "t";
+				namespace "urn:t";
+
+				import ext { prefix e; }
+				import grp { prefix g; }
+
+				grouping g {
+					leaf l {
+						e:a "l1";
+						e:b "l2";
+						e:a "l3";
+						e:b "l4";
+						e:a "l5";
+						type string;
+					}
+				}
+				container c1 {
+					uses g { e:a "u1"; }
+				}
+				container c2 {
+					uses g { e:a "u2"; }
+				}
+				container c3 {
+					uses g:g2 { e:b "u3"; }
+				}
+			}`,
+		"aug.yang": `
+			module aug {
+				prefix "a";
+				namespace "urn:a";
+
+				import ext { prefix x; }
+				import test { prefix t; }
+
+				augment /t:c1 {
+					x:b "aug";
+					leaf m { type string; }
+				}
+			}`,
+	} {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	test, _ := ms.GetModule("test")
+
+	tests := []struct {
+		desc    string
+		path    string
+		keyword string
+		want    []string
+	}{
+		{desc: "source order", path: "/c1/l", keyword: "a", want: []string{"l1", "l3", "l5", "u1"}},
+		{desc: "other uses of the grouping", path: "/c2/l", keyword: "a", want: []string{"l1", "l3", "l5", "u2"}},
+		{desc: "other keyword", path: "/c2/l", keyword: "b", want: []string{"l2", "l4"}},
+		{desc: "grouping of another module", path: "/c3/k", keyword: "a", want: []string{"k"}},
+		{desc: "uses of a grouping of another module", path: "/c3/k", keyword: "b", want: []string{"u3"}},
+		{desc: "augment", path: "/c1/m", keyword: "b", want: []string{"aug"}},
+		{desc: "no extension", path: "/c1", keyword: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			e := test.Find(tt.path)
+			if e == nil {
+				t.Fatalf("cannot find %s", tt.path)
+			}
+			var got []string
+			for _, ext := range e.Extensions("ext", tt.keyword) {
+				got = append(got, ext.Argument)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Extensions (-want, +got):\n%s", diff)
+			}
+			ext, ok := e.Extension("ext", tt.keyword)
+			if ok != (len(tt.want) > 0) || ok && ext.Argument != tt.want[0] {
+				t.Errorf("Extension: got %v, %v, want the first of %v", ext, ok, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,232 @@ type Options struct {
 	StoreUses bool
 	// DeviateOptions contains options for how deviations are handled.
 	DeviateOptions DeviateOptions
+	// Fetcher, if set, is used to retrieve the modules and submodules
+	// that are imported or included but cannot be found in Path.  By
+	// default modules are never fetched.
+	Fetcher ModuleFetcher
+	// ScanOptions controls which files and directories are searched for
+	// modules in the directories of Path.
+	ScanOptions ScanOptions
+	// DuplicatePolicy specifies which module is used when the same
+	// module or submodule revision is read from more than one source with
+	// differing content.
+	DuplicatePolicy DuplicatePolicy
+	// AllowMissingImports specifies that an empty placeholder module is
+	// used in place of each imported module that cannot be found, rather
+	// than reporting an error.  The errors caused by references into
+	// placeholder modules, such as unknown types or groupings, are
+	// reported as warnings rather than errors, see Modules.Warnings.
+	AllowMissingImports bool
+	// AllowImportCycles specifies that modules importing each other,
+	// directly or through other modules, are accepted, as they are found
+	// in some vendor trees, rather than being reported as a CycleError.
+	// The resolution of the import closing a cycle is deferred until the
+	// modules it passes through have been resolved.  See Modules.Cycles.
+	AllowImportCycles bool
+	// StatementHook, if set, is called by Modules.Parse for each
+	// statement parsed, see StatementHook.
+	StatementHook StatementHook
+	// Logger, if set, is sent trace messages describing how modules are
+	// found and processed, see Logger.
+	Logger Logger
+	// PhaseTimer, if set, is called with the time spent in each phase of
+	// reading and processing modules, see PhaseTimer.
+	PhaseTimer PhaseTimer
+	// Compact specifies the information released from the modules and
+	// entries once they have been processed by Modules.Process, see
+	// Modules.Compact.
+	Compact CompactOptions
+	// Arena, if set, allocates the statements parsed and the entries
+	// built for the modules, see Arena.
+	Arena *Arena
+	// Limits bounds the input accepted by Modules.Parse, see ParseLimits.
+	Limits ParseLimits
+	// StrictStrings specifies that the rules of RFC 7950 section 6.1.3
+	// for quoted and unquoted strings are enforced by Modules.Parse.  By
+	// default the parser accepts the escape sequences of regular
+	// expressions, such as \d, in double quoted patterns, and comment
+	// sequences in unquoted strings, which are errors when strict.  When
+	// strict, a tab that crosses the column of the opening quote of a
+	// multi-line double quoted string is trimmed as 8 spaces, rather than
+	// being kept.
+	StrictStrings bool
+	// StrictYANGVersion specifies that Modules.Process reports the
+	// errors of Module.CheckYANGVersion: the YANG version 1.1 constructs
+	// used by YANG version 1 modules, and the includes and imports not
+	// allowed between modules of differing versions.  By default, as
+	// many YANG version 1 modules use them, they are accepted.
+	StrictYANGVersion bool
+	// RetainSources specifies that Modules.Parse retains the source of each
+	// file it parses, so that Modules.FormatError, ExtractGrouping and
+	// Rename can use it.  By default the source is read again from the
+	// file when they need it, which cannot be done for a source given to
+	// Modules.Parse that was not read from a file.
+	RetainSources bool
+	// StatementOptions selects the statements kept by Modules.Parse, see
+	// StatementOptions.
+	StatementOptions StatementOptions
+	// DropStatus specifies the entries removed from the entry tree by
+	// status once the modules have been processed by Modules.Process, see
+	// StatusFilter.
+	DropStatus StatusFilter
+}
+
+// StatementOptions selects the statements kept when parsing.  A statement
+// that is not kept is dropped along with its substatements, as if it had not
+// been written, which reduces the memory used and the time spent building
+// modules that are only partially needed, e.g., when indexing.  Keywords are
+// matched as they are written, so an extension is named by its prefix and
+// name, e.g., "oc-ext:openconfig-version".  The module and submodule
+// statements are always kept.
+type StatementOptions struct {
+	// ExcludeStatements lists the keywords of the statements that are
+	// dropped.
+	ExcludeStatements []string
+	// IncludeOnlyStatements, if not empty, lists the keywords of the only
+	// statements that are kept, e.g.,
+	//
+	//	[]string{
+	//		"prefix", "namespace", "import", "include", "belongs-to",
+	//		"container", "list", "key", "leaf", "leaf-list",
+	//		"type", "typedef", "enum", "bit", "base", "path",
+	//		"grouping", "uses",
+	//	}
+	//
+	// keeps just the structure and types of modules.  A statement listed
+	// in both IncludeOnlyStatements and ExcludeStatements is dropped.
+	IncludeOnlyStatements []string
+	// AllowUnsafe specifies that the statements the rest of the schema
+	// depends on may be dropped.  By default dropping a base, belongs-to,
+	// bit, enum, grouping, import, include, key, namespace, path, prefix,
+	// type or typedef statement is a parse error, since the modules and
+	// entries built without them are invalid.
+	AllowUnsafe bool
+	// LatestRevisionOnly specifies that only the most recent revision
+	// statement of each module and submodule is kept, as only it is
+	// needed to identify the module.
+	LatestRevisionOnly bool
+	// LatestRevisionOnlyOverrides overrides LatestRevisionOnly for the
+	// modules and submodules it names.  For example, a documentation
+	// generator keeping the full history of the module being documented,
+	// but not of the modules it imports, sets LatestRevisionOnly and
+	//
+	//	LatestRevisionOnlyOverrides: map[string]bool{"my-module": false}
+	LatestRevisionOnlyOverrides map[string]bool
+}
+
+// latestRevisionOnly returns true if only the most recent revision of the
+// module or submodule name is kept.
+func (o StatementOptions) latestRevisionOnly(name string) bool {
+	if latest, ok := o.LatestRevisionOnlyOverrides[name]; ok {
+		return latest
+	}
+	return o.LatestRevisionOnly
+}
+
+// unsafeStatements are the statements that StatementOptions may only drop
+// when AllowUnsafe is set, and why dropping them makes the schema invalid.
+var unsafeStatements = map[string]string{
+	"base":       "identityref types and derived identities lose their base",
+	"belongs-to": "the submodule is not part of a module",
+	"bit":        "bits types have no bits",
+	"enum":       "enumeration types have no enums",
+	"grouping":   "the uses of the grouping cannot be resolved",
+	"import":     "the prefix of the imported module cannot be resolved",
+	"include":    "the submodule is not included",
+	"key":        "the list has no keys",
+	"namespace":  "the module has no namespace",
+	"path":       "leafref types have no path",
+	"prefix":     "the module or import has no prefix",
+	"type":       "the leaf, leaf-list or typedef has no type",
+	"typedef":    "the uses of the type cannot be resolved",
+}
+
+// keeper returns the function reporting whether a statement with a keyword is
+// kept, or nil if all statements are kept.
+func (o StatementOptions) keeper() func(keyword string) bool {
+	if len(o.ExcludeStatements) == 0 && len(o.IncludeOnlyStatements) == 0 {
+		return nil
+	}
+	exclude := map[string]bool{}
+	for _, k := range o.ExcludeStatements {
+		exclude[k] = true
+	}
+	var include map[string]bool
+	if len(o.IncludeOnlyStatements) > 0 {
+		include = map[string]bool{}
+		for _, k := range o.IncludeOnlyStatements {
+			include[k] = true
+		}
+	}
+	return func(keyword string) bool {
+		switch {
+		case keyword == "module" || keyword == "submodule":
+			return true
+		case exclude[keyword]:
+			return false
+		}
+		return include == nil || include[keyword]
+	}
+}
+
+// ParseLimits bounds the input accepted by the parser, so that untrusted
+// input cannot exhaust the stack or memory.  A zero limit is unlimited.
+type ParseLimits struct {
+	// MaxDepth is the deepest statements may be nested, where top level
+	// statements are at depth 1.
+	MaxDepth int
+	// MaxStatements is the largest number of statements parsed from a
+	// single input.
+	MaxStatements int
+	// MaxArgumentLength is the longest, in bytes, the argument of a
+	// statement may be, after any string concatenation.
+	MaxArgumentLength int
+}
+
+// A StatementHook is called for each statement s parsed by Modules.Parse,
+// before the statements are assembled into modules.  parent is the statement
+// containing s, or nil if s is a top level statement.  Statements are visited
+// in the order they appear in the source, parents before their children.  If
+// the hook returns an error then parsing stops and that error is returned by
+// Modules.Parse, no modules are added.
+type StatementHook func(s, parent *Statement) error
+
+// DuplicatePolicy specifies how a module or submodule that is read more than
+// once is handled.  Unless the policy is DuplicateError, a module read more
+// than once with the same content is silently only added once.  Each conflict
+// between modules with differing content is recorded, see Modules.Conflicts.
+type DuplicatePolicy int
+
+const (
+	// DuplicateError reports any duplicate as an error, the module read
+	// first is kept.  This is the default.
+	DuplicateError DuplicatePolicy = iota
+	// DuplicateFirst keeps the module read first.
+	DuplicateFirst
+	// DuplicateLast replaces the module read first by the module read
+	// last.
+	DuplicateLast
+)
+
+// ScanOptions controls how directories are searched for modules.  The zero
+// value searches every file and subdirectory, and does not follow symbolic
+// links to directories.
+type ScanOptions struct {
+	// Exclude is a list of glob patterns, in the syntax of
+	// filepath.Match, of the files and directories that are not searched.
+	// A pattern is matched against both the base name and the slash
+	// separated path relative to the directory being searched, e.g.,
+	// "testdata" or "vendor/*/old".
+	Exclude []string
+	// MaxDepth, if positive, is the deepest subdirectory searched when
+	// searching subdirectories, where the subdirectories of the directory
+	// being searched are at depth 1.
+	MaxDepth int
+	// FollowSymlinks specifies whether symbolic links to directories are
+	// followed.  Each directory is only searched once, so symbolic link
+	// loops are not followed.
+	FollowSymlinks bool
 }
 
 // DeviateOptions contains options for how deviations are handled.
@@ -24,13 +24,26 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 )
 
 // a parser is used to parse the contents of a single .yang file.
 type parser struct {
-	lex    *lexer
-	errout *bytes.Buffer
-	tokens []*token // stack of pushed tokens (for backing up)
+	lex     *lexer
+	errout  *bytes.Buffer
+	tokens  []*token  // stack of pushed tokens (for backing up)
+	arena   *Arena    // arena allocating statements, or nil
+	strings *interner // interner of the keywords and arguments
+	limits  ParseLimits
+	keep    func(keyword string) bool // statements kept, nil if all are
+	unsafe  bool                      // whether unsafe statements may be dropped
+
+	// dropping is true while parsing the substatements of a statement
+	// that is not kept.
+	dropping bool
+
+	// Number of statements parsed
+	statementCount int
 
 	// Depth of statements in nested braces
 	statementDepth int
@@ -39,8 +52,8 @@ type parser struct {
 	// is updated with the location of the '}'.  The brace may be legitimate
 	// but only the caller will know if it is.  That is, the brace may be
 	// closing our parent or may be an error (we didn't expect it).
-	// hitBrace is updated with the file, line, and column of the brace's
-	// location.
+	// hitBrace is updated with the file, line, column, and offset of the
+	// brace's location.
 	hitBrace *Statement
 }
 
@@ -57,11 +70,32 @@ type Statement struct {
 	Argument    string
 	statements  []*Statement
 
+	rawArgument string // the argument as written in the source
+
 	file string
 	line int // 1's based line number
 	col  int // 1's based column number
+
+	endLine   int // 1's based line number of the closing ';' or '}'
+	endCol    int // 1's based column number of the closing ';' or '}'
+	offset    int // byte offset of the keyword in the source
+	endOffset int // byte offset just past the closing ';' or '}'
+	argOffset int // byte offset of the raw argument in the source
+	argLine   int // 1's based line number of the raw argument
+	argCol    int // 1's based column number of the raw argument
 }
 
+// RawArgument returns the argument of s as it is written in the source,
+// including any quotes and concatenation, before escape sequences are
+// replaced and the whitespace of multi-line strings is trimmed.  For example,
+// the raw argument of
+//
+//	description "a\tb" + 'c';
+//
+// is `"a\tb" + 'c'`.  RawArgument returns "" if s has no argument or was not
+// parsed from source.
+func (s *Statement) RawArgument() string { return s.rawArgument }
+
 func (s *Statement) NName() string         { return s.Argument }
 func (s *Statement) Kind() string          { return s.Keyword }
 func (s *Statement) Statement() *Statement { return s }
@@ -89,6 +123,98 @@ func (s *Statement) Location() string {
 	}
 }
 
+// Position returns the file, and the 1's based line and column, where s was
+// defined.  The line and column are 0 if not known.
+func (s *Statement) Position() (file string, line, col int) {
+	return s.file, s.line, s.col
+}
+
+// A SourceRange is the range of the source text of a statement, or of its
+// keyword or argument, from its first character to its last, inclusive.  The
+// range of a statement ends with its closing ';' or '}'.  Lines and columns
+// are 1's based, Start and End are byte offsets into the source with End
+// just past the last character, so the text is source[Start:End].  The zero
+// SourceRange is returned for statements not parsed from source.
+type SourceRange struct {
+	File                string
+	StartLine, StartCol int
+	EndLine, EndCol     int
+	Start, End          int
+}
+
+// Range returns the range of the source text of s, including its
+// substatements.
+func (s *Statement) Range() SourceRange {
+	return SourceRange{
+		File:      s.file,
+		StartLine: s.line,
+		StartCol:  s.col,
+		EndLine:   s.endLine,
+		EndCol:    s.endCol,
+		Start:     s.offset,
+		End:       s.endOffset,
+	}
+}
+
+// KeywordRange returns the range of the source text of the keyword of s.
+func (s *Statement) KeywordRange() SourceRange {
+	if s.line == 0 {
+		return SourceRange{}
+	}
+	endLine, endCol := endPosition(s.line, s.col, s.Keyword)
+	return SourceRange{
+		File:      s.file,
+		StartLine: s.line,
+		StartCol:  s.col,
+		EndLine:   endLine,
+		EndCol:    endCol,
+		Start:     s.offset,
+		End:       s.offset + len(s.Keyword),
+	}
+}
+
+// ArgumentRange returns the range of the source text of the argument of s,
+// the text returned by RawArgument, including any quotes and concatenation.
+// The zero SourceRange is returned if s has no argument.
+func (s *Statement) ArgumentRange() SourceRange {
+	if s.argLine == 0 {
+		return SourceRange{}
+	}
+	endLine, endCol := endPosition(s.argLine, s.argCol, s.rawArgument)
+	return SourceRange{
+		File:      s.file,
+		StartLine: s.argLine,
+		StartCol:  s.argCol,
+		EndLine:   endLine,
+		EndCol:    endCol,
+		Start:     s.argOffset,
+		End:       s.argOffset + len(s.rawArgument),
+	}
+}
+
+// endPosition returns the line and column of the last character of text,
+// which starts at line and col of the source.
+func endPosition(line, col int, text string) (int, int) {
+	if n := strings.Count(text, "\n"); n > 0 {
+		return line + n, utf8.RuneCountInString(text[strings.LastIndex(text, "\n")+1:])
+	}
+	return line, col + utf8.RuneCountInString(text) - 1
+}
+
+// visitStatements calls hook for s, whose parent is parent, and then for each
+// of its substatements, until hook returns an error.
+func visitStatements(s, parent *Statement, hook StatementHook) error {
+	if err := hook(s, parent); err != nil {
+		return err
+	}
+	for _, ss := range s.statements {
+		if err := visitStatements(ss, s, hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Write writes the tree in s to w, each line indented by ident.  Children
 // nodes are indented further by a tab.  Typically indent is "" at the top
 // level.  Write is intended to display the contents of Statement, but
@@ -110,7 +236,8 @@ func (s *Statement) Write(w io.Writer, indent string) error {
 		if len(args) == 1 {
 			parts = append(parts, fmt.Sprintf(" %q", s.Argument))
 		} else {
-			parts = append(parts, ` "`, args[0], "\n")
+			first := fmt.Sprintf("%q", args[0])
+			parts = append(parts, ` `, first[:len(first)-1], "\n")
 			i := fmt.Sprintf("%*s", len(s.Keyword)+1, "")
 			for x, p := range args[1:] {
 				s := fmt.Sprintf("%q", p)
@@ -153,32 +280,101 @@ var ignoreMe = &Statement{}
 // the file name the input was read from).  If one more more errors are
 // encountered, nil and an error are returned.  The error's text includes all
 // errors encountered.
+//
+// The input must be encoded as UTF-8, or as UTF-16 starting with a byte order
+// mark.  A leading byte order mark is ignored, and CRLF line endings are
+// treated as LF, so they do not appear in arguments.
 func Parse(input, path string) ([]*Statement, error) {
+	return parse(input, path, nil, newInterner())
+}
+
+// ParseStatement parses text, the source of a single statement and its
+// substatements such as a deviation or a leaf, and returns the statement.
+// Unlike Parse, which parses whole modules, it is intended for tools that
+// work with fragments of YANG, e.g., an editor inserting a statement into a
+// module.  The statement is not checked against the grammar of any parent
+// statement.  An error is returned if text is not valid YANG syntax or does
+// not contain exactly one statement.
+func ParseStatement(text string) (*Statement, error) {
+	ss, err := Parse(text, "")
+	if err != nil {
+		return nil, err
+	}
+	switch len(ss) {
+	case 0:
+		return nil, errors.New("no statement found")
+	case 1:
+		return ss[0], nil
+	}
+	return nil, codeErrorf("GY0005", "%s: unexpected statement %s after %s", ss[1].Location(), ss[1].Keyword, ss[0].Keyword)
+}
+
+// ParseBytes parses data as Parse does, returning an error if data exceeds
+// limits.  It is intended for parsing untrusted input.
+func ParseBytes(data []byte, path string, limits ParseLimits) ([]*Statement, error) {
+	return parse(string(data), path, &Options{Limits: limits}, newInterner())
+}
+
+// parse parses input as Parse does, using the Arena, Limits and StrictStrings
+// of opts, if not nil, and interning keywords and arguments with in.
+func parse(input, path string, opts *Options, in *interner) ([]*Statement, error) {
+	input, err := decodeSource(input, path)
+	if err != nil {
+		return nil, err
+	}
+	return parseDecoded(input, path, opts, in)
+}
+
+// parseDecoded parses input, already decoded by decodeSource, as parse does.
+func parseDecoded(input, path string, opts *Options, in *interner) ([]*Statement, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
 	var statements []*Statement
 	p := &parser{
 		lex:      newLexer(input, path),
 		errout:   &bytes.Buffer{},
+		arena:    opts.Arena,
+		strings:  in,
+		limits:   opts.Limits,
+		keep:     opts.StatementOptions.keeper(),
+		unsafe:   opts.StatementOptions.AllowUnsafe,
 		hitBrace: &Statement{},
 	}
 	p.lex.errout = p.errout
+	p.lex.strict = opts.StrictStrings
 Loop:
 	for {
 		switch ns := p.nextStatement(); ns {
 		case nil:
 			break Loop
 		case p.hitBrace:
-			fmt.Fprintf(p.errout, "%s:%d:%d: unexpected %c\n", ns.file, ns.line, ns.col, '}')
+			p.errorf("GY0003", "%s:%d:%d: unexpected %c\n", ns.file, ns.line, ns.col, '}')
 		default:
-			statements = append(statements, ns)
+			if p.kept(ns) {
+				statements = append(statements, ns)
+			}
 		}
 	}
 
 	p.checkStatementDepthIsZero()
 
 	if p.errout.Len() == 0 {
+		for _, s := range statements {
+			if opts.StatementOptions.latestRevisionOnly(s.Argument) {
+				trimRevisions(s)
+			}
+		}
 		return statements, nil
 	}
-	return nil, errors.New(strings.TrimSpace(p.errout.String()))
+	return nil, withCode(p.lex.code, errors.New(strings.TrimSpace(p.errout.String())))
+}
+
+// errorf writes the error of the class with code, formatted as by
+// fmt.Printf, to p.errout.
+func (p *parser) errorf(code ErrorCode, format string, v ...interface{}) {
+	p.lex.noteError(code)
+	fmt.Fprintf(p.errout, format, v...)
 }
 
 // push pushes tokens t back on the input stream so they will be the next
@@ -240,8 +436,16 @@ func (p *parser) next() *token {
 			p.push(nt)
 			return t
 		case tString:
-			// Accumulate the concatenation.
+			// Accumulate the concatenation, stopping once the
+			// argument is too long.  nextStatement reports the
+			// error.
 			t.Text += nnt.Text
+			if end := nnt.offset + len(nnt.raw); end <= len(p.lex.input) {
+				t.raw = p.lex.input[t.offset:end]
+			}
+			if max := p.limits.MaxArgumentLength; max > 0 && len(t.Text) > max {
+				return t
+			}
 		default:
 			p.push(nnt, nt)
 			return t
@@ -264,19 +468,41 @@ func (p *parser) nextStatement() *Statement {
 		p.hitBrace.file = t.File
 		p.hitBrace.line = t.Line
 		p.hitBrace.col = t.Col
+		p.hitBrace.offset = t.offset
 		return p.hitBrace
 	case tUnquoted:
 	default:
-		fmt.Fprintf(p.errout, "%v: keyword token not an unquoted string\n", t)
+		p.errorf("GY0004", "%v: keyword token not an unquoted string\n", t)
 		return ignoreMe
 	}
 	// Invariant: t represents a keyword token.
 
-	s := &Statement{
-		Keyword: t.Text,
-		file:    t.File,
-		line:    t.Line,
-		col:     t.Col,
+	p.statementCount++
+	if max := p.limits.MaxStatements; max > 0 && p.statementCount > max {
+		p.errorf("GY0008", "%s:%d:%d: more than %d statements\n", t.File, t.Line, t.Col, max)
+		return nil
+	}
+	// The statement is nested one deeper than the braces enclosing it.
+	if max := p.limits.MaxDepth; max > 0 && p.statementDepth >= max {
+		p.errorf("GY0008", "%s:%d:%d: statements nested more than %d deep\n", t.File, t.Line, t.Col, max)
+		return nil
+	}
+
+	s := p.arena.newStatement()
+	s.Keyword = p.strings.intern(t.Text)
+	s.file = t.File
+	s.line = t.Line
+	s.col = t.Col
+	s.offset = t.offset
+
+	// The substatements of a statement that is not kept are dropped with
+	// it, so only the statement itself is checked.
+	if !p.dropping && p.keep != nil && !p.keep(s.Keyword) {
+		if why, ok := unsafeStatements[s.Keyword]; ok && !p.unsafe {
+			p.errorf("GY0010", "%s: dropping the %s statement makes the schema invalid, %s\n", s.Location(), s.Keyword, why)
+		}
+		p.dropping = true
+		defer func() { p.dropping = false }()
 	}
 
 	// The keyword "pattern" must be treated specially. When
@@ -287,16 +513,24 @@ func (p *parser) nextStatement() *Statement {
 	p.lex.inPattern = false
 	switch t.Code() {
 	case tString, tUnquoted:
+		if max := p.limits.MaxArgumentLength; max > 0 && len(t.Text) > max {
+			p.errorf("GY0008", "%s: argument of %s longer than %d bytes\n", s.Location(), s.Keyword, max)
+			return nil
+		}
 		s.HasArgument = true
-		s.Argument = t.Text
+		s.Argument = p.strings.intern(t.Text)
+		s.rawArgument = t.raw
+		s.argOffset = t.offset
+		s.argLine, s.argCol = t.Line, t.Col
 		t = p.next()
 	}
 
 	switch t.Code() {
 	case tEOF:
-		fmt.Fprintf(p.errout, "%s: unexpected EOF\n", s.file)
+		p.errorf("GY0003", "%s: unexpected EOF\n", s.file)
 		return nil
 	case ';':
+		s.endLine, s.endCol, s.endOffset = t.Line, t.Col, t.offset+1
 		return s
 	case '{':
 		p.statementDepth += 1
@@ -306,17 +540,46 @@ func (p *parser) nextStatement() *Statement {
 				// Signal EOF reached.
 				return nil
 			case p.hitBrace:
+				s.endLine, s.endCol, s.endOffset = p.hitBrace.line, p.hitBrace.col, p.hitBrace.offset+1
 				return s
 			default:
-				s.statements = append(s.statements, ns)
+				if p.kept(ns) {
+					s.statements = append(s.statements, ns)
+				}
 			}
 		}
 	default:
-		fmt.Fprintf(p.errout, "%v: syntax error, expected ';' or '{'\n", t)
+		p.errorf("GY0004", "%v: syntax error, expected ';' or '{'\n", t)
 		return ignoreMe
 	}
 }
 
+// kept returns true if the statement s is kept by the StatementOptions.
+func (p *parser) kept(s *Statement) bool {
+	return p.keep == nil || s == ignoreMe || p.keep(s.Keyword)
+}
+
+// trimRevisions removes all but the most recent revision substatement of the
+// module or submodule statement s.
+func trimRevisions(s *Statement) {
+	if s.Keyword != "module" && s.Keyword != "submodule" {
+		return
+	}
+	var latest *Statement
+	for _, ss := range s.statements {
+		if ss.Keyword == "revision" && (latest == nil || ss.Argument > latest.Argument) {
+			latest = ss
+		}
+	}
+	kept := s.statements[:0]
+	for _, ss := range s.statements {
+		if ss.Keyword != "revision" || ss == latest {
+			kept = append(kept, ss)
+		}
+	}
+	s.statements = kept
+}
+
 // checkStatementDepthIsZero checks that we aren't missing closing
 // braces. Note: the parser will error out for the case where we
 // start with an unmatched close brace, i.e. depth < 0
@@ -333,6 +596,6 @@ func (p *parser) checkStatementDepthIsZero() {
 	if p.statementDepth > 1 {
 		plural = "s"
 	}
-	fmt.Fprintf(p.errout, "%s:%d:%d: missing %d closing brace%s\n",
+	p.errorf("GY0003", "%s:%d:%d: missing %d closing brace%s\n",
 		p.lex.file, p.lex.line, p.lex.col, p.statementDepth, plural)
 }
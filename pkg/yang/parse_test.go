@@ -16,7 +16,11 @@ package yang
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
 )
 
 func (s1 *Statement) equal(s2 *Statement) bool {
@@ -510,6 +514,16 @@ module base {
 	}
 	uses "base-group";
 }
+`,
+		},
+		{line: line(),
+			in: `description
+  "Says \"hello\":
+     - indented
+   back";`,
+			out: `description "Says \"hello\":
+               - indented
+             back";
 `,
 		},
 	} {
@@ -537,3 +551,465 @@ module base {
 		}
 	}
 }
+
+func TestParseLimits(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		in     string
+		limits ParseLimits
+		err    string
+	}{{
+		desc:   "within limits",
+		in:     `a { b { c "abc" + "def"; } d; }`,
+		limits: ParseLimits{MaxDepth: 3, MaxStatements: 4, MaxArgumentLength: 6},
+	}, {
+		desc:   "too deep",
+		in:     `a { b { c { d; } } }`,
+		limits: ParseLimits{MaxDepth: 3},
+		err:    `limits.yang:1:13: statements nested more than 3 deep`,
+	}, {
+		desc:   "unbounded nesting stops at the limit",
+		in:     strings.Repeat("a {", 1000000),
+		limits: ParseLimits{MaxDepth: 100},
+		err:    `limits.yang:1:301: statements nested more than 100 deep`,
+	}, {
+		desc:   "too many statements",
+		in:     `a; b; c;`,
+		limits: ParseLimits{MaxStatements: 2},
+		err:    `limits.yang:1:7: more than 2 statements`,
+	}, {
+		desc:   "argument too long",
+		in:     `a { b "1234567"; }`,
+		limits: ParseLimits{MaxArgumentLength: 6},
+		err:    `limits.yang:1:5: argument of b longer than 6 bytes`,
+	}, {
+		desc:   "concatenated argument too long",
+		in:     `a "123" + "456" + "7" + "8";`,
+		limits: ParseLimits{MaxArgumentLength: 6},
+		err:    `limits.yang:1:1: argument of a longer than 6 bytes`,
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := ParseBytes([]byte(tt.in), "limits.yang", tt.limits)
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.err {
+				t.Errorf("got error %q, want %q", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestStatementOptions(t *testing.T) {
+	const src = `module a {
+	prefix a;
+	namespace "urn:a";
+	description "module a";
+	container c {
+		description "container c";
+		reference "RFC 0";
+		leaf l {
+			type string;
+			description "leaf l";
+		}
+	}
+}`
+	for _, tt := range []struct {
+		desc string
+		opts StatementOptions
+		want string
+	}{{
+		desc: "all statements",
+		want: "module a [prefix namespace description container c [description reference leaf l [type description]]]",
+	}, {
+		desc: "exclude",
+		opts: StatementOptions{ExcludeStatements: []string{"description", "reference"}},
+		want: "module a [prefix namespace container c [leaf l [type]]]",
+	}, {
+		desc: "include only",
+		opts: StatementOptions{IncludeOnlyStatements: []string{"prefix", "namespace", "container", "leaf", "type"}},
+		want: "module a [prefix namespace container c [leaf l [type]]]",
+	}, {
+		desc: "include only and exclude",
+		opts: StatementOptions{
+			IncludeOnlyStatements: []string{"prefix", "namespace", "container", "leaf", "type"},
+			ExcludeStatements:     []string{"leaf"},
+		},
+		want: "module a [prefix namespace container c]",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.StatementOptions = tt.opts
+			if err := ms.Parse(src, "a.yang"); err != nil {
+				t.Fatalf("Parse() got error: %v", err)
+			}
+			var outline func(s *Statement) string
+			outline = func(s *Statement) string {
+				out := s.Keyword
+				if s.Keyword == "module" || s.Keyword == "container" || s.Keyword == "leaf" {
+					out += " " + s.Argument
+				}
+				if len(s.statements) > 0 {
+					var subs []string
+					for _, ss := range s.statements {
+						subs = append(subs, outline(ss))
+					}
+					out += " [" + strings.Join(subs, " ") + "]"
+				}
+				return out
+			}
+			if got := outline(ms.Modules["a"].Source); got != tt.want {
+				t.Errorf("got statements %s, want %s", got, tt.want)
+			}
+			if errs := ms.Process(); len(errs) > 0 {
+				t.Errorf("Process() got errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestStatementOptionsUnsafe(t *testing.T) {
+	const src = `module a {
+	prefix a;
+	namespace "urn:a";
+	list l {
+		key k;
+		leaf k { type string; }
+	}
+	container c {
+		description "the key of c is not a statement";
+	}
+}`
+	for _, tt := range []struct {
+		desc string
+		opts StatementOptions
+		err  string
+	}{{
+		desc: "exclude key",
+		opts: StatementOptions{ExcludeStatements: []string{"key"}},
+		err:  "a.yang:5:3: dropping the key statement makes the schema invalid, the list has no keys",
+	}, {
+		desc: "include only without type",
+		opts: StatementOptions{IncludeOnlyStatements: []string{"prefix", "namespace", "list", "key", "leaf"}},
+		err:  "a.yang:6:12: dropping the type statement makes the schema invalid, the leaf, leaf-list or typedef has no type",
+	}, {
+		desc: "unsafe statements within a dropped statement",
+		opts: StatementOptions{ExcludeStatements: []string{"list"}},
+	}, {
+		desc: "allow unsafe",
+		opts: StatementOptions{ExcludeStatements: []string{"key"}, AllowUnsafe: true},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.StatementOptions = tt.opts
+			var got string
+			if err := ms.Parse(src, "a.yang"); err != nil {
+				got = err.Error()
+			}
+			if got != tt.err {
+				t.Errorf("Parse() got error %q, want %q", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestLatestRevisionOnly(t *testing.T) {
+	srcs := map[string]string{
+		"a.yang": `module a {
+	prefix a;
+	namespace "urn:a";
+	revision 2020-01-01;
+	revision 2022-01-01;
+	revision 2021-01-01;
+}`,
+		"b.yang": `module b {
+	prefix b;
+	namespace "urn:b";
+	revision 2021-01-01;
+	revision 2020-01-01;
+}`,
+	}
+	for _, tt := range []struct {
+		desc string
+		opts StatementOptions
+		want map[string][]string
+	}{{
+		desc: "full history",
+		want: map[string][]string{
+			"a": {"2020-01-01", "2022-01-01", "2021-01-01"},
+			"b": {"2021-01-01", "2020-01-01"},
+		},
+	}, {
+		desc: "latest revision only",
+		opts: StatementOptions{LatestRevisionOnly: true},
+		want: map[string][]string{
+			"a": {"2022-01-01"},
+			"b": {"2021-01-01"},
+		},
+	}, {
+		desc: "full history of one module",
+		opts: StatementOptions{
+			LatestRevisionOnly:          true,
+			LatestRevisionOnlyOverrides: map[string]bool{"a": false},
+		},
+		want: map[string][]string{
+			"a": {"2020-01-01", "2022-01-01", "2021-01-01"},
+			"b": {"2021-01-01"},
+		},
+	}, {
+		desc: "latest revision of one module",
+		opts: StatementOptions{LatestRevisionOnlyOverrides: map[string]bool{"b": true}},
+		want: map[string][]string{
+			"a": {"2020-01-01", "2022-01-01", "2021-01-01"},
+			"b": {"2021-01-01"},
+		},
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.StatementOptions = tt.opts
+			for name, src := range srcs {
+				if err := ms.Parse(src, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			got := map[string][]string{}
+			for _, m := range ms.SortedModules() {
+				for _, r := range m.Revision {
+					got[m.Name] = append(got[m.Name], r.Name)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("revisions (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func FuzzParseBytes(f *testing.F) {
+	for _, s := range []string{
+		`module m { prefix m; namespace "urn:m"; leaf l { type string; } }`,
+		`a "b" + 'c' { d; /* comment */ e f; } // comment`,
+		`pattern '\d+\s*[a-z]'; description "tab\tnewline\n";`,
+		`a { b { c`,
+		`} "unterminated`,
+		`foo;`,
+	} {
+		f.Add([]byte(s))
+	}
+	limits := ParseLimits{MaxDepth: 64, MaxStatements: 10000, MaxArgumentLength: 1 << 16}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Any input must either be processed or return errors, and must
+		// not panic.
+		if _, err := ParseBytes(data, "fuzz.yang", limits); err != nil {
+			return
+		}
+		ms := NewModules()
+		ms.ParseOptions.Limits = limits
+		if err := ms.Parse(string(data), "fuzz.yang"); err != nil {
+			return
+		}
+		ms.Process()
+	})
+}
+
+func TestRawArgument(t *testing.T) {
+	ss, err := Parse(`a "x\ty" + 'z' {
+	b c;
+	d "multi
+	   line";
+	e;
+}`, "raw.yang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := ss[0]
+	for _, tt := range []struct {
+		s       *Statement
+		wantArg string
+		wantRaw string
+	}{
+		{a, "x\tyz", `"x\ty" + 'z'`},
+		{a.statements[0], "c", "c"},
+		{a.statements[1], "multi\nline", "\"multi\n\t   line\""},
+		{a.statements[2], "", ""},
+	} {
+		if tt.s.Argument != tt.wantArg || tt.s.RawArgument() != tt.wantRaw {
+			t.Errorf("%s: got argument %q and raw argument %q, want %q and %q", tt.s.Keyword, tt.s.Argument, tt.s.RawArgument(), tt.wantArg, tt.wantRaw)
+		}
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		in      string
+		want    *Statement
+		wantErr string
+	}{{
+		desc: "statement with substatements",
+		in: `deviation /a:b {
+	deviate not-supported;
+}`,
+		want: SA("deviation", "/a:b",
+			SA("deviate", "not-supported"),
+		),
+	}, {
+		desc: "statement with comments",
+		in:   "// the leaf\nleaf c { type string; }\n",
+		want: SA("leaf", "c",
+			SA("type", "string"),
+		),
+	}, {
+		desc:    "empty",
+		in:      "  // nothing\n",
+		wantErr: "no statement found",
+	}, {
+		desc:    "two statements",
+		in:      "leaf c;\nleaf d;",
+		wantErr: "line 2:1: unexpected statement leaf after leaf",
+	}, {
+		desc:    "syntax error",
+		in:      "leaf c {",
+		wantErr: "missing 1 closing brace",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseStatement(tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if err != nil {
+				return
+			}
+			if !got.equal(tt.want) {
+				var g, w bytes.Buffer
+				got.Write(&g, "")
+				tt.want.Write(&w, "")
+				t.Errorf("got:\n%s\nwant:\n%s", &g, &w)
+			}
+		})
+	}
+}
+
+func TestStatementRange(t *testing.T) {
+	in := `a x {
+	b c;
+	d {
+		e;
+	}
+}`
+	ss, err := Parse(in, "range.yang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := ss[0]
+	for _, tt := range []struct {
+		s        *Statement
+		want     SourceRange
+		wantText string
+	}{
+		{a, SourceRange{File: "range.yang", StartLine: 1, StartCol: 1, EndLine: 6, EndCol: 1, Start: 0, End: 26}, in},
+		{a.statements[0], SourceRange{File: "range.yang", StartLine: 2, StartCol: 2, EndLine: 2, EndCol: 5, Start: 7, End: 11}, "b c;"},
+		{a.statements[1], SourceRange{File: "range.yang", StartLine: 3, StartCol: 2, EndLine: 5, EndCol: 2, Start: 13, End: 24}, "d {\n\t\te;\n\t}"},
+		{&Statement{Keyword: "f"}, SourceRange{}, ""},
+	} {
+		got := tt.s.Range()
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: Range() (-want, +got):\n%s", tt.s.Keyword, diff)
+		}
+		if text := in[got.Start:got.End]; text != tt.wantText {
+			t.Errorf("%s: source text got %q, want %q", tt.s.Keyword, text, tt.wantText)
+		}
+	}
+}
+
+func TestKeywordArgumentRange(t *testing.T) {
+	in := `a x {
+	description "one
+	  two" + 'three';
+	e;
+}`
+	ss, err := Parse(in, "range.yang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := ss[0]
+	for _, tt := range []struct {
+		s           *Statement
+		wantKeyword SourceRange
+		wantArg     SourceRange
+		wantArgText string
+	}{
+		{
+			a,
+			SourceRange{File: "range.yang", StartLine: 1, StartCol: 1, EndLine: 1, EndCol: 1, Start: 0, End: 1},
+			SourceRange{File: "range.yang", StartLine: 1, StartCol: 3, EndLine: 1, EndCol: 3, Start: 2, End: 3},
+			"x",
+		},
+		{
+			a.statements[0],
+			SourceRange{File: "range.yang", StartLine: 2, StartCol: 2, EndLine: 2, EndCol: 12, Start: 7, End: 18},
+			SourceRange{File: "range.yang", StartLine: 2, StartCol: 14, EndLine: 3, EndCol: 17, Start: 19, End: 41},
+			"\"one\n\t  two\" + 'three'",
+		},
+		{
+			a.statements[1],
+			SourceRange{File: "range.yang", StartLine: 4, StartCol: 2, EndLine: 4, EndCol: 2, Start: 44, End: 45},
+			SourceRange{},
+			"",
+		},
+		{&Statement{Keyword: "f"}, SourceRange{}, SourceRange{}, ""},
+	} {
+		if diff := cmp.Diff(tt.wantKeyword, tt.s.KeywordRange()); diff != "" {
+			t.Errorf("%s: KeywordRange() (-want, +got):\n%s", tt.s.Keyword, diff)
+		}
+		got := tt.s.ArgumentRange()
+		if diff := cmp.Diff(tt.wantArg, got); diff != "" {
+			t.Errorf("%s: ArgumentRange() (-want, +got):\n%s", tt.s.Keyword, diff)
+		}
+		if text := in[got.Start:got.End]; text != tt.wantArgText {
+			t.Errorf("%s: argument source text got %q, want %q", tt.s.Keyword, text, tt.wantArgText)
+		}
+	}
+}
+
+func TestStrictStrings(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		in        string
+		strictErr string
+		strictArg string
+	}{{
+		desc: "conformant strings",
+		in:   "pattern '\\d+';\ndescription \"a\\n\\t\\\"\\\\\";\npath /a/b;\n",
+	}, {
+		desc:      "regular expression escape in a double quoted pattern",
+		in:        `pattern "\d+";`,
+		strictErr: `strict.yang:1:10: invalid escape sequence: \d, use a single quoted string for the pattern`,
+	}, {
+		desc:      "comment sequence in an unquoted string",
+		in:        `a b//c;`,
+		strictErr: `strict.yang:1:4: comment sequence // in unquoted string`,
+	}, {
+		desc:      "tab crossing the indentation of a multi-line string",
+		in:        "a   \"x\n\ty\";",
+		strictArg: "x\n   y",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			if _, err := Parse(tt.in, "strict.yang"); err != nil {
+				t.Errorf("Parse() got error %v, want none", err)
+			}
+			ss, err := parse(tt.in, "strict.yang", &Options{StrictStrings: true}, newInterner())
+			var got string
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.strictErr {
+				t.Errorf("strict parse got error %q, want %q", got, tt.strictErr)
+			}
+			if tt.strictArg != "" && (err != nil || ss[0].Argument != tt.strictArg) {
+				t.Errorf("strict parse got argument %v, want %q", ss, tt.strictArg)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import "strings"
+
+// A PathQualifier selects how the names of a path are qualified.
+type PathQualifier int
+
+const (
+	// BareNames are not qualified, e.g., "/interfaces/interface".
+	BareNames PathQualifier = iota
+	// ModuleNames are qualified by the name of the module defining the
+	// node, as in RFC 7951 JSON and gNMI, e.g.,
+	// "/openconfig-interfaces:interfaces/interface".
+	ModuleNames
+	// PrefixNames are qualified by the prefix of the module defining the
+	// node, as in XPath, e.g., "/oc-if:interfaces/interface".
+	PrefixNames
+)
+
+// PathOpts selects how Entry.PathString writes the path of an entry.  The
+// zero value writes the data path, the names of the data nodes from the top
+// of the module, e.g., "/interfaces/interface/config".
+type PathOpts struct {
+	// Qualifier selects how names are qualified.
+	Qualifier PathQualifier
+	// QualifyAll, if set, qualifies every name.  By default only the
+	// first name, and each name defined by a different module than the
+	// name before it, is qualified, as in RFC 7951 JSON.
+	QualifyAll bool
+	// Module starts the path with the name of the module entry, e.g.,
+	// "/openconfig-interfaces/interfaces".
+	Module bool
+	// ChoiceCase includes the choice and case entries, which are not
+	// data nodes.
+	ChoiceCase bool
+	// OmitInputOutput omits the input and output entries of RPCs and
+	// actions, so the path of a parameter is that of the RPC followed by
+	// its name.
+	OmitInputOutput bool
+}
+
+// PathString returns the path of e as selected by opts.  The path of a
+// module entry is "/", or the name of the module if opts.Module is set.
+// Entry.Path is
+//
+//	e.PathString(PathOpts{Module: true, ChoiceCase: true})
+//
+// and the path of e in data is
+//
+//	e.PathString(PathOpts{Qualifier: ModuleNames})
+func (e *Entry) PathString(opts PathOpts) string {
+	var names []string
+	var child *Entry
+	for c := e; c != nil && c.Parent != nil; c = c.Parent {
+		switch {
+		case !opts.ChoiceCase && (c.IsChoice() || c.IsCase()):
+			continue
+		case opts.OmitInputOutput && (c.Kind == InputEntry || c.Kind == OutputEntry):
+			continue
+		}
+		if child != nil && opts.qualify(child, c) {
+			names[0] = opts.qualified(child)
+		}
+		names = append([]string{c.Name}, names...)
+		child = c
+	}
+	if child != nil && opts.Qualifier != BareNames {
+		names[0] = opts.qualified(child)
+	}
+	if opts.Module {
+		if r := e.Root(); r != nil {
+			names = append([]string{r.Name}, names...)
+		}
+	}
+	return "/" + strings.Join(names, "/")
+}
+
+// qualify returns true if opts qualifies the name of e, whose parent in the
+// path is parent.
+func (opts PathOpts) qualify(e, parent *Entry) bool {
+	switch {
+	case opts.Qualifier == BareNames:
+		return false
+	case opts.QualifyAll:
+		return true
+	}
+	return !sameNamespace(e, parent)
+}
+
+// qualified returns the name of e qualified by the name, or prefix, of the
+// module defining it, or just its name if that module cannot be found.
+func (opts PathOpts) qualified(e *Entry) string {
+	ns := e.Namespace()
+	root, ok := e.Root().Node.(*Module)
+	if ns == nil || !ok || root.Modules == nil {
+		return e.Name
+	}
+	m, err := root.Modules.FindModuleByNamespace(ns.Name)
+	if err != nil {
+		return e.Name
+	}
+	if opts.Qualifier == PrefixNames {
+		return m.GetPrefix() + ":" + e.Name
+	}
+	return m.Name + ":" + e.Name
+}
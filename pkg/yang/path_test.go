@@ -0,0 +1,79 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import "testing"
+
+func TestPathString(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"base.yang": `module base { prefix b; namespace "urn:b";
+			container top {
+				choice ch { case one { leaf x { type string; } } }
+			}
+			rpc reset { input { leaf force { type boolean; } } }
+		}`,
+		"aug.yang": `module aug { prefix a; namespace "urn:a"; import base { prefix b; }
+			augment "/b:top" { container extra { leaf y { type string; } } }
+		}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	base, errs := ms.GetModule("base")
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	x := base.Find("top/ch/one/x")
+	y := base.Find("top/extra/y")
+	force := base.Find("reset/input/force")
+	for _, e := range []*Entry{x, y, force} {
+		if e == nil {
+			t.Fatal("cannot find entries")
+		}
+	}
+
+	tests := []struct {
+		desc string
+		e    *Entry
+		opts PathOpts
+		want string
+	}{
+		{"data path", x, PathOpts{}, "/top/x"},
+		{"module entry", base, PathOpts{}, "/"},
+		{"module entry with module", base, PathOpts{Module: true}, "/base"},
+		{"Path", x, PathOpts{Module: true, ChoiceCase: true}, "/base/top/ch/one/x"},
+		{"choice and case", x, PathOpts{ChoiceCase: true}, "/top/ch/one/x"},
+		{"module names", x, PathOpts{Qualifier: ModuleNames}, "/base:top/x"},
+		{"module names across modules", y, PathOpts{Qualifier: ModuleNames}, "/base:top/aug:extra/y"},
+		{"prefix names across modules", y, PathOpts{Qualifier: PrefixNames}, "/b:top/a:extra/y"},
+		{"qualify all", y, PathOpts{Qualifier: PrefixNames, QualifyAll: true}, "/b:top/a:extra/a:y"},
+		{"qualify all with choice", x, PathOpts{Qualifier: ModuleNames, QualifyAll: true, ChoiceCase: true}, "/base:top/base:ch/base:one/base:x"},
+		{"input", force, PathOpts{}, "/reset/input/force"},
+		{"omit input", force, PathOpts{OmitInputOutput: true}, "/reset/force"},
+	}
+	for _, tt := range tests {
+		if got := tt.e.PathString(tt.opts); got != tt.want {
+			t.Errorf("%s: PathString(%+v) = %q, want %q", tt.desc, tt.opts, got, tt.want)
+		}
+	}
+	if got, want := x.Path(), "/base/top/ch/one/x"; got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Phase is one of the phases of reading and processing modules, as timed
+// by a PhaseTimer.
+type Phase int
+
+const (
+	// ScanPhase finds and reads the file of a module.
+	ScanPhase Phase = iota
+	// ParsePhase parses the source of a file and builds its modules.
+	ParsePhase
+	// ResolvePhase resolves the includes, imports, identities and typedefs
+	// of the modules, reading the modules they refer to.
+	ResolvePhase
+	// ToEntryPhase builds the entries of the modules, expanding their
+	// groupings, augments and choices.
+	ToEntryPhase
+	// DeviationPhase applies the deviations of the modules.
+	DeviationPhase
+	// FinishPhase resolves leafref targets and drops and compacts what the
+	// options specify.
+	FinishPhase
+)
+
+var phaseNames = []string{
+	ScanPhase:      "scan",
+	ParsePhase:     "parse",
+	ResolvePhase:   "resolve",
+	ToEntryPhase:   "to-entry",
+	DeviationPhase: "deviations",
+	FinishPhase:    "finish",
+}
+
+// Phases returns all the phases, in the order they run.
+func Phases() []Phase {
+	return []Phase{ScanPhase, ParsePhase, ResolvePhase, ToEntryPhase, DeviationPhase, FinishPhase}
+}
+
+// String returns the name of p, e.g., "to-entry".
+func (p Phase) String() string {
+	if p >= 0 && int(p) < len(phaseNames) {
+		return phaseNames[p]
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// A PhaseTimer is called, see Options.PhaseTimer, with the time d spent in
+// each run of phase p.  Modules.Read and Modules.Parse time the ScanPhase and
+// ParsePhase of the file name, Modules.Process times the other phases with an
+// empty name.  The modules found while resolving imports and includes are
+// scanned and parsed during the ResolvePhase, so those times are also
+// included in the time of the ResolvePhase.
+type PhaseTimer func(p Phase, name string, d time.Duration)
+
+// timePhase calls the phase timer of ms, if any, with the time since start.
+func (ms *Modules) timePhase(p Phase, name string, start time.Time) {
+	if t := ms.ParseOptions.PhaseTimer; t != nil {
+		t(p, name, time.Since(start))
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPhaseTimer(t *testing.T) {
+	dir := t.TempDir()
+	for name, mod := range map[string]string{
+		"a.yang": `module a { prefix a; namespace "urn:a"; import b { prefix b; } leaf x { type string; } }`,
+		"b.yang": `module b { prefix b; namespace "urn:b"; }`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(mod), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type run struct {
+		Phase Phase
+		Name  string
+	}
+	var got []run
+	ms := NewModules()
+	ms.AddPath(dir)
+	ms.ParseOptions.PhaseTimer = func(p Phase, name string, d time.Duration) {
+		if d < 0 {
+			t.Errorf("%s %s: negative duration %v", p, name, d)
+		}
+		got = append(got, run{p, filepath.Base(name)})
+	}
+	if err := ms.Read("a"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	want := []run{
+		{ScanPhase, "a.yang"},
+		{ParsePhase, "a.yang"},
+		{ScanPhase, "b.yang"},
+		{ParsePhase, "b.yang"},
+		{ResolvePhase, "."},
+		{ToEntryPhase, "."},
+		{DeviationPhase, "."},
+		{FinishPhase, "."},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("phases (-want, +got):\n%s", diff)
+	}
+}
+
+func TestPhaseString(t *testing.T) {
+	var got []string
+	for _, p := range append(Phases(), Phase(42)) {
+		got = append(got, p.String())
+	}
+	want := []string{"scan", "parse", "resolve", "to-entry", "deviations", "finish", "Phase(42)"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Phase.String (-want, +got):\n%s", diff)
+	}
+}
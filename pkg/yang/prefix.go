@@ -0,0 +1,77 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// PrefixMap returns the modules of the prefixes in effect at n, the prefix of
+// the module, or submodule, n is defined in and the prefixes of its imports,
+// as they are resolved by FindModuleByPrefix.  The module's own prefix maps
+// to the module or submodule itself.  When prefixes conflict, see
+// CheckPrefixes, the mapping FindModuleByPrefix uses is returned: the
+// module's own prefix, then the first import with the prefix.  An import
+// whose module cannot be found maps to nil.
+//
+// PrefixMap is used to resolve the prefixes of extension keywords and of
+// path and XPath strings found within n.
+func PrefixMap(n Node) map[string]*Module {
+	if n == nil {
+		return nil
+	}
+	mod := RootNode(n)
+	if mod == nil {
+		return nil
+	}
+	pm := map[string]*Module{}
+	for _, i := range mod.Import {
+		if i.Prefix == nil {
+			continue
+		}
+		if _, ok := pm[i.Prefix.Name]; ok {
+			continue
+		}
+		im := i.Module
+		if im == nil && mod.Modules != nil {
+			im = mod.Modules.FindModule(i)
+		}
+		pm[i.Prefix.Name] = im
+	}
+	if p := mod.GetPrefix(); p != "" {
+		pm[p] = mod
+	}
+	return pm
+}
+
+// CheckPrefixes returns an error for each import of s whose prefix is the
+// prefix of s itself, which shadows it, or the prefix of an earlier import,
+// as the prefixes of a module must be unique (RFC 7950 section 7.1.4).
+func (s *Module) CheckPrefixes() []error {
+	var errs []error
+	own := s.GetPrefix()
+	seen := map[string]*Import{}
+	for _, i := range s.Import {
+		if i.Prefix == nil {
+			continue
+		}
+		p := i.Prefix.Name
+		switch prev := seen[p]; {
+		case p == own:
+			errs = append(errs, codeErrorf("GY0111", "%s: prefix %q of import %s conflicts with the prefix of %s %s", Source(i.Prefix), p, i.Name, s.Kind(), s.Name))
+		case prev != nil:
+			errs = append(errs, codeErrorf("GY0111", "%s: prefix %q of import %s conflicts with the prefix of import %s at %s", Source(i.Prefix), p, i.Name, prev.Name, Source(prev)))
+		default:
+			seen[p] = i
+		}
+	}
+	return errs
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckPrefixes(t *testing.T) {
+	tests := []struct {
+		name      string
+		inModules map[string]string
+		wantErrs  []string
+	}{{
+		name: "unique prefixes",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix b; } import c { prefix c; } }`,
+		},
+	}, {
+		name: "import shadows own prefix",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix a; } }`,
+		},
+		wantErrs: []string{
+			`a:1:52: prefix "a" of import b conflicts with the prefix of module a`,
+		},
+	}, {
+		name: "imports share a prefix",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; import b { prefix x; } import c { prefix x; } }`,
+		},
+		wantErrs: []string{
+			`a:1:75: prefix "x" of import c conflicts with the prefix of import b at a:1:41`,
+		},
+	}, {
+		name: "submodule import shadows belongs-to prefix",
+		inModules: map[string]string{
+			"a": `module a { prefix a; namespace "urn:a"; include s; }`,
+			"s": `submodule s { belongs-to a { prefix a; } import b { prefix a; } }`,
+		},
+		wantErrs: []string{
+			`s:1:53: prefix "a" of import b conflicts with the prefix of submodule s`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := NewModules()
+			for name, src := range tt.inModules {
+				if err := ms.Parse(src, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			for _, name := range []string{"b", "c"} {
+				if err := ms.Parse(`module `+name+` { prefix `+name+`; namespace "urn:`+name+`"; }`, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			var got []string
+			for _, err := range ms.Process() {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.wantErrs, got); diff != "" {
+				t.Errorf("Process errors (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrefixMap(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"a": `module a { prefix a; namespace "urn:a"; include s; import b { prefix x; } import c { prefix x; } container top; }`,
+		"s": `submodule s { belongs-to a { prefix a; } import c { prefix c; } container sub; }`,
+		"b": `module b { prefix b; namespace "urn:b"; }`,
+		"c": `module c { prefix c; namespace "urn:c"; }`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	// The conflicting prefixes of a are reported, but the modules
+	// are still resolved.
+	ms.Process()
+
+	names := func(pm map[string]*Module) map[string]string {
+		got := map[string]string{}
+		for p, m := range pm {
+			got[p] = m.Name
+		}
+		return got
+	}
+	a := ms.Modules["a"]
+	if diff := cmp.Diff(map[string]string{"a": "a", "x": "b"}, names(PrefixMap(a.Container[0]))); diff != "" {
+		t.Errorf("PrefixMap of a (-want, +got):\n%s", diff)
+	}
+	s := ms.SubModules["s"]
+	if diff := cmp.Diff(map[string]string{"a": "s", "c": "c"}, names(PrefixMap(s.Container[0]))); diff != "" {
+		t.Errorf("PrefixMap of s (-want, +got):\n%s", diff)
+	}
+	if got := PrefixMap(nil); got != nil {
+		t.Errorf("PrefixMap(nil) = %v, want nil", got)
+	}
+}
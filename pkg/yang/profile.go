@@ -0,0 +1,150 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// A Profile declares the schema of a device: the modules it supports, at
+// which revisions, the features it enables and the modules deviating them.
+// Profiles are written as JSON, e.g.,
+//
+//	{
+//		"name": "edge-router",
+//		"path": ["yang/ietf", "yang/vendor"],
+//		"modules": [
+//			{"name": "ietf-interfaces", "revision": "2018-02-20", "features": ["if-mib"]},
+//			{"name": "ietf-ip"}
+//		],
+//		"deviations": [
+//			{"name": "vendor-ietf-interfaces-deviations", "revision": "2023-06-01"}
+//		]
+//	}
+//
+// so that the schema of a device can be checked into a repository and built
+// the same way each time, see Modules.ReadProfile.
+type Profile struct {
+	// Name names the device or platform the profile describes.
+	Name string `json:"name,omitempty"`
+	// Path lists the directories the modules are found in.  LoadProfile
+	// makes relative directories relative to the directory of the
+	// profile.
+	Path []string `json:"path,omitempty"`
+	// Modules are the modules supported.
+	Modules []ProfileModule `json:"modules"`
+	// Deviations are the modules deviating the modules supported.
+	Deviations []ProfileModule `json:"deviations,omitempty"`
+}
+
+// A ProfileModule is a module of a Profile.
+type ProfileModule struct {
+	// Name is the name of the module.
+	Name string `json:"name"`
+	// Revision, if set, is the revision of the module required.
+	Revision string `json:"revision,omitempty"`
+	// Features are the features of the module enabled.
+	Features []string `json:"features,omitempty"`
+}
+
+// ParseProfile parses data, a Profile written as JSON.  Fields that are not
+// part of a Profile are errors.
+func ParseProfile(data []byte) (*Profile, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	p := &Profile{}
+	if err := d.Decode(p); err != nil {
+		return nil, fmt.Errorf("invalid profile: %v", err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid profile: data after the profile")
+	}
+	for _, pm := range append(append([]ProfileModule{}, p.Modules...), p.Deviations...) {
+		if pm.Name == "" {
+			return nil, fmt.Errorf("invalid profile: module without a name")
+		}
+		if pm.Revision != "" && !revisionDateRegex.MatchString(pm.Revision) {
+			return nil, fmt.Errorf("invalid profile: module %s: invalid revision %q", pm.Name, pm.Revision)
+		}
+	}
+	return p, nil
+}
+
+// LoadProfile reads and parses the Profile in file, as ParseProfile does.
+// The relative directories of its Path are made relative to the directory
+// of file.
+func LoadProfile(file string) (*Profile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ParseProfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	for i, dir := range p.Path {
+		if !filepath.IsAbs(dir) {
+			p.Path[i] = filepath.Join(filepath.Dir(file), dir)
+		}
+	}
+	return p, nil
+}
+
+// ReadProfile adds the directories of the Path of p to the search path of ms,
+// reads the modules and deviation modules of p, at the revisions required,
+// and enables their features (see EnableFeatures).  The deviations are
+// applied when the modules are processed.  An error is returned for each
+// module that cannot be found, or is found at a revision other than the one
+// required, as well as for each module that cannot be parsed.
+func (ms *Modules) ReadProfile(p *Profile) []error {
+	ms.AddPath(p.Path...)
+	var errs []error
+	for _, pm := range append(append([]ProfileModule{}, p.Modules...), p.Deviations...) {
+		if err := ms.readRevision(pm.Name, pm.Revision); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m := ms.Modules[pm.Name+"@"+pm.Revision]
+		if m == nil {
+			m = ms.Modules[pm.Name]
+		}
+		switch {
+		case m == nil:
+			errs = append(errs, fmt.Errorf("profile %s: module %s not found", p.Name, pm.Name))
+			continue
+		case pm.Revision != "" && m.Current() != pm.Revision:
+			errs = append(errs, fmt.Errorf("profile %s: module %s is at revision %q, want %s", p.Name, pm.Name, m.Current(), pm.Revision))
+			continue
+		}
+		ms.EnableFeatures(pm.Name, pm.Features...)
+	}
+	return errs
+}
+
+// NewModulesForProfile returns a Modules for the schema declared by p, whose
+// modules have been read by ReadProfile and processed.  Any errors found
+// reading or processing the modules are returned.
+func NewModulesForProfile(p *Profile) (*Modules, []error) {
+	ms := NewModules()
+	if errs := ms.ReadProfile(p); len(errs) > 0 {
+		return ms, errs
+	}
+	return ms, ms.Process()
+}
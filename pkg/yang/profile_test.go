@@ -0,0 +1,186 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+var testProfile = &Profile{
+	Name: "router",
+	Path: []string{"yang"},
+	Modules: []ProfileModule{
+		{Name: "dev", Revision: "2020-01-01", Features: []string{"f1", "f2"}},
+		{Name: "other"},
+	},
+	Deviations: []ProfileModule{
+		{Name: "dev-deviations"},
+	},
+}
+
+func TestParseProfile(t *testing.T) {
+	for _, tt := range []struct {
+		desc    string
+		in      string
+		want    *Profile
+		wantErr string
+	}{{
+		desc: "profile",
+		in: `{
+	"name": "router",
+	"path": ["yang"],
+	"modules": [
+		{"name": "dev", "revision": "2020-01-01", "features": ["f1", "f2"]},
+		{"name": "other"}
+	],
+	"deviations": [{"name": "dev-deviations"}]
+}`,
+		want: testProfile,
+	}, {
+		desc: "empty modules and punctuation in strings",
+		in:   `{"name": "a # b, [c]", "path": ["x,y", "z"], "modules": []}`,
+		want: &Profile{Name: "a # b, [c]", Path: []string{"x,y", "z"}, Modules: []ProfileModule{}},
+	}, {
+		desc:    "unknown field",
+		in:      `{"name": "router", "module": [{"name": "dev"}]}`,
+		wantErr: `unknown field "module"`,
+	}, {
+		desc:    "missing name",
+		in:      `{"modules": [{"revision": "2020-01-01"}]}`,
+		wantErr: "module without a name",
+	}, {
+		desc:    "invalid revision",
+		in:      `{"modules": [{"name": "dev", "revision": "latest"}]}`,
+		wantErr: `module dev: invalid revision "latest"`,
+	}, {
+		desc:    "not JSON",
+		in:      "name: router\nmodules: []\n",
+		wantErr: "invalid profile: invalid character",
+	}, {
+		desc:    "data after the profile",
+		in:      `{"modules": []} {}`,
+		wantErr: "invalid profile: data after the profile",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseProfile([]byte(tt.in))
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseProfile (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadProfile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "yang"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, mod := range map[string]string{
+		"dev@2019-01-01.yang": `module dev {
+			prefix d; namespace "urn:d";
+			revision 2019-01-01;
+			feature f1; feature f2;
+			leaf a { type string; }
+			leaf b { type string; }
+		}`,
+		"dev@2020-01-01.yang": `module dev {
+			prefix d; namespace "urn:d";
+			revision 2020-01-01; revision 2019-01-01;
+			feature f1; feature f2;
+			leaf a { type string; }
+			leaf b { type string; }
+			leaf c { if-feature f1; type string; }
+			leaf d { if-feature f2; type string; }
+		}`,
+		"other.yang": `module other { prefix o; namespace "urn:o"; }`,
+		"dev-deviations.yang": `module dev-deviations {
+			prefix dd; namespace "urn:dd";
+			import dev { prefix d; }
+			deviation /d:b { deviate not-supported; }
+		}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, "yang", name), []byte(mod), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	profile := filepath.Join(dir, "router.json")
+	if err := os.WriteFile(profile, []byte(`{
+	"name": "router",
+	"path": ["yang"],
+	"modules": [
+		{"name": "dev", "revision": "2020-01-01", "features": ["f1"]},
+		{"name": "other"}
+	],
+	"deviations": [{"name": "dev-deviations"}]
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadProfile(profile)
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if want := filepath.Join(dir, "yang"); len(p.Path) != 1 || p.Path[0] != want {
+		t.Errorf("LoadProfile: got path %v, want [%s]", p.Path, want)
+	}
+	ms, errs := NewModulesForProfile(p)
+	if len(errs) > 0 {
+		t.Fatalf("NewModulesForProfile: %v", errs)
+	}
+	if got := ms.Modules["dev"].Current(); got != "2020-01-01" {
+		t.Errorf("got dev revision %s, want 2020-01-01", got)
+	}
+	if ms.Modules["other"] == nil {
+		t.Errorf("module other not read")
+	}
+	if diff := cmp.Diff([]string{"f1"}, ms.EnabledFeatures("dev")); diff != "" {
+		t.Errorf("EnabledFeatures (-want, +got):\n%s", diff)
+	}
+	dev, _ := ms.GetModule("dev")
+	if dev.Dir["a"] == nil || dev.Dir["b"] != nil {
+		t.Errorf("got leaves a %v and b %v, want only a, with b deviated", dev.Dir["a"] != nil, dev.Dir["b"] != nil)
+	}
+	if dev.Dir["c"] == nil || dev.Dir["d"] != nil {
+		t.Errorf("got leaves c %v and d %v, want only c, with d of feature f2 not enabled removed", dev.Dir["c"] != nil, dev.Dir["d"] != nil)
+	}
+
+	for _, tt := range []struct {
+		desc    string
+		module  ProfileModule
+		wantErr string
+	}{
+		{"missing module", ProfileModule{Name: "missing"}, "module missing not found"},
+		{"missing revision", ProfileModule{Name: "other", Revision: "2021-01-01"}, `module other is at revision "", want 2021-01-01`},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			errs := ms.ReadProfile(&Profile{Name: "router", Path: p.Path, Modules: []ProfileModule{tt.module}})
+			if len(errs) != 1 {
+				t.Fatalf("got errors %v, want one", errs)
+			}
+			if diff := errdiff.Substring(errs[0], tt.wantErr); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// Prune returns a copy of the entry tree rooted at e without the entries for
+// which keep returns false, and their descendants.  A non-presence
+// container, list, choice or case that had children but has none left is
+// removed too, as it no longer describes any data.  nil is returned if keep
+// returns false for e itself, which is never removed for being empty.
+//
+// keep is called with the entries of the tree rooted at e, parents before
+// their children, and is not called for the descendants of an entry it
+// returns false for.  The input and output of an RPC or action are its
+// children.  The copy of e has the parent of e, so the paths of the entries
+// of the copy are those of the entries they are copies of.  Nothing else is
+// changed, e.g., the key of a list whose key leaf is removed is kept.
+//
+// Prune is a general mechanism for slicing an entry tree, for example, keeping
+// only the configuration nodes:
+//
+//	config := e.Prune(func(e *Entry) bool { return !e.ReadOnly() })
+func (e *Entry) Prune(keep func(*Entry) bool) *Entry {
+	if !keep(e) {
+		return nil
+	}
+	ne := e.dup()
+	prune(e, ne, keep)
+	return ne
+}
+
+// prune removes the children of ne, the copy of e, as Prune does.  ne may be
+// e itself, to prune e in place.
+func prune(e, ne *Entry, keep func(*Entry) bool) {
+	for _, c := range e.SortedDir() {
+		nc := ne.Dir[c.Name]
+		if !keep(c) {
+			delete(ne.Dir, c.Name)
+			continue
+		}
+		hadChildren := len(c.Dir) > 0
+		prune(c, nc, keep)
+		if hadChildren && len(nc.Dir) == 0 && prunedWhenEmpty(nc) {
+			delete(ne.Dir, c.Name)
+		}
+	}
+	if e.RPC == nil {
+		return
+	}
+	for _, io := range []struct {
+		c  *Entry
+		nc **Entry
+	}{{e.RPC.Input, &ne.RPC.Input}, {e.RPC.Output, &ne.RPC.Output}} {
+		switch {
+		case io.c == nil:
+		case !keep(io.c):
+			*io.nc = nil
+		default:
+			prune(io.c, *io.nc, keep)
+		}
+	}
+}
+
+// prunedWhenEmpty returns true if e is removed by Prune when all of its
+// children are.
+func prunedWhenEmpty(e *Entry) bool {
+	switch {
+	case e.IsContainer():
+		return !e.IsPresenceContainer()
+	case e.IsList(), e.IsChoice(), e.IsCase():
+		return true
+	}
+	return false
+}
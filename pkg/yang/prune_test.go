@@ -0,0 +1,119 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testPruneModule = `
+module pr {
+	prefix "p";
+	namespace "urn:p";
+
+	container c {
+		leaf name { type string; }
+		container state {
+			config false;
+			leaf up { type boolean; }
+		}
+		container counters {
+			leaf in { type uint64; config false; }
+		}
+		container enabled {
+			presence "enabled";
+			leaf since { type string; config false; }
+		}
+		container empty {}
+		choice ch {
+			case a { leaf a1 { type string; config false; } }
+			case b { leaf b1 { type string; } }
+		}
+		list l {
+			key "k";
+			leaf k { type string; }
+			leaf hidden { type string; }
+		}
+	}
+	rpc reset {
+		input { leaf hidden { type string; } }
+		output { leaf result { type string; } }
+	}
+}
+`
+
+// entryPaths returns the paths of the entries of the tree rooted at e, in
+// depth first order of their names.
+func entryPaths(e *Entry) []string {
+	paths := []string{e.Path()}
+	for _, c := range e.children() {
+		paths = append(paths, entryPaths(c)...)
+	}
+	return paths
+}
+
+func TestPrune(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(testPruneModule, "pr.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	e, errs := ms.GetModule("pr")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module: %v", errs)
+	}
+	before := entryPaths(e)
+
+	got := e.Prune(func(e *Entry) bool { return !e.ReadOnly() && e.Name != "hidden" })
+	want := []string{
+		"/pr",
+		"/pr/c",
+		"/pr/c/ch",
+		"/pr/c/ch/b",
+		"/pr/c/ch/b/b1",
+		"/pr/c/empty",
+		"/pr/c/enabled",
+		"/pr/c/l",
+		"/pr/c/l/k",
+		"/pr/c/name",
+		"/pr/reset",
+		// The output of an RPC is state data.
+		"/pr/reset/input",
+	}
+	if diff := cmp.Diff(want, entryPaths(got)); diff != "" {
+		t.Errorf("Prune() entries (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(before, entryPaths(e)); diff != "" {
+		t.Errorf("Prune() modified the tree (-want, +got):\n%s", diff)
+	}
+	if reset := got.Dir["reset"]; reset.Parent != got || reset.RPC.Input.Parent != reset || reset.RPC.Output != nil {
+		t.Errorf("Prune() did not fix up the RPC of the copy")
+	}
+
+	// A subtree is pruned in place of its tree.
+	sub := e.Dir["c"].Prune(func(e *Entry) bool { return !strings.HasPrefix(e.Name, "c") || e.Name == "c" })
+	if diff := cmp.Diff([]string{"/pr/c", "/pr/c/empty", "/pr/c/enabled", "/pr/c/enabled/since", "/pr/c/l", "/pr/c/l/hidden", "/pr/c/l/k", "/pr/c/name", "/pr/c/state", "/pr/c/state/up"}, entryPaths(sub)); diff != "" {
+		t.Errorf("Prune() of a subtree (-want, +got):\n%s", diff)
+	}
+
+	if got := e.Prune(func(*Entry) bool { return false }); got != nil {
+		t.Errorf("Prune() of everything got %v, want nil", got)
+	}
+}
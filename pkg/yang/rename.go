@@ -0,0 +1,451 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// identifierRE matches a YANG identifier.
+var identifierRE = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\-]*$`)
+
+// Rename renames def, a *Typedef, *Grouping or *Identity, or the Node of a
+// schema node entry such as a container or leaf, to name, updating the
+// references to it in the processed modules of ms, including the prefixed
+// references of other modules.  It returns the edited source text of each
+// file changed, by the name it was parsed with.  ms is not changed.
+//
+// The references updated are those of type statements to a typedef, uses
+// statements to a grouping, and base statements to an identity.  The
+// references to a schema node updated are the steps of augment, deviation
+// and refine targets, of leafref paths, and of key and unique statements
+// that resolve to it, and the default of the choice of a case.  References
+// are found in the processed entry tree, so a deviation whose target was
+// removed by deviate not-supported is only updated if the modules were
+// processed with IgnoreDeviateNotSupported.  References within XPath
+// expressions, such as those of must and when statements, and identities in
+// default values, are not updated.
+//
+// An error is returned if name is not an identifier, a sibling definition is
+// already named name, or the source of a statement to change is not known.
+func (ms *Modules) Rename(def Node, name string) (map[string]string, error) {
+	if !identifierRE.MatchString(name) {
+		return nil, fmt.Errorf("%q is not a valid identifier", name)
+	}
+	ds := def.Statement()
+	if ds == nil || !ds.HasArgument {
+		return nil, fmt.Errorf("%s: %s %s has no source", Source(def), def.Kind(), def.NName())
+	}
+	if err := checkSiblings(def, name); err != nil {
+		return nil, err
+	}
+
+	r := &renamer{ms: ms, edits: map[*Statement]string{ds: name}}
+	r.walk(func(n Node) {
+		switch n := n.(type) {
+		case *Type:
+			r.typeRefs(n, def, name)
+		case *Uses:
+			if g, ok := def.(*Grouping); ok && FindGrouping(n, n.Name, map[string]bool{}) == g {
+				r.edit(n.Source, n.Name, name)
+			}
+		case *Identity:
+			if id, ok := def.(*Identity); ok {
+				for _, b := range n.Base {
+					if resolveIdentity(b) == id {
+						r.edit(b.Source, b.Name, name)
+					}
+				}
+			}
+		}
+	})
+	switch def.(type) {
+	case *Typedef, *Grouping, *Identity:
+	default:
+		r.nodeRefs(ds, name)
+	}
+	return r.apply()
+}
+
+// checkSiblings returns an error if a sibling of def of the same kind is
+// named name.
+func checkSiblings(def Node, name string) error {
+	parent := def.ParentNode()
+	if parent == nil || parent.Statement() == nil {
+		return nil
+	}
+	kind := func(keyword string) string {
+		switch keyword {
+		case "typedef", "grouping", "identity", "feature", "extension":
+			return keyword
+		}
+		return "node"
+	}
+	want := kind(def.Statement().Keyword)
+	for _, s := range parent.Statement().SubStatements() {
+		if s != def.Statement() && s.Argument == name && kind(s.Keyword) == want {
+			return fmt.Errorf("%s: %s %s already defined", s.Location(), s.Keyword, name)
+		}
+	}
+	return nil
+}
+
+// A renamer collects the edits of a rename.
+type renamer struct {
+	ms    *Modules
+	edits map[*Statement]string // the new arguments of statements
+	index map[*Statement][]*Entry
+}
+
+// edit records that the name in the prefixed reference ref, the argument of
+// s, becomes name.
+func (r *renamer) edit(s *Statement, ref, name string) {
+	if s == nil {
+		return
+	}
+	if prefix, _ := getPrefix(ref); prefix != "" {
+		name = prefix + ":" + name
+	}
+	r.edits[s] = name
+}
+
+// walk calls f for each node of the modules and submodules of ms.
+func (r *renamer) walk(f func(Node)) {
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		y := typeMap[v.Type()]
+		if y == nil || v.IsNil() {
+			return
+		}
+		f(v.Interface().(Node))
+		e := v.Elem()
+		for _, fd := range y.funcs {
+			fv := e.Field(fd.index)
+			switch fv.Kind() {
+			case reflect.Ptr:
+				walk(fv)
+			case reflect.Slice:
+				for i := 0; i < fv.Len(); i++ {
+					walk(fv.Index(i))
+				}
+			}
+		}
+	}
+	for _, mods := range []map[string]*Module{r.ms.Modules, r.ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			walk(reflect.ValueOf(m))
+		}
+	}
+}
+
+// typeRefs records the edits of t when it refers to def, a typedef or an
+// identity.
+func (r *renamer) typeRefs(t *Type, def Node, name string) {
+	switch def := def.(type) {
+	case *Typedef:
+		if BaseTypedefs[t.Name] == nil && t.YangType != nil && t.YangType.Base == def.Type {
+			r.edit(t.Source, t.Name, name)
+		}
+	case *Identity:
+		if b := t.IdentityBase; b != nil && resolveIdentity(b) == def {
+			r.edit(b.Source, b.Name, name)
+		}
+	}
+}
+
+// resolveIdentity returns the identity the base statement v refers to, or
+// nil.
+func resolveIdentity(v *Value) *Identity {
+	m := RootNode(v)
+	if m == nil {
+		return nil
+	}
+	id, errs := m.findIdentityBase(v.Name)
+	if len(errs) > 0 {
+		return nil
+	}
+	return id.Identity
+}
+
+// entriesOf returns the entries built from the statement s.
+func (r *renamer) entriesOf(s *Statement) []*Entry {
+	if r.index == nil {
+		r.index = map[*Statement][]*Entry{}
+		seen := map[*Entry]bool{}
+		var add func(e *Entry)
+		add = func(e *Entry) {
+			if e == nil || seen[e] {
+				return
+			}
+			seen[e] = true
+			if e.Node != nil && e.Node.Statement() != nil {
+				r.index[e.Node.Statement()] = append(r.index[e.Node.Statement()], e)
+			}
+			for _, c := range e.children() {
+				add(c)
+			}
+		}
+		for _, mods := range []map[string]*Module{r.ms.Modules, r.ms.SubModules} {
+			for _, m := range uniqueModules(mods) {
+				add(ToEntry(m))
+			}
+		}
+	}
+	return r.index[s]
+}
+
+// nodeRefs records the edits of the references to the schema node defined by
+// the statement ds.
+func (r *renamer) nodeRefs(ds *Statement, name string) {
+	isDef := func(e *Entry) bool {
+		return e != nil && e.Node != nil && e.Node.Statement() == ds
+	}
+	r.walk(func(n Node) {
+		switch n := n.(type) {
+		case *Augment:
+			if u, ok := n.Parent.(*Uses); ok {
+				r.schemaNodeIDs(n.Source, r.usesContexts(u), isDef, name)
+				break
+			}
+			r.schemaNodeIDs(n.Source, []*Entry{moduleEntry(n)}, isDef, name)
+		case *Deviation:
+			r.schemaNodeIDs(n.Source, []*Entry{moduleEntry(n)}, isDef, name)
+		case *Refine:
+			if u, ok := n.Parent.(*Uses); ok {
+				r.schemaNodeIDs(n.Source, r.usesContexts(u), isDef, name)
+			}
+		case *Type:
+			if n.Path != nil {
+				r.leafrefPath(n.Path.Source, r.entriesOf(leafStatement(n)), isDef, name)
+			}
+		case *List:
+			contexts := r.entriesOf(n.Source)
+			if n.Key != nil {
+				r.schemaNodeIDs(n.Key.Source, contexts, isDef, name)
+			}
+			for _, u := range n.Unique {
+				r.schemaNodeIDs(u.Source, contexts, isDef, name)
+			}
+		case *Choice:
+			if n.Default == nil {
+				break
+			}
+			for _, c := range r.entriesOf(n.Source) {
+				if isDef(c.Dir[n.Default.Name]) {
+					r.edit(n.Default.Source, n.Default.Name, name)
+				}
+			}
+		}
+	})
+}
+
+// schemaNodeIDs records the edit of the argument of s, a space separated list
+// of schema node identifiers relative to any of contexts, renaming each step
+// that resolves to a node for which isDef is true.
+func (r *renamer) schemaNodeIDs(s *Statement, contexts []*Entry, isDef func(*Entry) bool, name string) {
+	if s == nil {
+		return
+	}
+	changed := false
+	ids := strings.Fields(s.Argument)
+	for i, id := range ids {
+		steps := strings.Split(id, "/")
+		renamed := append([]string{}, steps...)
+		for j, step := range steps {
+			if step == "" {
+				continue
+			}
+			for _, c := range contexts {
+				if isDef(schemaNode(c, steps[:j+1])) {
+					renamed[j] = renameStep(step, name)
+					changed = true
+					break
+				}
+			}
+		}
+		ids[i] = strings.Join(renamed, "/")
+	}
+	if changed {
+		r.edits[s] = strings.Join(ids, " ")
+	}
+}
+
+// leafrefPath records the edit of the argument of s, the path of a leafref
+// relative to any of contexts, renaming each step that resolves to a node for
+// which isDef is true.  Predicates are not changed.
+func (r *renamer) leafrefPath(s *Statement, contexts []*Entry, isDef func(*Entry) bool, name string) {
+	if s == nil {
+		return
+	}
+	changed := false
+	steps := splitPath(s.Argument)
+	renamed := append([]string{}, steps...)
+	for i, step := range steps {
+		head := step
+		if j := strings.Index(step, "["); j >= 0 {
+			head = step[:j]
+		}
+		id := strings.TrimSpace(head)
+		if id == "" || id == "." || id == ".." {
+			continue
+		}
+		for _, c := range contexts {
+			if isDef(resolveLeafref(c, strings.Join(steps[:i+1], "/"))) {
+				renamed[i] = strings.Replace(head, id, renameStep(id, name), 1) + step[len(head):]
+				changed = true
+				break
+			}
+		}
+	}
+	if changed {
+		r.edits[s] = strings.Join(renamed, "/")
+	}
+}
+
+// renameStep returns the path step step, with its prefix, if any, renamed to
+// name.
+func renameStep(step, name string) string {
+	if prefix, _ := getPrefix(step); prefix != "" {
+		return prefix + ":" + name
+	}
+	return name
+}
+
+// schemaNode returns the entry the schema node identifier steps refers to,
+// relative to e unless steps is absolute, or nil.
+func schemaNode(e *Entry, steps []string) *Entry {
+	if len(steps) > 0 && steps[0] == "" {
+		steps = steps[1:]
+		if len(steps) == 0 {
+			return nil
+		}
+		prefix, _ := getPrefix(steps[0])
+		if prefix == "" || e.Node == nil {
+			e = e.Root()
+		} else {
+			m := FindModuleByPrefix(e.Node, prefix)
+			if m == nil {
+				return nil
+			}
+			e = moduleEntry(m)
+		}
+	}
+	for _, step := range steps {
+		if e == nil {
+			return nil
+		}
+		_, name := getPrefix(step)
+		switch {
+		case e.RPC != nil && name == "input":
+			e = e.RPC.Input
+		case e.RPC != nil && name == "output":
+			e = e.RPC.Output
+		default:
+			e = e.Dir[name]
+		}
+	}
+	return e
+}
+
+// moduleEntry returns the entry of the module n is defined in, the module a
+// submodule belongs to.
+func moduleEntry(n Node) *Entry {
+	m := RootNode(n)
+	if m == nil {
+		return nil
+	}
+	if m.BelongsTo != nil {
+		if bm := m.Modules.Modules[m.BelongsTo.Name]; bm != nil {
+			m = bm
+		}
+	}
+	return ToEntry(m)
+}
+
+// usesContexts returns the entries the grouping of u is instantiated in, the
+// parents of the entries built from the statements of the grouping.
+func (r *renamer) usesContexts(u *Uses) []*Entry {
+	g := FindGrouping(u, u.Name, map[string]bool{})
+	if g == nil || g.Source == nil {
+		return nil
+	}
+	var contexts []*Entry
+	seen := map[*Entry]bool{}
+	for _, s := range g.Source.SubStatements() {
+		for _, e := range r.entriesOf(s) {
+			if e.Parent != nil && !seen[e.Parent] {
+				seen[e.Parent] = true
+				contexts = append(contexts, e.Parent)
+			}
+		}
+	}
+	return contexts
+}
+
+// leafStatement returns the statement of the leaf or leaf-list whose type,
+// or union member type, is t, or nil if t is the type of a typedef.
+func leafStatement(t *Type) *Statement {
+	for n := t.Parent; n != nil; n = n.ParentNode() {
+		switch n.(type) {
+		case *Leaf, *LeafList:
+			return n.Statement()
+		case *Type:
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// apply returns the edited source of each file with edits.
+func (r *renamer) apply() (map[string]string, error) {
+	byFile := map[string][]*Statement{}
+	for s := range r.edits {
+		byFile[s.file] = append(byFile[s.file], s)
+	}
+	files := map[string]string{}
+	for file, ss := range byFile {
+		src, ok := r.ms.source(file)
+		if !ok {
+			return nil, fmt.Errorf("%s: source not retained", ss[0].Location())
+		}
+		sort.Slice(ss, func(i, j int) bool { return ss[i].argOffset > ss[j].argOffset })
+		for _, s := range ss {
+			start, end := s.argOffset, s.argOffset+len(s.rawArgument)
+			if s.rawArgument == "" || end > len(src) || src[start:end] != s.rawArgument {
+				return nil, fmt.Errorf("%s: cannot find the argument of %s in the source", s.Location(), s.Keyword)
+			}
+			src = src[:start] + requote(s.rawArgument, r.edits[s]) + src[end:]
+		}
+		files[file] = src
+	}
+	return files, nil
+}
+
+// requote returns arg quoted as the raw argument raw is, if possible, and
+// otherwise in double quotes.
+func requote(raw, arg string) string {
+	switch {
+	case raw[0] == '\'' && !strings.Contains(arg, "'"):
+		return "'" + arg + "'"
+	case raw[0] != '"' && raw[0] != '\'' && !strings.ContainsAny(arg, " \t\n\r'\";{}") && !strings.Contains(arg, "//") && !strings.Contains(arg, "/*"):
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
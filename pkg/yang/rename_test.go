@@ -0,0 +1,216 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testRenameModules = map[string]string{
+	"base.yang": `module base {
+  namespace "urn:base";
+  prefix "b";
+  identity kind;
+  identity eth { base kind; }
+  typedef name-type { type string; }
+  grouping ports {
+    list port {
+      key "id";
+      unique "id";
+      leaf id { type b:name-type; }
+    }
+  }
+  container top {
+    leaf name { type name-type; }
+    leaf kind { type identityref { base b:kind; } }
+    uses ports {
+      refine port/id { description "refined"; }
+    }
+    leaf ref { type leafref { path "../port[id = current()/../name]/id"; } }
+    choice ch {
+      default one;
+      case one { leaf x { type string; } }
+    }
+  }
+}`,
+	"user.yang": `module user {
+  namespace "urn:user";
+  prefix "u";
+  import base { prefix base; }
+  identity fast { base base:eth; }
+  leaf l { type base:name-type; }
+  augment "/base:top/base:port" {
+    leaf extra { type string; }
+  }
+  deviation /base:top/base:port/base:id {
+    deviate add { default zero; }
+  }
+  typedef name-type { type int8; }
+  leaf local { type name-type; }
+}`,
+}
+
+func renameModules(t *testing.T) *Modules {
+	t.Helper()
+	ms := NewModules()
+	ms.ParseOptions.RetainSources = true
+	for _, name := range []string{"base.yang", "user.yang"} {
+		if err := ms.Parse(testRenameModules[name], name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	return ms
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		desc string
+		def  func(ms *Modules) Node
+		name string
+		// want maps file names to the replacements made in them.
+		want map[string][][2]string
+	}{{
+		desc: "typedef",
+		def:  func(ms *Modules) Node { return ms.Modules["base"].Typedef[0] },
+		name: "label",
+		want: map[string][][2]string{
+			"base.yang": {
+				{"typedef name-type", "typedef label"},
+				{"type b:name-type", "type b:label"},
+				{"type name-type", "type label"},
+			},
+			"user.yang": {{"type base:name-type", "type base:label"}},
+		},
+	}, {
+		desc: "identity",
+		def:  func(ms *Modules) Node { return ms.Modules["base"].Identity[0] },
+		name: "category",
+		want: map[string][][2]string{
+			"base.yang": {
+				{"identity kind;", "identity category;"},
+				{"base kind;", "base category;"},
+				{"base b:kind;", "base b:category;"},
+			},
+		},
+	}, {
+		desc: "grouping",
+		def:  func(ms *Modules) Node { return ms.Modules["base"].Grouping[0] },
+		name: "interfaces",
+		want: map[string][][2]string{
+			"base.yang": {
+				{"grouping ports", "grouping interfaces"},
+				{"uses ports", "uses interfaces"},
+			},
+		},
+	}, {
+		desc: "node",
+		def: func(ms *Modules) Node {
+			return ToEntry(ms.Modules["base"]).Dir["top"].Dir["port"].Dir["id"].Node
+		},
+		name: "index",
+		want: map[string][][2]string{
+			"base.yang": {
+				{`key "id"`, `key "index"`},
+				{`unique "id"`, `unique "index"`},
+				{"leaf id", "leaf index"},
+				{"refine port/id", "refine port/index"},
+				{`path "../port[id = current()/../name]/id"`, `path "../port[id = current()/../name]/index"`},
+			},
+			"user.yang": {{"deviation /base:top/base:port/base:id", "deviation /base:top/base:port/base:index"}},
+		},
+	}, {
+		desc: "list through augment",
+		def: func(ms *Modules) Node {
+			return ToEntry(ms.Modules["base"]).Dir["top"].Dir["port"].Node
+		},
+		name: "ports",
+		want: map[string][][2]string{
+			"base.yang": {
+				{"list port", "list ports"},
+				{"refine port/id", "refine ports/id"},
+				{`path "../port[id`, `path "../ports[id`},
+			},
+			"user.yang": {
+				{`augment "/base:top/base:port"`, `augment "/base:top/base:ports"`},
+				{"deviation /base:top/base:port/base:id", "deviation /base:top/base:ports/base:id"},
+			},
+		},
+	}, {
+		desc: "case",
+		def: func(ms *Modules) Node {
+			return ToEntry(ms.Modules["base"]).Dir["top"].Dir["ch"].Dir["one"].Node
+		},
+		name: "first",
+		want: map[string][][2]string{
+			"base.yang": {
+				{"default one;", "default first;"},
+				{"case one", "case first"},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := renameModules(t)
+			got, err := ms.Rename(tt.def(ms), tt.name)
+			if err != nil {
+				t.Fatalf("Rename: %v", err)
+			}
+			want := map[string]string{}
+			for file, repls := range tt.want {
+				src := testRenameModules[file]
+				for _, r := range repls {
+					if !strings.Contains(src, r[0]) {
+						t.Fatalf("%s does not contain %q", file, r[0])
+					}
+					src = strings.Replace(src, r[0], r[1], 1)
+				}
+				want[file] = src
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Rename (-want, +got):\n%s", diff)
+			}
+			for file, src := range got {
+				if err := NewModules().Parse(src, file); err != nil {
+					t.Errorf("%s does not parse: %v", file, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRenameErrors(t *testing.T) {
+	ms := renameModules(t)
+	for _, tt := range []struct {
+		desc    string
+		def     Node
+		name    string
+		wantErr string
+	}{
+		{"invalid name", ms.Modules["base"].Typedef[0], "1bad", "not a valid identifier"},
+		{"conflict", ToEntry(ms.Modules["base"]).Dir["top"].Dir["name"].Node, "kind", "leaf kind already defined"},
+		{"identity conflict", ms.Modules["base"].Identity[0], "eth", "identity eth already defined"},
+	} {
+		_, err := ms.Rename(tt.def, tt.name)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: got error %v, want %q", tt.desc, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// A DeviceSchema describes the schema supported by a device.
+type DeviceSchema struct {
+	// Modules are the modules the device implements, the supported
+	// models of its gNMI CapabilityResponse, which are read and checked
+	// as by NewModulesForModels.
+	Modules []*gpb.ModelData
+	// Features are the features enabled, by module name.  The features
+	// of a module listed that are not enabled are disabled, as by
+	// Modules.EnableFeatures.
+	Features map[string][]string
+	// Deviations are the names of the modules holding the deviations the
+	// device applies to Modules.
+	Deviations []string
+}
+
+// modulesKey returns the key of the module set of d.
+func (d DeviceSchema) modulesKey() string {
+	var names []string
+	for _, md := range d.Modules {
+		names = append(names, md.GetName()+"@"+md.GetVersion())
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// key returns the key of d, which is the same for any two DeviceSchemas
+// describing the same schema.
+func (d DeviceSchema) key() string {
+	var features []string
+	for m, fs := range d.Features {
+		// A module without features enabled has all of them disabled.
+		features = append(features, m+":")
+		for _, f := range fs {
+			features = append(features, m+":"+f)
+		}
+	}
+	sort.Strings(features)
+	deviations := append([]string{}, d.Deviations...)
+	sort.Strings(deviations)
+	return fmt.Sprintf("%s|%s|%s", d.modulesKey(), strings.Join(features, " "), strings.Join(deviations, " "))
+}
+
+// A SchemaRepo provides the processed modules of the schemas of many
+// devices, reading and processing each set of modules once.  The Modules of
+// a device with no features or deviations is the processed module set
+// itself, shared by all such devices with the same modules.  Otherwise the
+// module set is cloned, and the features enabled and the deviations read into
+// and processed in the copy, which is shared by all devices with the same
+// DeviceSchema.  Nothing is shared between the copy and the module set it is
+// cloned from, each such schema costs as much memory as its modules do.
+//
+// A SchemaRepo is safe for concurrent use.  Each schema is built once, by the
+// first call to Modules requesting it, while the calls requesting it at the
+// same time wait for it; calls requesting other schemas are not held up.
+type SchemaRepo struct {
+	path []string
+
+	mu       sync.Mutex
+	bases    map[string]*repoSchema // processed module sets by modulesKey
+	contexts map[string]*repoSchema // device schemas by key
+}
+
+// A repoSchema is a module set of a SchemaRepo, which is being built until
+// done is closed.
+type repoSchema struct {
+	done chan struct{}
+	ms   *Modules
+	errs []error
+}
+
+// NewSchemaRepo returns a SchemaRepo that reads modules from the directories
+// of path.
+func NewSchemaRepo(path ...string) *SchemaRepo {
+	return &SchemaRepo{
+		path:     path,
+		bases:    map[string]*repoSchema{},
+		contexts: map[string]*repoSchema{},
+	}
+}
+
+// Modules returns the processed modules of the schema d.  The Modules
+// returned may be shared with other devices and must not be changed, use
+// Clone to make a copy that may be.  An error is returned for each module of
+// d that cannot be found, read or processed.
+func (r *SchemaRepo) Modules(d DeviceSchema) (*Modules, []error) {
+	return r.get(r.contexts, d.key(), func() (*Modules, []error) {
+		base, errs := r.get(r.bases, d.modulesKey(), func() (*Modules, []error) {
+			ms, unsatisfied, errs := NewModulesForModels(d.Modules, r.path...)
+			for _, md := range unsatisfied {
+				errs = append(errs, fmt.Errorf("module %s@%s not found", md.GetName(), md.GetVersion()))
+			}
+			return ms, errs
+		})
+		if len(errs) > 0 || len(d.Features) == 0 && len(d.Deviations) == 0 {
+			return base, errs
+		}
+		ms := base.Clone()
+		for _, dev := range d.Deviations {
+			if err := ms.Read(dev); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		for m, fs := range d.Features {
+			ms.EnableFeatures(m, fs...)
+		}
+		return ms, ms.Process()
+	})
+}
+
+// get returns the module set of schemas with key, calling build to build it if
+// it is not in schemas or being built.  r.mu is only held while schemas is
+// looked up and changed, not while the module set is built.  A module set
+// that cannot be built is removed from schemas once the calls waiting for it
+// have been given its errors, so that it is built again when next requested.
+func (r *SchemaRepo) get(schemas map[string]*repoSchema, key string, build func() (*Modules, []error)) (*Modules, []error) {
+	r.mu.Lock()
+	s := schemas[key]
+	if s != nil {
+		r.mu.Unlock()
+		<-s.done
+		return s.ms, s.errs
+	}
+	s = &repoSchema{done: make(chan struct{})}
+	schemas[key] = s
+	r.mu.Unlock()
+
+	s.ms, s.errs = build()
+	if len(s.errs) > 0 {
+		s.ms = nil
+		r.mu.Lock()
+		if schemas[key] == s {
+			delete(schemas, key)
+		}
+		r.mu.Unlock()
+	}
+	close(s.done)
+	return s.ms, s.errs
+}
+
+// Forget removes the schema d from r, so that it is read or cloned again the
+// next time it is requested.  The processed module set of d is kept while
+// other schemas use it.
+func (r *SchemaRepo) Forget(d DeviceSchema) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.contexts, d.key())
+	mk := d.modulesKey()
+	for k := range r.contexts {
+		if strings.HasPrefix(k, mk+"|") {
+			return
+		}
+	}
+	delete(r.bases, mk)
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+var testRepoFiles = map[string]string{
+	"dev.yang": `module dev {
+  namespace "urn:dev";
+  prefix "dev";
+  revision 2021-01-01;
+  feature fast;
+  container c {
+    leaf a { type string; }
+    leaf b { type string; }
+    leaf turbo { if-feature fast; type string; }
+  }
+}`,
+	"dev@2020-01-01.yang": `module dev {
+  namespace "urn:dev";
+  prefix "dev";
+  revision 2020-01-01;
+  container c {
+    leaf a { type string; }
+  }
+}`,
+	"dev-deviations.yang": `module dev-deviations {
+  namespace "urn:dev-deviations";
+  prefix "devd";
+  import dev { prefix dev; }
+  deviation /dev:c/dev:b { deviate not-supported; }
+}`,
+}
+
+func TestSchemaRepo(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range testRepoFiles {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewSchemaRepo(dir)
+	get := func(d DeviceSchema) *Modules {
+		t.Helper()
+		ms, errs := r.Modules(d)
+		if len(errs) > 0 {
+			t.Fatalf("Modules(%+v): unexpected errors: %v", d, errs)
+		}
+		return ms
+	}
+	dirNames := func(ms *Modules) []string {
+		var names []string
+		for _, e := range ToEntry(ms.Modules["dev"]).Dir["c"].SortedDir() {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+
+	plain := DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev"}}}
+	base := get(plain)
+	if got := get(DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev"}}}); got != base {
+		t.Errorf("Modules: the same schema returned different Modules")
+	}
+	if diff := cmp.Diff([]string{"a", "b", "turbo"}, dirNames(base)); diff != "" {
+		t.Errorf("latest revision (-want, +got):\n%s", diff)
+	}
+
+	old := get(DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev", Version: "2020-01-01"}}})
+	if diff := cmp.Diff([]string{"a"}, dirNames(old)); diff != "" {
+		t.Errorf("older revision (-want, +got):\n%s", diff)
+	}
+
+	deviated := DeviceSchema{
+		Modules:    []*gpb.ModelData{{Name: "dev"}},
+		Features:   map[string][]string{"dev": {"fast"}},
+		Deviations: []string{"dev-deviations"},
+	}
+	dms := get(deviated)
+	if dms == base {
+		t.Fatalf("Modules: deviated schema shares the Modules of its base")
+	}
+	if diff := cmp.Diff([]string{"a", "turbo"}, dirNames(dms)); diff != "" {
+		t.Errorf("deviated schema (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"a", "b", "turbo"}, dirNames(base)); diff != "" {
+		t.Errorf("base after deviation (-want, +got):\n%s", diff)
+	}
+	if !dms.FeatureEnabled("dev", "fast") {
+		t.Errorf("deviated schema: feature fast not enabled")
+	}
+	if base.FeatureEnabled("dev", "fast") {
+		t.Errorf("base: feature fast enabled")
+	}
+	if got := get(DeviceSchema{
+		Modules:    []*gpb.ModelData{{Name: "dev"}},
+		Deviations: []string{"dev-deviations"},
+		Features:   map[string][]string{"dev": {"fast"}},
+	}); got != dms {
+		t.Errorf("Modules: the same deviated schema returned different Modules")
+	}
+
+	slow := get(DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev"}}, Features: map[string][]string{"dev": nil}})
+	if diff := cmp.Diff([]string{"a", "b"}, dirNames(slow)); diff != "" {
+		t.Errorf("schema without feature fast (-want, +got):\n%s", diff)
+	}
+
+	if _, errs := r.Modules(DeviceSchema{Modules: []*gpb.ModelData{{Name: "missing"}}}); len(errs) == 0 {
+		t.Errorf("Modules: missing module: got no errors")
+	}
+	if _, errs := r.Modules(DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev"}}, Deviations: []string{"missing"}}); len(errs) == 0 {
+		t.Errorf("Modules: missing deviation module: got no errors")
+	}
+
+	r.Forget(plain)
+	if _, ok := r.bases[plain.modulesKey()]; !ok {
+		t.Errorf("Forget: module set removed while still in use")
+	}
+	r.Forget(deviated)
+	if _, ok := r.bases[plain.modulesKey()]; !ok {
+		t.Errorf("Forget: module set removed while still in use")
+	}
+	r.Forget(DeviceSchema{Modules: []*gpb.ModelData{{Name: "dev"}}, Features: map[string][]string{"dev": nil}})
+	if _, ok := r.bases[plain.modulesKey()]; ok {
+		t.Errorf("Forget: unused module set not removed")
+	}
+}
+
+func TestSchemaRepoConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	for name, data := range testRepoFiles {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := NewSchemaRepo(dir)
+	schemas := []DeviceSchema{
+		{Modules: []*gpb.ModelData{{Name: "dev"}}},
+		{Modules: []*gpb.ModelData{{Name: "dev"}}, Features: map[string][]string{"dev": {"fast"}}},
+		{Modules: []*gpb.ModelData{{Name: "dev"}}, Deviations: []string{"dev-deviations"}},
+		{Modules: []*gpb.ModelData{{Name: "dev", Version: "2020-01-01"}}},
+	}
+	const n = 8
+	got := make([][]*Modules, len(schemas))
+	var wg sync.WaitGroup
+	for i, d := range schemas {
+		got[i] = make([]*Modules, n)
+		for j := 0; j < n; j++ {
+			wg.Add(1)
+			go func(i, j int, d DeviceSchema) {
+				defer wg.Done()
+				ms, errs := r.Modules(d)
+				if len(errs) > 0 {
+					t.Errorf("Modules(%+v): unexpected errors: %v", d, errs)
+				}
+				got[i][j] = ms
+			}(i, j, d)
+		}
+	}
+	wg.Wait()
+	for i, mss := range got {
+		for _, ms := range mss {
+			if ms == nil || ms != mss[0] {
+				t.Errorf("Modules(%+v): concurrent calls returned different Modules", schemas[i])
+				break
+			}
+		}
+	}
+}
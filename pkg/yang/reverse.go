@@ -0,0 +1,230 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+)
+
+// This file has functions that find the entries of the processed modules
+// by properties of their types, for impact analysis.
+
+// entries returns the entries of the latest revision of each processed module
+// in ms, other than the module entries themselves, for which match is true.
+// Modules are in the order of their names, and the entries of each in
+// depth first order of their names.
+func (ms *Modules) entries(match func(*Entry) bool) []*Entry {
+	var found []*Entry
+	var walk func(e *Entry)
+	walk = func(e *Entry) {
+		for _, c := range e.children() {
+			if match(c) {
+				found = append(found, c)
+			}
+			walk(c)
+		}
+	}
+	for _, m := range ms.SortedModules() {
+		walk(ToEntry(m))
+	}
+	return found
+}
+
+// anyType returns true if f is true for t or, if t is a union, for any of its
+// member types.
+func anyType(t *YangType, f func(*YangType) bool) bool {
+	if t == nil {
+		return false
+	}
+	if f(t) {
+		return true
+	}
+	for _, ut := range t.Type {
+		if anyType(ut, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// EntriesWithType returns the leaf and leaf-list entries whose type is of
+// kind, or is a union with a member of kind.
+func (ms *Modules) EntriesWithType(kind TypeKind) []*Entry {
+	return ms.entries(func(e *Entry) bool {
+		return anyType(e.Type, func(t *YangType) bool { return t.Kind == kind })
+	})
+}
+
+// EntriesUsingTypedef returns the leaf and leaf-list entries whose type is
+// derived, directly or through other typedefs or union members, from the
+// typedef name defined in module, e.g., ("ietf-inet-types", "ip-address").  A
+// typedef defined in a submodule is defined in the module it belongs to.
+func (ms *Modules) EntriesUsingTypedef(module, name string) []*Entry {
+	return ms.entries(func(e *Entry) bool {
+		return anyType(e.Type, func(t *YangType) bool { return t.derivedFrom(module, name) })
+	})
+}
+
+// derivedFrom returns true if the typedef chain of y includes the typedef
+// name of module.
+func (y *YangType) derivedFrom(module, name string) bool {
+	for seen := map[*YangType]bool{}; y != nil && y.Base != nil && !seen[y]; y = y.Base.YangType {
+		seen[y] = true
+		m := RootNode(y.Base)
+		if m == nil {
+			return false
+		}
+		mname := m.Name
+		if m.BelongsTo != nil {
+			mname = m.BelongsTo.Name
+		}
+		if mname == module && y.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EntriesReferencing returns the leaf and leaf-list entries with a leafref
+// type, or a union with a leafref member, whose path refers to target.
+func (ms *Modules) EntriesReferencing(target *Entry) []*Entry {
+	return ms.entries(func(e *Entry) bool {
+		return anyType(e.Type, func(t *YangType) bool {
+			return t.Kind == Yleafref && resolveLeafref(e, t.Path) == target
+		})
+	})
+}
+
+// LeafrefTarget returns the entry the path of the leafref type of e refers
+// to, or nil if e is not a leafref or the entry cannot be found.
+func (e *Entry) LeafrefTarget() *Entry {
+	if e.Type == nil || e.Type.Kind != Yleafref {
+		return nil
+	}
+	return resolveLeafref(e, e.Type.Path)
+}
+
+// resolveLeafref returns the entry that the leafref path refers to from the
+// entry e, or nil if it cannot be found.  Predicates in path are ignored.
+func resolveLeafref(e *Entry, path string) *Entry {
+	var b strings.Builder
+	depth := 0
+	for _, c := range path {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case depth == 0 && c != ' ' && c != '\t' && c != '\n':
+			b.WriteRune(c)
+		}
+	}
+	steps := strings.Split(b.String(), "/")
+	cur := e
+	if steps[0] == "" {
+		steps = steps[1:]
+		if len(steps) == 0 {
+			return nil
+		}
+		cur = e.Root()
+		if prefix, _ := getPrefix(steps[0]); prefix != "" && e.Node != nil {
+			m := FindModuleByPrefix(e.Node, prefix)
+			if m == nil {
+				return nil
+			}
+			if m.BelongsTo != nil {
+				m = m.Modules.Modules[m.BelongsTo.Name]
+			}
+			if m == nil {
+				return nil
+			}
+			cur = ToEntry(m)
+		}
+	}
+	for _, step := range steps {
+		switch step {
+		case ".":
+		case "..":
+			cur = cur.DataParent()
+		default:
+			_, name := getPrefix(step)
+			cur = cur.DataChild(name)
+		}
+		if cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// resolveLeafrefTargets sets the Target of each leafref type, and leafref
+// union member type, of the entries of ms.  The types of an entry with a
+// leafref are copied, as they are shared by each use of a grouping.
+func (ms *Modules) resolveLeafrefTargets() {
+	r := &leafrefResolver{done: map[*Entry]bool{}}
+	var walk func(e *Entry)
+	walk = func(e *Entry) {
+		for _, c := range e.children() {
+			r.resolve(c)
+			walk(c)
+		}
+	}
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			walk(ToEntry(m))
+		}
+	}
+}
+
+// A leafrefResolver sets the targets of the leafrefs of entries.
+type leafrefResolver struct {
+	done map[*Entry]bool // entries resolved, or being resolved
+}
+
+// resolve sets the targets of the leafrefs of the type of e and returns the
+// type.
+func (r *leafrefResolver) resolve(e *Entry) *YangType {
+	if e == nil {
+		return nil
+	}
+	if !r.done[e] {
+		r.done[e] = true
+		e.Type = r.targets(e, e.Type)
+	}
+	return e.Type
+}
+
+// targets returns t, or a copy of t with the Target of its leafrefs, and
+// those of its union members, set as used by e.
+func (r *leafrefResolver) targets(e *Entry, t *YangType) *YangType {
+	if !anyType(t, func(t *YangType) bool { return t.Kind == Yleafref }) {
+		return t
+	}
+	y := *t
+	switch {
+	case y.Kind == Yleafref:
+		target := r.resolve(resolveLeafref(e, y.Path))
+		if target != nil && target.Kind == Yleafref {
+			target = target.Target
+		}
+		y.Target = target
+	case len(y.Type) > 0:
+		y.Type = make([]*YangType, len(t.Type))
+		for i, ut := range t.Type {
+			y.Type[i] = r.targets(e, ut)
+		}
+	}
+	return &y
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReverseLookups(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"types.yang": `module types {
+	prefix t;
+	namespace "urn:t";
+	typedef addr { type string { length 1..64; } }
+	typedef v4 { type addr { pattern '[0-9.]+'; } }
+}`,
+		"a.yang": `module a {
+	prefix a;
+	namespace "urn:a";
+	import types { prefix t; }
+	container c {
+		list l {
+			key "name";
+			leaf name { type string; }
+			leaf peer { type t:v4; }
+		}
+		leaf primary {
+			type leafref { path "/a:c/a:l/a:name"; }
+		}
+		leaf either {
+			type union {
+				type leafref { path "../l[name = current()/../primary]/name"; }
+				type int32;
+			}
+		}
+		leaf other { type t:addr; }
+		leaf count { type int32; }
+	}
+}`,
+		"b.yang": `module b {
+	prefix b;
+	namespace "urn:b";
+	import a { prefix a; }
+	leaf backup { type leafref { path "/a:c/a:l/a:name"; } }
+	leaf bad { type leafref { path "/a:c/a:missing"; } }
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	paths := func(entries []*Entry) []string {
+		var p []string
+		for _, e := range entries {
+			p = append(p, e.Path())
+		}
+		return p
+	}
+
+	if diff := cmp.Diff([]string{"/a/c/count", "/a/c/either"}, paths(ms.EntriesWithType(Yint32))); diff != "" {
+		t.Errorf("EntriesWithType(int32) (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/a/c/l/peer", "/a/c/other"}, paths(ms.EntriesUsingTypedef("types", "addr"))); diff != "" {
+		t.Errorf("EntriesUsingTypedef(types, addr) (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"/a/c/l/peer"}, paths(ms.EntriesUsingTypedef("types", "v4"))); diff != "" {
+		t.Errorf("EntriesUsingTypedef(types, v4) (-want, +got):\n%s", diff)
+	}
+	if got := ms.EntriesUsingTypedef("a", "addr"); len(got) != 0 {
+		t.Errorf("EntriesUsingTypedef(a, addr) got %v, want none", paths(got))
+	}
+
+	a, errs := ms.GetModule("a")
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module a: %v", errs)
+	}
+	target := a.Dir["c"].Dir["l"].Dir["name"]
+	if diff := cmp.Diff([]string{"/a/c/either", "/a/c/primary", "/b/backup"}, paths(ms.EntriesReferencing(target))); diff != "" {
+		t.Errorf("EntriesReferencing(%s) (-want, +got):\n%s", target.Path(), diff)
+	}
+}
+
+func TestUnionMemberResolution(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"i.yang": `module i {
+	prefix i;
+	namespace "urn:i";
+	identity ib;
+	typedef iref { type identityref { base ib; } }
+}`,
+		"m.yang": `module m {
+	prefix m;
+	namespace "urn:m";
+	import i { prefix i; }
+	identity b;
+	typedef u {
+		type union {
+			type leafref { path "../x"; }
+			type identityref { base b; }
+		}
+	}
+	typedef nested { type union { type u; type i:iref; } }
+	grouping g {
+		leaf x { type int32; }
+		leaf direct {
+			type union {
+				type leafref { path "../x"; }
+				type identityref { base i:ib; }
+				type string;
+			}
+		}
+		leaf typedef { type u; }
+		leaf nested { type nested; }
+		leaf chain { type union { type leafref { path "../ref"; } type i:iref; } }
+		leaf ref { type leafref { path "../x"; } }
+		leaf missing { type union { type leafref { path "../none"; } type string; } }
+	}
+	container c { uses g; }
+	container d { uses g { refine x { } } }
+	augment "/m:d" { leaf y { type string; } }
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+
+	// describe returns the kinds of t and its members, with the targets of
+	// leafrefs and the bases of identityrefs.
+	var describe func(t *YangType) string
+	describe = func(t *YangType) string {
+		switch t.Kind {
+		case Yleafref:
+			if t.Target == nil {
+				return "leafref(nil)"
+			}
+			return "leafref(" + describe(t.Target) + ")"
+		case Yidentityref:
+			if t.IdentityBase == nil {
+				return "identityref(nil)"
+			}
+			return "identityref(" + t.IdentityBase.Name + ")"
+		case Yunion:
+			var s []string
+			for _, ut := range t.Type {
+				s = append(s, describe(ut))
+			}
+			return "union(" + strings.Join(s, " ") + ")"
+		}
+		return t.Kind.String()
+	}
+	for _, tt := range []struct {
+		path string
+		want string
+	}{
+		{"direct", "union(leafref(int32) identityref(ib) string)"},
+		{"typedef", "union(leafref(int32) identityref(b))"},
+		{"nested", "union(union(leafref(int32) identityref(b)) identityref(ib))"},
+		{"chain", "union(leafref(int32) identityref(ib))"},
+		{"ref", "leafref(int32)"},
+		{"missing", "union(leafref(nil) string)"},
+	} {
+		for _, c := range []string{"c", "d"} {
+			e := ToEntry(ms.Modules["m"]).Dir[c].Dir[tt.path]
+			if got := describe(e.Type); got != tt.want {
+				t.Errorf("%s/%s: got %s, want %s", c, tt.path, got, tt.want)
+			}
+		}
+	}
+
+	// The types of the grouping are not changed.
+	for _, l := range ms.Modules["m"].Grouping[0].Leaf {
+		if l.Name == "ref" && l.Type.YangType.Target != nil {
+			t.Errorf("grouping leaf ref has target %v, want nil", l.Type.YangType.Target)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"time"
+)
+
+// RevisionDateLayout is the layout, as used by time.Parse, of a revision date.
+const RevisionDateLayout = "2006-01-02"
+
+// A RevisionInfo is a revision statement of a module with its date parsed.
+type RevisionInfo struct {
+	Name        string    // Name is the revision date as written.
+	Date        time.Time // Date is the revision date, or zero if it is invalid.
+	Description string    // Description is the description of the revision, if any.
+	Reference   string    // Reference is the reference of the revision, if any.
+	Revision    *Revision // Revision is the revision statement.
+}
+
+// Valid returns true if the revision date of r is a valid date.
+func (r RevisionInfo) Valid() bool {
+	return !r.Date.IsZero()
+}
+
+// Revisions returns the revisions of the module or submodule s in the order
+// they are written, normally the most recent first.
+func (s *Module) Revisions() []RevisionInfo {
+	revs := make([]RevisionInfo, len(s.Revision))
+	for i, r := range s.Revision {
+		revs[i] = RevisionInfo{Name: r.Name, Revision: r}
+		if d, err := time.Parse(RevisionDateLayout, r.Name); err == nil {
+			revs[i].Date = d
+		}
+		if r.Description != nil {
+			revs[i].Description = r.Description.Name
+		}
+		if r.Reference != nil {
+			revs[i].Reference = r.Reference.Name
+		}
+	}
+	return revs
+}
+
+// LatestRevision returns the most recent revision of s, wherever it is
+// written.  It returns false if s has no revision with a valid date.
+func (s *Module) LatestRevision() (RevisionInfo, bool) {
+	var latest RevisionInfo
+	for _, r := range s.Revisions() {
+		if r.Valid() && r.Date.After(latest.Date) {
+			latest = r
+		}
+	}
+	return latest, latest.Valid()
+}
+
+// CheckRevisions returns an error for each revision of s that is not a valid
+// date, is repeated or, as RFC 7950 section 7.1.9 requires, is not listed in
+// reverse chronological order.
+func (s *Module) CheckRevisions() []error {
+	var errs []error
+	var prev RevisionInfo
+	seen := map[string]bool{}
+	for _, r := range s.Revisions() {
+		switch {
+		case !r.Valid():
+			errs = append(errs, codeErrorf("GY0109", "%s: invalid revision date %q, want YYYY-MM-DD", Source(r.Revision), r.Name))
+			continue
+		case seen[r.Name]:
+			errs = append(errs, codeErrorf("GY0109", "%s: revision %s is repeated", Source(r.Revision), r.Name))
+		case prev.Valid() && !r.Date.Before(prev.Date):
+			errs = append(errs, codeErrorf("GY0109", "%s: revision %s is not listed in reverse chronological order, it follows %s", Source(r.Revision), r.Name, prev.Name))
+		}
+		seen[r.Name] = true
+		prev = r
+	}
+	return errs
+}
+
+// ModuleAt returns the revision of the module name in ms that was current at
+// date, i.e., the module whose most recent revision is the latest on or
+// before date.  Only the modules read into ms are considered.  nil is
+// returned if ms has no such revision of the module.
+func (ms *Modules) ModuleAt(name string, date time.Time) *Module {
+	var found *Module
+	var foundDate time.Time
+	for _, m := range byKey(ms.Modules) {
+		if m.Name != name {
+			continue
+		}
+		latest, ok := m.LatestRevision()
+		if !ok || latest.Date.After(date) {
+			continue
+		}
+		if found == nil || latest.Date.After(foundDate) {
+			found, foundDate = m, latest.Date
+		}
+	}
+	return found
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRevisions(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module a {
+	prefix a;
+	namespace "urn:a";
+	revision 2021-03-01 { description "third"; reference "RFC 3"; }
+	revision 2021-02-30;
+	revision 2020-01-01;
+	revision 2020-06-01;
+	revision 2020-06-01;
+}`, "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	m := ms.Modules["a"]
+
+	var got []string
+	for _, r := range m.Revisions() {
+		got = append(got, fmt.Sprintf("%s %t %q %q", r.Name, r.Valid(), r.Description, r.Reference))
+	}
+	want := []string{
+		`2021-03-01 true "third" "RFC 3"`,
+		`2021-02-30 false "" ""`,
+		`2020-01-01 true "" ""`,
+		`2020-06-01 true "" ""`,
+		`2020-06-01 true "" ""`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Revisions() (-want, +got):\n%s", diff)
+	}
+
+	if r, ok := m.LatestRevision(); !ok || r.Name != "2021-03-01" {
+		t.Errorf("LatestRevision() got %s, %t, want 2021-03-01, true", r.Name, ok)
+	}
+
+	got = nil
+	for _, err := range m.CheckRevisions() {
+		got = append(got, err.Error())
+	}
+	want = []string{
+		`a.yang:5:2: invalid revision date "2021-02-30", want YYYY-MM-DD`,
+		`a.yang:7:2: revision 2020-06-01 is not listed in reverse chronological order, it follows 2020-01-01`,
+		`a.yang:8:2: revision 2020-06-01 is repeated`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CheckRevisions() (-want, +got):\n%s", diff)
+	}
+}
+
+func TestModuleAt(t *testing.T) {
+	ms := NewModules()
+	for _, rev := range []string{"2020-01-01", "2021-01-01", "2022-01-01"} {
+		src := fmt.Sprintf(`module a { prefix a; namespace "urn:a"; revision %s; }`, rev)
+		if err := ms.Parse(src, "a@"+rev+".yang"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	date := func(s string) time.Time {
+		d, err := time.Parse(RevisionDateLayout, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	for _, tt := range []struct {
+		name, date, want string
+	}{
+		{"a", "2021-01-01", "a@2021-01-01"},
+		{"a", "2021-06-30", "a@2021-01-01"},
+		{"a", "2030-01-01", "a@2022-01-01"},
+		{"a", "2019-01-01", ""},
+		{"b", "2021-01-01", ""},
+	} {
+		var got string
+		if m := ms.ModuleAt(tt.name, date(tt.date)); m != nil {
+			got = m.FullName()
+		}
+		if got != tt.want {
+			t.Errorf("ModuleAt(%s, %s) got %q, want %q", tt.name, tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A TokenKind is the kind of a Token.
+type TokenKind int
+
+const (
+	// UnquotedToken is an unquoted string, such as a keyword, an
+	// identifier or the + that concatenates quoted strings.
+	UnquotedToken TokenKind = iota
+	// QuotedToken is a single or double quoted string.
+	QuotedToken
+	// OpenBraceToken is a {.
+	OpenBraceToken
+	// CloseBraceToken is a }.
+	CloseBraceToken
+	// SemicolonToken is a ;.
+	SemicolonToken
+	// CommentToken is a // or /* */ comment, returned only if
+	// Scanner.Comments is set.
+	CommentToken
+)
+
+var tokenKindNames = map[TokenKind]string{
+	UnquotedToken:   "unquoted",
+	QuotedToken:     "quoted",
+	OpenBraceToken:  "{",
+	CloseBraceToken: "}",
+	SemicolonToken:  ";",
+	CommentToken:    "comment",
+}
+
+// String returns the name of k.
+func (k TokenKind) String() string {
+	if s, ok := tokenKindNames[k]; ok {
+		return s
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// A Token is one lexical unit of YANG source, as returned by a Scanner.
+type Token struct {
+	Kind TokenKind
+	// Text is the value of the token.  Quoted strings are dequoted, with
+	// the escapes of double quoted strings replaced and their indentation
+	// removed, as RFC 7950 section 6.1.3 describes.
+	Text string
+	// Raw is the token as written in the source, including any quotes.
+	Raw  string
+	File string // File is the name of the source.
+	Line int    // Line is the 1's based line of the start of the token.
+	Col  int    // Col is the 1's based column of the start (8 space tabs).
+	// Offset is the byte offset of the start of Raw in the source, after
+	// any byte order mark has been removed.
+	Offset int
+}
+
+// End returns the byte offset in the source just past the end of t.
+func (t *Token) End() int {
+	return t.Offset + len(t.Raw)
+}
+
+// String returns the location and text of t.
+func (t *Token) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s %q", t.File, t.Line, t.Col, t.Kind, t.Text)
+}
+
+// A Scanner returns the tokens of YANG source using the same rules the
+// parser uses, without building statements.  It is intended for syntax
+// highlighters and other tools that need the tokens, and their positions,
+// rather than an AST.
+//
+// A Scanner is used as a bufio.Scanner is:
+//
+//	s := yang.NewScanner(r)
+//	for s.Scan() {
+//		t := s.Token()
+//		...
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type Scanner struct {
+	// File is the name of the source used in tokens and errors.  It
+	// must be set, as must the options below, before the first call
+	// to Scan.
+	File string
+	// Comments requests that comments be returned as CommentTokens
+	// rather than skipped.
+	Comments bool
+	// StrictStrings enforces the rules of RFC 7950 on quoted strings, as
+	// Options.StrictStrings does for the parser.
+	StrictStrings bool
+
+	r      io.Reader
+	lex    *lexer
+	errout bytes.Buffer
+	tok    *Token
+	err    error
+}
+
+// NewScanner returns a Scanner that reads YANG source from r.  The source
+// is read in full by the first call to Scan, as a double quoted string can
+// only be dequoted once its end has been seen, and is then tokenized one
+// token per call to Scan.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{r: r}
+}
+
+// Scan advances s to the next token, which is then available through
+// Token.  It returns false at the end of the source or after an error,
+// which is returned by Err.
+func (s *Scanner) Scan() bool {
+	s.tok = nil
+	if s.err != nil {
+		return false
+	}
+	if s.lex == nil {
+		data, err := io.ReadAll(s.r)
+		if err != nil {
+			s.err = err
+			return false
+		}
+		input, err := decodeSource(string(data), s.File)
+		if err != nil {
+			s.err = err
+			return false
+		}
+		s.lex = newLexer(input, s.File)
+		s.lex.errout = &s.errout
+		s.lex.strict = s.StrictStrings
+		s.lex.comments = s.Comments
+	}
+	t := s.lex.NextToken()
+	if t == nil {
+		return false
+	}
+	var kind TokenKind
+	switch t.code {
+	case tError:
+		s.err = errors.New(strings.TrimSpace(s.errout.String()))
+		return false
+	case tUnquoted:
+		kind = UnquotedToken
+	case tString:
+		kind = QuotedToken
+	case tComment:
+		kind = CommentToken
+	case '{':
+		kind = OpenBraceToken
+	case '}':
+		kind = CloseBraceToken
+	case ';':
+		kind = SemicolonToken
+	default:
+		s.err = fmt.Errorf("%v: unexpected token %v", t, t.code)
+		return false
+	}
+	s.tok = &Token{
+		Kind:   kind,
+		Text:   t.Text,
+		Raw:    t.raw,
+		File:   t.File,
+		Line:   t.Line,
+		Col:    t.Col,
+		Offset: t.offset,
+	}
+	return true
+}
+
+// Token returns the token found by the last call to Scan, or nil if Scan
+// returned false.
+func (s *Scanner) Token() *Token {
+	return s.tok
+}
+
+// Err returns the first error encountered by s, or nil if the source was
+// tokenized without error.
+func (s *Scanner) Err() error {
+	return s.err
+}
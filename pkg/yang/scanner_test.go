@@ -0,0 +1,101 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestScanner(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		in       string
+		comments bool
+		want     []Token
+		wantErr  string
+	}{{
+		desc: "empty",
+	}, {
+		desc: "statements",
+		in:   "leaf a {\n  type 'string';\n}",
+		want: []Token{
+			{Kind: UnquotedToken, Text: "leaf", Raw: "leaf", File: "f.yang", Line: 1, Col: 1, Offset: 0},
+			{Kind: UnquotedToken, Text: "a", Raw: "a", File: "f.yang", Line: 1, Col: 6, Offset: 5},
+			{Kind: OpenBraceToken, Text: "{", Raw: "{", File: "f.yang", Line: 1, Col: 8, Offset: 7},
+			{Kind: UnquotedToken, Text: "type", Raw: "type", File: "f.yang", Line: 2, Col: 3, Offset: 11},
+			{Kind: QuotedToken, Text: "string", Raw: "'string'", File: "f.yang", Line: 2, Col: 8, Offset: 16},
+			{Kind: SemicolonToken, Text: ";", Raw: ";", File: "f.yang", Line: 2, Col: 16, Offset: 24},
+			{Kind: CloseBraceToken, Text: "}", Raw: "}", File: "f.yang", Line: 3, Col: 1, Offset: 26},
+		},
+	}, {
+		desc: "comments skipped",
+		in:   "// line\na /* block */ ;",
+		want: []Token{
+			{Kind: UnquotedToken, Text: "a", Raw: "a", File: "f.yang", Line: 2, Col: 1, Offset: 8},
+			{Kind: SemicolonToken, Text: ";", Raw: ";", File: "f.yang", Line: 2, Col: 15, Offset: 22},
+		},
+	}, {
+		desc:     "comments returned",
+		in:       "// line\na /* block */ ;",
+		comments: true,
+		want: []Token{
+			{Kind: CommentToken, Text: "// line", Raw: "// line", File: "f.yang", Line: 1, Col: 1, Offset: 0},
+			{Kind: UnquotedToken, Text: "a", Raw: "a", File: "f.yang", Line: 2, Col: 1, Offset: 8},
+			{Kind: CommentToken, Text: "/* block */", Raw: "/* block */", File: "f.yang", Line: 2, Col: 3, Offset: 10},
+			{Kind: SemicolonToken, Text: ";", Raw: ";", File: "f.yang", Line: 2, Col: 15, Offset: 22},
+		},
+	}, {
+		desc: "double quoted and concatenated",
+		in:   `"a\tb" + "c";`,
+		want: []Token{
+			{Kind: QuotedToken, Text: "a\tb", Raw: `"a\tb"`, File: "f.yang", Line: 1, Col: 1, Offset: 0},
+			{Kind: UnquotedToken, Text: "+", Raw: "+", File: "f.yang", Line: 1, Col: 8, Offset: 7},
+			{Kind: QuotedToken, Text: "c", Raw: `"c"`, File: "f.yang", Line: 1, Col: 10, Offset: 9},
+			{Kind: SemicolonToken, Text: ";", Raw: ";", File: "f.yang", Line: 1, Col: 13, Offset: 12},
+		},
+	}, {
+		desc: "unterminated string",
+		in:   "a 'b;\n",
+		want: []Token{
+			{Kind: UnquotedToken, Text: "a", Raw: "a", File: "f.yang", Line: 1, Col: 1, Offset: 0},
+		},
+		wantErr: "f.yang:1:3: missing closing '",
+	}} {
+		t.Run(tt.desc, func(t *testing.T) {
+			s := NewScanner(strings.NewReader(tt.in))
+			s.File = "f.yang"
+			s.Comments = tt.comments
+			var got []Token
+			for s.Scan() {
+				got = append(got, *s.Token())
+			}
+			if diff := errdiff.Substring(s.Err(), tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("tokens (-want, +got):\n%s", diff)
+			}
+			for _, tok := range got {
+				if tok.Raw != tt.in[tok.Offset:tok.End()] {
+					t.Errorf("%v: Raw %q is not the source %q at its offset", &tok, tok.Raw, tt.in[tok.Offset:tok.End()])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+// SMIv2Module is the name of the module defining the extensions used by YANG
+// modules translated from SMIv2 MIB modules, see RFC 6643.
+const SMIv2Module = "ietf-yang-smiv2"
+
+// SMIv2 is the SMIv2 mapping of a schema node, as described by the extensions
+// of the ietf-yang-smiv2 module.  Fields are empty when the corresponding
+// extension is not used.
+type SMIv2 struct {
+	OID         string        // OID is the object identifier of the node, from smiv2:oid.
+	SubID       string        // SubID is the sub-identifier appended to the parent's OID, from smiv2:subid.
+	MaxAccess   string        // MaxAccess is the MAX-ACCESS of the object, from smiv2:max-access.
+	DisplayHint string        // DisplayHint is the DISPLAY-HINT of a type, from smiv2:display-hint.
+	Defval      string        // Defval is the DEFVAL of the object, from smiv2:defval.
+	Implied     string        // Implied names the IMPLIED index of a list, from smiv2:implied.
+	Aliases     []*SMIv2Alias // Aliases are the smiv2:alias statements of a module.
+}
+
+// An SMIv2Alias maps an SMIv2 descriptor that has no YANG equivalent, such as
+// a MODULE-IDENTITY, to its object identifier.
+type SMIv2Alias struct {
+	Descriptor string
+	OID        string
+}
+
+// SMIv2 returns the SMIv2 mapping of e, or nil if e does not use any of the
+// extensions of the ietf-yang-smiv2 module.
+func (e *Entry) SMIv2() *SMIv2 {
+	if e == nil || e.Node == nil {
+		return nil
+	}
+	var s *SMIv2
+	for _, ext := range e.Exts {
+		id, ok := extensionIdentifier(e.Node, ext, SMIv2Module)
+		if !ok {
+			continue
+		}
+		if s == nil {
+			s = &SMIv2{}
+		}
+		switch id {
+		case "oid":
+			s.OID = ext.Argument
+		case "subid":
+			s.SubID = ext.Argument
+		case "max-access":
+			s.MaxAccess = ext.Argument
+		case "display-hint":
+			s.DisplayHint = ext.Argument
+		case "defval":
+			s.Defval = ext.Argument
+		case "implied":
+			s.Implied = ext.Argument
+		case "alias":
+			a := &SMIv2Alias{Descriptor: ext.Argument}
+			for _, sub := range ext.SubStatements() {
+				if id, ok := extensionIdentifier(e.Node, sub, SMIv2Module); ok && id == "oid" {
+					a.OID = sub.Argument
+				}
+			}
+			s.Aliases = append(s.Aliases, a)
+		}
+	}
+	return s
+}
+
+// SMIv2OID returns the object identifier of e and true, or false if e has no
+// object identifier.  A node using smiv2:subid has the OID of its nearest
+// ancestor with an OID followed by its sub-identifier.
+func (e *Entry) SMIv2OID() (string, bool) {
+	s := e.SMIv2()
+	switch {
+	case s == nil:
+		return "", false
+	case s.OID != "":
+		return s.OID, true
+	case s.SubID != "":
+		for p := e.Parent; p != nil; p = p.Parent {
+			if oid, ok := p.SMIv2OID(); ok {
+				return oid + "." + s.SubID, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSMIv2(t *testing.T) {
+	modules := map[string]string{
+		"ietf-yang-smiv2.yang": `
+			module ietf-yang-smiv2 {
+				prefix "smiv2";
+				namespace "urn:ietf:params:xml:ns:yang:ietf-yang-smiv2";
+
+				extension display-hint { argument "format"; }
+				extension max-access { argument "access"; }
+				extension defval { argument "value"; }
+				extension implied { argument "index"; }
+				extension alias { argument "descriptor"; }
+				extension oid { argument "value"; }
+				extension subid { argument "value"; }
+			}`,
+		"IF-MIB.yang": `
+			module IF-MIB {
+				prefix "if-mib";
+				namespace "urn:ietf:params:xml:ns:yang:smiv2:IF-MIB";
+
+				import ietf-yang-smiv2 { prefix smiv2; }
+
+				smiv2:alias "ifMIB" {
+					smiv2:oid "1.3.6.1.2.1.31";
+				}
+
+				container interfaces {
+					smiv2:oid "1.3.6.1.2.1.2";
+
+					leaf ifNumber {
+						smiv2:max-access "read-only";
+						smiv2:oid "1.3.6.1.2.1.2.1";
+						type int32;
+					}
+
+					list ifEntry {
+						smiv2:oid "1.3.6.1.2.1.2.2.1";
+						key "ifIndex";
+						leaf ifIndex {
+							smiv2:max-access "read-only";
+							smiv2:defval "1";
+							type int32;
+						}
+						leaf ifDescr {
+							smiv2:subid "2";
+							smiv2:display-hint "255a";
+							type string;
+						}
+					}
+				}
+				leaf plain { type string; }
+			}`,
+	}
+	ms := NewModules()
+	for name, mod := range modules {
+		if err := ms.Parse(mod, name); err != nil {
+			t.Fatalf("cannot parse module %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	mib, _ := ms.GetModule("IF-MIB")
+
+	tests := []struct {
+		desc    string
+		entry   *Entry
+		want    *SMIv2
+		wantOID string
+	}{{
+		desc:  "module alias",
+		entry: mib,
+		want:  &SMIv2{Aliases: []*SMIv2Alias{{Descriptor: "ifMIB", OID: "1.3.6.1.2.1.31"}}},
+	}, {
+		desc:    "leaf with oid and max-access",
+		entry:   mib.Dir["interfaces"].Dir["ifNumber"],
+		want:    &SMIv2{OID: "1.3.6.1.2.1.2.1", MaxAccess: "read-only"},
+		wantOID: "1.3.6.1.2.1.2.1",
+	}, {
+		desc:  "leaf with defval",
+		entry: mib.Dir["interfaces"].Dir["ifEntry"].Dir["ifIndex"],
+		want:  &SMIv2{MaxAccess: "read-only", Defval: "1"},
+	}, {
+		desc:    "leaf with subid",
+		entry:   mib.Dir["interfaces"].Dir["ifEntry"].Dir["ifDescr"],
+		want:    &SMIv2{SubID: "2", DisplayHint: "255a"},
+		wantOID: "1.3.6.1.2.1.2.2.1.2",
+	}, {
+		desc:  "no smiv2 extensions",
+		entry: mib.Dir["plain"],
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.entry.SMIv2()); diff != "" {
+				t.Errorf("SMIv2() (-want, +got):\n%s", diff)
+			}
+			oid, ok := tt.entry.SMIv2OID()
+			if oid != tt.wantOID || ok != (tt.wantOID != "") {
+				t.Errorf("SMIv2OID() got (%q, %v), want %q", oid, ok, tt.wantOID)
+			}
+		})
+	}
+}
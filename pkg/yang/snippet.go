@@ -0,0 +1,127 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// locationRegex matches the file:line:col location of an error.
+var locationRegex = regexp.MustCompile(`([^\s:]+):(\d+):(\d+)`)
+
+// FormatError returns the message of err with the line of source each
+// location in it refers to, with the token at the location underlined.  Only
+// locations in the sources parsed by ms are shown.  For example:
+//
+//	a.yang:3:10: unknown type: a:t
+//	    3 |   leaf x { type t; }
+//	      |            ^^^^^^
+func (ms *Modules) FormatError(err error) string {
+	var b strings.Builder
+	for i, line := range strings.Split(err.Error(), "\n") {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(line)
+		// Only the first location in a line is shown, as later
+		// locations generally refer to related statements.
+		m := locationRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		src, ok := ms.source(m[1])
+		if !ok {
+			continue
+		}
+		ln, _ := strconv.Atoi(m[2])
+		col, _ := strconv.Atoi(m[3])
+		if s := Snippet(src, ln, col); s != "" {
+			b.WriteByte('\n')
+			b.WriteString(s)
+		}
+	}
+	return b.String()
+}
+
+// Snippet returns line of src, prefixed by its line number, followed by a line
+// underlining the token starting at col.  Lines and columns are 1's based, and
+// columns count characters.  An empty string is returned if src does not have
+// line.
+func Snippet(src string, line, col int) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	text := []rune(strings.TrimRight(lines[line-1], "\r"))
+	if col < 1 {
+		col = 1
+	}
+	if col > len(text)+1 {
+		col = len(text) + 1
+	}
+
+	// Preserve tabs so the underline lines up with the source.
+	var under strings.Builder
+	for _, r := range text[:col-1] {
+		if r == '\t' {
+			under.WriteRune('\t')
+		} else {
+			under.WriteRune(' ')
+		}
+	}
+	n := tokenLength(text[col-1:])
+	under.WriteString(strings.Repeat("^", n))
+
+	num := strconv.Itoa(line)
+	pad := strings.Repeat(" ", len(num))
+	return fmt.Sprintf("    %s | %s\n    %s | %s", num, string(text), pad, under.String())
+}
+
+// tokenLength returns the length of the token at the start of text, at least
+// 1.  A token is either a quoted string, a run of characters other than
+// spaces and ";{}", or a single other character.
+func tokenLength(text []rune) int {
+	if len(text) == 0 {
+		return 1
+	}
+	switch q := text[0]; q {
+	case '"', '\'':
+		for i := 1; i < len(text); i++ {
+			if text[i] == '\\' && q == '"' {
+				i++
+				continue
+			}
+			if text[i] == q {
+				return i + 1
+			}
+		}
+		return len(text)
+	}
+	n := 0
+	for _, r := range text {
+		if unicode.IsSpace(r) || strings.ContainsRune(";{}", r) {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		desc string
+		src  string
+		line int
+		col  int
+		want string
+	}{{
+		desc: "word",
+		src:  "module a {\n  leaf x { type t; }\n}",
+		line: 2,
+		col:  12,
+		want: "    2 |   leaf x { type t; }\n      |            ^^^^",
+	}, {
+		desc: "tabs",
+		src:  "module a {\n\tleaf x {\n\t\ttype t;\n\t}\n}",
+		line: 3,
+		col:  3,
+		want: "    3 | \t\ttype t;\n      | \t\t^^^^",
+	}, {
+		desc: "quoted string",
+		src:  `description "a \" b" ;`,
+		line: 1,
+		col:  13,
+		want: "    1 | description \"a \\\" b\" ;\n      |             ^^^^^^^^",
+	}, {
+		desc: "punctuation",
+		src:  "leaf x {}",
+		line: 1,
+		col:  9,
+		want: "    1 | leaf x {}\n      |         ^",
+	}, {
+		desc: "end of line",
+		src:  "leaf x",
+		line: 1,
+		col:  10,
+		want: "    1 | leaf x\n      |       ^",
+	}, {
+		desc: "no such line",
+		src:  "leaf x",
+		line: 3,
+		col:  1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := Snippet(tt.src, tt.line, tt.col); got != tt.want {
+				t.Errorf("Snippet() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	ms := NewModules()
+	ms.ParseOptions.RetainSources = true
+	if err := ms.Parse("module a {\n  prefix a;\n  namespace urn:a;\n  leaf x { type t; }\n}\n", "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	errs := ms.Process()
+	if len(errs) != 1 {
+		t.Fatalf("Process() got errors %v, want 1", errs)
+	}
+	want := "a.yang:4:12: unknown type: a:t\n    4 |   leaf x { type t; }\n      |            ^^^^"
+	if got := ms.FormatError(errs[0]); got != want {
+		t.Errorf("FormatError() got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Parse errors hold an error on each line.
+	err := ms.Parse("module b {\n  prefix \"b;\n", "b.yang")
+	if err == nil {
+		t.Fatal("Parse() got no error")
+	}
+	want = "b.yang:2:10: missing closing \"\n    2 |   prefix \"b;\n      |          ^^^"
+	if got := ms.FormatError(err); !strings.HasPrefix(got, want) {
+		t.Errorf("FormatError() got:\n%s\nwant prefix:\n%s", got, want)
+	}
+
+	// Locations in files not parsed are not shown.
+	if got, want := ms.FormatError(errors.New("c.yang:1:1: bad")), "c.yang:1:1: bad"; got != want {
+		t.Errorf("FormatError() got %q, want %q", got, want)
+	}
+}
+
+func TestFormatErrorRereadsSource(t *testing.T) {
+	const src = "module a {\n  prefix a;\n  namespace urn:a;\n  leaf x { type t; }\n}\n"
+	file := filepath.Join(t.TempDir(), "a.yang")
+	if err := os.WriteFile(file, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ms := NewModules()
+	if err := ms.Read(file); err != nil {
+		t.Fatal(err)
+	}
+	if len(ms.sources) != 0 {
+		t.Errorf("Read() retained the sources of %v", ms.sources)
+	}
+	errs := ms.Process()
+	if len(errs) != 1 {
+		t.Fatalf("Process() got errors %v, want 1", errs)
+	}
+	want := file + ":4:12: unknown type: a:t\n    4 |   leaf x { type t; }\n      |            ^^^^"
+	if got := ms.FormatError(errs[0]); got != want {
+		t.Errorf("FormatError() got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// A source not read from a file cannot be shown unless retained.
+	ms = NewModules()
+	if err := ms.Parse(src, "b.yang"); err != nil {
+		t.Fatal(err)
+	}
+	errs = ms.Process()
+	if len(errs) != 1 {
+		t.Fatalf("Process() got errors %v, want 1", errs)
+	}
+	if got, want := ms.FormatError(errs[0]), "b.yang:4:12: unknown type: a:t"; got != want {
+		t.Errorf("FormatError() got %q, want %q", got, want)
+	}
+}
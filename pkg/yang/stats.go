@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"sort"
+)
+
+// Stats describes the size and complexity of the schema of a Modules.
+type Stats struct {
+	Modules    int // Modules is the number of modules.
+	Submodules int // Submodules is the number of submodules.
+	// Nodes is the number of schema nodes in the schema trees of the
+	// modules, including choice and case nodes and the nodes of RPCs,
+	// actions and notifications.
+	Nodes int
+	// Kinds is the number of nodes of each kind, keyed by the keyword
+	// of the statement defining them, such as "container" or "leaf".
+	Kinds map[string]int
+	// Depths is the number of nodes at each depth, where the top level
+	// nodes of a module are at depth 1.
+	Depths   map[int]int
+	MaxDepth int // MaxDepth is the depth of the deepest node.
+	// Leafrefs, Unions and Identityrefs are the number of leaves and
+	// leaf-lists of type leafref, union and identityref.
+	Leafrefs     int
+	Unions       int
+	Identityrefs int
+	Augments     int // Augments is the number of augment statements.
+	Deviations   int // Deviations is the number of deviate statements.
+	// ModuleNodes is the number of nodes defined by each module, keyed by
+	// module name.  Nodes defined in a submodule, or augmented into
+	// another module, count towards the module defining them.
+	ModuleNodes map[string]int
+}
+
+// Stats returns the statistics of the schema of ms, which must have been
+// processed.
+func (ms *Modules) Stats() *Stats {
+	st := &Stats{
+		Kinds:       map[string]int{},
+		Depths:      map[int]int{},
+		ModuleNodes: map[string]int{},
+	}
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			if mods[m.Name] != m {
+				continue
+			}
+			if m.Kind() == "module" {
+				st.Modules++
+			} else {
+				st.Submodules++
+			}
+			st.Augments += len(m.Augment)
+			for _, d := range m.Deviation {
+				st.Deviations += len(d.Deviate)
+			}
+		}
+	}
+	for _, m := range uniqueModules(ms.Modules) {
+		if ms.Modules[m.Name] != m {
+			continue
+		}
+		e := ToEntry(m)
+		for _, c := range e.children() {
+			st.add(c, 1)
+		}
+	}
+	return st
+}
+
+// uniqueModules returns the modules in mods, which holds each module under
+// both its name and name@revision, once each, ordered by name.
+func uniqueModules(mods map[string]*Module) []*Module {
+	seen := map[*Module]bool{}
+	var list []*Module
+	for _, m := range mods {
+		if !seen[m] {
+			seen[m] = true
+			list = append(list, m)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].FullName() < list[j].FullName() })
+	return list
+}
+
+// children returns the child schema nodes of e, sorted by name, including the
+// input and output of an RPC or action.
+func (e *Entry) children() []*Entry {
+	children := e.SortedDir()
+	if e.RPC != nil {
+		for _, c := range []*Entry{e.RPC.Input, e.RPC.Output} {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	}
+	return children
+}
+
+// add adds e, at depth, and its descendants to st.
+func (st *Stats) add(e *Entry, depth int) {
+	st.Nodes++
+	st.Depths[depth]++
+	if depth > st.MaxDepth {
+		st.MaxDepth = depth
+	}
+	if e.Node != nil {
+		// Leaf-lists are built from an equivalent leaf statement.
+		kind := e.Node.Kind()
+		if e.IsLeafList() {
+			kind = "leaf-list"
+		}
+		st.Kinds[kind]++
+		if RootNode(e.Node) != nil {
+			if m := module(e.Node); m != nil {
+				st.ModuleNodes[m.Name]++
+			}
+		}
+	}
+	if e.Type != nil {
+		switch e.Type.Kind {
+		case Yleafref:
+			st.Leafrefs++
+		case Yunion:
+			st.Unions++
+		case Yidentityref:
+			st.Identityrefs++
+		}
+	}
+	for _, c := range e.children() {
+		st.add(c, depth+1)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStats(t *testing.T) {
+	ms := NewModules()
+	for name, src := range map[string]string{
+		"base.yang": `module base {
+  prefix b;
+  namespace "urn:b";
+  include base-sub;
+  identity id;
+  container c {
+    leaf ref { type leafref { path "../name"; } }
+    leaf name { type string; }
+    leaf u { type union { type string; type int8; } }
+    leaf-list ids { type identityref { base id; } }
+  }
+  rpc reset {
+    input { leaf force { type boolean; } }
+  }
+}`,
+		"base-sub.yang": `submodule base-sub {
+  belongs-to base { prefix b; }
+  list l {
+    key k;
+    leaf k { type string; }
+    choice ch { leaf a { type string; } }
+  }
+}`,
+		"aug.yang": `module aug {
+  prefix a;
+  namespace "urn:a";
+  import base { prefix b; }
+  augment /b:c { leaf extra { type string; } }
+  deviation /b:c/b:u { deviate not-supported; }
+}`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("Process() got unexpected errors: %v", errs)
+	}
+
+	want := &Stats{
+		Modules:    2,
+		Submodules: 1,
+		Nodes:      13,
+		Kinds: map[string]int{
+			"case":      1,
+			"choice":    1,
+			"container": 1,
+			"input":     1,
+			"leaf":      6,
+			"leaf-list": 1,
+			"list":      1,
+			"rpc":       1,
+		},
+		Depths:       map[int]int{1: 3, 2: 7, 3: 2, 4: 1},
+		MaxDepth:     4,
+		Leafrefs:     1,
+		Identityrefs: 1,
+		Augments:     1,
+		Deviations:   1,
+		ModuleNodes:  map[string]int{"aug": 1, "base": 12},
+	}
+	if diff := cmp.Diff(want, ms.Stats()); diff != "" {
+		t.Errorf("Stats() (-want, +got):\n%s", diff)
+	}
+}
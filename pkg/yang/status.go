@@ -0,0 +1,147 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A StatusFilter selects the entries removed from the entry tree by the
+// value of their status statements.
+type StatusFilter int
+
+const (
+	// KeepAllStatus removes no entries.
+	KeepAllStatus StatusFilter = iota
+	// DropObsolete removes the entries whose status is obsolete.
+	DropObsolete
+	// DropDeprecated removes the entries whose status is deprecated or
+	// obsolete.
+	DropDeprecated
+)
+
+// drops returns true if f removes entries with status.
+func (f StatusFilter) drops(status string) bool {
+	switch f {
+	case DropObsolete:
+		return status == "obsolete"
+	case DropDeprecated:
+		return status == "obsolete" || status == "deprecated"
+	}
+	return false
+}
+
+// Status returns the value of the status statement of e, "current" if e has
+// none.  The status of an entry is not inherited by its descendants.
+func (e *Entry) Status() string {
+	for _, x := range e.Extra["status"] {
+		if v, ok := x.(*Value); ok {
+			return v.Name
+		}
+	}
+	return "current"
+}
+
+// DropStatus removes the entries selected by f, and their descendants, from
+// the entry trees of the processed modules of ms, along with the cases left
+// empty.  It is called by Process
+// when ParseOptions.DropStatus is set.
+func (ms *Modules) DropStatus(f StatusFilter) {
+	if f == KeepAllStatus {
+		return
+	}
+	var walk func(e *Entry)
+	walk = func(e *Entry) {
+		for _, c := range e.children() {
+			if f.drops(c.Status()) {
+				c.Parent.delete(c.Name)
+				continue
+			}
+			walk(c)
+			// A case left with no data nodes, such as the implicit
+			// case of a dropped shorthand case, is dropped with them.
+			if c.IsCase() && len(c.Dir) == 0 {
+				c.Parent.delete(c.Name)
+			}
+		}
+	}
+	for _, mods := range []map[string]*Module{ms.Modules, ms.SubModules} {
+		for _, m := range uniqueModules(mods) {
+			if e := ms.getEntryCache(m); e != nil {
+				walk(e)
+			}
+		}
+	}
+}
+
+// A StatusInfo describes an entry whose status is deprecated or obsolete.
+type StatusInfo struct {
+	Status string // Status is "deprecated" or "obsolete".
+	Path   string // Path is the schema path of the entry.
+	// Module is the name of the module the entry is defined in, the module
+	// a submodule belongs to.
+	Module string
+	// Replacement is the sentence of the description of the entry that
+	// names its replacement, such as "Replaced by the state container.",
+	// or "" if none is found.
+	Replacement string
+	Entry       *Entry
+}
+
+// replacementRE matches the phrases of a description that introduce the
+// replacement of a deprecated or obsolete node.
+var replacementRE = regexp.MustCompile(`(?i)\b(replaced by|replacement|superseded by|in favou?r of|instead|moved to|migrate to|renamed to)\b`)
+
+// sentenceRE matches the end of a sentence.
+var sentenceRE = regexp.MustCompile(`[.!?]\s+`)
+
+// replacement returns the first sentence of the description d that names a
+// replacement, or "".
+func replacement(d string) string {
+	d = strings.Join(strings.Fields(d), " ")
+	var sentences []string
+	start := 0
+	for _, loc := range sentenceRE.FindAllStringIndex(d, -1) {
+		// Keep the punctuation ending the sentence.
+		sentences = append(sentences, d[start:loc[0]+1])
+		start = loc[1]
+	}
+	for _, s := range append(sentences, d[start:]) {
+		if replacementRE.MatchString(s) {
+			return s
+		}
+	}
+	return ""
+}
+
+// StatusReport returns the entries of the processed modules in ms whose
+// status is deprecated or obsolete.  Modules are in the order of their
+// names, and the entries of each in depth first order of their names.  The
+// descendants of such an entry are only listed if they have a status of
+// their own.
+func (ms *Modules) StatusReport() []*StatusInfo {
+	var infos []*StatusInfo
+	for _, e := range ms.entries(func(e *Entry) bool { return e.Status() != "current" }) {
+		infos = append(infos, &StatusInfo{
+			Status:      e.Status(),
+			Path:        e.Path(),
+			Module:      e.Provenance.Module,
+			Replacement: replacement(e.Description),
+			Entry:       e,
+		})
+	}
+	return infos
+}
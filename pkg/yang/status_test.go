@@ -0,0 +1,106 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testStatusModule = `module st {
+  namespace "urn:st";
+  prefix "st";
+  container c {
+    leaf cur { type string; }
+    leaf old {
+      type string;
+      status deprecated;
+      description
+        "The old name.  Replaced by the leaf cur.  Do not use.";
+    }
+    container gone {
+      status obsolete;
+      description "No longer supported.";
+      leaf x {
+        type string;
+        status deprecated;
+      }
+    }
+    choice ch {
+      leaf in-choice {
+        type string;
+        status obsolete;
+        description "Use cur instead";
+      }
+    }
+  }
+}`
+
+func TestStatusReport(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(testStatusModule, "st.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	type info struct{ Status, Path, Module, Replacement string }
+	var got []info
+	for _, si := range ms.StatusReport() {
+		got = append(got, info{si.Status, si.Path, si.Module, si.Replacement})
+	}
+	want := []info{
+		{"obsolete", "/st/c/ch/in-choice/in-choice", "st", "Use cur instead"},
+		{"obsolete", "/st/c/gone", "st", ""},
+		{"deprecated", "/st/c/gone/x", "st", ""},
+		{"deprecated", "/st/c/old", "st", "Replaced by the leaf cur."},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StatusReport (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDropStatus(t *testing.T) {
+	for _, tt := range []struct {
+		filter    StatusFilter
+		want      []string
+		wantCases int
+	}{
+		{KeepAllStatus, []string{"ch", "cur", "gone", "old"}, 1},
+		{DropObsolete, []string{"ch", "cur", "old"}, 0},
+		{DropDeprecated, []string{"ch", "cur"}, 0},
+	} {
+		ms := NewModules()
+		ms.ParseOptions.DropStatus = tt.filter
+		if err := ms.Parse(testStatusModule, "st.yang"); err != nil {
+			t.Fatal(err)
+		}
+		if errs := ms.Process(); len(errs) > 0 {
+			t.Fatalf("cannot process modules: %v", errs)
+		}
+		c := ToEntry(ms.Modules["st"]).Dir["c"]
+		var got []string
+		for _, e := range c.SortedDir() {
+			got = append(got, e.Name)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("filter %d: entries (-want, +got):\n%s", tt.filter, diff)
+		}
+		if got := len(c.Dir["ch"].Dir); got != tt.wantCases {
+			t.Errorf("filter %d: got %d cases, want %d", tt.filter, got, tt.wantCases)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+)
+
+// A Text is the text of a description or reference statement.
+type Text struct {
+	// Text is the argument of the statement, with its line breaks and
+	// the indentation of its continuation lines relative to the opening
+	// quote kept, as given by RFC 7950 section 6.1.3.
+	Text string
+	// Raw is the argument as written in the source, including its quotes
+	// and concatenation, or "" if it is not known.
+	Raw string
+}
+
+// textOf returns the Text of the statement v, which may be nil.
+func textOf(v *Value) Text {
+	if v == nil {
+		return Text{}
+	}
+	t := Text{Text: v.Name}
+	if v.Source != nil {
+		t.Raw = v.Source.RawArgument()
+	}
+	return t
+}
+
+// Normalized returns the text of t as paragraphs, separated by a blank line,
+// in each of which the line breaks and runs of whitespace are replaced by a
+// single space.
+func (t Text) Normalized() string {
+	var paras []string
+	var words []string
+	for _, line := range strings.Split(t.Text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(words) > 0 {
+				paras = append(paras, strings.Join(words, " "))
+				words = nil
+			}
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	if len(words) > 0 {
+		paras = append(paras, strings.Join(words, " "))
+	}
+	return strings.Join(paras, "\n\n")
+}
+
+// DescriptionText returns the Text of the description of e, that of a refine
+// statement if refined.  Its Text is e.Description.
+func (e *Entry) DescriptionText() Text {
+	if e.description == nil || e.description.Name != e.Description {
+		// The description was set or truncated without a statement.
+		return Text{Text: e.Description}
+	}
+	return textOf(e.description)
+}
+
+// ReferenceText returns the Text of the reference statement of e, that of a
+// refine statement if refined.
+func (e *Entry) ReferenceText() Text {
+	for _, x := range e.Extra["reference"] {
+		if v, ok := x.(*Value); ok {
+			return textOf(v)
+		}
+	}
+	return Text{}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEntryText(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module tx {
+  namespace "urn:tx";
+  prefix "tx";
+  grouping g {
+    leaf l {
+      type string;
+      description "From the grouping.";
+    }
+  }
+  container c {
+    description
+      "A container.
+       Its fields are:
+         - a list
+         - a leaf
+
+       See below.";
+    reference
+      'RFC 7950
+         section 6.1.3';
+    uses g {
+      refine l {
+        description "Refined " +
+          "text.";
+      }
+    }
+  }
+}`, "tx.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	c := ToEntry(ms.Modules["tx"]).Dir["c"]
+	for _, tt := range []struct {
+		desc           string
+		got            Text
+		want           Text
+		wantNormalized string
+	}{{
+		desc: "multi-line description",
+		got:  c.DescriptionText(),
+		want: Text{
+			Text: "A container.\nIts fields are:\n  - a list\n  - a leaf\n\nSee below.",
+			Raw:  "\"A container.\n       Its fields are:\n         - a list\n         - a leaf\n\n       See below.\"",
+		},
+		wantNormalized: "A container. Its fields are: - a list - a leaf\n\nSee below.",
+	}, {
+		desc: "single quoted reference",
+		got:  c.ReferenceText(),
+		want: Text{
+			Text: "RFC 7950\n         section 6.1.3",
+			Raw:  "'RFC 7950\n         section 6.1.3'",
+		},
+		wantNormalized: "RFC 7950 section 6.1.3",
+	}, {
+		desc:           "refined description",
+		got:            c.Dir["l"].DescriptionText(),
+		want:           Text{Text: "Refined text.", Raw: "\"Refined \" +\n          \"text.\""},
+		wantNormalized: "Refined text.",
+	}, {
+		desc: "no reference",
+		got:  c.Dir["l"].ReferenceText(),
+	}} {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("%s (-want, +got):\n%s", tt.desc, diff)
+		}
+		if got := tt.got.Normalized(); got != tt.wantNormalized {
+			t.Errorf("%s: Normalized() got %q, want %q", tt.desc, got, tt.wantNormalized)
+		}
+	}
+	if c.DescriptionText().Text != c.Description {
+		t.Errorf("DescriptionText().Text got %q, want Description %q", c.DescriptionText().Text, c.Description)
+	}
+}
@@ -65,7 +65,7 @@ func (d *typeDictionary) find(n Node, name string) *Typedef {
 func (d *typeDictionary) findExternal(n Node, prefix, name string) (*Typedef, error) {
 	root := FindModuleByPrefix(n, prefix)
 	if root == nil {
-		return nil, fmt.Errorf("%s: unknown prefix: %s for type %s", Source(n), prefix, name)
+		return nil, codeErrorf("GY0108", "%s: unknown prefix: %s for type %s", Source(n), prefix, name)
 	}
 	if td := d.find(root, name); td != nil {
 		return td, nil
@@ -73,7 +73,7 @@ func (d *typeDictionary) findExternal(n Node, prefix, name string) (*Typedef, er
 	if prefix != "" {
 		name = prefix + ":" + name
 	}
-	return nil, fmt.Errorf("%s: unknown type %s", Source(n), name)
+	return nil, missingImportErrorf("GY0201", n, name, "%s: unknown type %s", Source(n), name)
 }
 
 // typedefs returns a slice of all typedefs in d.
@@ -145,7 +145,7 @@ func (t *Typedef) resolve(d *typeDictionary) []error {
 		if idBase, err := RootNode(t).findIdentityBase(t.Type.IdentityBase.Name); err == nil {
 			y.IdentityBase = idBase.Identity
 		} else {
-			return []error{fmt.Errorf("could not resolve identity base for typedef: %s", t.Type.IdentityBase.Name)}
+			return []error{codeErrorf("GY0207", "could not resolve identity base for typedef: %s", t.Type.IdentityBase.Name)}
 		}
 	}
 
@@ -201,7 +201,7 @@ check:
 			pname = fmt.Sprintf("%s[%s]:%s", prefix, root.Prefix.Name, t.Name)
 		}
 
-		return []error{fmt.Errorf("%s: unknown type: %s", Source(t), pname)}
+		return []error{codeErrorf("GY0201", "%s: unknown type: %s", Source(t), pname)}
 
 	default:
 		source = "imported"
@@ -211,6 +211,13 @@ check:
 		var err error
 		td, err = d.findExternal(t, prefix, name)
 		if err != nil {
+			if isMissingImport(err) {
+				// The type is from a placeholder module, so
+				// all that is known is its name.
+				y := &YangType{Name: t.Name, Kind: Ynone, Base: t}
+				y.Root = y
+				t.YangType = y
+			}
 			return []error{err}
 		}
 	}
@@ -230,8 +237,11 @@ check:
 
 	if v := t.RequireInstance; v != nil {
 		b, err := v.asBool()
-		if err != nil {
-			errs = append(errs, err)
+		switch {
+		case err != nil:
+			errs = append(errs, withCode("GY0804", err))
+		case y.Kind != Yleafref && y.Kind != YinstanceIdentifier:
+			errs = append(errs, fmt.Errorf("%s: require-instance only allowed for leafref and instance-identifier types", Source(v)))
 		}
 		y.OptionalInstance = !b
 	}
@@ -242,7 +252,7 @@ check:
 	switch {
 	case isDecimal64 && y.FractionDigits != 0:
 		if t.FractionDigits != nil {
-			return append(errs, fmt.Errorf("%s: overriding of fraction-digits not allowed", Source(t)))
+			return append(errs, codeErrorf("GY0205", "%s: overriding of fraction-digits not allowed", Source(t)))
 		}
 		// FractionDigits already set via type inheritance.
 	case isDecimal64:
@@ -250,7 +260,7 @@ check:
 		// fraction-digits in the range from 1-18.
 		i, err := t.FractionDigits.asRangeInt(1, 18)
 		if err != nil {
-			errs = append(errs, fmt.Errorf("%s: %v", Source(t), err))
+			errs = append(errs, codeErrorf("GY0205", "%s: %v", Source(t), err))
 		}
 		y.FractionDigits = int(i)
 		// We only know to how to populate Range after knowing the
@@ -260,7 +270,7 @@ check:
 			Number{Value: MaxInt64, FractionDigits: uint8(i)},
 		}}
 	case t.FractionDigits != nil:
-		errs = append(errs, fmt.Errorf("%s: fraction-digits only allowed for decimal64 values", Source(t)))
+		errs = append(errs, codeErrorf("GY0205", "%s: fraction-digits only allowed for decimal64 values", Source(t)))
 	case y.Kind == Yidentityref:
 		if source != "builtin" {
 			// This is a typedef that refers to an identityref, so we want to simply
@@ -269,7 +279,7 @@ check:
 		}
 
 		if t.IdentityBase == nil {
-			errs = append(errs, fmt.Errorf("%s: an identityref must specify a base", Source(t)))
+			errs = append(errs, codeErrorf("GY0207", "%s: an identityref must specify a base", Source(t)))
 			break
 		}
 
@@ -281,7 +291,7 @@ check:
 		}
 
 		if resolvedBase.Identity == nil {
-			errs = append(errs, fmt.Errorf("%s: identity has a null base", t.IdentityBase.Name))
+			errs = append(errs, codeErrorf("GY0301", "%s: identity has a null base", t.IdentityBase.Name))
 			break
 		}
 		y.IdentityBase = resolvedBase.Identity
@@ -291,10 +301,12 @@ check:
 		yr, err := y.Range.parseChildRanges(t.Range.Name, isDecimal64, uint8(y.FractionDigits))
 		switch {
 		case err != nil:
-			errs = append(errs, fmt.Errorf("%s: bad range: %v", Source(t.Range), err))
+			errs = append(errs, codeErrorf("GY0202", "%s: bad range: %v", Source(t.Range), err))
 		case yr.Equal(y.Range):
+			y.RangeError = t.Range.ConstraintError()
 		default:
 			y.Range = yr
+			y.RangeError = t.Range.ConstraintError()
 		}
 	}
 
@@ -306,12 +318,14 @@ check:
 		yr, err := parentRange.parseChildRanges(t.Length.Name, false, 0)
 		switch {
 		case err != nil:
-			errs = append(errs, fmt.Errorf("%s: bad length: %v", Source(t.Length), err))
+			errs = append(errs, codeErrorf("GY0203", "%s: bad length: %v", Source(t.Length), err))
 		case yr.Equal(y.Length):
+			y.LengthError = t.Length.ConstraintError()
 		default:
+			y.LengthError = t.Length.ConstraintError()
 			for _, r := range yr {
 				if r.Min.Negative {
-					errs = append(errs, fmt.Errorf("%s: negative length: %v", Source(t.Length), yr))
+					errs = append(errs, codeErrorf("GY0203", "%s: negative length: %v", Source(t.Length), yr))
 					break
 				}
 			}
@@ -325,11 +339,11 @@ check:
 		}
 		n, err := ParseInt(value.Name)
 		if err != nil {
-			return err
+			return withCode("GY0804", err)
 		}
 		i, err := n.Int()
 		if err != nil {
-			return err
+			return withCode("GY0804", err)
 		}
 		return e.Set(name, i)
 	}
@@ -338,7 +352,7 @@ check:
 		enum := NewEnumType()
 		for _, e := range t.Enum {
 			if err := set(enum, e.Name, e.Value); err != nil {
-				errs = append(errs, fmt.Errorf("%s: %v", Source(e), err))
+				errs = append(errs, fmt.Errorf("%s: %w", Source(e), err))
 			}
 		}
 		y.Enum = enum
@@ -348,7 +362,7 @@ check:
 		bit := NewBitfield()
 		for _, e := range t.Bit {
 			if err := set(bit, e.Name, e.Position); err != nil {
-				errs = append(errs, fmt.Errorf("%s: %v", Source(e), err))
+				errs = append(errs, fmt.Errorf("%s: %w", Source(e), err))
 			}
 		}
 		y.Bit = bit
@@ -372,6 +386,14 @@ check:
 	for _, pv := range t.Pattern {
 		if !seenPatterns[pv.Name] {
 			seenPatterns[pv.Name] = true
+			if pe := pv.ConstraintError(); pe != nil || y.PatternErrors != nil {
+				// Pad the errors to the patterns, copying
+				// those of the base type as it may share the
+				// array.
+				pes := make([]*ConstraintError, len(y.Pattern), len(y.Pattern)+1)
+				copy(pes, y.PatternErrors)
+				y.PatternErrors = append(pes, pe)
+			}
 			y.Pattern = append(y.Pattern, pv.Name)
 		}
 	}
@@ -390,7 +412,7 @@ check:
 				// the error, re.Code is the real error.
 				err = errors.New(re.Code.String())
 			}
-			errs = append(errs, fmt.Errorf("%s: bad pattern: %v: %s", Source(n), err, p))
+			errs = append(errs, codeErrorf("GY0204", "%s: bad pattern: %v: %s", Source(n), err, p))
 		}
 	}
 	for _, ext := range posixPatterns {
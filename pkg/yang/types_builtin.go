@@ -608,16 +608,16 @@ func NewBitfield() *EnumType {
 // the most recently assigned name.
 func (e *EnumType) Set(name string, value int64) error {
 	if _, ok := e.ToInt[name]; ok {
-		return fmt.Errorf("field %s already assigned", name)
+		return codeErrorf("GY0206", "field %s already assigned", name)
 	}
 	if oname, ok := e.ToString[value]; e.unique && ok {
-		return fmt.Errorf("fields %s and %s conflict on value %d", name, oname, value)
+		return codeErrorf("GY0206", "fields %s and %s conflict on value %d", name, oname, value)
 	}
 	if value < e.min {
-		return fmt.Errorf("value %d for %s too small (minimum is %d)", value, name, e.min)
+		return codeErrorf("GY0804", "value %d for %s too small (minimum is %d)", value, name, e.min)
 	}
 	if value > e.max {
-		return fmt.Errorf("value %d for %s too large (maximum is %d)", value, name, e.max)
+		return codeErrorf("GY0804", "value %d for %s too large (maximum is %d)", value, name, e.max)
 	}
 	e.ToString[value] = name
 	e.ToInt[name] = value
@@ -631,7 +631,7 @@ func (e *EnumType) Set(name string, value int64) error {
 // all previous values.
 func (e *EnumType) SetNext(name string) error {
 	if e.last == MaxEnum {
-		return fmt.Errorf("enum %q must specify a value since previous enum is the maximum value allowed", name)
+		return codeErrorf("GY0206", "enum %q must specify a value since previous enum is the maximum value allowed", name)
 	}
 	return e.Set(name, e.last+1)
 }
@@ -1718,3 +1718,84 @@ func filterRanges(ytype *YangType) *testRangeTypeStruct {
 	}
 	return filteredType
 }
+
+func TestConstraintErrors(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module a {
+	prefix a;
+	namespace "urn:a";
+	typedef percent {
+		type uint8 {
+			range "0..100" {
+				error-app-tag "out-of-range";
+				error-message "must be a percentage";
+			}
+		}
+	}
+	typedef name {
+		type string {
+			length "1..32" { error-message "too long"; }
+			pattern "[a-z]+";
+		}
+	}
+	container c {
+		must "p > 0" {
+			error-app-tag "zero";
+			error-message "p must not be zero";
+		}
+		must "count(n) > 0";
+		leaf p { type percent; }
+		leaf half { type percent { range "0..50"; } }
+		leaf n {
+			type name {
+				pattern "[a-m]+" { error-app-tag "bad-name"; }
+			}
+		}
+	}
+}`, "a.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process: %v", errs)
+	}
+	a, _ := ms.GetModule("a")
+	c := a.Dir["c"]
+
+	for _, tt := range []struct {
+		desc string
+		got  interface{}
+		want interface{}
+	}{{
+		desc: "range of a typedef",
+		got:  c.Dir["p"].Type.RangeError,
+		want: &ConstraintError{AppTag: "out-of-range", Message: "must be a percentage"},
+	}, {
+		desc: "range restricted without errors",
+		got:  c.Dir["half"].Type.RangeError,
+		want: (*ConstraintError)(nil),
+	}, {
+		desc: "length of a typedef",
+		got:  c.Dir["n"].Type.LengthError,
+		want: &ConstraintError{Message: "too long"},
+	}, {
+		desc: "patterns",
+		got:  c.Dir["n"].Type.PatternErrors,
+		want: []*ConstraintError{nil, {AppTag: "bad-name"}},
+	}, {
+		desc: "patterns of the typedef",
+		got:  ms.Modules["a"].Typedef[1].YangType.PatternErrors,
+		want: []*ConstraintError(nil),
+	}} {
+		if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+			t.Errorf("%s (-want, +got):\n%s", tt.desc, diff)
+		}
+	}
+
+	var musts []string
+	for _, m := range c.Musts() {
+		musts = append(musts, fmt.Sprintf("%s %v", m.Name, m.ConstraintError()))
+	}
+	if diff := cmp.Diff([]string{`p > 0 &{zero p must not be zero}`, `count(n) > 0 <nil>`}, musts); diff != "" {
+		t.Errorf("Musts() (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A ModuleVersion is the version of a module, given by its most recent
+// revision and, for OpenConfig modules, its oc-ext:openconfig-version.
+type ModuleVersion struct {
+	Module   string // Module is the name of the module.
+	Revision string // Revision is the most recent revision date, if any.
+	// SemVer is the semantic version given by the module's
+	// openconfig-version extension, if any.
+	SemVer string
+}
+
+// ModuleVersion returns the version of the module m.
+func (m *Module) ModuleVersion() ModuleVersion {
+	v := ModuleVersion{Module: m.Name, Revision: m.Current()}
+	// Extensions with unresolvable prefixes are reported when the module
+	// is processed, they are ignored here.
+	if exts, _ := MatchingExtensions(m, "openconfig-extensions", "openconfig-version"); len(exts) > 0 {
+		v.SemVer = exts[0].Argument
+	}
+	return v
+}
+
+// ModuleVersions returns the versions of the modules in ms, ordered by module
+// name.  Only the most recent revision of each module is included.
+func (ms *Modules) ModuleVersions() []ModuleVersion {
+	mods := ms.SortedModules()
+	vs := make([]ModuleVersion, len(mods))
+	for i, m := range mods {
+		vs[i] = m.ModuleVersion()
+	}
+	return vs
+}
+
+// AtLeast returns true if v has a semantic version greater than or equal to
+// min.  An error is returned if either v has no semantic version or it, or
+// min, cannot be parsed.
+func (v ModuleVersion) AtLeast(min string) (bool, error) {
+	if v.SemVer == "" {
+		return false, fmt.Errorf("module %s has no openconfig-version", v.Module)
+	}
+	c, err := CompareSemVer(v.SemVer, min)
+	if err != nil {
+		return false, fmt.Errorf("module %s: %v", v.Module, err)
+	}
+	return c >= 0, nil
+}
+
+// A SemVer is a semantic version, see https://semver.org.  Build metadata is
+// discarded when parsing, it does not take part in comparisons.
+type SemVer struct {
+	Major, Minor, Patch uint64
+	PreRelease          string // PreRelease is the pre-release version, if any.
+}
+
+// String returns v in its textual form.
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	return s
+}
+
+// ParseSemVer parses the semantic version s.
+func ParseSemVer(s string) (SemVer, error) {
+	var v SemVer
+	core := s
+	if i := strings.Index(core, "+"); i >= 0 {
+		core = core[:i]
+	}
+	if i := strings.Index(core, "-"); i >= 0 {
+		v.PreRelease = core[i+1:]
+		core = core[:i]
+		if v.PreRelease == "" {
+			return SemVer{}, fmt.Errorf("invalid semantic version %q: empty pre-release", s)
+		}
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return SemVer{}, fmt.Errorf("invalid semantic version %q: want MAJOR.MINOR.PATCH", s)
+	}
+	for i, p := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		n, err := strconv.ParseUint(parts[i], 10, 64)
+		if err != nil {
+			return SemVer{}, fmt.Errorf("invalid semantic version %q: %v", s, err)
+		}
+		*p = n
+	}
+	return v, nil
+}
+
+// Compare returns -1 if v is less than o, 0 if they are equal, and 1 if v is
+// greater than o, following the precedence rules of semantic versioning.
+func (v SemVer) Compare(o SemVer) int {
+	for _, c := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		switch {
+		case c[0] < c[1]:
+			return -1
+		case c[0] > c[1]:
+			return 1
+		}
+	}
+	// A version without a pre-release is greater than one with.
+	switch {
+	case v.PreRelease == o.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case o.PreRelease == "":
+		return -1
+	}
+	return comparePreRelease(v.PreRelease, o.PreRelease)
+}
+
+// comparePreRelease compares the pre-release versions a and b, identifier by
+// identifier.  Numeric identifiers are compared numerically and have lower
+// precedence than alphanumeric identifiers, which are compared lexically.
+func comparePreRelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.ParseUint(as[i], 10, 64)
+		bn, berr := strconv.ParseUint(bs[i], 10, 64)
+		switch {
+		case aerr == nil && berr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aerr == nil:
+			return -1
+		case berr == nil:
+			return 1
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// CompareSemVer parses and compares the semantic versions a and b, see
+// SemVer.Compare.
+func CompareSemVer(a, b string) (int, error) {
+	va, err := ParseSemVer(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseSemVer(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
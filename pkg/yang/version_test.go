@@ -0,0 +1,95 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestModuleVersions(t *testing.T) {
+	ms := NewModules()
+	ms.AddPath(filepath.Join("testdata", "modeldata"))
+	for _, name := range []string{"openconfig-platform", "ietf-interfaces"} {
+		if err := ms.Read(name); err != nil {
+			t.Fatalf("cannot read %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+
+	want := []ModuleVersion{
+		{Module: "ietf-interfaces", Revision: "2018-02-20"},
+		{Module: "openconfig-extensions"},
+		{Module: "openconfig-platform", Revision: "2021-01-18", SemVer: "0.13.0"},
+	}
+	got := ms.ModuleVersions()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("ModuleVersions (-want, +got):\n%s", diff)
+	}
+
+	platform := got[2]
+	for _, tt := range []struct {
+		min  string
+		want bool
+	}{
+		{min: "0.12.9", want: true},
+		{min: "0.13.0", want: true},
+		{min: "0.13.0-rc.1", want: true},
+		{min: "1.0.0", want: false},
+	} {
+		if got, err := platform.AtLeast(tt.min); err != nil || got != tt.want {
+			t.Errorf("AtLeast(%s): got (%v, %v), want %v", tt.min, got, err, tt.want)
+		}
+	}
+	if _, err := got[0].AtLeast("1.0.0"); err == nil {
+		t.Errorf("AtLeast: got no error for a module without an openconfig-version")
+	}
+}
+
+func TestCompareSemVer(t *testing.T) {
+	tests := []struct {
+		a, b          string
+		want          int
+		wantErrSubstr string
+	}{
+		{a: "1.0.0", b: "1.0.0", want: 0},
+		{a: "1.0.0+build.5", b: "1.0.0", want: 0},
+		{a: "1.2.3", b: "1.10.0", want: -1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.0.0-alpha", b: "1.0.0", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-alpha.1", want: -1},
+		{a: "1.0.0-alpha.1", b: "1.0.0-alpha.beta", want: -1},
+		{a: "1.0.0-beta.11", b: "1.0.0-beta.2", want: 1},
+		{a: "1.0.0-rc.1", b: "1.0.0-beta.11", want: 1},
+		{a: "1.0", b: "1.0.0", wantErrSubstr: "want MAJOR.MINOR.PATCH"},
+		{a: "1.0.x", b: "1.0.0", wantErrSubstr: "invalid semantic version"},
+		{a: "1.0.0-", b: "1.0.0", wantErrSubstr: "empty pre-release"},
+	}
+	for _, tt := range tests {
+		got, err := CompareSemVer(tt.a, tt.b)
+		if diff := errdiff.Substring(err, tt.wantErrSubstr); diff != "" {
+			t.Errorf("CompareSemVer(%s, %s): %s", tt.a, tt.b, diff)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CompareSemVer(%s, %s): got %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -74,7 +74,7 @@ func (s *Value) asBool() (bool, error) {
 	case "false":
 		return false, nil
 	default:
-		return false, fmt.Errorf("invalid boolean: %s", s.Name)
+		return false, codeErrorf("GY0804", "invalid boolean: %s", s.Name)
 	}
 }
 
@@ -378,6 +378,12 @@ func (s *Must) NName() string         { return s.Name }
 func (s *Must) Statement() *Statement { return s.Source }
 func (s *Must) Exts() []*Statement    { return s.Extensions }
 
+// ConstraintError returns the error-app-tag and error-message of s, or nil if
+// it has neither.
+func (s *Must) ConstraintError() *ConstraintError {
+	return newConstraintError(s.ErrorAppTag, s.ErrorMessage)
+}
+
 // A Leaf is defined in: http://tools.ietf.org/html/rfc6020#section-7.6
 type Leaf struct {
 	Name       string       `yang:"Name,nomerge"`
@@ -646,7 +652,10 @@ type Refine struct {
 	Parent     Node         `yang:"Parent,nomerge"`
 	Extensions []*Statement `yang:"Ext"`
 
-	Default     *Value   `yang:"default"`
+	Default *Value `yang:"default"`
+	// MoreDefault holds the default statements after the first, which
+	// are only allowed when refining the default values of a leaf-list.
+	MoreDefault []*Value `yang:"default,more"`
 	Description *Value   `yang:"description"`
 	IfFeature   []*Value `yang:"if-feature"`
 	Reference   *Value   `yang:"reference"`
@@ -664,6 +673,14 @@ func (s *Refine) NName() string         { return s.Name }
 func (s *Refine) Statement() *Statement { return s.Source }
 func (s *Refine) Exts() []*Statement    { return s.Extensions }
 
+// Defaults returns the default statements of s, in order.
+func (s *Refine) Defaults() []*Value {
+	if s.Default == nil {
+		return nil
+	}
+	return append([]*Value{s.Default}, s.MoreDefault...)
+}
+
 // An RPC is defined in: http://tools.ietf.org/html/rfc6020#section-7.13
 type RPC struct {
 	Name       string       `yang:"Name,nomerge"`
@@ -950,8 +967,11 @@ type Deviate struct {
 	Parent     Node         `yang:"Parent,nomerge"`
 	Extensions []*Statement `yang:"Ext"`
 
-	Config      *Value   `yang:"config"`
-	Default     *Value   `yang:"default"`
+	Config  *Value `yang:"config"`
+	Default *Value `yang:"default"`
+	// MoreDefault holds the default statements after the first, which
+	// are only allowed when deviating the default values of a leaf-list.
+	MoreDefault []*Value `yang:"default,more"`
 	Mandatory   *Value   `yang:"mandatory"`
 	MaxElements *Value   `yang:"max-elements"`
 	MinElements *Value   `yang:"min-elements"`
@@ -967,6 +987,14 @@ func (s *Deviate) NName() string         { return s.Name }
 func (s *Deviate) Statement() *Statement { return s.Source }
 func (s *Deviate) Exts() []*Statement    { return s.Extensions }
 
+// Defaults returns the default statements of s, in order.
+func (s *Deviate) Defaults() []*Value {
+	if s.Default == nil {
+		return nil
+	}
+	return append([]*Value{s.Default}, s.MoreDefault...)
+}
+
 // An Enum is defined in: http://tools.ietf.org/html/rfc6020#section-9.6.4
 type Enum struct {
 	Name       string       `yang:"Name,nomerge"`
@@ -1026,6 +1054,12 @@ func (s *Range) NName() string         { return s.Name }
 func (s *Range) Statement() *Statement { return s.Source }
 func (s *Range) Exts() []*Statement    { return s.Extensions }
 
+// ConstraintError returns the error-app-tag and error-message of s, or nil if
+// it has neither.
+func (s *Range) ConstraintError() *ConstraintError {
+	return newConstraintError(s.ErrorAppTag, s.ErrorMessage)
+}
+
 // A Length is defined in: http://tools.ietf.org/html/rfc6020#section-9.4.4
 type Length struct {
 	Name       string       `yang:"Name,nomerge"`
@@ -1045,6 +1079,12 @@ func (s *Length) NName() string         { return s.Name }
 func (s *Length) Statement() *Statement { return s.Source }
 func (s *Length) Exts() []*Statement    { return s.Extensions }
 
+// ConstraintError returns the error-app-tag and error-message of s, or nil if
+// it has neither.
+func (s *Length) ConstraintError() *ConstraintError {
+	return newConstraintError(s.ErrorAppTag, s.ErrorMessage)
+}
+
 // A Pattern is defined in: http://tools.ietf.org/html/rfc6020#section-9.4.6
 type Pattern struct {
 	Name       string       `yang:"Name,nomerge"`
@@ -1064,6 +1104,12 @@ func (s *Pattern) NName() string         { return s.Name }
 func (s *Pattern) Statement() *Statement { return s.Source }
 func (s *Pattern) Exts() []*Statement    { return s.Extensions }
 
+// ConstraintError returns the error-app-tag and error-message of s, or nil if
+// it has neither.
+func (s *Pattern) ConstraintError() *ConstraintError {
+	return newConstraintError(s.ErrorAppTag, s.ErrorMessage)
+}
+
 // An Action is defined in http://tools.ietf.org/html/rfc7950#section-7.15
 //
 // Action define an RPC operation connected to a specific container or list data
@@ -16,6 +16,8 @@ package yang
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -243,13 +245,71 @@ type YangType struct {
 	Default          string      `json:",omitempty"` // default value, if any
 	HasDefault       bool        `json:",omitempty"` // whether the type has a default.
 	FractionDigits   int         `json:",omitempty"` // decimal64 fixed point precision
-	Length           YangRange   `json:",omitempty"` // this should be processed by section 12
+	Length           YangRange   `json:",omitempty"` // length of strings in characters, or of binary in decoded octets
 	OptionalInstance bool        `json:",omitempty"` // !require-instances which defaults to true
 	Path             string      `json:",omitempty"` // the path in a leafref
 	Pattern          []string    `json:",omitempty"` // limiting XSD-TYPES expressions on strings
 	POSIXPattern     []string    `json:",omitempty"` // limiting POSIX ERE on strings (specified by openconfig-extensions:posix-pattern)
 	Range            YangRange   `json:",omitempty"` // range for integers
 	Type             []*YangType `json:",omitempty"` // for unions
+
+	// Target is the type of the node the path of a leafref refers to,
+	// following any leafrefs it is in turn, or nil if it cannot be found.
+	// It is only set in the types of entries, and of their union members,
+	// once the modules are processed, as the node a relative path refers
+	// to depends on where a grouping is used.
+	Target *YangType `json:"-"`
+
+	// LengthError and RangeError are the error-app-tag and error-message
+	// of the length and range restrictions, if any.  They are those of
+	// the restriction in the type or typedef that last restricted the
+	// length or range.
+	LengthError *ConstraintError `json:",omitempty"`
+	RangeError  *ConstraintError `json:",omitempty"`
+	// PatternErrors, if not nil, holds the error-app-tag and
+	// error-message of each of the patterns in Pattern, at the same
+	// index.  An entry is nil if its pattern has neither.
+	PatternErrors []*ConstraintError `json:",omitempty"`
+}
+
+// TypedefChain returns the typedefs y is derived from, starting with the one
+// the type statement of y names and ending with the one derived from a
+// builtin type.  It returns nil if y is a builtin type.
+func (y *YangType) TypedefChain() []*Typedef {
+	return typedefChain(y)
+}
+
+// RequireInstance returns true if the values of y, a leafref or
+// instance-identifier, must refer to existing data.  It is the value of the
+// require-instance statement of the type, or of the nearest typedef it is
+// derived from with one, and true if there is none.
+func (y *YangType) RequireInstance() bool {
+	return !y.OptionalInstance
+}
+
+// A ConstraintError is the error-app-tag and error-message of a must, length,
+// range or pattern statement, see RFC 7950 sections 7.5.4 and 9.4.4.  They are
+// returned by a server in the error reported when the constraint is not
+// satisfied.
+type ConstraintError struct {
+	AppTag  string `json:",omitempty"` // AppTag is the error-app-tag, if any.
+	Message string `json:",omitempty"` // Message is the error-message, if any.
+}
+
+// newConstraintError returns the ConstraintError given by the error-app-tag
+// tag and error-message msg, or nil if both are nil.
+func newConstraintError(tag, msg *Value) *ConstraintError {
+	if tag == nil && msg == nil {
+		return nil
+	}
+	ce := &ConstraintError{}
+	if tag != nil {
+		ce.AppTag = tag.Name
+	}
+	if msg != nil {
+		ce.Message = msg.Name
+	}
+	return ce
 }
 
 // Equal returns true if y and t describe the same type.
@@ -286,6 +346,64 @@ func (y *YangType) Equal(t *YangType) bool {
 	return true
 }
 
+// checkValue returns an error if s, the lexical representation of a value
+// such as a default, is not a valid value of y.  Patterns are not checked,
+// nor are the values of types that reference other parts of the schema, such
+// as leafrefs and identityrefs.
+func (y *YangType) checkValue(s string) error {
+	switch y.Kind {
+	case Yempty:
+		return codeErrorf("GY0804", "type empty cannot have a value")
+	case Ybool:
+		if s != "true" && s != "false" {
+			return codeErrorf("GY0804", "invalid boolean value %q", s)
+		}
+	case Yint8, Yint16, Yint32, Yint64, Yuint8, Yuint16, Yuint32, Yuint64:
+		n, err := ParseInt(s)
+		if err != nil {
+			return err
+		}
+		if !y.Range.Contains(YangRange{{Min: n, Max: n}}) {
+			return codeErrorf("GY0804", "value %s outside of range %s", s, y.Range)
+		}
+	case Ydecimal64:
+		n, err := ParseDecimal(s, uint8(y.FractionDigits))
+		if err != nil {
+			return err
+		}
+		if !y.Range.Contains(YangRange{{Min: n, Max: n}}) {
+			return codeErrorf("GY0804", "value %s outside of range %s", s, y.Range)
+		}
+	case Ystring:
+		n := FromInt(int64(utf8.RuneCountInString(s)))
+		if !y.Length.Contains(YangRange{{Min: n, Max: n}}) {
+			return codeErrorf("GY0804", "length of %q outside of %s", s, y.Length)
+		}
+	case Ybinary:
+		if _, err := DecodeBinary(y, s); err != nil {
+			return err
+		}
+	case Yenum:
+		if y.Enum == nil || !y.Enum.IsDefined(s) {
+			return codeErrorf("GY0804", "invalid enumeration value %q", s)
+		}
+	case Ybits:
+		for _, b := range strings.Fields(s) {
+			if y.Bit == nil || !y.Bit.IsDefined(b) {
+				return codeErrorf("GY0804", "invalid bit %q", b)
+			}
+		}
+	case Yunion:
+		for _, t := range y.Type {
+			if t.checkValue(s) == nil {
+				return nil
+			}
+		}
+		return codeErrorf("GY0804", "value %q does not match any union member type", s)
+	}
+	return nil
+}
+
 // typedef returns a Typedef created from y for insertion into the BaseTypedefs
 // map.
 func (y *YangType) typedef() *Typedef {
@@ -134,3 +134,37 @@ func TestYangTypeEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestTypedefChain(t *testing.T) {
+	ms := NewModules()
+	if err := ms.Parse(`module chain {
+		prefix c;
+		namespace "urn:c";
+
+		typedef base { type string { length 1..10; } }
+		typedef derived { type base; }
+		leaf a { type derived; }
+		leaf b { type string; }
+	}`, "chain.yang"); err != nil {
+		t.Fatal(err)
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	m, _ := ms.GetModule("chain")
+	for _, tt := range []struct {
+		leaf string
+		want []string
+	}{
+		{"a", []string{"derived", "base"}},
+		{"b", nil},
+	} {
+		var got []string
+		for _, td := range m.Dir[tt.leaf].Type.TypedefChain() {
+			got = append(got, td.Name)
+		}
+		if !ssEqual(got, tt.want) {
+			t.Errorf("%s: TypedefChain() got %v, want %v", tt.leaf, got, tt.want)
+		}
+	}
+}
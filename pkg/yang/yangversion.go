@@ -0,0 +1,121 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"strings"
+)
+
+// The YANG versions of RFC 6020 and RFC 7950.
+const (
+	YANGVersion1  = "1"
+	YANGVersion11 = "1.1"
+)
+
+// YANGVersion returns the yang-version of the module or submodule s,
+// YANGVersion1 if s has no yang-version statement.
+func (s *Module) YANGVersion() string {
+	if s.YangVersion == nil {
+		return YANGVersion1
+	}
+	return s.YangVersion.Name
+}
+
+// CheckYANGVersion returns an error for each use, in s, of a statement or
+// construct that its yang-version does not allow: an unknown yang-version,
+// the constructs that RFC 7950 section 1.1 added to YANG version 1.1 when s is
+// YANG version 1, and the includes and imports that RFC 7950 section 12 does
+// not allow between the versions.  The modules imported and included by s
+// must have been resolved.
+func (s *Module) CheckYANGVersion() []error {
+	version := s.YANGVersion()
+	if version != YANGVersion1 && version != YANGVersion11 {
+		return []error{codeErrorf("GY0113", "%s: unknown yang-version %q, want %s or %s", Source(s.YangVersion), version, YANGVersion1, YANGVersion11)}
+	}
+	var errs []error
+	for _, i := range s.Include {
+		if i.Module != nil && i.Module.YANGVersion() != version {
+			errs = append(errs, codeErrorf("GY0113", "%s: yang-version %s %s %s cannot include yang-version %s submodule %s", Source(i), version, s.Kind(), s.Name, i.Module.YANGVersion(), i.Name))
+		}
+	}
+	if version == YANGVersion11 {
+		return errs
+	}
+	for _, i := range s.Import {
+		if i.Module != nil && i.RevisionDate != nil && i.Module.YANGVersion() == YANGVersion11 {
+			errs = append(errs, codeErrorf("GY0113", "%s: yang-version 1 %s %s cannot import yang-version 1.1 module %s by revision", Source(i), s.Kind(), s.Name, i.Name))
+		}
+	}
+	if s.Source == nil {
+		return errs
+	}
+	for _, ss := range s.Source.SubStatements() {
+		errs = append(errs, checkYANG11Statements(s, ss, s.Source)...)
+	}
+	return errs
+}
+
+// checkYANG11Statements returns an error for each statement of the tree
+// rooted at st, whose parent is parent, that is only allowed in YANG
+// version 1.1, as used in the YANG version 1 module, or submodule, s.
+func checkYANG11Statements(s *Module, st, parent *Statement) []error {
+	var errs []error
+	if what := yang11Only(st, parent); what != "" {
+		errs = append(errs, codeErrorf("GY0113", "%s: %s requires yang-version 1.1, %s %s is yang-version 1", st.Location(), what, s.Kind(), s.Name))
+	}
+	bases := 0
+	for _, ss := range st.SubStatements() {
+		if st.Keyword == "identity" && ss.Keyword == "base" {
+			if bases++; bases == 2 {
+				errs = append(errs, codeErrorf("GY0113", "%s: identity with more than one base requires yang-version 1.1, %s %s is yang-version 1", ss.Location(), s.Kind(), s.Name))
+			}
+		}
+		errs = append(errs, checkYANG11Statements(s, ss, st)...)
+	}
+	return errs
+}
+
+// yang11Only returns a description of st, whose parent is parent, if it is
+// only allowed in YANG version 1.1, or "" if it is allowed in YANG version 1.
+func yang11Only(st, parent *Statement) string {
+	switch st.Keyword {
+	case "action", "anydata":
+		return st.Keyword
+	case "notification":
+		if parent.Keyword != "module" && parent.Keyword != "submodule" {
+			return "notification in " + parent.Keyword
+		}
+	case "if-feature":
+		switch {
+		case parent.Keyword == "enum" || parent.Keyword == "bit":
+			return "if-feature in " + parent.Keyword
+		case strings.ContainsAny(st.Argument, " \t\n()"):
+			return "if-feature expression"
+		}
+	case "default":
+		if parent.Keyword == "leaf-list" {
+			return "default in leaf-list"
+		}
+	case "require-instance":
+		if parent.Keyword == "type" && parent.Argument == "leafref" {
+			return "require-instance in leafref type"
+		}
+	case "type":
+		if parent.Keyword == "type" && parent.Argument == "union" && (st.Argument == "empty" || st.Argument == "leafref") {
+			return st.Argument + " type in union"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckYANGVersion(t *testing.T) {
+	const yang1 = `
+module v {
+	prefix v;
+	namespace "urn:v";
+	import i { prefix i; revision-date 2020-01-01; }
+	feature f;
+	feature g;
+	identity a;
+	identity b;
+	identity c { base a; base b; }
+	container c {
+		action reset;
+		notification changed;
+		anydata blob;
+		leaf-list l { type string; default "x"; }
+		leaf e {
+			type enumeration { enum one { if-feature f; } }
+		}
+		leaf p {
+			type string;
+			if-feature "f or g";
+		}
+		leaf r {
+			type leafref { path "../p"; require-instance false; }
+		}
+		leaf u {
+			type union { type string; type empty; }
+		}
+	}
+}
+`
+	tests := []struct {
+		desc      string
+		inModules map[string]string
+		strict    bool
+		want      []string
+	}{{
+		desc: "lenient",
+		inModules: map[string]string{
+			"v": yang1,
+			"i": `module i { yang-version 1.1; prefix i; namespace "urn:i"; revision 2020-01-01; }`,
+		},
+	}, {
+		desc: "strict yang-version 1",
+		inModules: map[string]string{
+			"v": yang1,
+			"i": `module i { yang-version 1.1; prefix i; namespace "urn:i"; revision 2020-01-01; }`,
+		},
+		strict: true,
+		want: []string{
+			"v:5:2: yang-version 1 module v cannot import yang-version 1.1 module i by revision",
+			"v:10:23: identity with more than one base requires yang-version 1.1, module v is yang-version 1",
+			"v:12:3: action requires yang-version 1.1, module v is yang-version 1",
+			"v:13:3: notification in container requires yang-version 1.1, module v is yang-version 1",
+			"v:14:3: anydata requires yang-version 1.1, module v is yang-version 1",
+			"v:15:30: default in leaf-list requires yang-version 1.1, module v is yang-version 1",
+			"v:17:34: if-feature in enum requires yang-version 1.1, module v is yang-version 1",
+			"v:21:4: if-feature expression requires yang-version 1.1, module v is yang-version 1",
+			"v:24:32: require-instance in leafref type requires yang-version 1.1, module v is yang-version 1",
+			"v:27:30: empty type in union requires yang-version 1.1, module v is yang-version 1",
+		},
+	}, {
+		desc: "strict yang-version 1.1",
+		inModules: map[string]string{
+			"v": `module v { yang-version 1.1; prefix v; namespace "urn:v"; include s; container c { action reset; } }`,
+			"s": `submodule s { belongs-to v { prefix v; } }`,
+		},
+		strict: true,
+		want: []string{
+			"v:1:59: yang-version 1.1 module v cannot include yang-version 1 submodule s",
+		},
+	}, {
+		desc: "unknown yang-version",
+		inModules: map[string]string{
+			"v": `module v { yang-version 2; prefix v; namespace "urn:v"; }`,
+		},
+		strict: true,
+		want: []string{
+			`v:1:12: unknown yang-version "2", want 1 or 1.1`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ms := NewModules()
+			ms.ParseOptions.StrictYANGVersion = tt.strict
+			for name, src := range tt.inModules {
+				if err := ms.Parse(src, name); err != nil {
+					t.Fatalf("cannot parse %s: %v", name, err)
+				}
+			}
+			var got []string
+			for _, err := range ms.Process() {
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Process errors (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestYANGVersion(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{`module v { prefix v; namespace "urn:v"; }`, "1"},
+		{`module v { yang-version 1; prefix v; namespace "urn:v"; }`, "1"},
+		{`module v { yang-version 1.1; prefix v; namespace "urn:v"; }`, "1.1"},
+	} {
+		ms := NewModules()
+		if err := ms.Parse(tt.in, "v"); err != nil {
+			t.Fatal(err)
+		}
+		if got := ms.Modules["v"].YANGVersion(); got != tt.want {
+			t.Errorf("%s: YANGVersion() = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
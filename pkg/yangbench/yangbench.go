@@ -0,0 +1,213 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yangbench times and profiles reading and processing YANG modules,
+// by phase, so that performance regressions can be reported with the phase
+// and the file they are in, and hot spots tracked with Go benchmarks.
+//
+// A Recorder collects the times of the phases of a yang.Modules:
+//
+//	r := &yangbench.Recorder{}
+//	ms.ParseOptions.PhaseTimer = r.Record
+//	... read and process the modules ...
+//	r.Report().Write(os.Stderr)
+//
+// Load does the same for a list of modules.  The Benchmark function of package
+// yangbenchtest runs Load from a Go benchmark, reporting the time of each
+// phase as a metric, and is kept apart so that programs using yangbench do not
+// link package testing.
+package yangbench
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// A PhaseTime is the time spent in a phase.
+type PhaseTime struct {
+	Phase yang.Phase
+	Runs  int           // the number of times the phase ran
+	Time  time.Duration // the total time of the runs
+	// Slowest is the name of the file of the slowest run, for the
+	// phases run for each file, and SlowestTime is the time of that run.
+	Slowest     string
+	SlowestTime time.Duration
+}
+
+// A Report is the time spent reading and processing modules.
+type Report struct {
+	// Phases is the time of each phase that ran, in the order the phases
+	// run.
+	Phases []PhaseTime
+	// Total, if not zero, is the time from the start of reading to the end
+	// of processing.  It is less than the sum of the times of the phases
+	// when modules are read while resolving imports and includes, see
+	// yang.PhaseTimer.
+	Total time.Duration
+}
+
+// Phase returns the time of phase p in r, which is zero if p did not run.
+func (r *Report) Phase(p yang.Phase) PhaseTime {
+	for _, pt := range r.Phases {
+		if pt.Phase == p {
+			return pt
+		}
+	}
+	return PhaseTime{Phase: p}
+}
+
+// Write writes r to w as a table, with a line for each phase.
+func (r *Report) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "phase\truns\ttime\tslowest")
+	for _, pt := range r.Phases {
+		fmt.Fprintf(tw, "%s\t%d\t%v", pt.Phase, pt.Runs, round(pt.Time))
+		if pt.Slowest != "" {
+			fmt.Fprintf(tw, "\t%s (%v)", pt.Slowest, round(pt.SlowestTime))
+		}
+		fmt.Fprintln(tw)
+	}
+	if r.Total != 0 {
+		fmt.Fprintf(tw, "total\t\t%v\n", round(r.Total))
+	}
+	return tw.Flush()
+}
+
+// round returns d rounded to make it readable in a report.
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Microsecond)
+	}
+	return d
+}
+
+// A Recorder records the times of phases as a yang.PhaseTimer.  The zero
+// value is ready to use, and a Recorder may be shared by Modules used
+// concurrently.
+type Recorder struct {
+	mu    sync.Mutex
+	times map[yang.Phase]*PhaseTime
+}
+
+// Record records that phase p ran for d on the file name.  Record is a
+// yang.PhaseTimer.
+func (r *Recorder) Record(p yang.Phase, name string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.times == nil {
+		r.times = map[yang.Phase]*PhaseTime{}
+	}
+	pt := r.times[p]
+	if pt == nil {
+		pt = &PhaseTime{Phase: p}
+		r.times[p] = pt
+	}
+	pt.Runs++
+	pt.Time += d
+	if name != "" && d >= pt.SlowestTime {
+		pt.Slowest, pt.SlowestTime = name, d
+	}
+}
+
+// Report returns the times recorded by r.
+func (r *Recorder) Report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &Report{}
+	for _, p := range yang.Phases() {
+		if pt := r.times[p]; pt != nil {
+			report.Phases = append(report.Phases, *pt)
+		}
+	}
+	return report
+}
+
+// Reset discards the times recorded by r.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	r.times = nil
+	r.mu.Unlock()
+}
+
+// Load reads the modules or files names, searching the directories of path,
+// and processes them with the options opts, replacing any PhaseTimer of opts.
+// It returns the modules and the report of the time spent, or the errors
+// found.
+func Load(names, path []string, opts yang.Options) (*yang.Modules, *Report, []error) {
+	r := &Recorder{}
+	ms := yang.NewModules()
+	opts.PhaseTimer = r.Record
+	ms.ParseOptions = opts
+	ms.AddPath(path...)
+
+	start := time.Now()
+	var errs []error
+	for _, name := range names {
+		if err := ms.Read(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		return nil, nil, errs
+	}
+	report := r.Report()
+	report.Total = time.Since(start)
+	return ms, report, nil
+}
+
+// StartCPUProfile starts writing a CPU profile to the file name.  The
+// returned function stops the profile and closes the file.
+func StartCPUProfile(name string) (stop func() error, err error) {
+	fp, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(fp); err != nil {
+		fp.Close()
+		return nil, err
+	}
+	return func() error {
+		pprof.StopCPUProfile()
+		return fp.Close()
+	}, nil
+}
+
+// WriteHeapProfile writes a profile of the memory allocated so far to the
+// file name, after a garbage collection so it shows the memory in use.
+func WriteHeapProfile(name string) error {
+	fp, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(fp); err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangbench
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// writeModules writes the modules used by the tests to a new directory,
+// which it returns.
+func writeModules(t *testing.T) string {
+	dir := t.TempDir()
+	for name, mod := range map[string]string{
+		"a.yang": `module a {
+			prefix a; namespace "urn:a";
+			import b { prefix b; }
+			grouping g { leaf x { type string; } }
+			container c { uses g; }
+			augment /b:top { leaf y { type b:t; } }
+			deviation /a:c/a:x { deviate add { default "d"; } }
+		}`,
+		"b.yang": `module b {
+			prefix b; namespace "urn:b";
+			typedef t { type uint8; }
+			container top { leaf z { type t; } }
+		}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(mod), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeModules(t)
+	ms, report, errs := Load([]string{"a"}, []string{dir}, yang.Options{})
+	if len(errs) > 0 {
+		t.Fatalf("Load: %v", errs)
+	}
+	if _, errs := ms.GetModule("a"); len(errs) > 0 {
+		t.Errorf("GetModule(a): %v", errs)
+	}
+	var phases []string
+	for _, pt := range report.Phases {
+		phases = append(phases, pt.Phase.String())
+	}
+	if diff := cmp.Diff([]string{"scan", "parse", "resolve", "to-entry", "deviations", "finish"}, phases); diff != "" {
+		t.Errorf("phases (-want, +got):\n%s", diff)
+	}
+	if got := report.Phase(yang.ParsePhase).Runs; got != 2 {
+		t.Errorf("got %d parse runs, want 2", got)
+	}
+	if report.Total <= 0 {
+		t.Errorf("got total %v, want a positive time", report.Total)
+	}
+
+	if _, _, errs := Load([]string{"missing"}, []string{dir}, yang.Options{}); len(errs) != 1 {
+		t.Errorf("Load(missing): got errors %v, want one", errs)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	r := &Recorder{}
+	r.Record(yang.ParsePhase, "a.yang", 2*time.Millisecond)
+	r.Record(yang.ScanPhase, "a.yang", time.Millisecond)
+	r.Record(yang.ParsePhase, "b.yang", 3*time.Millisecond)
+	r.Record(yang.ParsePhase, "c.yang", time.Millisecond)
+	r.Record(yang.ToEntryPhase, "", 5*time.Millisecond)
+	want := &Report{Phases: []PhaseTime{
+		{Phase: yang.ScanPhase, Runs: 1, Time: time.Millisecond, Slowest: "a.yang", SlowestTime: time.Millisecond},
+		{Phase: yang.ParsePhase, Runs: 3, Time: 6 * time.Millisecond, Slowest: "b.yang", SlowestTime: 3 * time.Millisecond},
+		{Phase: yang.ToEntryPhase, Runs: 1, Time: 5 * time.Millisecond},
+	}}
+	got := r.Report()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Report (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(PhaseTime{Phase: yang.DeviationPhase}, got.Phase(yang.DeviationPhase)); diff != "" {
+		t.Errorf("Phase(DeviationPhase) (-want, +got):\n%s", diff)
+	}
+
+	got.Total = 12 * time.Millisecond
+	var b strings.Builder
+	if err := got.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	wantTable := `phase     runs  time  slowest
+scan      1     1ms   a.yang (1ms)
+parse     3     6ms   b.yang (3ms)
+to-entry  1     5ms
+total           12ms
+`
+	if diff := cmp.Diff(wantTable, b.String()); diff != "" {
+		t.Errorf("Write (-want, +got):\n%s", diff)
+	}
+
+	r.Reset()
+	if got := r.Report(); len(got.Phases) != 0 {
+		t.Errorf("Report after Reset: got %v, want no phases", got.Phases)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	stop, err := StartCPUProfile(filepath.Join(dir, "cpu.prof"))
+	if err != nil {
+		t.Fatalf("StartCPUProfile: %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Errorf("stopping CPU profile: %v", err)
+	}
+	if err := WriteHeapProfile(filepath.Join(dir, "mem.prof")); err != nil {
+		t.Errorf("WriteHeapProfile: %v", err)
+	}
+	for _, name := range []string{"cpu.prof", "mem.prof"} {
+		if fi, err := os.Stat(filepath.Join(dir, name)); err != nil || fi.Size() == 0 {
+			t.Errorf("%s: not written: %v", name, err)
+		}
+	}
+	if _, err := StartCPUProfile(filepath.Join(dir, "missing", "cpu.prof")); err == nil {
+		t.Errorf("StartCPUProfile in a missing directory: got no error")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yangbenchtest runs the loads of package yangbench as Go benchmarks.
+package yangbenchtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/goyang/pkg/yangbench"
+)
+
+// Benchmark runs yangbench.Load b.N times from b, failing b if it returns
+// errors.  Along with the time and allocations of each load, it reports the
+// time of each phase per load as the metric PHASE-ns/op, e.g.,
+// to-entry-ns/op.
+func Benchmark(b *testing.B, names, path []string, opts yang.Options) {
+	b.Helper()
+	b.ReportAllocs()
+	times := map[yang.Phase]time.Duration{}
+	for i := 0; i < b.N; i++ {
+		_, report, errs := yangbench.Load(names, path, opts)
+		if len(errs) > 0 {
+			b.Fatal(errs)
+		}
+		for _, pt := range report.Phases {
+			times[pt.Phase] += pt.Time
+		}
+	}
+	for _, p := range yang.Phases() {
+		if d, ok := times[p]; ok {
+			b.ReportMetric(float64(d.Nanoseconds())/float64(b.N), p.String()+"-ns/op")
+		}
+	}
+}
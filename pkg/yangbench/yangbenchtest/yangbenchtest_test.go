@@ -0,0 +1,46 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangbenchtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func BenchmarkLoad(b *testing.B) {
+	dir := b.TempDir()
+	for name, mod := range map[string]string{
+		"a.yang": `module a {
+			prefix a; namespace "urn:a";
+			import b { prefix b; }
+			grouping g { leaf x { type string; } }
+			container c { uses g; }
+			augment /b:top { leaf y { type b:t; } }
+		}`,
+		"b.yang": `module b {
+			prefix b; namespace "urn:b";
+			typedef t { type uint8; }
+			container top { leaf z { type t; } }
+		}`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(mod), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	Benchmark(b, []string{"a"}, []string{dir}, yang.Options{})
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"sort"
+	"strings"
+)
+
+// A DataNode is a node of an instance data tree: a container, a list entry, a
+// leaf or a single value of a leaf-list.  DataNode allows data held in any
+// representation to be validated without first converting it.  Adapters are
+// provided for data decoded from JSON by encoding/json, see NewJSONNode, and
+// for XML element trees, see XMLElement.
+//
+// The root of a data tree holds the top level nodes of a module as its
+// children, its own name is ignored.
+type DataNode interface {
+	// Name returns the name of the node, without any module name or
+	// prefix qualifying it.
+	Name() string
+	// Namespace returns the name of the module, or the XML namespace,
+	// qualifying the name of the node, or "" if it is not qualified.
+	Namespace() string
+	// Value returns the value of a leaf or leaf-list value, or nil for
+	// a container or list entry.  The value is either in the form
+	// produced by decoding RFC 7951 JSON with encoding/json, or is a Text
+	// for encodings, such as XML, that represent values as text.
+	Value() interface{}
+	// Children returns the children of a container or list entry.  Each
+	// entry of a list, and each value of a leaf-list, is a separate child
+	// with the name of the list or leaf-list, in the order of the entries.
+	Children() []DataNode
+	// Key returns the value of the key leaf name of a list entry, and
+	// whether the entry has the key.
+	Key(name string) (interface{}, bool)
+}
+
+// A Text is a value in the lexical representation of RFC 7950 section 9,
+// e.g., as it is written in XML.  For example, a boolean is "true" or "false"
+// and an empty leaf is "".
+type Text string
+
+// NewJSONNode returns the DataNode for data, as decoded from RFC 7951 JSON by
+// encoding/json into an interface{}, which is the root of the data tree:
+// containers and list entries are map[string]interface{}, lists and
+// leaf-lists are []interface{}, and leaf values are string, float64, or bool,
+// or json.Number rather than float64 if the decoder used UseNumber.
+// A leaf of type empty has the value []interface{}{nil}.  Member names may
+// optionally be qualified by a module name (e.g., "module:name").  Members
+// whose names start with "@" hold RFC 7952 metadata annotations and are not
+// data nodes, see NodeInsertion.
+func NewJSONNode(data interface{}) DataNode {
+	return &jsonNode{data: data}
+}
+
+// A jsonNode is a DataNode for data decoded from JSON.
+type jsonNode struct {
+	module, name string
+	data         interface{}
+	// array is the JSON array the node is an element of, if any, i.e.,
+	// the list or leaf-list it is an entry of.
+	array []interface{}
+	// meta is the object of metadata annotations of the node, if any.
+	meta interface{}
+}
+
+func (n *jsonNode) Name() string      { return n.name }
+func (n *jsonNode) Namespace() string { return n.module }
+
+func (n *jsonNode) Value() interface{} {
+	if _, ok := n.data.(map[string]interface{}); ok {
+		return nil
+	}
+	return n.data
+}
+
+func (n *jsonNode) Children() []DataNode {
+	m, ok := n.data.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var children []DataNode
+	for _, k := range sortedKeys(m) {
+		if strings.HasPrefix(k, "@") {
+			continue
+		}
+		module, name := splitQualified(k)
+		v := m[k]
+		l, ok := v.([]interface{})
+		if !ok || isEmptyValue(l) {
+			// The annotations of a container are its member "@",
+			// those of a leaf the member "@NAME" of its parent.
+			c := &jsonNode{module: module, name: name, data: v, meta: m["@"+k]}
+			if cm, ok := v.(map[string]interface{}); ok {
+				c.meta = cm["@"]
+			}
+			children = append(children, c)
+			continue
+		}
+		meta, _ := m["@"+k].([]interface{})
+		for i, le := range l {
+			c := &jsonNode{module: module, name: name, data: le, array: l}
+			if lm, ok := le.(map[string]interface{}); ok {
+				c.meta = lm["@"]
+			} else if i < len(meta) {
+				c.meta = meta[i]
+			}
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+func (n *jsonNode) Key(name string) (interface{}, bool) {
+	m, ok := n.data.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookup(m, name)
+}
+
+// isEmptyValue returns true if l is the value of a leaf of type empty.
+func isEmptyValue(l []interface{}) bool {
+	return len(l) == 1 && l[0] == nil
+}
+
+// lookup returns the value of the member named name in m, which may have
+// been qualified by a module name.
+func lookup(m map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if _, n := splitQualified(k); n == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// A Datastore is a datastore of the Network Management Datastore
+// Architecture (NMDA) of RFC 8342, which determines the data a data tree may
+// hold and the constraints it must satisfy.
+type Datastore int
+
+const (
+	// AnyDatastore validates configuration and state data with all
+	// constraints enforced, and no default values in use.  It is the
+	// behavior of ValidateNode.
+	AnyDatastore Datastore = iota
+	// Running is the running configuration datastore, which holds only
+	// configuration, i.e., config true, data (RFC 8342 section 5.1.3).
+	// All constraints are enforced, and the default values of leaves
+	// that are not present are in use, as with the explicit basic mode
+	// of with-defaults (RFC 6243), e.g., as the instances leafrefs refer
+	// to.
+	Running
+	// Intended is the intended configuration datastore, which is
+	// validated as Running is (RFC 8342 section 5.1.4).
+	Intended
+	// Operational is the operational state datastore, which holds
+	// configuration and state data (RFC 8342 section 5.3).  The
+	// constraints that its data need not satisfy, mandatory nodes,
+	// min-elements, max-elements and the instances required by leafrefs
+	// and instance-identifiers, are not enforced.  A node that is not
+	// present is not in use, so default values are not.
+	Operational
+)
+
+func (d Datastore) String() string {
+	switch d {
+	case AnyDatastore:
+		return "any"
+	case Running:
+		return "running"
+	case Intended:
+		return "intended"
+	case Operational:
+		return "operational"
+	}
+	return "unknown"
+}
+
+// configOnly returns true if v only accepts configuration data.
+func (v *validator) configOnly() bool {
+	return v.datastore == Running || v.datastore == Intended
+}
+
+// relaxed returns true if v does not enforce the constraints that data of
+// the operational state datastore need not satisfy.
+func (v *validator) relaxed() bool {
+	return v.datastore == Operational
+}
+
+// defaults records the default values of the leaves of e, a container or
+// list entry with the children present, that are not present, and so are in
+// use in a configuration datastore.  The leaves of choices are not included,
+// as which case of a choice is in effect depends on the data of its siblings.
+func (v *validator) defaults(e *yang.Entry, present map[string]bool) {
+	if !v.configOnly() {
+		return
+	}
+	for _, c := range e.SortedDir() {
+		if present[c.Name] || !c.IsLeaf() || c.ReadOnly() {
+			continue
+		}
+		for _, d := range c.DefaultValues() {
+			if v.values[c] == nil {
+				v.values[c] = map[string]bool{}
+			}
+			v.values[c][d] = true
+		}
+	}
+}
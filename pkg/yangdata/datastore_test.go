@@ -0,0 +1,84 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateDatastore(t *testing.T) {
+	e := moduleEntry(t, "ds", `
+module ds {
+	prefix "d";
+	namespace "urn:d";
+
+	container c {
+		leaf name { type string; mandatory true; }
+		leaf mode { type string; default "auto"; }
+		leaf mode-ref { type leafref { path "../mode"; } }
+		leaf-list ll { type string; min-elements 1; }
+		container state {
+			config false;
+			leaf up { type boolean; }
+		}
+	}
+}`)
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"c": {"mode-ref": "auto", "state": {"up": true}}}`), &data); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+
+	tests := []struct {
+		datastore Datastore
+		wantErrs  []string
+	}{{
+		datastore: AnyDatastore,
+		wantErrs: []string{
+			`/c: missing "ll", which requires at least 1 elements`,
+			`/c: missing mandatory element "name"`,
+			`/c/mode-ref: no instance of /ds/c/mode with value auto`,
+		},
+	}, {
+		// The default of mode is in use, so mode-ref refers to it.
+		datastore: Running,
+		wantErrs: []string{
+			`/c: state data "state" in the running datastore`,
+			`/c: missing "ll", which requires at least 1 elements`,
+			`/c: missing mandatory element "name"`,
+		},
+	}, {
+		datastore: Intended,
+		wantErrs: []string{
+			`/c: state data "state" in the intended datastore`,
+			`/c: missing "ll", which requires at least 1 elements`,
+			`/c: missing mandatory element "name"`,
+		},
+	}, {
+		datastore: Operational,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.datastore.String(), func(t *testing.T) {
+			var got []string
+			for _, err := range ValidateWithOptions(e, NewJSONNode(data), ValidateOptions{Datastore: tt.datastore}) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("ValidateWithOptions() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// Diff returns a YANG Patch whose edits, applied with Patch.Apply, change the
+// configuration of the data tree from into that of the data tree to, both as
+// decoded from JSON by encoding/json and rooted at the schema entry e.  The
+// edits can equally be used to build a NETCONF edit-config or a gNMI
+// SetRequest, each edit being a single update or delete of its target.
+//
+// Only configuration is compared, state data is ignored, and the edits are
+// few and small:
+//
+//   - a changed leaf, anydata or anyxml is replaced, and a removed one
+//     deleted, but a leaf that is absent in one tree and has its default
+//     value in the other is unchanged
+//   - the entries of a list are matched by their keys, and the values of a
+//     leaf-list by value, so only the entries and values added or removed
+//     are created or deleted, and the entries in both trees are compared
+//     node by node
+//   - entries and values that are moved within an ordered-by user list or
+//     leaf-list are moved, the fewest needed to give the order of to, and
+//     those added are inserted at their position
+//   - a node that is added, or removed, with all of its descendants is
+//     created, or deleted, with a single edit
+//   - a non-presence container that is absent is the same as one that only
+//     has default values, but a presence container is changed by its
+//     presence alone
+//
+// The top member of the value of each edit is qualified by the name of its
+// module, as RFC 7951 requires of the top of a data tree, however deep the
+// target is.
+//
+// A keyless list cannot be the target of an edit, so when one differs its
+// parent is replaced.  The edits that remove data come before those that add
+// data to the same node, so that a change of the case of a choice deletes
+// the data of the old case before adding that of the new one.  The metadata
+// annotations of the data are not compared.
+func Diff(e *yang.Entry, from, to interface{}) (*Patch, error) {
+	d := &differ{edits: []*Edit{}}
+	fm, err := diffObject(e, "", from)
+	if err != nil {
+		return nil, err
+	}
+	tm, err := diffObject(e, "", to)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.dir(e, "", fm, tm, tm); err != nil {
+		return nil, err
+	}
+	return &Patch{ID: "diff", Edits: d.edits}, nil
+}
+
+// A differ accumulates the edits of a Diff.  edits is not nil, so that a
+// Patch without edits is encoded with an empty edit array.
+type differ struct {
+	edits []*Edit
+}
+
+// add adds the edit with operation op, of target, with value v.
+func (d *differ) add(op, target string, v interface{}) *Edit {
+	if target == "" {
+		target = "/"
+	}
+	ed := &Edit{ID: strconv.Itoa(len(d.edits) + 1), Operation: op, Target: target, Value: copyValue(v)}
+	d.edits = append(d.edits, ed)
+	return ed
+}
+
+// differs returns true if the contents of the directory entry e at path
+// differ between from and to.
+func differs(e *yang.Entry, path string, from, to map[string]interface{}) (bool, error) {
+	sd := &differ{}
+	if err := sd.dir(e, path, from, to, nil); err != nil {
+		return false, err
+	}
+	return len(sd.edits) > 0, nil
+}
+
+// A diffMember is a child data node in either or both of the trees compared
+// by dir.
+type diffMember struct {
+	entry    *yang.Entry
+	from, to string // from and to are the member names, "" if absent.
+}
+
+// dir adds the edits that change from into to, the contents of the directory
+// entry e at target.  whole is the value of an edit replacing the node at
+// target with to.
+func (d *differ) dir(e *yang.Entry, target string, from, to map[string]interface{}, whole interface{}) error {
+	var names []string
+	members := map[string]*diffMember{}
+	for i, m := range []map[string]interface{}{from, to} {
+		for _, k := range sortedKeys(m) {
+			if strings.HasPrefix(k, "@") {
+				continue
+			}
+			_, n := splitQualified(k)
+			c := e.DataChild(n)
+			if c == nil {
+				return mergeErrorf(target, "unknown element %q", k)
+			}
+			if c.ReadOnly() {
+				continue
+			}
+			dm := members[n]
+			if dm == nil {
+				dm = &diffMember{entry: c}
+				members[n] = dm
+				names = append(names, n)
+			}
+			if i == 0 {
+				dm.from = k
+			} else {
+				dm.to = k
+			}
+		}
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		dm := members[n]
+		if dm.entry.IsList() && dm.entry.Key == "" && !reflect.DeepEqual(from[dm.from], to[dm.to]) {
+			d.add(OpReplace, target, whole)
+			return nil
+		}
+	}
+	// Removals come first, then the other changes.
+	for _, removals := range []bool{true, false} {
+		for _, n := range names {
+			dm := members[n]
+			if (dm.to == "") != removals {
+				continue
+			}
+			if err := d.node(dm, target, from, to); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// node adds the edits that change the data node of dm in from into that in
+// to, from and to being the contents of the parent of the node at target.
+func (d *differ) node(dm *diffMember, target string, from, to map[string]interface{}) error {
+	c := dm.entry
+	fv, hasFrom := from[dm.from]
+	tv, hasTo := to[dm.to]
+	if dm.from == "" {
+		hasFrom = false
+	}
+	if dm.to == "" {
+		hasTo = false
+	}
+	name := dm.to
+	if name == "" {
+		name = dm.from
+	}
+	path := target + "/" + name
+	switch {
+	case c.IsList():
+		return d.list(c, target, name, fv, tv)
+	case c.IsLeafList():
+		return d.leafList(c, target, name, fv, tv)
+	case c.IsDir() && !c.IsAny():
+		fm, err := diffObject(c, path, fv)
+		if err != nil {
+			return err
+		}
+		tm, err := diffObject(c, path, tv)
+		if err != nil {
+			return err
+		}
+		switch {
+		case hasFrom && hasTo:
+			return d.dir(c, path, fm, tm, map[string]interface{}{qualified(c, name): tv})
+		case hasFrom != hasTo && c.IsPresenceContainer():
+		default:
+			if diff, err := differs(c, path, fm, tm); err != nil || !diff {
+				return err
+			}
+		}
+		if hasTo {
+			d.add(OpCreate, path, map[string]interface{}{qualified(c, name): tv})
+		} else {
+			d.add(OpDelete, path, nil)
+		}
+		return nil
+	}
+	switch {
+	case hasFrom && hasTo && sameValue(c, fv, tv):
+	case !hasFrom && isDefault(c, tv), !hasTo && isDefault(c, fv):
+	case hasTo:
+		d.add(OpReplace, path, map[string]interface{}{qualified(c, name): tv})
+	default:
+		d.add(OpDelete, path, nil)
+	}
+	return nil
+}
+
+// list adds the edits that change from into to, the entries of the list e
+// named name within the node at target.
+func (d *differ) list(e *yang.Entry, target, name string, from, to interface{}) error {
+	path := target + "/" + name
+	fl, err := diffArray(e, path, from)
+	if err != nil {
+		return err
+	}
+	tl, err := diffArray(e, path, to)
+	if err != nil {
+		return err
+	}
+	fids, fentries, err := entryKeys(e, path, fl)
+	if err != nil {
+		return err
+	}
+	tids, tentries, err := entryKeys(e, path, tl)
+	if err != nil {
+		return err
+	}
+	for _, id := range fids {
+		if tentries[id] == nil {
+			d.add(OpDelete, path+id, nil)
+		}
+	}
+	ordered := e.ListAttr != nil && e.ListAttr.OrderedByUser
+	stay := inOrder(fids, tids)
+	for i, id := range tids {
+		te := tentries[id]
+		fe, ok := fentries[id]
+		switch {
+		case !ok && ordered:
+			d.place(OpInsert, path, tids, i, map[string]interface{}{qualified(e, name): []interface{}{te}})
+			continue
+		case !ok:
+			d.add(OpCreate, path+id, map[string]interface{}{qualified(e, name): []interface{}{te}})
+			continue
+		case ordered && !stay[id]:
+			d.place(OpMove, path, tids, i, nil)
+		}
+		if err := d.dir(e, path+id, fe, te, map[string]interface{}{qualified(e, name): []interface{}{te}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leafList adds the edits that change from into to, the values of the
+// leaf-list e named name within the node at target.
+func (d *differ) leafList(e *yang.Entry, target, name string, from, to interface{}) error {
+	path := target + "/" + name
+	fl, err := diffArray(e, path, from)
+	if err != nil {
+		return err
+	}
+	tl, err := diffArray(e, path, to)
+	if err != nil {
+		return err
+	}
+	fids, fvalues := valueKeys(e, fl)
+	tids, tvalues := valueKeys(e, tl)
+	for _, id := range fids {
+		if _, ok := tvalues[id]; !ok {
+			d.add(OpDelete, path+valueTarget(fvalues[id]), nil)
+		}
+	}
+	ordered := e.ListAttr != nil && e.ListAttr.OrderedByUser
+	// A value is named as in from if it is there, as that is the value
+	// the edits find until it is replaced.
+	targets := make([]string, len(tids))
+	for i, id := range tids {
+		v, ok := fvalues[id]
+		if !ok {
+			v = tvalues[id]
+		}
+		targets[i] = valueTarget(v)
+	}
+	stay := inOrder(fids, tids)
+	for i, id := range tids {
+		_, ok := fvalues[id]
+		value := map[string]interface{}{qualified(e, name): []interface{}{tvalues[id]}}
+		switch {
+		case !ok && ordered:
+			d.place(OpInsert, path, targets, i, value)
+		case !ok:
+			d.add(OpCreate, path+targets[i], value)
+		case ordered && !stay[id]:
+			d.place(OpMove, path, targets, i, nil)
+		}
+	}
+	return nil
+}
+
+// place adds the insert or move edit op of the entry ids[i] of the list at
+// path, placing it at position i of ids, the entries in their final order.
+// v is the value of an insert.
+func (d *differ) place(op, path string, ids []string, i int, v interface{}) {
+	ed := d.add(op, path+ids[i], v)
+	ed.Where, ed.Point = where(path, ids, i)
+}
+
+// where returns the where and point of an edit placing an entry at position
+// i of ids, the entries of the list at path in their final order: after the
+// entry before it, or first.
+func where(path string, ids []string, i int) (string, string) {
+	if i == 0 {
+		return "first", ""
+	}
+	return "after", path + ids[i-1]
+}
+
+// inOrder returns the elements of the longest subsequence common to from
+// and to, which need not move to change the order of from into that of to.
+func inOrder(from, to []string) map[string]bool {
+	// lcs[i][j] is the length of the longest common subsequence of
+	// from[i:] and to[j:].
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			switch {
+			case from[i] == to[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	stay := map[string]bool{}
+	for i, j := 0, 0; i < len(from) && j < len(to); {
+		switch {
+		case from[i] == to[j]:
+			stay[from[i]] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return stay
+}
+
+// entryKeys returns the key predicates, as used in a data resource
+// identifier, of the entries l of the list e at path, in order, and the
+// entries by their key predicates.
+func entryKeys(e *yang.Entry, path string, l []interface{}) ([]string, map[string]map[string]interface{}, error) {
+	var ids []string
+	entries := map[string]map[string]interface{}{}
+	for _, le := range l {
+		m, ok := le.(map[string]interface{})
+		if !ok {
+			return nil, nil, mergeErrorf(path, "expected an object for list entry of %s, got %T", e.Name, le)
+		}
+		values := map[string]string{}
+		for _, k := range e.KeyNames() {
+			v, ok := lookup(m, k)
+			if !ok {
+				return nil, nil, mergeErrorf(path, "missing key %q", k)
+			}
+			values[k] = fmt.Sprint(v)
+		}
+		values, err := CanonicalKeys(e, values)
+		if err != nil {
+			return nil, nil, mergeErrorf(path, "%v", err)
+		}
+		id, err := keyID(e, values)
+		if err != nil {
+			return nil, nil, mergeErrorf(path, "%v", err)
+		}
+		if entries[id] != nil {
+			return nil, nil, mergeErrorf(path, "duplicate entry for key %s", id)
+		}
+		ids = append(ids, id)
+		entries[id] = m
+	}
+	return ids, entries, nil
+}
+
+// valueKeys returns the canonical values of l, the values of the leaf-list e,
+// in order, and the values of l by their canonical values.
+func valueKeys(e *yang.Entry, l []interface{}) ([]string, map[string]interface{}) {
+	var ids []string
+	values := map[string]interface{}{}
+	for _, v := range l {
+		id := valueString(e, v)
+		if _, ok := values[id]; ok {
+			continue
+		}
+		ids = append(ids, id)
+		values[id] = v
+	}
+	return ids, values
+}
+
+// valueTarget returns the suffix of the data resource identifier of the
+// leaf-list value v.
+func valueTarget(v interface{}) string {
+	return "=" + yang.EscapeRESTCONFValue(fmt.Sprint(v))
+}
+
+// qualified returns name, the member name of the data node e, qualified by
+// the name of the module that instantiates e.  name is returned as is if the
+// module is not known.
+func qualified(e *yang.Entry, name string) string {
+	m, err := e.InstantiatingModule()
+	if err != nil {
+		return name
+	}
+	_, n := splitQualified(name)
+	return m + ":" + n
+}
+
+// valueString returns the canonical form of v, a value of the leaf or
+// leaf-list e, or v as is if it is not valid.
+func valueString(e *yang.Entry, v interface{}) string {
+	s := fmt.Sprint(v)
+	if e.Type == nil {
+		return s
+	}
+	if cs, err := CanonicalValue(e, s); err == nil {
+		return cs
+	}
+	return s
+}
+
+// sameValue returns true if a and b are the same value of the leaf, anydata
+// or anyxml e.
+func sameValue(e *yang.Entry, a, b interface{}) bool {
+	if e.IsAny() || e.Type == nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return valueString(e, a) == valueString(e, b)
+}
+
+// isDefault returns true if v is the default value of the leaf e.
+func isDefault(e *yang.Entry, v interface{}) bool {
+	if !e.IsLeaf() {
+		return false
+	}
+	defs := e.DefaultValues()
+	return len(defs) == 1 && sameValue(e, defs[0], v)
+}
+
+// diffObject returns v, the data of the directory entry e at path, as an
+// object.  A missing node is an empty object.
+func diffObject(e *yang.Entry, path string, v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, mergeErrorf(path, "expected an object for %s, got %T", e.Name, v)
+	}
+	return m, nil
+}
+
+// diffArray returns v, the data of the list or leaf-list e at path, as an
+// array.  A missing node is an empty array.
+func diffArray(e *yang.Entry, path string, v interface{}) ([]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	l, ok := v.([]interface{})
+	if !ok {
+		return nil, mergeErrorf(path, "expected an array for %s, got %T", e.Name, v)
+	}
+	return l, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testDiffModule = `
+module diff {
+	prefix "d";
+	namespace "urn:d";
+
+	container c {
+		leaf name { type string; }
+		leaf mtu { type uint16; default 1500; }
+		container np { leaf x { type string; } leaf y { type boolean; default false; } }
+		container p { presence "enabled"; leaf x { type string; } }
+		choice transport {
+			case tcp { leaf tcp-port { type uint16; } }
+			case udp { leaf udp-port { type uint16; } }
+		}
+		list l {
+			key "k";
+			leaf k { type int32; }
+			leaf v { type string; }
+		}
+		list ul {
+			key "k";
+			ordered-by user;
+			leaf k { type string; }
+			leaf v { type string; }
+		}
+		leaf-list ll { type string; }
+		leaf-list ull { type string; ordered-by user; }
+		list kl { leaf v { type int32; } }
+		leaf counter { type uint32; config false; }
+	}
+}
+`
+
+func TestDiff(t *testing.T) {
+	e := moduleEntry(t, "diff", testDiffModule)
+
+	tests := []struct {
+		desc     string
+		from, to string
+		want     []*Edit
+		// wantTo is the result of applying the edits to from, if it is
+		// not to.
+		wantTo  string
+		wantErr string
+	}{{
+		desc: "no change",
+		from: `{"c": {"name": "a", "l": [{"k": 1}, {"k": 2}]}}`,
+		to:   `{"c": {"l": [{"k": 2}, {"k": "+1"}], "name": "a"}}`,
+		want: []*Edit{},
+		// The key +1 is the key 1.
+		wantTo: `{"c": {"name": "a", "l": [{"k": 1}, {"k": 2}]}}`,
+	}, {
+		desc: "leaves",
+		from: `{"c": {"name": "a", "tcp-port": 80}}`,
+		to:   `{"c": {"name": "b"}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/tcp-port"},
+			{ID: "2", Operation: OpReplace, Target: "/c/name", Value: map[string]interface{}{"diff:name": "b"}},
+		},
+	}, {
+		desc:   "defaults",
+		from:   `{"c": {"name": "a", "mtu": 1500, "np": {"y": false}}}`,
+		to:     `{"c": {"name": "a"}}`,
+		want:   []*Edit{},
+		wantTo: `{"c": {"name": "a", "mtu": 1500, "np": {"y": false}}}`,
+	}, {
+		desc: "choice",
+		from: `{"c": {"tcp-port": 80}}`,
+		to:   `{"c": {"udp-port": 53}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/tcp-port"},
+			{ID: "2", Operation: OpReplace, Target: "/c/udp-port", Value: map[string]interface{}{"diff:udp-port": float64(53)}},
+		},
+	}, {
+		desc: "containers",
+		from: `{"c": {"np": {"x": "a"}}}`,
+		to:   `{"c": {"p": {}}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/np"},
+			{ID: "2", Operation: OpCreate, Target: "/c/p", Value: map[string]interface{}{"diff:p": map[string]interface{}{}}},
+		},
+	}, {
+		desc: "whole tree",
+		from: `{}`,
+		to:   `{"c": {"name": "a"}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpCreate, Target: "/c", Value: map[string]interface{}{"diff:c": map[string]interface{}{"name": "a"}}},
+		},
+	}, {
+		desc: "list entries",
+		from: `{"c": {"l": [{"k": 1, "v": "a"}, {"k": 2}]}}`,
+		to:   `{"c": {"l": [{"k": 1, "v": "b"}, {"k": 3}]}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/l=2"},
+			{ID: "2", Operation: OpReplace, Target: "/c/l=1/v", Value: map[string]interface{}{"diff:v": "b"}},
+			{ID: "3", Operation: OpCreate, Target: "/c/l=3", Value: map[string]interface{}{"diff:l": []interface{}{map[string]interface{}{"k": float64(3)}}}},
+		},
+	}, {
+		desc: "ordered list",
+		from: `{"c": {"ul": [{"k": "a"}, {"k": "b"}, {"k": "c"}, {"k": "d"}]}}`,
+		to:   `{"c": {"ul": [{"k": "d"}, {"k": "a"}, {"k": "b"}, {"k": "e"}, {"k": "c", "v": "x"}]}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpMove, Target: "/c/ul=d", Where: "first"},
+			{ID: "2", Operation: OpInsert, Target: "/c/ul=e", Where: "after", Point: "/c/ul=b", Value: map[string]interface{}{"diff:ul": []interface{}{map[string]interface{}{"k": "e"}}}},
+			{ID: "3", Operation: OpReplace, Target: "/c/ul=c/v", Value: map[string]interface{}{"diff:v": "x"}},
+		},
+	}, {
+		desc: "leaf-lists",
+		from: `{"c": {"ll": ["a", "b"], "ull": ["x", "y", "z"]}}`,
+		to:   `{"c": {"ll": ["b", "c"], "ull": ["z", "x", "y w"]}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/ll=a"},
+			{ID: "2", Operation: OpCreate, Target: "/c/ll=c", Value: map[string]interface{}{"diff:ll": []interface{}{"c"}}},
+			{ID: "3", Operation: OpDelete, Target: "/c/ull=y"},
+			{ID: "4", Operation: OpMove, Target: "/c/ull=x", Where: "after", Point: "/c/ull=z"},
+			{ID: "5", Operation: OpInsert, Target: "/c/ull=y%20w", Where: "after", Point: "/c/ull=x", Value: map[string]interface{}{"diff:ull": []interface{}{"y w"}}},
+		},
+	}, {
+		desc: "keyless list",
+		from: `{"c": {"name": "a", "kl": [{"v": 1}]}}`,
+		to:   `{"c": {"name": "a", "kl": [{"v": 2}]}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpReplace, Target: "/c", Value: map[string]interface{}{"diff:c": map[string]interface{}{"name": "a", "kl": []interface{}{map[string]interface{}{"v": float64(2)}}}}},
+		},
+	}, {
+		desc: "reserved characters in keys",
+		from: `{"c": {"ul": [{"k": "a=b"}, {"k": "50%"}], "ll": ["x=y"]}}`,
+		to:   `{"c": {"ul": [{"k": "50%"}, {"k": "a=b", "v": "1"}, {"k": "c,d/e"}], "ll": ["x=y", "100%"]}}`,
+		want: []*Edit{
+			{ID: "1", Operation: OpCreate, Target: "/c/ll=100%25", Value: map[string]interface{}{"diff:ll": []interface{}{"100%"}}},
+			{ID: "2", Operation: OpMove, Target: "/c/ul=a%3Db", Where: "after", Point: "/c/ul=50%25"},
+			{ID: "3", Operation: OpReplace, Target: "/c/ul=a%3Db/v", Value: map[string]interface{}{"diff:v": "1"}},
+			{ID: "4", Operation: OpInsert, Target: "/c/ul=c%2Cd%2Fe", Where: "after", Point: "/c/ul=a%3Db", Value: map[string]interface{}{"diff:ul": []interface{}{map[string]interface{}{"k": "c,d/e"}}}},
+		},
+	}, {
+		desc:   "state",
+		from:   `{"c": {"name": "a", "counter": 1}}`,
+		to:     `{"c": {"name": "a", "counter": 2}}`,
+		want:   []*Edit{},
+		wantTo: `{"c": {"name": "a", "counter": 1}}`,
+	}, {
+		desc:    "unknown element",
+		from:    `{"c": {}}`,
+		to:      `{"c": {"bad": 1}}`,
+		wantErr: `/c: unknown element "bad"`,
+	}, {
+		desc:    "duplicate key",
+		from:    `{}`,
+		to:      `{"c": {"l": [{"k": 1}, {"k": "01"}]}}`,
+		wantErr: "/c/l: duplicate entry for key =1",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			from, to := decodeJSON(t, tt.from), decodeJSON(t, tt.to)
+			p, err := Diff(e, from, to)
+			if err != nil {
+				if tt.wantErr == "" || err.Error() != tt.wantErr {
+					t.Fatalf("Diff() got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("Diff() got no error, want %q", tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, p.Edits); diff != "" {
+				t.Errorf("Diff() edits (-want, +got):\n%s", diff)
+			}
+			got, errs := p.Apply(e, from)
+			if len(errs) > 0 {
+				t.Fatalf("Apply() got errors %v", errs)
+			}
+			want := to
+			if tt.wantTo != "" {
+				want = decodeJSON(t, tt.wantTo)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Apply(Diff()) (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffNoEdits(t *testing.T) {
+	e := moduleEntry(t, "diff", testDiffModule)
+	data := decodeJSON(t, `{"c": {"name": "a"}}`)
+	p, err := Diff(e, data, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"patch-id":"diff","edit":[]}`; got != want {
+		t.Errorf("json.Marshal(Diff()) got %s, want %s", got, want)
+	}
+}
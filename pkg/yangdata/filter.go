@@ -0,0 +1,220 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// CheckSubtreeFilter checks the subtree filter of RFC 6241 section 6, the
+// children of the <filter> element filter, against the schema rooted at e and
+// returns all of the errors found.  Every element must be a data node of the
+// schema, containment nodes must be containers or lists, and content match
+// nodes must be leaves or leaf-lists whose text is a valid value.  If state is
+// true the filter is for a subscription to state data, and it is an error for
+// the filter to select a node with no config false data.
+func CheckSubtreeFilter(e *yang.Entry, filter *XMLElement, state bool) []error {
+	v := &validator{}
+	v.subtree(e, "", filter, state)
+	return v.errs
+}
+
+// subtree checks the children of the filter element x against e.
+func (v *validator) subtree(e *yang.Entry, path string, x *XMLElement, state bool) {
+	for _, c := range x.Elements {
+		cpath := path + "/" + c.XMLName.Local
+		ce := e.DataChild(c.XMLName.Local)
+		switch {
+		case ce == nil:
+			v.errorf(path, "unknown element %q", c.XMLName.Local)
+		case len(c.Elements) > 0:
+			if !ce.IsDir() || ce.IsAny() {
+				v.errorf(cpath, "%s is not a container or list and cannot be a containment node", ce.Name)
+				continue
+			}
+			v.subtree(ce, cpath, c, state)
+			if state && !selects(c) && !hasState(ce) {
+				v.errorf(cpath, "%s selects no state data", ce.Name)
+			}
+		case strings.TrimSpace(c.Text) != "":
+			if ce.IsDir() || ce.IsAny() {
+				v.errorf(cpath, "%s is not a leaf or leaf-list and cannot be a content match node", ce.Name)
+				continue
+			}
+			if ce.Type != nil {
+				if err := checkValue(ce.Type, Text(strings.TrimSpace(c.Text)), false); err != nil {
+					v.errorf(cpath, "%v", err)
+				}
+			}
+		case state && !hasState(ce):
+			v.errorf(cpath, "%s selects no state data", ce.Name)
+		}
+	}
+}
+
+// selects returns true if any child of the containment node x is a selection
+// or containment node, i.e., x does not select all of its children.
+func selects(x *XMLElement) bool {
+	for _, c := range x.Elements {
+		if len(c.Elements) > 0 || strings.TrimSpace(c.Text) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasState returns true if e, or any of its descendants, is config false.
+func hasState(e *yang.Entry) bool {
+	if e.ReadOnly() {
+		return true
+	}
+	for _, c := range e.Dir {
+		if hasState(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckXPathFilter checks the XPath filter xpath, as used by NETCONF
+// <get> and <create-subscription> and YANG-Push subscriptions, against the
+// schema rooted at e and returns all of the errors found.  The filter is
+// a union ("|") of absolute location paths in abbreviated syntax, each step a
+// node name, optionally with a prefix, or "*", and "//" selects descendants.
+// The names compared by predicates of the form [name = 'value'] must be
+// children of the node they apply to, the predicates of a step are not
+// checked unless they all are of that form.  If
+// state is true the filter is for a subscription to state data, and it is an
+// error for a path to select only nodes with no config false data.
+func CheckXPathFilter(e *yang.Entry, xpath string, state bool) []error {
+	var errs []error
+	for _, p := range splitTopLevel(xpath, '|') {
+		if err := checkLocationPath(e, strings.TrimSpace(p), state); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// checkLocationPath checks the absolute location path p against e.
+func checkLocationPath(e *yang.Entry, p string, state bool) error {
+	if !strings.HasPrefix(p, "/") {
+		return fmt.Errorf("%s: not an absolute location path", p)
+	}
+	nodes := []*yang.Entry{e}
+	rest := p
+	for rest != "" {
+		descendants := strings.HasPrefix(rest, "//")
+		rest = strings.TrimLeft(rest, "/")
+		steps := splitTopLevel(rest, '/')
+		step := steps[0]
+		rest = strings.TrimPrefix(rest, step)
+		name, preds := step, ""
+		if i := strings.Index(step, "["); i >= 0 {
+			name, preds = step[:i], step[i:]
+		}
+		_, name = splitQualified(strings.TrimSpace(name))
+		var next []*yang.Entry
+		for _, n := range nodes {
+			next = append(next, stepNodes(n, name, descendants)...)
+		}
+		if len(next) == 0 {
+			return fmt.Errorf("%s: no data node matches %s", p, step)
+		}
+		// SplitKeyPredicates fails, and nothing is checked, unless
+		// every predicate is of the form [name = 'value'].
+		kps, _ := yang.SplitKeyPredicates(preds, yang.XPathKeys)
+		for _, kp := range kps {
+			_, child := splitQualified(kp.Name)
+			found := false
+			for _, n := range next {
+				if n.DataChild(child) != nil {
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("%s: predicate of %s compares %s, which is not a child", p, step, child)
+			}
+		}
+		nodes = next
+	}
+	if state {
+		for _, n := range nodes {
+			if hasState(n) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: selects no state data", p)
+	}
+	return nil
+}
+
+// stepNodes returns the data nodes named name, or all data nodes if name is
+// "*", that are children of e or, if descendants is true, descendants of e.
+func stepNodes(e *yang.Entry, name string, descendants bool) []*yang.Entry {
+	var nodes []*yang.Entry
+	for _, c := range dataChildren(e) {
+		if name == "*" || c.Name == name {
+			nodes = append(nodes, c)
+		}
+		if descendants {
+			nodes = append(nodes, stepNodes(c, name, true)...)
+		}
+	}
+	return nodes
+}
+
+// dataChildren returns the data node children of e, descending through
+// choices and cases, ordered by name.
+func dataChildren(e *yang.Entry) []*yang.Entry {
+	var children []*yang.Entry
+	for _, c := range e.SortedDir() {
+		if c.IsChoice() || c.IsCase() {
+			children = append(children, dataChildren(c)...)
+			continue
+		}
+		children = append(children, c)
+	}
+	return children
+}
+
+// splitTopLevel splits s at each sep that is not within a predicate or a
+// quoted string.
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"strings"
+	"testing"
+)
+
+const testFilterModule = `
+module filter {
+	prefix "f";
+	namespace "urn:f";
+
+	container interfaces {
+		list interface {
+			key "name";
+			leaf name { type string; }
+			leaf mtu { type uint16; }
+			container config {
+				leaf enabled { type boolean; }
+			}
+			container state {
+				config false;
+				leaf oper-status { type enumeration { enum up; enum down; } }
+				choice counters {
+					leaf in-octets { type uint64; }
+				}
+			}
+		}
+	}
+}
+`
+
+func TestCheckSubtreeFilter(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       string
+		state    bool
+		wantErrs []string
+	}{{
+		desc: "valid filter",
+		in: `<filter type="subtree"><interfaces xmlns="urn:f"><interface>
+			<name>eth0</name><config/><state><oper-status/><in-octets/></state>
+		</interface></interfaces></filter>`,
+	}, {
+		desc: "valid state filter",
+		in: `<filter><interfaces><interface>
+			<name>eth0</name><state/>
+		</interface></interfaces></filter>`,
+		state: true,
+	}, {
+		desc: "unknown elements",
+		in:   `<filter><interfaces><bogus/><interface><mtu><x/></mtu></interface></interfaces><other/></filter>`,
+		wantErrs: []string{
+			`/interfaces: unknown element "bogus"`,
+			`/interfaces/interface/mtu: mtu is not a container or list and cannot be a containment node`,
+			`/: unknown element "other"`,
+		},
+	}, {
+		desc: "content match nodes",
+		in:   `<filter><interfaces><interface><mtu>big</mtu><config>x</config></interface></interfaces></filter>`,
+		wantErrs: []string{
+			`/interfaces/interface/mtu: invalid value big for type uint16`,
+			`/interfaces/interface/config: config is not a leaf or leaf-list and cannot be a content match node`,
+		},
+	}, {
+		desc: "config selected in a state subscription",
+		in: `<filter><interfaces><interface>
+			<name>eth0</name><mtu/><config><enabled>true</enabled></config>
+		</interface></interfaces></filter>`,
+		state: true,
+		wantErrs: []string{
+			`/interfaces/interface/mtu: mtu selects no state data`,
+			`/interfaces/interface/config: config selects no state data`,
+		},
+	}}
+
+	e := moduleEntry(t, "filter", testFilterModule)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			x, err := ParseXML(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("invalid test XML: %v", err)
+			}
+			var got []string
+			for _, err := range CheckSubtreeFilter(e, x, tt.state) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("CheckSubtreeFilter() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestCheckXPathFilter(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       string
+		state    bool
+		wantErrs []string
+	}{{
+		desc: "valid paths",
+		in:   `/f:interfaces/f:interface[f:name='eth0']/state | /interfaces/interface[1]/config/enabled`,
+	}, {
+		desc: "descendants and wildcards",
+		in:   `//oper-status | /interfaces/*/state/in-octets | //interface[name = "a|b"]/*`,
+	}, {
+		desc:  "state paths",
+		in:    `/interfaces/interface/state/oper-status | //in-octets | /interfaces`,
+		state: true,
+	}, {
+		desc: "unknown nodes",
+		in:   `/interfaces/bogus | //missing | /interfaces/interface[bogus='x'] | interfaces`,
+		wantErrs: []string{
+			`/interfaces/bogus: no data node matches bogus`,
+			`//missing: no data node matches missing`,
+			`/interfaces/interface[bogus='x']: predicate of interface[bogus='x'] compares bogus, which is not a child`,
+			`interfaces: not an absolute location path`,
+		},
+	}, {
+		desc: "every predicate of a step checked",
+		in:   `/interfaces/interface[name='a'][bogus = "b"]`,
+		wantErrs: []string{
+			`/interfaces/interface[name='a'][bogus = "b"]: predicate of interface[name='a'][bogus = "b"] compares bogus, which is not a child`,
+		},
+	}, {
+		desc:  "config selected in a state subscription",
+		in:    `/interfaces/interface/config | /interfaces/interface/state`,
+		state: true,
+		wantErrs: []string{
+			`/interfaces/interface/config: selects no state data`,
+		},
+	}}
+
+	e := moduleEntry(t, "filter", testFilterModule)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, err := range CheckXPathFilter(e, tt.in, tt.state) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("CheckXPathFilter() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,486 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// GNMIPathString returns p as a gNMI path string, e.g., "/c/l[k=1]/v", the
+// keys of each element ordered by name.  The path of the whole data tree, a
+// path without elements, is "/".
+func GNMIPathString(p *gpb.Path) string {
+	var b strings.Builder
+	if p.GetOrigin() != "" {
+		b.WriteString(p.GetOrigin() + ":")
+	}
+	for _, pe := range p.GetElem() {
+		b.WriteString("/" + pe.GetName())
+		if len(pe.GetKey()) == 0 {
+			continue
+		}
+		var keys []string
+		for k := range pe.GetKey() {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		// p has no schema, so the keys are written as those of a list
+		// whose keys are the keys of pe, which FormatKeys cannot fail
+		// to write.
+		l := &yang.Entry{
+			Name:     pe.GetName(),
+			Kind:     yang.DirectoryEntry,
+			Dir:      map[string]*yang.Entry{},
+			ListAttr: yang.NewDefaultListAttr(),
+			Key:      strings.Join(keys, " "),
+		}
+		preds, _ := l.FormatKeys(pe.GetKey(), yang.GNMIKeys)
+		b.WriteString(preds)
+	}
+	if len(p.GetElem()) == 0 {
+		b.WriteString("/")
+	}
+	return b.String()
+}
+
+// ParseGNMIPath parses the gNMI path string s, e.g., "/c/l[k=1]/v", with the
+// escapes of yang.GNMIKeys.  s has no origin.
+func ParseGNMIPath(s string) (*gpb.Path, error) {
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("path %q does not start with /", s)
+	}
+	p := &gpb.Path{}
+	if s == "/" {
+		return p, nil
+	}
+	var elems []string
+	depth, start := 0, 1
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && depth > 0:
+			i++
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			elems = append(elems, s[start:i])
+			start = i + 1
+		}
+	}
+	elems = append(elems, s[start:])
+	for _, elem := range elems {
+		name, rest := elem, ""
+		if i := strings.IndexByte(elem, '['); i >= 0 {
+			name, rest = elem[:i], elem[i:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("path %q has an empty element", s)
+		}
+		preds, err := yang.SplitKeyPredicates(rest, yang.GNMIKeys)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %v", s, err)
+		}
+		pe := &gpb.PathElem{Name: name}
+		for _, kp := range preds {
+			if pe.Key == nil {
+				pe.Key = map[string]string{}
+			}
+			pe.Key[kp.Name] = kp.Value
+		}
+		p.Elem = append(p.Elem, pe)
+	}
+	return p, nil
+}
+
+// TypedValueOf returns the gNMI TypedValue of v, the data of the node e, as
+// decoded from RFC 7951 JSON by encoding/json.  The encoding is chosen from
+// the resolved type of a leaf or leaf-list: IntVal and UintVal for integers,
+// DoubleVal for decimal64, BoolVal for booleans and for empty, which is
+// always true, BytesVal for binary and StringVal for every other type,
+// including the identities of identityrefs, which keep their module names.
+// A leafref has the encoding of its target, and a union that of the first of
+// its member types that v is valid for.  A leaf-list is a LeaflistVal of its
+// values and any other node is JSONIETFVal, its RFC 7951 JSON encoding.  An
+// error is returned if v is not a valid value of a leaf or leaf-list, with
+// integers and decimal64 values accepted as either JSON numbers or strings.
+func TypedValueOf(e *yang.Entry, v interface{}) (*gpb.TypedValue, error) {
+	switch {
+	case e.IsLeafList():
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an array for leaf-list %s, got %T", e.Name, v)
+		}
+		var tvs []*gpb.TypedValue
+		for _, x := range l {
+			tv, err := leafTypedValue(e, e.Type, x)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", e.Name, err)
+			}
+			tvs = append(tvs, tv)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{Element: tvs}}}, nil
+	case e.IsLeaf():
+		tv, err := leafTypedValue(e, e.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", e.Name, err)
+		}
+		return tv, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", e.Name, err)
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: b}}, nil
+}
+
+// leafTypedValue returns the TypedValue of v, a value of type t of the leaf
+// or leaf-list e.
+func leafTypedValue(e *yang.Entry, t *yang.YangType, v interface{}) (*gpb.TypedValue, error) {
+	switch t.Kind {
+	case yang.Yleafref:
+		if target := e.LeafrefTarget(); target != nil && target.Type != nil {
+			return leafTypedValue(target, target.Type, v)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: fmt.Sprint(v)}}, nil
+	case yang.Yunion:
+		for _, ut := range t.Type {
+			if checkValue(ut, v, true) == nil {
+				return leafTypedValue(e, ut, v)
+			}
+		}
+		return nil, fmt.Errorf("value %v does not match any union member type", v)
+	}
+	if err := checkValue(t, v, true); err != nil {
+		return nil, err
+	}
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64:
+		n, err := strconv.ParseInt(numberString(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v for type %s", v, t.Kind)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: n}}, nil
+	case yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		n, err := strconv.ParseUint(strings.TrimPrefix(numberString(v), "+"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v for type %s", v, t.Kind)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: n}}, nil
+	case yang.Ydecimal64:
+		f, err := strconv.ParseFloat(numberString(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v for type decimal64", v)
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: f}}, nil
+	case yang.Ybool:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: v.(bool)}}, nil
+	case yang.Yempty:
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}, nil
+	case yang.Ybinary:
+		b, err := yang.DecodeBinary(t, v.(string))
+		if err != nil {
+			return nil, err
+		}
+		return &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: b}}, nil
+	}
+	return &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: fmt.Sprint(v)}}, nil
+}
+
+// GNMIUpdates returns the gNMI updates setting each of the nodes of values,
+// the data of the nodes indexed by gNMI path string, see ParseGNMIPath, of the
+// schema rooted at e.  The updates are ordered by path.  An error is returned
+// if a path is not a data node of e, a list entry does not have all of its
+// keys, or a value is not valid, see TypedValueOf.
+func GNMIUpdates(e *yang.Entry, values map[string]interface{}) ([]*gpb.Update, error) {
+	var paths []string
+	for p := range values {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	var updates []*gpb.Update
+	for _, ps := range paths {
+		p, err := ParseGNMIPath(ps)
+		if err != nil {
+			return nil, err
+		}
+		c, p, err := ResolveGNMIPath(e, p)
+		if err != nil {
+			return nil, err
+		}
+		tv, err := TypedValueOf(c, values[ps])
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %v", ps, err)
+		}
+		updates = append(updates, &gpb.Update{Path: p, Val: tv})
+	}
+	return updates, nil
+}
+
+// ResolveGNMIPath returns the entry of the data node at p, in the schema
+// rooted at e, and a copy of p with canonical key values, see CanonicalKeys.
+// p is not modified.  An error is returned if p is not a data node of e, or
+// has a list entry without all of its keys or with invalid key values.  A
+// list without keys refers to the whole list.
+func ResolveGNMIPath(e *yang.Entry, p *gpb.Path) (*yang.Entry, *gpb.Path, error) {
+	ps := GNMIPathString(p)
+	out := &gpb.Path{Origin: p.GetOrigin(), Target: p.GetTarget()}
+	for _, pe := range p.GetElem() {
+		_, n := splitQualified(pe.GetName())
+		c := e.DataChild(n)
+		if c == nil {
+			return nil, nil, fmt.Errorf("path %s: unknown element %q", ps, pe.GetName())
+		}
+		ope := &gpb.PathElem{Name: pe.GetName()}
+		switch {
+		case len(pe.GetKey()) == 0:
+		case !c.IsList() || c.Key == "":
+			return nil, nil, fmt.Errorf("path %s: %s is not a list with keys", ps, c.Name)
+		default:
+			keys := map[string]string{}
+			for k, v := range pe.GetKey() {
+				_, kn := splitQualified(k)
+				keys[kn] = v
+			}
+			if len(keys) != len(c.KeyNames()) {
+				return nil, nil, fmt.Errorf("path %s: list %s has %d keys, got %d", ps, c.Name, len(c.KeyNames()), len(keys))
+			}
+			keys, err := CanonicalKeys(c, keys)
+			if err != nil {
+				return nil, nil, fmt.Errorf("path %s: %v", ps, err)
+			}
+			ope.Key = keys
+		}
+		out.Elem = append(out.Elem, ope)
+		e = c
+	}
+	return e, out, nil
+}
+
+// NewGNMISetRequest returns the gNMI SetRequest that changes the
+// configuration of the data tree from into that of the data tree to, both
+// rooted at the schema entry e, as computed by Diff.  gNMI has neither edits
+// of single leaf-list values nor the insert and move edits of ordered-by user
+// lists, so a leaf-list, or ordered-by user list, with such an edit is
+// replaced, or deleted, as a whole, and its other edits are dropped.  The
+// edits that replace a node are replaces and those that create one are
+// updates.
+func NewGNMISetRequest(e *yang.Entry, from, to interface{}) (*gpb.SetRequest, error) {
+	p, err := Diff(e, from, to)
+	if err != nil {
+		return nil, err
+	}
+	tm, err := diffObject(e, "", to)
+	if err != nil {
+		return nil, err
+	}
+	req := &gpb.SetRequest{}
+	var whole []string // whole are the paths of the nodes replaced as a whole.
+	within := func(path string) bool {
+		for _, w := range whole {
+			if path == w || strings.HasPrefix(path, w+"/") || strings.HasPrefix(path, w+"[") {
+				return true
+			}
+		}
+		return false
+	}
+	for _, ed := range p.Edits {
+		steps, err := parseTarget(e, ed.Target)
+		if err != nil {
+			return nil, err
+		}
+		path := gnmiPath(steps)
+		var last pathStep
+		if len(steps) > 0 {
+			last = steps[len(steps)-1]
+		}
+		if len(steps) > 0 && (last.entry.IsLeafList() || ed.Operation == OpInsert || ed.Operation == OpMove) {
+			// Replace the whole node, once.
+			path.Elem[len(path.Elem)-1].Key = nil
+			if within(GNMIPathString(path)) {
+				continue
+			}
+			whole = append(whole, GNMIPathString(path))
+			v, ok := dataAt(steps, tm)
+			if !ok {
+				req.Delete = append(req.Delete, path)
+				continue
+			}
+			tv, err := TypedValueOf(last.entry, v)
+			if err != nil {
+				return nil, fmt.Errorf("path %s: %v", GNMIPathString(path), err)
+			}
+			req.Replace = append(req.Replace, &gpb.Update{Path: path, Val: tv})
+			continue
+		}
+		if within(GNMIPathString(path)) {
+			continue
+		}
+		if ed.Operation == OpDelete || ed.Operation == OpRemove {
+			req.Delete = append(req.Delete, path)
+			continue
+		}
+		v, entry := ed.Value, e
+		if len(steps) > 0 {
+			if v, err = editValue(last, ed.Value); err != nil {
+				return nil, err
+			}
+			entry = last.entry
+		}
+		tv, err := TypedValueOf(entry, v)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %v", GNMIPathString(path), err)
+		}
+		u := &gpb.Update{Path: path, Val: tv}
+		if ed.Operation == OpReplace {
+			req.Replace = append(req.Replace, u)
+		} else {
+			req.Update = append(req.Update, u)
+		}
+	}
+	return req, nil
+}
+
+// gnmiPath returns the gNMI path of the data resource identified by steps.
+func gnmiPath(steps []pathStep) *gpb.Path {
+	p := &gpb.Path{}
+	for _, s := range steps {
+		pe := &gpb.PathElem{Name: s.name}
+		if s.entry.IsList() {
+			pe.Key = map[string]string{}
+			for i, k := range s.entry.KeyNames() {
+				pe.Key[k] = s.keys[i]
+			}
+		}
+		p.Elem = append(p.Elem, pe)
+	}
+	return p
+}
+
+// dataAt returns the data of the whole list, leaf-list or other node of the
+// last of steps in data, and whether it is present.
+func dataAt(steps []pathStep, data map[string]interface{}) (interface{}, bool) {
+	m := data
+	for i, s := range steps {
+		key, ok := memberKey(m, s.entry.Name)
+		if !ok {
+			return nil, false
+		}
+		if i == len(steps)-1 {
+			return m[key], true
+		}
+		if !s.entry.IsList() {
+			if m, ok = m[key].(map[string]interface{}); !ok {
+				return nil, false
+			}
+			continue
+		}
+		l, _ := m[key].([]interface{})
+		m = nil
+		for _, le := range l {
+			if s.matches(le) {
+				m, _ = le.(map[string]interface{})
+			}
+		}
+		if m == nil {
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// NewGNMINotification returns a gNMI Notification, with timestamp, that
+// updates each leaf and leaf-list of data, the data tree rooted at the schema
+// entry e, as telemetry reports it: one update for each leaf and leaf-list,
+// ordered by path.  Unlike a SetRequest, state data is included.
+func NewGNMINotification(e *yang.Entry, timestamp int64, data interface{}) (*gpb.Notification, error) {
+	m, err := diffObject(e, "", data)
+	if err != nil {
+		return nil, err
+	}
+	n := &gpb.Notification{Timestamp: timestamp}
+	if err := leafUpdates(e, nil, m, n); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(n.Update, func(i, j int) bool {
+		return GNMIPathString(n.Update[i].Path) < GNMIPathString(n.Update[j].Path)
+	})
+	return n, nil
+}
+
+// leafUpdates adds to n the updates of the leaves and leaf-lists of m, the
+// contents of the directory entry e at the elements elems.
+func leafUpdates(e *yang.Entry, elems []*gpb.PathElem, m map[string]interface{}, n *gpb.Notification) error {
+	for _, k := range sortedKeys(m) {
+		if strings.HasPrefix(k, "@") {
+			continue
+		}
+		_, name := splitQualified(k)
+		c := e.DataChild(name)
+		if c == nil {
+			return fmt.Errorf("%s: unknown element %q", GNMIPathString(&gpb.Path{Elem: elems}), k)
+		}
+		pe := &gpb.PathElem{Name: k}
+		path := append(append([]*gpb.PathElem{}, elems...), pe)
+		switch {
+		case c.IsList():
+			l, ok := m[k].([]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected an array for list %s, got %T", GNMIPathString(&gpb.Path{Elem: path}), c.Name, m[k])
+			}
+			for _, le := range l {
+				lm, ok := le.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%s: expected an object for list entry of %s, got %T", GNMIPathString(&gpb.Path{Elem: path}), c.Name, le)
+				}
+				epe := &gpb.PathElem{Name: k}
+				for _, key := range c.KeyNames() {
+					if epe.Key == nil {
+						epe.Key = map[string]string{}
+					}
+					v, _ := lookup(lm, key)
+					epe.Key[key] = fmt.Sprint(v)
+				}
+				if err := leafUpdates(c, append(append([]*gpb.PathElem{}, elems...), epe), lm, n); err != nil {
+					return err
+				}
+			}
+		case c.IsDir() && !c.IsAny():
+			cm, ok := m[k].(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%s: expected an object for %s, got %T", GNMIPathString(&gpb.Path{Elem: path}), c.Name, m[k])
+			}
+			if err := leafUpdates(c, path, cm, n); err != nil {
+				return err
+			}
+		default:
+			p := &gpb.Path{Elem: path}
+			tv, err := TypedValueOf(c, m[k])
+			if err != nil {
+				return fmt.Errorf("%s: %v", GNMIPathString(p), err)
+			}
+			n.Update = append(n.Update, &gpb.Update{Path: p, Val: tv})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const testGNMIModule = `
+module gnmi {
+	prefix "g";
+	namespace "urn:g";
+
+	identity base;
+	identity derived { base base; }
+
+	container c {
+		leaf s { type string; }
+		leaf i { type int64; }
+		leaf u { type uint8; }
+		leaf d { type decimal64 { fraction-digits 2; } }
+		leaf b { type boolean; }
+		leaf e { type empty; }
+		leaf bin { type binary; }
+		leaf id { type identityref { base base; } }
+		leaf un { type union { type uint32; type string; } }
+		leaf ref { type leafref { path "../u"; } }
+		leaf-list ll { type int32; }
+		list l {
+			key "k";
+			leaf k { type string; }
+			leaf v { type string; }
+			leaf state { type string; config false; }
+		}
+	}
+}
+`
+
+func TestGNMIPath(t *testing.T) {
+	for _, s := range []string{"/", "/c/l[k=a\\]b]/v", "/c/l[a=1][b=x/y]", "/c/l[k=a\\\\b]", "/g:c/s"} {
+		p, err := ParseGNMIPath(s)
+		if err != nil {
+			t.Errorf("ParseGNMIPath(%q) got error %v", s, err)
+			continue
+		}
+		if got := GNMIPathString(p); got != s {
+			t.Errorf("GNMIPathString(ParseGNMIPath(%q)) = %q", s, got)
+		}
+	}
+	for _, s := range []string{"c", "/c//s", "/c/l[k=a"} {
+		if _, err := ParseGNMIPath(s); err == nil {
+			t.Errorf("ParseGNMIPath(%q) got no error", s)
+		}
+	}
+}
+
+func TestGNMIUpdates(t *testing.T) {
+	e := moduleEntry(t, "gnmi", testGNMIModule)
+	tests := []struct {
+		desc    string
+		values  map[string]interface{}
+		want    []*gpb.Update
+		wantErr string
+	}{{
+		desc: "typed values",
+		values: map[string]interface{}{
+			"/c/s":   "x",
+			"/c/i":   "-5",
+			"/c/u":   float64(7),
+			"/c/d":   1.25,
+			"/c/b":   true,
+			"/c/e":   []interface{}{nil},
+			"/c/bin": "aGk=",
+			"/c/id":  "gnmi:derived",
+			"/c/un":  float64(3),
+			"/c/ref": float64(7),
+			"/c/ll":  []interface{}{float64(1), float64(2)},
+			"/c/l[k=a]": map[string]interface{}{
+				"k": "a", "v": "b",
+			},
+		},
+		want: []*gpb.Update{
+			{Path: mustGNMIPath(t, "/c/b"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}},
+			{Path: mustGNMIPath(t, "/c/bin"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_BytesVal{BytesVal: []byte("hi")}}},
+			{Path: mustGNMIPath(t, "/c/d"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_DoubleVal{DoubleVal: 1.25}}},
+			{Path: mustGNMIPath(t, "/c/e"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_BoolVal{BoolVal: true}}},
+			{Path: mustGNMIPath(t, "/c/i"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: -5}}},
+			{Path: mustGNMIPath(t, "/c/id"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "gnmi:derived"}}},
+			{Path: mustGNMIPath(t, "/c/l[k=a]"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"k":"a","v":"b"}`)}}},
+			{Path: mustGNMIPath(t, "/c/ll"), Val: leafListValue(
+				&gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 1}},
+				&gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 2}},
+			)},
+			{Path: mustGNMIPath(t, "/c/ref"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 7}}},
+			{Path: mustGNMIPath(t, "/c/s"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "x"}}},
+			{Path: mustGNMIPath(t, "/c/u"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 7}}},
+			{Path: mustGNMIPath(t, "/c/un"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_UintVal{UintVal: 3}}},
+		},
+	}, {
+		desc:   "union string",
+		values: map[string]interface{}{"/c/un": "auto"},
+		want:   []*gpb.Update{{Path: mustGNMIPath(t, "/c/un"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "auto"}}}},
+	}, {
+		desc:    "out of range",
+		values:  map[string]interface{}{"/c/u": float64(300)},
+		wantErr: "path /c/u: u: value 300 outside of range",
+	}, {
+		desc:    "unknown element",
+		values:  map[string]interface{}{"/c/x": "a"},
+		wantErr: `path /c/x: unknown element "x"`,
+	}, {
+		desc:    "keys of a container",
+		values:  map[string]interface{}{"/c[k=a]/s": "a"},
+		wantErr: "c is not a list with keys",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := GNMIUpdates(e, tt.values)
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GNMIUpdates() got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("GNMIUpdates() got no error, want %q", tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("GNMIUpdates() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// mustGNMIPath returns the gNMI path of s.
+func mustGNMIPath(t *testing.T, s string) *gpb.Path {
+	t.Helper()
+	p, err := ParseGNMIPath(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// leafListValue returns the LeaflistVal of tvs.
+func leafListValue(tvs ...*gpb.TypedValue) *gpb.TypedValue {
+	return &gpb.TypedValue{Value: &gpb.TypedValue_LeaflistVal{LeaflistVal: &gpb.ScalarArray{Element: tvs}}}
+}
+
+func TestResolveGNMIPath(t *testing.T) {
+	e := moduleEntry(t, "gnmi", testGNMIModule)
+	p := mustGNMIPath(t, "/g:c/l[g:k=a]/v")
+	got, rp, err := ResolveGNMIPath(e, p)
+	if err != nil {
+		t.Fatalf("ResolveGNMIPath() got error %v", err)
+	}
+	if got != e.Dir["c"].Dir["l"].Dir["v"] {
+		t.Errorf("ResolveGNMIPath() got entry %s, want /gnmi/c/l/v", got.Path())
+	}
+	if diff := cmp.Diff(mustGNMIPath(t, "/g:c/l[k=a]/v"), rp, protocmp.Transform()); diff != "" {
+		t.Errorf("ResolveGNMIPath() path (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(mustGNMIPath(t, "/g:c/l[g:k=a]/v"), p, protocmp.Transform()); diff != "" {
+		t.Errorf("ResolveGNMIPath() modified its path (-want, +got):\n%s", diff)
+	}
+}
+
+func TestNewGNMISetRequest(t *testing.T) {
+	e := moduleEntry(t, "diff", testDiffModule)
+	from := decodeJSON(t, `{"c": {"name": "a", "tcp-port": 80, "l": [{"k": 1, "v": "x"}], "ll": ["a"], "ul": [{"k": "a"}, {"k": "b"}]}}`)
+	to := decodeJSON(t, `{"c": {"name": "b", "l": [{"k": 1, "v": "y"}, {"k": 2}], "ll": ["a", "b"], "ul": [{"k": "b"}, {"k": "a", "v": "z"}]}}`)
+	got, err := NewGNMISetRequest(e, from, to)
+	if err != nil {
+		t.Fatalf("NewGNMISetRequest() got error %v", err)
+	}
+	want := &gpb.SetRequest{
+		Delete: []*gpb.Path{mustGNMIPath(t, "/c/tcp-port")},
+		Replace: []*gpb.Update{
+			{Path: mustGNMIPath(t, "/c/l[k=1]/v"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "y"}}},
+			{Path: mustGNMIPath(t, "/c/ll"), Val: leafListValue(
+				&gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}},
+				&gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "b"}},
+			)},
+			{Path: mustGNMIPath(t, "/c/name"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "b"}}},
+			{Path: mustGNMIPath(t, "/c/ul"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`[{"k":"b"},{"k":"a","v":"z"}]`)}}},
+		},
+		Update: []*gpb.Update{
+			{Path: mustGNMIPath(t, "/c/l[k=2]"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"k":2}`)}}},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("NewGNMISetRequest() (-want, +got):\n%s", diff)
+	}
+}
+
+func TestNewGNMINotification(t *testing.T) {
+	e := moduleEntry(t, "gnmi", testGNMIModule)
+	data := decodeJSON(t, `{"c": {"s": "x", "l": [{"k": "a", "state": "up"}], "ll": [1]}}`)
+	got, err := NewGNMINotification(e, 42, data)
+	if err != nil {
+		t.Fatalf("NewGNMINotification() got error %v", err)
+	}
+	want := &gpb.Notification{
+		Timestamp: 42,
+		Update: []*gpb.Update{
+			{Path: mustGNMIPath(t, "/c/l[k=a]/k"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "a"}}},
+			{Path: mustGNMIPath(t, "/c/l[k=a]/state"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "up"}}},
+			{Path: mustGNMIPath(t, "/c/ll"), Val: leafListValue(&gpb.TypedValue{Value: &gpb.TypedValue_IntVal{IntVal: 1}})},
+			{Path: mustGNMIPath(t, "/c/s"), Val: &gpb.TypedValue{Value: &gpb.TypedValue_StringVal{StringVal: "x"}}},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("NewGNMINotification() (-want, +got):\n%s", diff)
+	}
+	if _, err := NewGNMINotification(e, 0, decodeJSON(t, `{"c": {"u": "x"}}`)); err == nil {
+		t.Error("NewGNMINotification() with an invalid value got no error")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// This file has the insert, key and value attributes of RFC 7950 sections
+// 7.7.9 and 7.8.6, which place a value of an ordered-by user leaf-list, or an
+// entry of an ordered-by user list, that an edit creates or moves.  In XML
+// they are attributes in the YANGNamespace namespace.  In JSON they are the
+// RFC 7952 metadata annotations yang:insert, yang:key and yang:value: the
+// member "@" of a list entry, or the member "@NAME" of the object holding
+// the leaf-list NAME, an array with an object, or null, for each of its
+// values.
+
+// YANGNamespace is the XML namespace of the insert, key and value
+// attributes.
+const YANGNamespace = "urn:ietf:params:xml:ns:yang:1"
+
+// An Insertion is where an edit places an entry of an ordered-by user list or
+// a value of an ordered-by user leaf-list.
+type Insertion struct {
+	// Where is "first", "last", "before" or "after".
+	Where string
+	// Point is the entry, or value, the entry is placed before or after.
+	// For a list it is the value of the key attribute, the predicates
+	// of the keys of the entry, e.g., "[name='eth0']".  For a leaf-list
+	// it is the value of the value attribute.
+	Point string
+}
+
+// NodeInsertion returns the insertion given for n, a list entry or leaf-list
+// value, or nil if there is none.  Only XMLElements, and nodes returned by
+// NewJSONNode, have insertions.
+func NodeInsertion(n DataNode) *Insertion {
+	var insert, key, value string
+	switch n := n.(type) {
+	case *XMLElement:
+		for _, a := range n.Attr {
+			if a.Name.Space != YANGNamespace {
+				continue
+			}
+			switch a.Name.Local {
+			case "insert":
+				insert = a.Value
+			case "key":
+				key = a.Value
+			case "value":
+				value = a.Value
+			}
+		}
+	case *jsonNode:
+		return metaInsertion(n.meta)
+	}
+	return newInsertion(insert, key, value)
+}
+
+// metaInsertion returns the insertion given by meta, the JSON metadata
+// annotations of a list entry or leaf-list value, or nil if there is none.
+func metaInsertion(meta interface{}) *Insertion {
+	m, _ := meta.(map[string]interface{})
+	insert, _ := m["yang:insert"].(string)
+	key, _ := m["yang:key"].(string)
+	value, _ := m["yang:value"].(string)
+	return newInsertion(insert, key, value)
+}
+
+// newInsertion returns the insertion given by the insert, key and value
+// attributes, or nil if insert is not set.
+func newInsertion(insert, key, value string) *Insertion {
+	if insert == "" {
+		return nil
+	}
+	if key == "" {
+		key = value
+	}
+	return &Insertion{Where: insert, Point: key}
+}
+
+// check returns an error if ins cannot place an entry of e.
+func (ins *Insertion) check(e *yang.Entry) error {
+	if e.ListAttr == nil || !e.ListAttr.OrderedByUser {
+		return fmt.Errorf("insert requires an ordered-by user list or leaf-list, %s is not", e.Name)
+	}
+	switch ins.Where {
+	case "first", "last":
+		return nil
+	case "before", "after":
+	default:
+		return fmt.Errorf("invalid insert %q", ins.Where)
+	}
+	if !e.IsList() {
+		return nil
+	}
+	_, err := ins.pointKey(e)
+	return err
+}
+
+// pointKey returns the keyID of the point of ins, an insertion of an entry of
+// the list e.
+func (ins *Insertion) pointKey(e *yang.Entry) (string, error) {
+	values, err := e.ParseKeys(ins.Point, yang.XPathKeys)
+	if err != nil {
+		return "", fmt.Errorf("invalid key %q: %v", ins.Point, err)
+	}
+	return keyID(e, values)
+}
+
+// position returns the index in ids, the keys of the other entries of the
+// list e, or the other values of the leaf-list e, at which ins places an
+// entry.  A nil ins places it last.
+func (ins *Insertion) position(e *yang.Entry, ids []string) (int, error) {
+	if ins == nil {
+		return len(ids), nil
+	}
+	if err := ins.check(e); err != nil {
+		return 0, err
+	}
+	switch ins.Where {
+	case "first":
+		return 0, nil
+	case "last":
+		return len(ids), nil
+	}
+	point := ins.Point
+	if e.IsList() {
+		point, _ = ins.pointKey(e)
+	}
+	i := indexOf(ids, point)
+	if i < 0 {
+		return 0, fmt.Errorf("insert point %s does not exist", ins.Point)
+	}
+	if ins.Where == "after" {
+		i++
+	}
+	return i, nil
+}
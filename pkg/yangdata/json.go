@@ -0,0 +1,69 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// JSONValue returns the RFC 7951 JSON encoding of s, a value of type t in the
+// lexical representation of RFC 7950 section 9, as a value encoding/json
+// marshals correctly:
+//
+//   - int8 through int32 and uint8 through uint32 are json.Numbers,
+//   - int64, uint64 and decimal64 are strings, see RFC 7951 section 6.1,
+//   - booleans are bools, and an empty value is []interface{}{nil},
+//   - a union value is encoded as the first member type it is valid for,
+//   - any other value is a string, in the canonical form of its type if t is
+//     derived from a well-known type.
+//
+// An error is returned if s is not a valid value of t.
+func JSONValue(t *yang.YangType, s string) (interface{}, error) {
+	if err := checkValue(t, Text(s), false); err != nil {
+		return nil, err
+	}
+	switch t.Kind {
+	case yang.Yunion:
+		for _, ut := range t.Type {
+			if checkValue(ut, Text(s), false) == nil {
+				return JSONValue(ut, s)
+			}
+		}
+		return nil, fmt.Errorf("value %q does not match any union member type", s)
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		n, err := yang.ParseInt(s)
+		if err != nil {
+			return nil, err
+		}
+		return json.Number(n.String()), nil
+	case yang.Yint64, yang.Yuint64:
+		n, err := yang.ParseInt(s)
+		if err != nil {
+			return nil, err
+		}
+		return n.String(), nil
+	case yang.Ydecimal64:
+		return strings.TrimSpace(s), nil
+	case yang.Ybool:
+		return s == "true", nil
+	case yang.Yempty:
+		return []interface{}{nil}, nil
+	}
+	return Canonical(t, s)
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONValue(t *testing.T) {
+	m := moduleEntry(t, "j", `
+module j {
+	prefix "j";
+	namespace "urn:j";
+
+	leaf i8 { type int8; }
+	leaf u32 { type uint32; }
+	leaf i64 { type int64; }
+	leaf d { type decimal64 { fraction-digits 2; } }
+	leaf b { type boolean; }
+	leaf e { type empty; }
+	leaf s { type string; }
+	leaf u { type union { type int32; type string; } }
+}`)
+
+	tests := []struct {
+		leaf    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{leaf: "i8", in: "-12", want: `-12`},
+		{leaf: "i8", in: "+12", want: `12`},
+		{leaf: "i8", in: "300", wantErr: true},
+		{leaf: "u32", in: "4294967295", want: `4294967295`},
+		{leaf: "i64", in: "-9223372036854775808", want: `"-9223372036854775808"`},
+		{leaf: "d", in: "1.25", want: `"1.25"`},
+		{leaf: "b", in: "true", want: `true`},
+		{leaf: "b", in: "TRUE", wantErr: true},
+		{leaf: "e", in: "", want: `[null]`},
+		{leaf: "s", in: "42", want: `"42"`},
+		{leaf: "u", in: "42", want: `42`},
+		{leaf: "u", in: "forty-two", want: `"forty-two"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.leaf+" "+tt.in, func(t *testing.T) {
+			v, err := JSONValue(m.Dir[tt.leaf].Type, tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JSONValue() got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := json.Marshal(v)
+			if err != nil {
+				t.Fatalf("cannot marshal %#v: %v", v, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("JSONValue() encodes as %s, want %s", got, tt.want)
+			}
+			// The encoding is one the validator accepts.
+			var data interface{}
+			if err := json.Unmarshal([]byte(`{"`+tt.leaf+`": `+string(got)+`}`), &data); err != nil {
+				t.Fatal(err)
+			}
+			if errs := Validate(m, data); len(errs) > 0 {
+				t.Errorf("Validate() of %s got errors %v", got, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// CanonicalKeys returns values, the values of the keys of an entry of the
+// list e indexed by key name, in the canonical form of the type of each key,
+// so that the same entry always has the same key predicates, as written by
+// Entry.FormatKeys.  Integers lose their sign and leading zeros, decimal64
+// values their trailing zeros, and the values of well-known types are made
+// canonical by Canonical.  An error is returned if a value is not valid for
+// the type of its key.
+func CanonicalKeys(e *yang.Entry, values map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(values))
+	for k, v := range values {
+		c := e.Dir[k]
+		if c == nil || c.Type == nil {
+			return nil, fmt.Errorf("%s is not a key of list %s", k, e.Name)
+		}
+		cv, err := CanonicalValue(c, v)
+		if err != nil {
+			return nil, fmt.Errorf("key %s of list %s: %v", k, e.Name, err)
+		}
+		out[k] = cv
+	}
+	return out, nil
+}
+
+// keyID returns the key values of the entry of the list e whose keys have
+// the values values, written as in a RESTCONF data resource identifier, e.g.,
+// =x%20y,z.  The values are percent-encoded, so two entries have the same
+// keyID only if their key values are the same.
+func keyID(e *yang.Entry, values map[string]string) (string, error) {
+	return e.FormatKeys(values, yang.RESTCONFKeys)
+}
+
+// CanonicalValue returns s, a value of the leaf or leaf-list e, in the
+// canonical form of its type, see CanonicalKeys.  The canonical form of a
+// leafref is that of its target and of a union that of the first member type
+// s is valid for.
+func CanonicalValue(e *yang.Entry, s string) (string, error) {
+	t := e.Type
+	if t.Kind == yang.Yleafref {
+		target := e.LeafrefTarget()
+		if target == nil {
+			return s, nil
+		}
+		return CanonicalValue(target, s)
+	}
+	return canonicalValue(t, s)
+}
+
+// canonicalValue returns s, a value of type t, in canonical form.
+func canonicalValue(t *yang.YangType, s string) (string, error) {
+	switch t.Kind {
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64,
+		yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		// The lexical form of an integer is decimal, unlike the
+		// numbers of ranges that yang.ParseInt parses.
+		var n yang.Number
+		digits := strings.TrimPrefix(s, "+")
+		if strings.HasPrefix(s, "-") {
+			n.Negative, digits = true, s[1:]
+		}
+		v, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil || digits[0] == '+' || digits[0] == '-' {
+			return "", fmt.Errorf("invalid value %q for type %s", s, t.Kind)
+		}
+		n.Value, n.Negative = v, n.Negative && v != 0
+		if !t.Range.Contains(yang.YangRange{{Min: n, Max: n}}) {
+			return "", fmt.Errorf("value %q outside of range %s", s, t.Range)
+		}
+		return n.String(), nil
+	case yang.Ydecimal64:
+		n, err := yang.ParseDecimal(s, uint8(t.FractionDigits))
+		if err != nil {
+			return "", fmt.Errorf("invalid value %q for type decimal64: %v", s, err)
+		}
+		if n.Value == 0 {
+			n.Negative = false
+		}
+		d := strings.TrimRight(n.String(), "0")
+		if strings.HasSuffix(d, ".") {
+			d += "0"
+		}
+		return d, nil
+	case yang.Yunion:
+		for _, ut := range t.Type {
+			if cs, err := canonicalValue(ut, s); err == nil && checkValue(ut, Text(cs), true) == nil {
+				return cs, nil
+			}
+		}
+		return "", fmt.Errorf("value %q does not match any union member type", s)
+	}
+	return Canonical(t, s)
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/gnmi/errdiff"
+)
+
+func TestCanonicalKeys(t *testing.T) {
+	e := moduleEntry(t, "ck", `module ck { prefix ck; namespace "urn:ck";
+		list l {
+			key "id ratio flag ref choice";
+			leaf id { type int32; }
+			leaf ratio { type decimal64 { fraction-digits 3; } }
+			leaf flag { type boolean; }
+			leaf ref { type leafref { path "../id"; } }
+			leaf choice { type union { type uint8; type string; } }
+		}
+	}`)
+	l := e.Dir["l"]
+	tests := []struct {
+		desc    string
+		in      map[string]string
+		want    map[string]string
+		wantErr string
+	}{{
+		desc: "canonical",
+		in:   map[string]string{"id": "+007", "ratio": "1.500", "flag": "true", "ref": "-0", "choice": "08"},
+		want: map[string]string{"id": "7", "ratio": "1.5", "flag": "true", "ref": "0", "choice": "8"},
+	}, {
+		desc: "decimal without fraction",
+		in:   map[string]string{"ratio": "2"},
+		want: map[string]string{"ratio": "2.0"},
+	}, {
+		desc: "union string member",
+		in:   map[string]string{"choice": "abc"},
+		want: map[string]string{"choice": "abc"},
+	}, {
+		desc:    "invalid integer",
+		in:      map[string]string{"id": "x"},
+		wantErr: `key id of list l: invalid value "x" for type int32`,
+	}, {
+		desc:    "invalid boolean",
+		in:      map[string]string{"flag": "True"},
+		wantErr: "invalid boolean value",
+	}, {
+		desc:    "not a key",
+		in:      map[string]string{"mtu": "1"},
+		wantErr: "mtu is not a key of list l",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := CanonicalKeys(l, tt.in)
+			if diff := errdiff.Substring(err, tt.wantErr); diff != "" {
+				t.Fatal(diff)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CanonicalKeys() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// Merge merges the data tree src into the data tree dst, both as decoded from
+// JSON by encoding/json and rooted at the schema entry e, and returns the
+// result.  It follows the NETCONF merge operation of RFC 6241 section 7.2,
+// which RESTCONF uses for a plain PATCH:
+//
+//   - a leaf, anydata or anyxml in src replaces the one in dst
+//   - containers are merged member by member
+//   - the entries of a list are matched by their keys, an entry of src is
+//     merged into the matching entry of dst or, if there is none, is added
+//   - values of a leaf-list in src are added to it unless already present
+//   - data for a case of a choice removes the data of its other cases
+//   - a non-presence container only exists if it has children, a presence
+//     container given in src exists even if it is empty
+//
+// Entries of a list and values of a leaf-list that are new are added after
+// those of dst.  For an ordered-by user list or leaf-list, the entries in src
+// are also moved after the others, in the order of src, as if each had been
+// given with the insert="last" attribute of RFC 7950 section 7.8.6, unless
+// one has a yang:insert annotation, see NodeInsertion, which places it as
+// the attribute would.  A keyless list is merged by appending the entries of
+// src.
+//
+// The RFC 7952 metadata annotations of the nodes of src, other than those
+// of insertions, are merged into those of the nodes of dst, an annotation in
+// src replacing the same annotation in dst, so that, e.g., the origins of
+// operational data, see NodeOrigin, are kept.
+//
+// Neither dst nor src is modified.  Only the structure of the data is checked,
+// the result may be checked against the schema with Validate.
+func Merge(e *yang.Entry, dst, src interface{}) (interface{}, error) {
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	return mergeNode(e, "", dst, src)
+}
+
+// mergeNode merges src into dst, the values of e at path.
+func mergeNode(e *yang.Entry, path string, dst, src interface{}) (interface{}, error) {
+	switch {
+	case e.IsAny():
+		return copyValue(src), nil
+	case e.IsList():
+		return mergeList(e, path, dst, src)
+	case e.IsLeafList():
+		return mergeLeafList(e, path, dst, src, nil)
+	case e.IsDir():
+		return mergeDir(e, path, dst, src)
+	}
+	return copyValue(src), nil
+}
+
+// mergeDir merges src into dst, the contents of the directory entry e.
+func mergeDir(e *yang.Entry, path string, dst, src interface{}) (interface{}, error) {
+	sm, ok := src.(map[string]interface{})
+	if !ok {
+		return nil, mergeErrorf(path, "expected an object for %s, got %T", e.Name, src)
+	}
+	dm, ok := dst.(map[string]interface{})
+	if dst != nil && !ok {
+		return nil, mergeErrorf(path, "expected an object for %s, got %T", e.Name, dst)
+	}
+	out := copyValue(dm).(map[string]interface{})
+	if out == nil {
+		out = map[string]interface{}{}
+	}
+	for _, name := range sortedKeys(sm) {
+		if strings.HasPrefix(name, "@") {
+			continue
+		}
+		_, n := splitQualified(name)
+		c := e.DataChild(n)
+		if c == nil {
+			return nil, mergeErrorf(path, "unknown element %q", name)
+		}
+		removeOtherCases(e, c, out)
+		key, exists := memberKey(out, n)
+		if !exists {
+			key = name
+		}
+		old := out[key]
+		var v interface{}
+		var err error
+		if c.IsLeafList() {
+			v, err = mergeLeafList(c, path+"/"+name, old, sm[name], sm["@"+name])
+		} else {
+			v, err = mergeNode(c, path+"/"+name, old, sm[name])
+		}
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := v.(map[string]interface{}); ok && len(m) == 0 && !exists && !c.IsPresenceContainer() && !c.IsAny() {
+			continue
+		}
+		out[key] = v
+		if c.IsLeafList() {
+			setAnnotations(out, "@"+key, leafListAnnotations(old, out["@"+key], sm[name], sm["@"+name], v))
+		}
+	}
+	for _, name := range sortedKeys(sm) {
+		if name == "@" {
+			setAnnotations(out, name, mergeAnnotations(out[name], sm[name]))
+			continue
+		}
+		if !strings.HasPrefix(name, "@") {
+			continue
+		}
+		_, n := splitQualified(name[1:])
+		if c := e.DataChild(n); c == nil || c.IsLeafList() {
+			continue
+		}
+		if key, exists := memberKey(out, n); exists {
+			setAnnotations(out, "@"+key, mergeAnnotations(out["@"+key], sm[name]))
+		}
+	}
+	return out, nil
+}
+
+// mergeAnnotations returns the annotations of src, an object of metadata
+// annotations, merged into those of dst, without those of insertions, or nil
+// if there are none.
+func mergeAnnotations(dst, src interface{}) interface{} {
+	out := copyValue(dst)
+	om, _ := out.(map[string]interface{})
+	if om == nil {
+		om = map[string]interface{}{}
+	}
+	sm, _ := src.(map[string]interface{})
+	for k, v := range sm {
+		om[k] = copyValue(v)
+	}
+	for _, k := range []string{"yang:insert", "yang:key", "yang:value"} {
+		delete(om, k)
+	}
+	if len(om) == 0 {
+		return nil
+	}
+	return om
+}
+
+// leafListAnnotations returns the array of the annotations of values, the
+// merged values of a leaf-list whose values in dst and src, dvals and svals,
+// had the arrays of annotations dmeta and smeta, or nil if no value has any.
+func leafListAnnotations(dvals, dmeta, svals, smeta, values interface{}) interface{} {
+	byValue := map[string]interface{}{}
+	for _, vm := range [][2]interface{}{{dvals, dmeta}, {svals, smeta}} {
+		vl, _ := vm[0].([]interface{})
+		ml, _ := vm[1].([]interface{})
+		for i, v := range vl {
+			if i < len(ml) {
+				id := fmt.Sprint(v)
+				byValue[id] = mergeAnnotations(byValue[id], ml[i])
+			}
+		}
+	}
+	vl, _ := values.([]interface{})
+	meta := make([]interface{}, len(vl))
+	found := false
+	for i, v := range vl {
+		if meta[i] = byValue[fmt.Sprint(v)]; meta[i] != nil {
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return meta
+}
+
+// setAnnotations sets the member name of m to the annotations ann, or
+// removes it if ann is nil.
+func setAnnotations(m map[string]interface{}, name string, ann interface{}) {
+	if ann == nil {
+		delete(m, name)
+		return
+	}
+	m[name] = ann
+}
+
+// memberKey returns the member name, which may be qualified by a module name,
+// that m has for the data node name.
+func memberKey(m map[string]interface{}, name string) (string, bool) {
+	if _, ok := m[name]; ok {
+		return name, true
+	}
+	for _, k := range sortedKeys(m) {
+		if _, n := splitQualified(k); n == name {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// removeOtherCases removes from m, the contents of e, the data of every case
+// that is not the one containing c, e's data child, in each choice between e
+// and c.
+func removeOtherCases(e, c *yang.Entry, m map[string]interface{}) {
+	prev := c
+	for p := c.Parent; p != nil && p != e; p = p.Parent {
+		if p.IsChoice() {
+			for k := range m {
+				_, n := splitQualified(k)
+				if d := e.DataChild(n); d != nil && isDescendant(p, d) && !isDescendant(prev, d) {
+					delete(m, k)
+					delete(m, "@"+k)
+				}
+			}
+		}
+		prev = p
+	}
+}
+
+// isDescendant returns true if d is e or one of e's descendants.
+func isDescendant(e, d *yang.Entry) bool {
+	for ; d != nil; d = d.Parent {
+		if d == e {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeList merges src into dst, the entries of the list e.
+func mergeList(e *yang.Entry, path string, dst, src interface{}) (interface{}, error) {
+	sl, ok := src.([]interface{})
+	if !ok {
+		return nil, mergeErrorf(path, "expected an array for list %s, got %T", e.Name, src)
+	}
+	dl, ok := dst.([]interface{})
+	if dst != nil && !ok {
+		return nil, mergeErrorf(path, "expected an array for list %s, got %T", e.Name, dst)
+	}
+	keys := strings.Fields(e.Key)
+	out := copyValue(dl).([]interface{})
+	if len(keys) == 0 {
+		return append(out, copyValue(sl).([]interface{})...), nil
+	}
+	var ids []string
+	for i, le := range out {
+		id, err := listKey(e, fmt.Sprintf("%s[%d]", path, i), keys, le)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	for i, le := range sl {
+		epath := fmt.Sprintf("%s[%d]", path, i)
+		id, err := listKey(e, epath, keys, le)
+		if err != nil {
+			return nil, err
+		}
+		j := indexOf(ids, id)
+		var old interface{}
+		if j >= 0 {
+			old = out[j]
+		}
+		v, err := mergeDir(e, epath, old, le)
+		if err != nil {
+			return nil, err
+		}
+		if out, ids, err = place(e, out, ids, j, v, id, metaInsertion(le.(map[string]interface{})["@"])); err != nil {
+			return nil, mergeErrorf(epath, "%v", err)
+		}
+	}
+	return out, nil
+}
+
+// place places v, with the key or value id, in out, the entries of the list,
+// or values of the leaf-list, e whose keys or values are ids.  j is the index
+// of the entry v replaces, or -1 if it is new.  An entry of an ordered-by
+// user list or leaf-list is moved to where ins places it, or last, any other
+// replaces the entry at j or is added last.
+func place(e *yang.Entry, out []interface{}, ids []string, j int, v interface{}, id string, ins *Insertion) ([]interface{}, []string, error) {
+	switch {
+	case e.ListAttr.OrderedByUser:
+		if j >= 0 {
+			out = append(out[:j], out[j+1:]...)
+			ids = append(ids[:j], ids[j+1:]...)
+		}
+		pos, err := ins.position(e, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out[:pos], append([]interface{}{v}, out[pos:]...)...)
+		ids = append(ids[:pos], append([]string{id}, ids[pos:]...)...)
+	case ins != nil:
+		return nil, nil, ins.check(e)
+	case j >= 0:
+		out[j] = v
+	default:
+		out = append(out, v)
+		ids = append(ids, id)
+	}
+	return out, ids, nil
+}
+
+// listKey returns the keyID of the entry le of the list e.
+func listKey(e *yang.Entry, path string, keys []string, le interface{}) (string, error) {
+	m, ok := le.(map[string]interface{})
+	if !ok {
+		return "", mergeErrorf(path, "expected an object for list entry of %s, got %T", e.Name, le)
+	}
+	values := map[string]string{}
+	for _, k := range keys {
+		val, ok := lookup(m, k)
+		if !ok {
+			return "", mergeErrorf(path, "missing key %q", k)
+		}
+		values[k] = fmt.Sprint(val)
+	}
+	id, err := keyID(e, values)
+	if err != nil {
+		return "", mergeErrorf(path, "%v", err)
+	}
+	return id, nil
+}
+
+// mergeLeafList merges src into dst, the values of the leaf-list e.  meta is
+// the array of the metadata annotations of the values of src, if any.
+func mergeLeafList(e *yang.Entry, path string, dst, src, meta interface{}) (interface{}, error) {
+	sl, ok := src.([]interface{})
+	if !ok {
+		return nil, mergeErrorf(path, "expected an array for leaf-list %s, got %T", e.Name, src)
+	}
+	dl, ok := dst.([]interface{})
+	if dst != nil && !ok {
+		return nil, mergeErrorf(path, "expected an array for leaf-list %s, got %T", e.Name, dst)
+	}
+	out := copyValue(dl).([]interface{})
+	var ids []string
+	for _, v := range out {
+		ids = append(ids, fmt.Sprint(v))
+	}
+	ml, _ := meta.([]interface{})
+	for i, v := range sl {
+		var ins *Insertion
+		if i < len(ml) {
+			ins = metaInsertion(ml[i])
+		}
+		id := fmt.Sprint(v)
+		j := indexOf(ids, id)
+		if j >= 0 && !e.ListAttr.OrderedByUser && ins == nil {
+			continue
+		}
+		var err error
+		if out, ids, err = place(e, out, ids, j, v, id, ins); err != nil {
+			return nil, mergeErrorf(fmt.Sprintf("%s[%d]", path, i), "%v", err)
+		}
+	}
+	return out, nil
+}
+
+// indexOf returns the index of s in ss, or -1 if it is not present.
+func indexOf(ss []string, s string) int {
+	for i, x := range ss {
+		if x == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyValue returns a deep copy of the JSON value v.
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if v == nil {
+			return map[string]interface{}(nil)
+		}
+		m := make(map[string]interface{}, len(v))
+		for k, x := range v {
+			m[k] = copyValue(x)
+		}
+		return m
+	case []interface{}:
+		if v == nil {
+			return []interface{}(nil)
+		}
+		l := make([]interface{}, len(v))
+		for i, x := range v {
+			l[i] = copyValue(x)
+		}
+		return l
+	}
+	return v
+}
+
+// mergeErrorf returns an error found at the data path path.
+func mergeErrorf(path, format string, a ...interface{}) error {
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Errorf("%s: "+format, append([]interface{}{path}, a...)...)
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testMergeModule = `
+module merge {
+	prefix "m";
+	namespace "urn:m";
+
+	container c {
+		leaf name { type string; }
+		container np { leaf x { type string; } }
+		container p { presence "enabled"; leaf x { type string; } }
+		choice transport {
+			case tcp { leaf tcp-port { type uint16; } }
+			case udp { leaf udp-port { type uint16; } }
+		}
+		list l {
+			key "k";
+			leaf k { type string; }
+			leaf v { type int32; }
+			leaf w { type int32; }
+		}
+		list ul {
+			key "k";
+			ordered-by user;
+			leaf k { type string; }
+		}
+		list ml {
+			key "a b";
+			leaf a { type string; }
+			leaf b { type string; }
+			leaf v { type int32; }
+		}
+		leaf-list ll { type string; }
+		leaf-list ull { type string; ordered-by user; }
+		list kl {
+			config false;
+			leaf v { type int32; }
+		}
+		anydata content;
+	}
+}
+`
+
+// decodeJSON returns s decoded by encoding/json.
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid test JSON %s: %v", s, err)
+	}
+	return v
+}
+
+func TestMerge(t *testing.T) {
+	e := moduleEntry(t, "merge", testMergeModule)
+
+	tests := []struct {
+		desc    string
+		dst     string
+		src     string
+		want    string
+		wantErr string
+	}{{
+		desc: "leaf replaced",
+		dst:  `{"c": {"name": "a", "tcp-port": 1}}`,
+		src:  `{"c": {"name": "b"}}`,
+		want: `{"c": {"name": "b", "tcp-port": 1}}`,
+	}, {
+		desc: "into empty tree",
+		dst:  `null`,
+		src:  `{"merge:c": {"name": "b"}}`,
+		want: `{"merge:c": {"name": "b"}}`,
+	}, {
+		desc: "qualified name matches unqualified name",
+		dst:  `{"merge:c": {"name": "a"}}`,
+		src:  `{"c": {"name": "b"}}`,
+		want: `{"merge:c": {"name": "b"}}`,
+	}, {
+		desc: "list entries matched by key",
+		dst:  `{"c": {"l": [{"k": "one", "v": 1}, {"k": "two", "v": 2}]}}`,
+		src:  `{"c": {"l": [{"k": "three"}, {"k": "one", "w": 3}]}}`,
+		want: `{"c": {"l": [{"k": "one", "v": 1, "w": 3}, {"k": "two", "v": 2}, {"k": "three"}]}}`,
+	}, {
+		desc: "list entries matched by all their keys",
+		dst:  `{"c": {"ml": [{"a": "x y", "b": "z", "v": 1}, {"a": "x", "b": "y", "v": 2}]}}`,
+		src:  `{"c": {"ml": [{"a": "x", "b": "y z", "v": 3}, {"a": "x", "b": "y", "v": 4}]}}`,
+		want: `{"c": {"ml": [{"a": "x y", "b": "z", "v": 1}, {"a": "x", "b": "y", "v": 4}, {"a": "x", "b": "y z", "v": 3}]}}`,
+	}, {
+		desc: "ordered-by user list entries move last",
+		dst:  `{"c": {"ul": [{"k": "one"}, {"k": "two"}, {"k": "three"}]}}`,
+		src:  `{"c": {"ul": [{"k": "one"}, {"k": "four"}]}}`,
+		want: `{"c": {"ul": [{"k": "two"}, {"k": "three"}, {"k": "one"}, {"k": "four"}]}}`,
+	}, {
+		desc: "ordered-by user list entries inserted",
+		dst:  `{"c": {"ul": [{"k": "one"}, {"k": "two"}, {"k": "three"}]}}`,
+		src: `{"c": {"ul": [
+			{"k": "four", "@": {"yang:insert": "before", "yang:key": "[m:k='two']"}},
+			{"k": "three", "@": {"yang:insert": "first"}}
+		]}}`,
+		want: `{"c": {"ul": [{"k": "three"}, {"k": "one"}, {"k": "four"}, {"k": "two"}]}}`,
+	}, {
+		desc: "insert point with a space in its key",
+		dst:  `{"c": {"ul": [{"k": "x y"}, {"k": "x"}]}}`,
+		src:  `{"c": {"ul": [{"k": "new", "@": {"yang:insert": "before", "yang:key": "[k = \"x y\"]"}}]}}`,
+		want: `{"c": {"ul": [{"k": "new"}, {"k": "x y"}, {"k": "x"}]}}`,
+	}, {
+		desc:    "insert point not quoted",
+		dst:     `{"c": {"ul": [{"k": "one"}]}}`,
+		src:     `{"c": {"ul": [{"k": "two", "@": {"yang:insert": "after", "yang:key": "[k=one]"}}]}}`,
+		wantErr: `/c/ul[0]: invalid key "[k=one]": unquoted value of key k in "[k=one]"`,
+	}, {
+		desc:    "insert point missing",
+		dst:     `{"c": {"ul": [{"k": "one"}]}}`,
+		src:     `{"c": {"ul": [{"k": "two", "@": {"yang:insert": "after", "yang:key": "[k='nine']"}}]}}`,
+		wantErr: `/c/ul[0]: insert point [k='nine'] does not exist`,
+	}, {
+		desc:    "insert into a list ordered by the system",
+		dst:     `{}`,
+		src:     `{"c": {"l": [{"k": "one", "@": {"yang:insert": "first"}}]}}`,
+		wantErr: `/c/l[0]: insert requires an ordered-by user list or leaf-list, l is not`,
+	}, {
+		desc: "keyless list entries appended",
+		dst:  `{"c": {"kl": [{"v": 1}]}}`,
+		src:  `{"c": {"kl": [{"v": 1}, {"v": 2}]}}`,
+		want: `{"c": {"kl": [{"v": 1}, {"v": 1}, {"v": 2}]}}`,
+	}, {
+		desc: "annotations merged",
+		dst: `{"c": {"@": {"ietf-origin:origin": "ietf-origin:intended"},
+			"name": "a", "@name": {"ietf-origin:origin": "ietf-origin:intended", "x:y": 1},
+			"ll": ["a", "b"], "@ll": [{"ietf-origin:origin": "ietf-origin:system"}, null]
+		}}`,
+		src: `{"c": {
+			"name": "b", "@name": {"ietf-origin:origin": "ietf-origin:learned"},
+			"ll": ["c", "b"], "@ll": [null, {"ietf-origin:origin": "ietf-origin:learned"}],
+			"ul": [{"k": "one", "@": {"yang:insert": "first", "ietf-origin:origin": "ietf-origin:learned"}}]
+		}}`,
+		want: `{"c": {"@": {"ietf-origin:origin": "ietf-origin:intended"},
+			"name": "b", "@name": {"ietf-origin:origin": "ietf-origin:learned", "x:y": 1},
+			"ll": ["a", "b", "c"],
+			"@ll": [{"ietf-origin:origin": "ietf-origin:system"}, {"ietf-origin:origin": "ietf-origin:learned"}, null],
+			"ul": [{"k": "one", "@": {"ietf-origin:origin": "ietf-origin:learned"}}]
+		}}`,
+	}, {
+		desc: "leaf-list values added",
+		dst:  `{"c": {"ll": ["a", "b"]}}`,
+		src:  `{"c": {"ll": ["c", "a"]}}`,
+		want: `{"c": {"ll": ["a", "b", "c"]}}`,
+	}, {
+		desc: "ordered-by user leaf-list values move last",
+		dst:  `{"c": {"ull": ["a", "b", "c"]}}`,
+		src:  `{"c": {"ull": ["a", "d"]}}`,
+		want: `{"c": {"ull": ["b", "c", "a", "d"]}}`,
+	}, {
+		desc: "ordered-by user leaf-list values inserted",
+		dst:  `{"c": {"ull": ["a", "b", "c"]}}`,
+		src:  `{"c": {"ull": ["d", "c"], "@ull": [{"yang:insert": "after", "yang:value": "a"}, {"yang:insert": "first"}]}}`,
+		want: `{"c": {"ull": ["c", "a", "d", "b"]}}`,
+	}, {
+		desc: "empty non-presence container is not created",
+		dst:  `{"c": {}}`,
+		src:  `{"c": {"np": {}}}`,
+		want: `{"c": {}}`,
+	}, {
+		desc: "empty presence container is created",
+		dst:  `{"c": {}}`,
+		src:  `{"c": {"p": {}}}`,
+		want: `{"c": {"p": {}}}`,
+	}, {
+		desc: "case replaces other case",
+		dst:  `{"c": {"name": "a", "tcp-port": 1}}`,
+		src:  `{"c": {"udp-port": 2}}`,
+		want: `{"c": {"name": "a", "udp-port": 2}}`,
+	}, {
+		desc: "anydata replaced",
+		dst:  `{"c": {"content": {"x": 1}}}`,
+		src:  `{"c": {"content": {"y": 2}}}`,
+		want: `{"c": {"content": {"y": 2}}}`,
+	}, {
+		desc:    "unknown element",
+		dst:     `{}`,
+		src:     `{"c": {"bogus": 1}}`,
+		wantErr: `/c: unknown element "bogus"`,
+	}, {
+		desc:    "missing key",
+		dst:     `{}`,
+		src:     `{"c": {"l": [{"v": 1}]}}`,
+		wantErr: `/c/l[0]: missing key "k"`,
+	}, {
+		desc:    "list that is not an array",
+		dst:     `{}`,
+		src:     `{"c": {"l": {"k": "one"}}}`,
+		wantErr: `/c/l: expected an array for list l, got map[string]interface {}`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dst, src := decodeJSON(t, tt.dst), decodeJSON(t, tt.src)
+			dstCopy := decodeJSON(t, tt.dst)
+			got, err := Merge(e, dst, src)
+			if err != nil {
+				if err.Error() != tt.wantErr {
+					t.Fatalf("Merge() got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("Merge() got no error, want %q", tt.wantErr)
+			}
+			if diff := cmp.Diff(decodeJSON(t, tt.want), got); diff != "" {
+				t.Errorf("Merge() (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(dstCopy, dst); diff != "" {
+				t.Errorf("Merge() modified dst (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// This file has the origin metadata annotation of the ietf-origin module of
+// RFC 8342 section 7.4, which the data of the operational datastore, as
+// returned by NETCONF (RFC 8526) or RESTCONF, uses to report where each node
+// came from.  In XML it is the attribute origin in the OriginNamespace
+// namespace, in JSON the annotation ietf-origin:origin, see NodeInsertion.
+// Its value is an identity derived from ietf-origin:origin.
+
+// OriginNamespace is the XML namespace of the ietf-origin module.
+const OriginNamespace = "urn:ietf:params:xml:ns:yang:ietf-origin"
+
+// originIdentities are the identities derived from ietf-origin:origin by
+// ietf-origin itself.
+var originIdentities = map[string]bool{
+	"intended": true,
+	"dynamic":  true,
+	"system":   true,
+	"learned":  true,
+	"default":  true,
+	"unknown":  true,
+}
+
+// NodeOrigin returns the value of the origin annotation of n, as written,
+// e.g., "ietf-origin:intended" in JSON or "or:intended" in XML, or "" if n
+// has none.  A node without an origin has the origin of its parent (RFC 8342
+// section 5.3.4).  Only XMLElements, and nodes returned by NewJSONNode, have
+// origins.
+func NodeOrigin(n DataNode) string {
+	switch n := n.(type) {
+	case *XMLElement:
+		for _, a := range n.Attr {
+			if a.Name.Space == OriginNamespace && a.Name.Local == "origin" {
+				return a.Value
+			}
+		}
+	case *jsonNode:
+		m, _ := n.meta.(map[string]interface{})
+		s, _ := m["ietf-origin:origin"].(string)
+		return s
+	}
+	return ""
+}
+
+// origin checks the origin annotation, if any, of n, a node of e.
+func (v *validator) origin(e *yang.Entry, path string, n DataNode) {
+	if o := NodeOrigin(n); o != "" {
+		if err := checkOrigin(e, o); err != nil {
+			v.errorf(path, "%v", err)
+		}
+	}
+}
+
+// checkOrigin returns an error if origin, the origin annotation of a node of
+// the schema e, does not name an identity derived from ietf-origin:origin.
+// The identities derived from it by other modules are known if ietf-origin
+// is one of the modules of e.  The prefix, or module name, of origin is not
+// checked, as the prefixes of XML are not known.
+func checkOrigin(e *yang.Entry, origin string) error {
+	_, name := splitQualified(origin)
+	if base := originBase(e); base != nil {
+		if base.IsDefined(name) {
+			return nil
+		}
+	} else if originIdentities[name] {
+		return nil
+	}
+	return fmt.Errorf("origin %q is not derived from ietf-origin:origin", origin)
+}
+
+// originBase returns the identity ietf-origin:origin of the modules of e, or
+// nil if ietf-origin is not one of them.
+func originBase(e *yang.Entry) *yang.Identity {
+	m, ok := e.Root().Node.(*yang.Module)
+	if !ok || m.Modules == nil {
+		return nil
+	}
+	om := m.Modules.Modules["ietf-origin"]
+	if om == nil {
+		return nil
+	}
+	for _, id := range om.Identities() {
+		if id.Name == "origin" {
+			return id
+		}
+	}
+	return nil
+}
@@ -0,0 +1,531 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// The operations of a YANG Patch edit, RFC 8072 section 2.5.
+const (
+	OpCreate  = "create"
+	OpDelete  = "delete"
+	OpInsert  = "insert"
+	OpMerge   = "merge"
+	OpMove    = "move"
+	OpReplace = "replace"
+	OpRemove  = "remove"
+)
+
+// The error-tags, RFC 8040 section 7, reported for a failed edit.
+const (
+	TagDataExists       = "data-exists"
+	TagDataMissing      = "data-missing"
+	TagInvalidValue     = "invalid-value"
+	TagMalformedMessage = "malformed-message"
+	TagUnknownElement   = "unknown-element"
+)
+
+// A Patch is a YANG Patch document, the yang-patch container of RFC 8072.
+type Patch struct {
+	ID      string  `json:"patch-id"`          // ID is the patch-id.
+	Comment string  `json:"comment,omitempty"` // Comment is the comment, if any.
+	Edits   []*Edit `json:"edit"`              // Edits are the edits, in the order they are applied.
+}
+
+// An Edit is a single edit of a Patch.
+type Edit struct {
+	ID        string `json:"edit-id"`   // ID is the edit-id.
+	Operation string `json:"operation"` // Operation is one of the Op constants.
+	// Target is the data resource identifier, RFC 8040 section 3.5.3, of
+	// the node the edit applies to, relative to the data tree the patch
+	// is applied to, e.g., "/module:container/list=key".  "/" is the
+	// data tree itself.
+	Target string `json:"target"`
+	// Point is the data resource identifier of the list entry or
+	// leaf-list value an insert or move edit is relative to, if Where is
+	// "before" or "after".
+	Point string `json:"point,omitempty"`
+	// Where is "before", "after", "first" or "last", the position of an
+	// insert or move edit.  It defaults to "last".
+	Where string `json:"where,omitempty"`
+	// Value is the data of a create, insert, merge or replace edit, an
+	// object whose single member is the target node, as decoded from
+	// JSON by encoding/json.
+	Value interface{} `json:"value,omitempty"`
+}
+
+// An EditError is the reason an edit of a Patch cannot be applied.
+type EditError struct {
+	EditID  string // EditID is the edit-id of the edit.
+	Tag     string // Tag is the RFC 8040 error-tag of the error.
+	Message string // Message describes the error.
+}
+
+func (e *EditError) Error() string {
+	return fmt.Sprintf("edit %s: %s: %s", e.EditID, e.Tag, e.Message)
+}
+
+// ParsePatch parses the RFC 7951 JSON encoding of a YANG Patch document, an
+// object with a single yang-patch member, which may be qualified with the
+// module name ietf-yang-patch.
+func ParsePatch(data []byte) (*Patch, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid yang-patch document: %v", err)
+	}
+	raw, ok := doc["ietf-yang-patch:yang-patch"]
+	if !ok {
+		raw, ok = doc["yang-patch"]
+	}
+	if !ok || len(doc) != 1 {
+		return nil, fmt.Errorf("invalid yang-patch document: want a single yang-patch member")
+	}
+	p := &Patch{}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return nil, fmt.Errorf("invalid yang-patch document: %v", err)
+	}
+	return p, nil
+}
+
+// A pathStep is a single node of a data resource identifier.
+type pathStep struct {
+	entry *yang.Entry
+	name  string   // name is the node name as written, possibly qualified.
+	keys  []string // keys are the key values of a list or leaf-list node.
+}
+
+// parseTarget returns the steps of the data resource identifier target,
+// relative to e.
+func parseTarget(e *yang.Entry, target string) ([]pathStep, error) {
+	if target == "/" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(target, "/") {
+		return nil, fmt.Errorf("target %q does not start with /", target)
+	}
+	var steps []pathStep
+	for _, seg := range strings.Split(target[1:], "/") {
+		name, keys, hasKeys := strings.Cut(seg, "=")
+		_, n := splitQualified(name)
+		c := e.DataChild(n)
+		if c == nil {
+			return nil, fmt.Errorf("unknown element %q in target %q", name, target)
+		}
+		step := pathStep{entry: c, name: name}
+		switch {
+		case hasKeys && c.IsList() && c.Key != "":
+			values, err := c.ParseKeys("="+keys, yang.RESTCONFKeys)
+			if err != nil {
+				return nil, fmt.Errorf("invalid keys in target %q: %v", target, err)
+			}
+			if values, err = CanonicalKeys(c, values); err != nil {
+				return nil, fmt.Errorf("invalid keys in target %q: %v", target, err)
+			}
+			for _, k := range c.KeyNames() {
+				step.keys = append(step.keys, values[k])
+			}
+		case hasKeys:
+			for _, k := range strings.Split(keys, ",") {
+				v, err := url.PathUnescape(k)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %q in target %q: %v", k, target, err)
+				}
+				step.keys = append(step.keys, v)
+			}
+		}
+		want := 0
+		switch {
+		case c.IsList():
+			want = len(strings.Fields(c.Key))
+			if want == 0 {
+				return nil, fmt.Errorf("keyless list %s cannot be a target", c.Name)
+			}
+		case c.IsLeafList():
+			want = 1
+		}
+		if len(step.keys) != want {
+			return nil, fmt.Errorf("%s in target %q has %d key values, want %d", c.Name, target, len(step.keys), want)
+		}
+		steps = append(steps, step)
+		e = c
+	}
+	return steps, nil
+}
+
+// matches returns true if v, a list entry or leaf-list value, is the one
+// identified by s.
+func (s pathStep) matches(v interface{}) bool {
+	if s.entry.IsLeafList() {
+		return fmt.Sprint(v) == s.keys[0]
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for i, k := range s.entry.KeyNames() {
+		val, ok := lookup(m, k)
+		if !ok {
+			return false
+		}
+		v := fmt.Sprint(val)
+		if c := s.entry.Dir[k]; c != nil && c.Type != nil {
+			if cv, err := CanonicalValue(c, v); err == nil {
+				v = cv
+			}
+		}
+		if v != s.keys[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Check checks the edits of p against the schema rooted at e, without
+// applying them, and returns an error for each edit that is invalid.
+func (p *Patch) Check(e *yang.Entry) []*EditError {
+	var errs []*EditError
+	seen := map[string]bool{}
+	for _, ed := range p.Edits {
+		if seen[ed.ID] {
+			errs = append(errs, &EditError{EditID: ed.ID, Tag: TagMalformedMessage, Message: "edit-id is repeated"})
+			continue
+		}
+		seen[ed.ID] = true
+		if _, err := ed.check(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// check checks ed against the schema rooted at e and returns the steps of its
+// target.
+func (ed *Edit) check(e *yang.Entry) ([]pathStep, *EditError) {
+	errorf := func(tag, format string, a ...interface{}) *EditError {
+		return &EditError{EditID: ed.ID, Tag: tag, Message: fmt.Sprintf(format, a...)}
+	}
+	if ed.ID == "" {
+		return nil, errorf(TagMalformedMessage, "missing edit-id")
+	}
+	steps, err := parseTarget(e, ed.Target)
+	if err != nil {
+		return nil, errorf(TagUnknownElement, "%v", err)
+	}
+	switch ed.Operation {
+	case OpCreate, OpInsert, OpMerge, OpReplace:
+		if ed.Value == nil {
+			return nil, errorf(TagMalformedMessage, "%s requires a value", ed.Operation)
+		}
+		if len(steps) > 0 {
+			if _, err := editValue(steps[len(steps)-1], ed.Value); err != nil {
+				return nil, errorf(TagInvalidValue, "%v", err)
+			}
+		}
+	case OpDelete, OpMove, OpRemove:
+		if ed.Value != nil {
+			return nil, errorf(TagMalformedMessage, "%s does not take a value", ed.Operation)
+		}
+	default:
+		return nil, errorf(TagMalformedMessage, "unknown operation %q", ed.Operation)
+	}
+	switch {
+	case len(steps) == 0 && ed.Operation != OpMerge && ed.Operation != OpReplace:
+		return nil, errorf(TagInvalidValue, "%s cannot be applied to the data tree itself", ed.Operation)
+	case ed.Operation != OpInsert && ed.Operation != OpMove:
+		return steps, nil
+	}
+	last := steps[len(steps)-1]
+	if last.entry.ListAttr == nil || !last.entry.ListAttr.OrderedByUser {
+		return nil, errorf(TagInvalidValue, "%s requires an ordered-by user list or leaf-list, %s is not", ed.Operation, last.entry.Name)
+	}
+	switch ed.Where {
+	case "", "first", "last":
+	case "before", "after":
+		point, err := parseTarget(e, ed.Point)
+		if err != nil {
+			return nil, errorf(TagInvalidValue, "invalid point: %v", err)
+		}
+		if len(point) != len(steps) || point[len(point)-1].entry != last.entry {
+			return nil, errorf(TagInvalidValue, "point %q is not an entry of %s", ed.Point, last.entry.Name)
+		}
+	default:
+		return nil, errorf(TagMalformedMessage, "invalid where %q", ed.Where)
+	}
+	return steps, nil
+}
+
+// editValue returns the data of the node identified by s in the value v of
+// an edit.
+func editValue(s pathStep, v interface{}) (interface{}, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return nil, fmt.Errorf("value must be an object with a single member %s", s.entry.Name)
+	}
+	for k, val := range m {
+		if _, n := splitQualified(k); n != s.entry.Name {
+			return nil, fmt.Errorf("value has member %q, want %s", k, s.entry.Name)
+		}
+		if !s.entry.IsList() && !s.entry.IsLeafList() {
+			return val, nil
+		}
+		l, ok := val.([]interface{})
+		if !ok || len(l) != 1 {
+			return nil, fmt.Errorf("value must hold a single entry of %s", s.entry.Name)
+		}
+		if !s.matches(l[0]) {
+			return nil, fmt.Errorf("value does not match the keys of the target")
+		}
+		return l[0], nil
+	}
+	return nil, nil
+}
+
+// Apply applies the edits of p, in order, to data, a data tree as decoded
+// from JSON by encoding/json rooted at the schema entry e, and returns the
+// result.  The edits are first checked with Check, and if any is invalid no
+// edit is applied and all of the errors are returned.  Otherwise the edits
+// are applied until one fails, and its error is returned.  The members of the
+// edit values are added to the data tree qualified by a module name only where
+// RFC 7951 requires, whether or not the values qualify them.  A patch is applied
+// as a whole or not at all, as RFC 8072 requires, so data is never modified.
+func (p *Patch) Apply(e *yang.Entry, data interface{}) (interface{}, []*EditError) {
+	if errs := p.Check(e); len(errs) > 0 {
+		return nil, errs
+	}
+	out := copyValue(data)
+	if out == nil {
+		out = map[string]interface{}{}
+	}
+	for _, ed := range p.Edits {
+		steps, _ := ed.check(e)
+		var err *EditError
+		out, err = ed.apply(e, steps, out)
+		if err != nil {
+			return nil, []*EditError{err}
+		}
+	}
+	return out, nil
+}
+
+// apply applies ed, whose target is steps, to data, the data tree rooted at
+// e, which it may modify.
+func (ed *Edit) apply(e *yang.Entry, steps []pathStep, data interface{}) (interface{}, *EditError) {
+	errorf := func(tag, format string, a ...interface{}) *EditError {
+		return &EditError{EditID: ed.ID, Tag: tag, Message: fmt.Sprintf(format, a...)}
+	}
+	if len(steps) == 0 {
+		value := memberNames(e, copyValue(ed.Value))
+		if ed.Operation == OpReplace {
+			return value, nil
+		}
+		v, err := Merge(e, data, value)
+		if err != nil {
+			return nil, errorf(TagInvalidValue, "%v", err)
+		}
+		return v, nil
+	}
+
+	// Find the parent of the target, creating any missing containers
+	// for the operations that add data.
+	adding := ed.Operation != OpDelete && ed.Operation != OpMove && ed.Operation != OpRemove
+	parent, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, errorf(TagInvalidValue, "expected an object for the data tree, got %T", data)
+	}
+	pe := e
+	for _, s := range steps[:len(steps)-1] {
+		key, exists := memberKey(parent, s.entry.Name)
+		switch {
+		case s.entry.IsList():
+			l, _ := parent[key].([]interface{})
+			var next map[string]interface{}
+			for _, le := range l {
+				if s.matches(le) {
+					next, _ = le.(map[string]interface{})
+				}
+			}
+			if next == nil {
+				return nil, errorf(TagDataMissing, "%s does not exist", ed.Target)
+			}
+			parent = next
+		case !exists && ed.Operation == OpRemove:
+			return data, nil
+		case !exists && !adding:
+			return nil, errorf(TagDataMissing, "%s does not exist", ed.Target)
+		case !exists:
+			next := map[string]interface{}{}
+			parent[s.name] = next
+			parent = next
+		default:
+			next, ok := parent[key].(map[string]interface{})
+			if !ok {
+				return nil, errorf(TagInvalidValue, "expected an object for %s, got %T", s.entry.Name, parent[key])
+			}
+			parent = next
+		}
+		pe = s.entry
+	}
+
+	last := steps[len(steps)-1]
+	key, exists := memberKey(parent, last.entry.Name)
+	if !exists {
+		key = last.name
+	}
+	var value interface{}
+	if adding {
+		value, _ = editValue(last, ed.Value)
+		value = memberNames(last.entry, copyValue(value))
+		removeOtherCases(pe, last.entry, parent)
+	}
+	path := ed.Target
+
+	if !last.entry.IsList() && !last.entry.IsLeafList() {
+		switch {
+		case ed.Operation == OpCreate && exists:
+			return nil, errorf(TagDataExists, "%s already exists", path)
+		case ed.Operation == OpDelete && !exists:
+			return nil, errorf(TagDataMissing, "%s does not exist", path)
+		case ed.Operation == OpDelete, ed.Operation == OpRemove:
+			delete(parent, key)
+		case ed.Operation == OpMerge:
+			v, err := mergeNode(last.entry, path, parent[key], value)
+			if err != nil {
+				return nil, errorf(TagInvalidValue, "%v", err)
+			}
+			parent[key] = v
+		default:
+			parent[key] = value
+		}
+		return data, nil
+	}
+
+	l, ok := parent[key].([]interface{})
+	if exists && !ok {
+		return nil, errorf(TagInvalidValue, "expected an array for %s, got %T", last.entry.Name, parent[key])
+	}
+	idx := -1
+	for i, le := range l {
+		if last.matches(le) {
+			idx = i
+		}
+	}
+	switch {
+	case (ed.Operation == OpCreate || ed.Operation == OpInsert) && idx >= 0:
+		return nil, errorf(TagDataExists, "%s already exists", path)
+	case (ed.Operation == OpDelete || ed.Operation == OpMove) && idx < 0:
+		return nil, errorf(TagDataMissing, "%s does not exist", path)
+	case ed.Operation == OpRemove && idx < 0:
+		return data, nil
+	case ed.Operation == OpDelete, ed.Operation == OpRemove:
+		l = append(l[:idx], l[idx+1:]...)
+	case ed.Operation == OpInsert, ed.Operation == OpMove:
+		if ed.Operation == OpMove {
+			value = l[idx]
+			l = append(l[:idx], l[idx+1:]...)
+		}
+		pos, err := ed.position(e, l)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l[:pos], append([]interface{}{value}, l[pos:]...)...)
+	case idx < 0:
+		l = append(l, value)
+	case ed.Operation == OpMerge && last.entry.IsList():
+		v, err := mergeDir(last.entry, path, l[idx], value)
+		if err != nil {
+			return nil, errorf(TagInvalidValue, "%v", err)
+		}
+		l[idx] = v
+	default:
+		l[idx] = value
+	}
+	if len(l) == 0 {
+		delete(parent, key)
+	} else {
+		parent[key] = l
+	}
+	return data, nil
+}
+
+// memberNames returns v, the data of the node e, with the member names of its
+// descendants written as RFC 7951 section 4 requires, qualified by the name
+// of their module only if it is not that of their parent.  An edit value may
+// qualify any of its members, and Apply writes it to the data tree as is
+// otherwise.  The top level nodes of a module are always qualified.
+func memberNames(e *yang.Entry, v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for i, le := range v {
+			v[i] = memberNames(e, le)
+		}
+	case map[string]interface{}:
+		if !e.IsDir() || e.IsAny() {
+			break
+		}
+		var module string
+		if e.Parent != nil {
+			module, _ = e.InstantiatingModule()
+		}
+		for _, k := range sortedKeys(v) {
+			name := strings.TrimPrefix(k, "@")
+			_, n := splitQualified(name)
+			c := e.DataChild(n)
+			if c == nil {
+				continue
+			}
+			if m, err := c.InstantiatingModule(); err == nil {
+				name = n
+				if m != module {
+					name = m + ":" + n
+				}
+			}
+			cv := v[k]
+			delete(v, k)
+			if strings.HasPrefix(k, "@") {
+				v["@"+name] = cv
+			} else {
+				v[name] = memberNames(c, cv)
+			}
+		}
+	}
+	return v
+}
+
+// position returns the index in l, the other entries of the target list or
+// leaf-list, at which an insert or move edit places its target.
+func (ed *Edit) position(e *yang.Entry, l []interface{}) (int, *EditError) {
+	switch ed.Where {
+	case "first":
+		return 0, nil
+	case "", "last":
+		return len(l), nil
+	}
+	point, _ := parseTarget(e, ed.Point)
+	for i, le := range l {
+		if point[len(point)-1].matches(le) {
+			if ed.Where == "after" {
+				i++
+			}
+			return i, nil
+		}
+	}
+	return 0, &EditError{EditID: ed.ID, Tag: TagDataMissing, Message: fmt.Sprintf("point %s does not exist", ed.Point)}
+}
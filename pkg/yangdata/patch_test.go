@@ -0,0 +1,356 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func TestParsePatch(t *testing.T) {
+	p, err := ParsePatch([]byte(`{"ietf-yang-patch:yang-patch": {
+		"patch-id": "p1",
+		"comment": "add an entry",
+		"edit": [{"edit-id": "e1", "operation": "insert", "target": "/c/ul=b", "where": "before", "point": "/c/ul=a", "value": {"ul": [{"k": "b"}]}}]
+	}}`))
+	if err != nil {
+		t.Fatalf("ParsePatch() got unexpected error: %v", err)
+	}
+	want := &Patch{
+		ID:      "p1",
+		Comment: "add an entry",
+		Edits: []*Edit{{
+			ID:        "e1",
+			Operation: OpInsert,
+			Target:    "/c/ul=b",
+			Where:     "before",
+			Point:     "/c/ul=a",
+			Value:     map[string]interface{}{"ul": []interface{}{map[string]interface{}{"k": "b"}}},
+		}},
+	}
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Errorf("ParsePatch() (-want, +got):\n%s", diff)
+	}
+
+	for _, in := range []string{`[]`, `{"patch": {}}`, `{"yang-patch": {"edit": 1}}`} {
+		if _, err := ParsePatch([]byte(in)); err == nil {
+			t.Errorf("ParsePatch(%s) got no error", in)
+		}
+	}
+}
+
+func TestPatchApply(t *testing.T) {
+	e := moduleEntry(t, "merge", testMergeModule)
+	const data = `{"c": {"name": "a", "tcp-port": 1, "l": [{"k": "one", "v": 1}], "ul": [{"k": "a"}, {"k": "b"}], "ll": ["x"]}}`
+
+	tests := []struct {
+		desc     string
+		edits    []*Edit
+		want     string
+		wantErrs []string
+	}{{
+		desc: "create, merge, replace and delete",
+		edits: []*Edit{
+			{ID: "1", Operation: OpCreate, Target: "/c/l=two", Value: decodeJSON(t, `{"l": [{"k": "two", "v": 2}]}`)},
+			{ID: "2", Operation: OpMerge, Target: "/c/l=one", Value: decodeJSON(t, `{"l": [{"k": "one", "w": 3}]}`)},
+			{ID: "3", Operation: OpReplace, Target: "/merge:c/name", Value: decodeJSON(t, `{"merge:name": "b"}`)},
+			{ID: "4", Operation: OpDelete, Target: "/c/ll=x"},
+			{ID: "5", Operation: OpRemove, Target: "/c/np/x"},
+		},
+		want: `{"c": {"name": "b", "tcp-port": 1, "l": [{"k": "one", "v": 1, "w": 3}, {"k": "two", "v": 2}], "ul": [{"k": "a"}, {"k": "b"}]}}`,
+	}, {
+		desc: "insert and move",
+		edits: []*Edit{
+			{ID: "1", Operation: OpInsert, Target: "/c/ul=c", Where: "first", Value: decodeJSON(t, `{"ul": [{"k": "c"}]}`)},
+			{ID: "2", Operation: OpInsert, Target: "/c/ul=d", Where: "after", Point: "/c/ul=a", Value: decodeJSON(t, `{"ul": [{"k": "d"}]}`)},
+			{ID: "3", Operation: OpMove, Target: "/c/ul=b", Where: "before", Point: "/c/ul=c"},
+		},
+		want: `{"c": {"name": "a", "tcp-port": 1, "l": [{"k": "one", "v": 1}], "ul": [{"k": "b"}, {"k": "c"}, {"k": "a"}, {"k": "d"}], "ll": ["x"]}}`,
+	}, {
+		desc: "create in a new container and case",
+		edits: []*Edit{
+			{ID: "1", Operation: OpCreate, Target: "/c/np/x", Value: decodeJSON(t, `{"x": "v"}`)},
+			{ID: "2", Operation: OpCreate, Target: "/c/udp-port", Value: decodeJSON(t, `{"udp-port": 2}`)},
+		},
+		want: `{"c": {"name": "a", "udp-port": 2, "np": {"x": "v"}, "l": [{"k": "one", "v": 1}], "ul": [{"k": "a"}, {"k": "b"}], "ll": ["x"]}}`,
+	}, {
+		desc: "merge into the data tree",
+		edits: []*Edit{
+			{ID: "1", Operation: OpMerge, Target: "/", Value: decodeJSON(t, `{"c": {"ll": ["y"]}}`)},
+		},
+		want: `{"c": {"name": "a", "tcp-port": 1, "l": [{"k": "one", "v": 1}], "ul": [{"k": "a"}, {"k": "b"}], "ll": ["x", "y"]}}`,
+	}, {
+		desc: "invalid edits",
+		edits: []*Edit{
+			{ID: "1", Operation: OpCreate, Target: "/c/bogus", Value: decodeJSON(t, `{"bogus": 1}`)},
+			{ID: "2", Operation: OpCreate, Target: "/c/l", Value: decodeJSON(t, `{"l": []}`)},
+			{ID: "3", Operation: OpCreate, Target: "/c/l=two", Value: decodeJSON(t, `{"l": [{"k": "three"}]}`)},
+			{ID: "4", Operation: OpInsert, Target: "/c/ll=y", Value: decodeJSON(t, `{"ll": ["y"]}`)},
+			{ID: "5", Operation: OpDelete, Target: "/c/name", Value: decodeJSON(t, `{"name": "a"}`)},
+			{ID: "6", Operation: "frob", Target: "/c/name"},
+			{ID: "6", Operation: OpRemove, Target: "/c/name"},
+		},
+		wantErrs: []string{
+			`edit 1: unknown-element: unknown element "bogus" in target "/c/bogus"`,
+			`edit 2: unknown-element: l in target "/c/l" has 0 key values, want 1`,
+			`edit 3: invalid-value: value does not match the keys of the target`,
+			`edit 4: invalid-value: insert requires an ordered-by user list or leaf-list, ll is not`,
+			`edit 5: malformed-message: delete does not take a value`,
+			`edit 6: malformed-message: unknown operation "frob"`,
+			`edit 6: malformed-message: edit-id is repeated`,
+		},
+	}, {
+		desc: "failed edit",
+		edits: []*Edit{
+			{ID: "1", Operation: OpDelete, Target: "/c/name"},
+			{ID: "2", Operation: OpCreate, Target: "/c/l=one", Value: decodeJSON(t, `{"l": [{"k": "one"}]}`)},
+			{ID: "3", Operation: OpDelete, Target: "/c/np"},
+		},
+		wantErrs: []string{
+			`edit 2: data-exists: /c/l=one already exists`,
+		},
+	}, {
+		desc: "missing data",
+		edits: []*Edit{
+			{ID: "1", Operation: OpMerge, Target: "/c/l=two/v", Value: decodeJSON(t, `{"v": 1}`)},
+		},
+		wantErrs: []string{
+			`edit 1: data-missing: /c/l=two/v does not exist`,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			in := decodeJSON(t, data)
+			p := &Patch{ID: "p", Edits: tt.edits}
+			got, errs := p.Apply(e, in)
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if strings.Join(gotErrs, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Fatalf("Apply() got errors:\n%s\nwant:\n%s", strings.Join(gotErrs, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+			if diff := cmp.Diff(decodeJSON(t, data), in); diff != "" {
+				t.Errorf("Apply() modified data (-want, +got):\n%s", diff)
+			}
+			if len(errs) > 0 {
+				return
+			}
+			if diff := cmp.Diff(decodeJSON(t, tt.want), got); diff != "" {
+				t.Errorf("Apply() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// testJukeboxModule is the part of the example-jukebox module of RFC 8040
+// appendix A used by the examples of RFC 8072 appendix A.
+const testJukeboxModule = `
+module example-jukebox {
+	namespace "http://example.com/ns/example-jukebox";
+	prefix "jbox";
+
+	container jukebox {
+		presence "An empty container indicates that the jukebox service is available.";
+		container library {
+			list artist {
+				key name;
+				leaf name { type string; }
+				list album {
+					key name;
+					leaf name { type string; }
+					leaf year { type uint16; }
+					list song {
+						key name;
+						leaf name { type string; }
+						leaf location { type string; mandatory true; }
+						leaf format { type string; }
+						leaf length { type uint32; }
+					}
+				}
+			}
+		}
+		list playlist {
+			key name;
+			leaf name { type string; }
+			leaf description { type string; }
+			list song {
+				key index;
+				ordered-by user;
+				leaf index { type uint32; }
+				leaf id { type instance-identifier; mandatory true; }
+			}
+		}
+	}
+}
+`
+
+// TestPatchApplyRFC8072 applies the examples of RFC 8072 appendix A, whose
+// targets are relative to the album and the playlist they are sent to.
+func TestPatchApplyRFC8072(t *testing.T) {
+	jukebox := moduleEntry(t, "example-jukebox", testJukeboxModule)
+	album := jukebox.Dir["jukebox"].Dir["library"].Dir["artist"].Dir["album"]
+	playlist := jukebox.Dir["jukebox"].Dir["playlist"]
+
+	const songs = `{"name": "Wasting Light", "song": [{"name": "Rope", "location": "/media/rope.mp3", "format": "MP3", "length": 259}]}`
+	const addSongs = `{"ietf-yang-patch:yang-patch": {
+		"patch-id": "add-songs-patch",
+		"edit": [{
+			"edit-id": "edit1",
+			"operation": "%s",
+			"target": "/song=Bridge%%20Burning",
+			"value": {"example-jukebox:song": [{"name": "Bridge Burning", "location": "/media/bridge_burning.mp3", "format": "MP3", "length": 288}]}
+		}, {
+			"edit-id": "edit2",
+			"operation": "%[1]s",
+			"target": "/song=Rope",
+			"value": {"example-jukebox:song": [{"name": "Rope", "location": "/media/rope.mp3", "format": "MP3", "length": 259}]}
+		}, {
+			"edit-id": "edit3",
+			"operation": "%[1]s",
+			"target": "/song=Dear%%20Rosemary",
+			"value": {"example-jukebox:song": [{"name": "Dear Rosemary", "location": "/media/dear_rosemary.mp3", "format": "MP3", "length": 269}]}
+		}]
+	}}`
+	const playlistSongs = `{"name": "Foo-One", "song": [
+		{"index": 1, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Rope']"},
+		{"index": 2, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Bridge Burning']"},
+		{"index": 3, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Dear Rosemary']"}
+	]}`
+
+	tests := []struct {
+		desc     string
+		e        *yang.Entry
+		data     string
+		patch    string
+		want     string
+		wantErrs []string
+	}{{
+		desc:  "A.1.1 add resources, error",
+		e:     album,
+		data:  songs,
+		patch: fmt.Sprintf(addSongs, OpCreate),
+		wantErrs: []string{
+			"edit edit2: data-exists: /song=Rope already exists",
+		},
+	}, {
+		desc:  "A.1.2 add resources, success",
+		e:     album,
+		data:  songs,
+		patch: fmt.Sprintf(addSongs, OpMerge),
+		want: `{"name": "Wasting Light", "song": [
+			{"name": "Rope", "location": "/media/rope.mp3", "format": "MP3", "length": 259},
+			{"name": "Bridge Burning", "location": "/media/bridge_burning.mp3", "format": "MP3", "length": 288},
+			{"name": "Dear Rosemary", "location": "/media/dear_rosemary.mp3", "format": "MP3", "length": 269}
+		]}`,
+	}, {
+		desc: "A.2.1 insert list entry",
+		e:    playlist,
+		data: playlistSongs,
+		patch: `{"ietf-yang-patch:yang-patch": {
+			"patch-id": "insert-song-patch",
+			"edit": [{
+				"edit-id": "edit1",
+				"operation": "insert",
+				"target": "/song=5",
+				"point": "/song=1",
+				"where": "after",
+				"value": {"example-jukebox:song": [{"index": 5, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Bridge Burning']"}]}
+			}]
+		}}`,
+		want: `{"name": "Foo-One", "song": [
+			{"index": 1, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Rope']"},
+			{"index": 5, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Bridge Burning']"},
+			{"index": 2, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Bridge Burning']"},
+			{"index": 3, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Dear Rosemary']"}
+		]}`,
+	}, {
+		desc: "A.2.2 move list entry",
+		e:    playlist,
+		data: playlistSongs,
+		patch: `{"ietf-yang-patch:yang-patch": {
+			"patch-id": "move-song-patch",
+			"comment": "Move song 1 after song 3",
+			"edit": [{
+				"edit-id": "edit1",
+				"operation": "move",
+				"target": "/song=1",
+				"point": "/song=3",
+				"where": "after"
+			}]
+		}}`,
+		want: `{"name": "Foo-One", "song": [
+			{"index": 2, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Bridge Burning']"},
+			{"index": 3, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Dear Rosemary']"},
+			{"index": 1, "id": "/example-jukebox:jukebox/library/artist[name='Foo Fighters']/album[name='Wasting Light']/song[name='Rope']"}
+		]}`,
+	}, {
+		desc: "qualified members at every depth",
+		e:    jukebox,
+		data: `{"example-jukebox:jukebox": {"library": {"artist": [{"name": "Foo Fighters", "album": [` + songs + `]}]}}}`,
+		patch: `{"ietf-yang-patch:yang-patch": {
+			"patch-id": "qualified-patch",
+			"edit": [{
+				"edit-id": "edit1",
+				"operation": "merge",
+				"target": "/example-jukebox:jukebox/library/artist=Foo%20Fighters",
+				"value": {"example-jukebox:artist": [{"example-jukebox:name": "Foo Fighters", "example-jukebox:album": [{"example-jukebox:name": "Wasting Light", "example-jukebox:year": 2011}]}]}
+			}, {
+				"edit-id": "edit2",
+				"operation": "replace",
+				"target": "/example-jukebox:jukebox/library/artist=Foo%20Fighters/album=Wasting%20Light/song=Rope/length",
+				"value": {"example-jukebox:length": 260}
+			}, {
+				"edit-id": "edit3",
+				"operation": "create",
+				"target": "/example-jukebox:jukebox/playlist=Foo-One",
+				"value": {"example-jukebox:playlist": [{"example-jukebox:name": "Foo-One"}]}
+			}]
+		}}`,
+		want: `{"example-jukebox:jukebox": {
+			"library": {"artist": [{"name": "Foo Fighters", "album": [{"name": "Wasting Light", "year": 2011, "song": [
+				{"name": "Rope", "location": "/media/rope.mp3", "format": "MP3", "length": 260}
+			]}]}]},
+			"playlist": [{"name": "Foo-One"}]
+		}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			p, err := ParsePatch([]byte(tt.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, errs := p.Apply(tt.e, decodeJSON(t, tt.data))
+			var gotErrs []string
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			if strings.Join(gotErrs, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Fatalf("Apply() got errors:\n%s\nwant:\n%s", strings.Join(gotErrs, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+			if len(errs) > 0 {
+				return
+			}
+			if diff := cmp.Diff(decodeJSON(t, tt.want), got); diff != "" {
+				t.Errorf("Apply() (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,847 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yangdata validates YANG modelled instance data against the Entry
+// trees produced by package yang.
+//
+// Instance data is any tree of DataNodes.  Validate accepts data in the form
+// produced by decoding RFC 7951 JSON into an interface{} with encoding/json,
+// see NewJSONNode, and XMLElement holds data decoded from XML.
+package yangdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// Validate validates data, as decoded from JSON by encoding/json, against the
+// schema rooted at e and returns all of the errors found.  It is the same as
+// ValidateNode(e, NewJSONNode(data)).
+func Validate(e *yang.Entry, data interface{}) []error {
+	return ValidateNode(e, NewJSONNode(data))
+}
+
+// ValidateNode validates the data tree rooted at n against the schema rooted
+// at e and returns all of the errors found.  Choice and case entries do not
+// appear in data, their children are matched as if they were children of the
+// choice's parent.  At most one case of each choice may have data present.
+//
+// The value of a leafref, or instance-identifier, that requires an instance
+// must refer to data in the tree.  A leafref refers to data if any instance
+// of its target leaf in the tree has its value; the predicates of its path
+// are not evaluated.  The leafref members of unions are not checked.
+//
+// The origin annotation of a node, see NodeOrigin, must name an identity
+// derived from ietf-origin:origin.
+//
+// The entries of a list without keys, which is only valid as state data, are
+// not checked for duplicates.  The values of a configuration leaf-list must
+// be unique.  An insert attribute, see NodeInsertion, is only valid on the
+// entries and values of an ordered-by user list or leaf-list.
+func ValidateNode(e *yang.Entry, n DataNode) []error {
+	return ValidateWithOptions(e, n, ValidateOptions{})
+}
+
+// ValidateOptions are the options of ValidateWithOptions.
+type ValidateOptions struct {
+	// LenientNumbers accepts integer and decimal64 values encoded as
+	// either JSON numbers or JSON strings.  By default, as required by
+	// RFC 7951 section 6.1, values of int64, uint64 and decimal64 must be
+	// JSON strings and values of the other integer types JSON numbers.
+	// Values that are Text are never checked for their encoding.
+	LenientNumbers bool
+	// Datastore is the datastore the data is from, which determines
+	// whether state data is allowed and which constraints are enforced.
+	Datastore Datastore
+}
+
+// ValidateWithOptions is ValidateNode with the options opts.
+func ValidateWithOptions(e *yang.Entry, n DataNode, opts ValidateOptions) []error {
+	v := newValidator(n, opts)
+	v.node(e, "", []DataNode{n})
+	v.instances()
+	return v.errs
+}
+
+// ValidateDocument validates the data tree rooted at n, whose top level nodes
+// may belong to any of modules, the module entries of the modules the data is
+// modelled by, as the data of a RESTCONF datastore does.  Each top level node
+// is validated against the top level node of the module its namespace names,
+// a module name in JSON or a namespace URI in XML.  A node without a
+// namespace, which RFC 7951 does not allow at the top level of JSON data, is
+// matched if exactly one of modules has a top level node with its name.  The
+// mandatory top level nodes of each of modules must be present.  Otherwise
+// ValidateDocument is the same as ValidateWithOptions.
+func ValidateDocument(modules []*yang.Entry, n DataNode, opts ValidateOptions) []error {
+	v := newValidator(n, opts)
+	v.document(modules, n)
+	v.instances()
+	return v.errs
+}
+
+// A topLevel is a top level node of a module.
+type topLevel struct {
+	module *yang.Entry
+	name   string
+}
+
+// document validates n as a data tree of the modules.
+func (v *validator) document(modules []*yang.Entry, n DataNode) {
+	if val := n.Value(); hasValue(val) {
+		v.errorf("", "expected an object for the data tree, got %T", val)
+		return
+	}
+	var nodes []topLevel
+	children := map[topLevel][]DataNode{}
+	for _, c := range n.Children() {
+		m, err := documentModule(modules, c)
+		if err != nil {
+			v.errorf("", "%v", err)
+			continue
+		}
+		tl := topLevel{m, c.Name()}
+		if children[tl] == nil {
+			nodes = append(nodes, tl)
+		}
+		children[tl] = append(children[tl], c)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].name != nodes[j].name {
+			return nodes[i].name < nodes[j].name
+		}
+		return nodes[i].module.Name < nodes[j].module.Name
+	})
+	present := map[*yang.Entry]map[string]bool{}
+	for _, tl := range nodes {
+		if present[tl.module] == nil {
+			present[tl.module] = map[string]bool{}
+		}
+		present[tl.module][tl.name] = true
+		c := tl.module.DataChild(tl.name)
+		if v.configOnly() && c.ReadOnly() {
+			v.errorf("", "state data %q in the %s datastore", tl.name, v.datastore)
+			continue
+		}
+		v.node(c, "/"+tl.name, children[tl])
+	}
+	for _, m := range modules {
+		v.mandatory(m, "", present[m])
+		v.defaults(m, present[m])
+	}
+}
+
+// documentModule returns the module entry, of modules, of the top level node
+// c.
+func documentModule(modules []*yang.Entry, c DataNode) (*yang.Entry, error) {
+	ns := c.Namespace()
+	var found []*yang.Entry
+	var names []string
+	for _, m := range modules {
+		if m.DataChild(c.Name()) == nil {
+			continue
+		}
+		if ns == "" || ns == m.Name || ns == m.Namespace().Name {
+			found = append(found, m)
+			names = append(names, m.Name)
+		}
+	}
+	switch {
+	case len(found) == 1:
+		return found[0], nil
+	case len(found) > 1:
+		sort.Strings(names)
+		return nil, fmt.Errorf("element %q is defined by more than one module: %s", c.Name(), strings.Join(names, ", "))
+	case ns != "":
+		return nil, fmt.Errorf("unknown element %q of %s", c.Name(), ns)
+	}
+	return nil, fmt.Errorf("unknown element %q", c.Name())
+}
+
+// A validator accumulates the errors found while validating a data tree.
+type validator struct {
+	errs []error
+
+	root   DataNode                        // root of the data tree
+	values map[*yang.Entry]map[string]bool // values of the leaves found
+	refs   []instanceRef                   // values that require instances
+
+	lenient   bool      // accept numbers encoded as JSON numbers or strings
+	datastore Datastore // datastore the data is from
+}
+
+// newValidator returns a validator of the data tree rooted at root.
+func newValidator(root DataNode, opts ValidateOptions) *validator {
+	return &validator{
+		root:      root,
+		values:    map[*yang.Entry]map[string]bool{},
+		lenient:   opts.LenientNumbers,
+		datastore: opts.Datastore,
+	}
+}
+
+// An instanceRef is a leafref or instance-identifier value found at path
+// that must refer to an existing instance.
+type instanceRef struct {
+	path  string
+	e     *yang.Entry
+	value string
+}
+
+// errorf records an error found at the data path path.
+func (v *validator) errorf(path, format string, a ...interface{}) {
+	if path == "" {
+		path = "/"
+	}
+	v.errs = append(v.errs, fmt.Errorf("%s: "+format, append([]interface{}{path}, a...)...))
+}
+
+// node validates the data nodes ns, all with the same name and found at path,
+// against e.  ns has more than one node only for the entries of a list or the
+// values of a leaf-list.
+func (v *validator) node(e *yang.Entry, path string, ns []DataNode) {
+	switch {
+	case e.IsList():
+		v.list(e, path, ns)
+		return
+	case e.IsLeafList():
+		v.leafList(e, path, ns)
+		return
+	}
+	if j, ok := ns[0].(*jsonNode); ok && j.array != nil {
+		// A JSON array given for a node that is not a list or
+		// leaf-list is validated as a single value.
+		ns = []DataNode{&jsonNode{module: j.module, name: j.name, data: j.array}}
+	}
+	if len(ns) > 1 {
+		v.errorf(path, "%d instances of %s, want at most 1", len(ns), e.Name)
+		return
+	}
+	switch {
+	case e.IsAny():
+		// The content of anydata and anyxml is only validated when a
+		// schema describing it has been attached.
+		if s := e.AttachedSchema(); s != nil {
+			v.node(s, path, ns)
+		}
+	case e.IsDir():
+		v.dir(e, path, ns[0])
+	default:
+		v.leaf(e, path, ns[0])
+	}
+}
+
+// dir validates n as the contents of the directory entry e.
+func (v *validator) dir(e *yang.Entry, path string, n DataNode) {
+	if val := n.Value(); hasValue(val) {
+		v.errorf(path, "expected an object for %s, got %T", e.Name, val)
+		return
+	}
+	v.origin(e, path, n)
+	var names []string
+	children := map[string][]DataNode{}
+	for _, c := range n.Children() {
+		if children[c.Name()] == nil {
+			names = append(names, c.Name())
+		}
+		children[c.Name()] = append(children[c.Name()], c)
+	}
+	sort.Strings(names)
+	present := map[string]bool{}
+	for _, name := range names {
+		c := e.DataChild(name)
+		if c == nil {
+			v.errorf(path, "unknown element %q", name)
+			continue
+		}
+		present[name] = true
+		if v.configOnly() && c.ReadOnly() {
+			v.errorf(path, "state data %q in the %s datastore", name, v.datastore)
+			continue
+		}
+		v.node(c, path+"/"+name, children[name])
+	}
+	v.mandatory(e, path, present)
+	v.defaults(e, present)
+}
+
+// hasValue returns true if val, the value of a container or list entry, is
+// a value other than the white space between XML elements.
+func hasValue(val interface{}) bool {
+	s, ok := val.(Text)
+	return val != nil && (!ok || strings.TrimSpace(string(s)) != "")
+}
+
+// mandatory checks that the mandatory children of e are in present and that
+// at most one case of each of e's choices has data.  Choices and their cases
+// are descended into as if they were part of e, as are the non-presence
+// containers not in present.
+func (v *validator) mandatory(e *yang.Entry, path string, present map[string]bool) {
+	for _, c := range e.SortedDir() {
+		switch {
+		case c.IsChoice():
+			v.choice(c, path, present)
+		case present[c.Name], v.relaxed():
+		case v.configOnly() && c.ReadOnly():
+			// State data is not in a configuration datastore.
+		case c.IsContainer() && !c.IsPresenceContainer():
+			// A non-presence container without an instance is
+			// mandatory if any of its children are, so they are
+			// reported (RFC 7950 section 3).
+			v.mandatory(c, path+"/"+c.Name, nil)
+		case c.Mandatory == yang.TSTrue:
+			v.errorf(path, "missing mandatory element %q", c.Name)
+		case c.ListAttr != nil && c.ListAttr.MinElements > 0:
+			v.errorf(path, "missing %q, which requires at least %d elements", c.Name, c.ListAttr.MinElements)
+		}
+	}
+}
+
+// choice checks that at most one case of the choice c has data in present,
+// and that the mandatory nodes of that case are present.
+func (v *validator) choice(c *yang.Entry, path string, present map[string]bool) {
+	var active []string
+	for _, cs := range c.SortedDir() {
+		if hasData(cs, present) {
+			active = append(active, cs.Name)
+		}
+	}
+	if len(active) > 1 {
+		v.errorf(path, "data for more than one case of choice %q: %s", c.Name, strings.Join(active, ", "))
+		return
+	}
+	cs := ActiveCase(c, present)
+	switch {
+	case cs == nil && c.Mandatory == yang.TSTrue && !v.relaxed():
+		v.errorf(path, "missing data for mandatory choice %q", c.Name)
+	case cs == nil:
+	case len(active) == 0:
+		// The default case is in effect, its nodes take their default
+		// values and must not be mandatory.
+	case cs.IsCase():
+		v.mandatory(cs, path, present)
+	}
+}
+
+// ActiveCase returns the case of the choice c that is in effect given the
+// names of the data nodes present alongside the choice.  If no case has data
+// then the choice's default case, if any, is returned.  A shorthand case that
+// has not been wrapped in a case entry by FixChoice is returned as the data
+// node itself.  nil is returned if there is no case in effect.
+func ActiveCase(c *yang.Entry, present map[string]bool) *yang.Entry {
+	for _, cs := range c.SortedDir() {
+		if hasData(cs, present) {
+			return cs
+		}
+	}
+	if len(c.Default) == 1 {
+		return c.Dir[c.Default[0]]
+	}
+	return nil
+}
+
+// hasData returns true if e, or for choice and case entries any of
+// their data node descendants, is named in present.
+func hasData(e *yang.Entry, present map[string]bool) bool {
+	if !e.IsChoice() && !e.IsCase() {
+		return present[e.Name]
+	}
+	for _, c := range e.Dir {
+		if hasData(c, present) {
+			return true
+		}
+	}
+	return false
+}
+
+// list validates ns as the entries of the list e.
+func (v *validator) list(e *yang.Entry, path string, ns []DataNode) {
+	if j, ok := ns[0].(*jsonNode); ok && j.array == nil {
+		v.errorf(path, "expected an array for list %s, got %T", e.Name, j.data)
+		return
+	}
+	v.elements(e, path, len(ns))
+	keys := strings.Fields(e.Key)
+	seen := map[string]bool{}
+	for i, le := range ns {
+		epath := fmt.Sprintf("%s[%d]", path, i)
+		if val := le.Value(); hasValue(val) {
+			v.errorf(epath, "expected an object for list entry of %s, got %T", e.Name, val)
+			continue
+		}
+		v.insertion(e, epath, le)
+		if len(keys) > 0 {
+			values := map[string]string{}
+			for _, k := range keys {
+				val, ok := le.Key(k)
+				if !ok {
+					v.errorf(epath, "missing key %q", k)
+					continue
+				}
+				values[k] = fmt.Sprint(val)
+			}
+			if id, err := keyID(e, values); err == nil {
+				if seen[id] {
+					v.errorf(epath, "duplicate entry for key %s", id)
+				}
+				seen[id] = true
+			}
+		}
+		v.dir(e, epath, le)
+	}
+}
+
+// leafList validates ns as the values of the leaf-list e.
+func (v *validator) leafList(e *yang.Entry, path string, ns []DataNode) {
+	if j, ok := ns[0].(*jsonNode); ok && j.array == nil {
+		v.errorf(path, "expected an array for leaf-list %s, got %T", e.Name, j.data)
+		return
+	}
+	v.elements(e, path, len(ns))
+	seen := map[string]bool{}
+	for i, n := range ns {
+		vpath := fmt.Sprintf("%s[%d]", path, i)
+		v.insertion(e, vpath, n)
+		v.leaf(e, vpath, n)
+		// The values of a configuration leaf-list are unique, those of
+		// state data need not be (RFC 7950 section 7.7).
+		if val := n.Value(); val != nil && !e.ReadOnly() {
+			id := fmt.Sprint(val)
+			if seen[id] {
+				v.errorf(vpath, "duplicate value %s", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+// insertion checks the insertion, if any, of n, an entry of the list or a
+// value of the leaf-list e.
+func (v *validator) insertion(e *yang.Entry, path string, n DataNode) {
+	if ins := NodeInsertion(n); ins != nil {
+		if err := ins.check(e); err != nil {
+			v.errorf(path, "%v", err)
+		}
+	}
+}
+
+// elements checks that n elements satisfies the min-elements and
+// max-elements of e.
+func (v *validator) elements(e *yang.Entry, path string, n int) {
+	switch {
+	case v.relaxed():
+	case uint64(n) < e.ListAttr.MinElements:
+		v.errorf(path, "%d elements is less than min-elements %d", n, e.ListAttr.MinElements)
+	case uint64(n) > e.ListAttr.MaxElements:
+		v.errorf(path, "%d elements is more than max-elements %d", n, e.ListAttr.MaxElements)
+	}
+}
+
+// leaf validates the value of n as the value of the leaf, or single value of
+// the leaf-list, e.
+func (v *validator) leaf(e *yang.Entry, path string, n DataNode) {
+	v.origin(e, path, n)
+	if e.Type == nil {
+		return
+	}
+	val := n.Value()
+	if val == nil && e.Type.Kind != yang.Yempty {
+		v.errorf(path, "expected a value for %s", e.Name)
+		return
+	}
+	if err := checkValue(e.Type, val, v.lenient); err != nil {
+		v.errorf(path, "%v", err)
+		return
+	}
+	if err := v.leafrefValue(e, val); err != nil {
+		v.errorf(path, "%v", err)
+		return
+	}
+	if v.values == nil {
+		return
+	}
+	value := fmt.Sprint(val)
+	if v.values[e] == nil {
+		v.values[e] = map[string]bool{}
+	}
+	v.values[e][value] = true
+	switch e.Type.Kind {
+	case yang.Yleafref, yang.YinstanceIdentifier:
+		if e.Type.RequireInstance() {
+			v.refs = append(v.refs, instanceRef{path, e, value})
+		}
+	}
+}
+
+// leafrefValue returns an error if e is a leafref and val is not a valid value
+// of the type of the leaf it refers to.  A leafref whose target cannot be
+// found is not checked, the incomplete schema is reported when processed.
+func (v *validator) leafrefValue(e *yang.Entry, val interface{}) error {
+	if e.Type.Kind != yang.Yleafref {
+		return nil
+	}
+	// The leafref may refer to another leafref.
+	seen := map[*yang.Entry]bool{}
+	for t := e.LeafrefTarget(); t != nil && t.Type != nil && !seen[t]; t = t.LeafrefTarget() {
+		seen[t] = true
+		if t.Type.Kind != yang.Yleafref {
+			return checkValue(t.Type, val, v.lenient)
+		}
+	}
+	return nil
+}
+
+// instances checks that each value found that requires an instance refers
+// to one.
+func (v *validator) instances() {
+	if v.relaxed() {
+		return
+	}
+	for _, r := range v.refs {
+		switch r.e.Type.Kind {
+		case yang.Yleafref:
+			target := r.e.LeafrefTarget()
+			if target == nil {
+				// The schema is not complete, which is reported
+				// when it is processed.
+				continue
+			}
+			if !v.values[target][r.value] {
+				v.errorf(r.path, "no instance of %s with value %s", target.Path(), r.value)
+			}
+		case yang.YinstanceIdentifier:
+			if len(instanceNodes(v.root, r.value)) == 0 {
+				v.errorf(r.path, "no instance of %s", r.value)
+			}
+		}
+	}
+}
+
+// selectInstances returns the nodes of ns selected by preds, the predicates
+// of a step of an instance-identifier: a position, [.='value'] or
+// [key='value'] for each key.
+func selectInstances(ns []DataNode, preds string) []DataNode {
+	if preds == "" {
+		return ns
+	}
+	if strings.HasPrefix(preds, "[") && strings.HasSuffix(preds, "]") {
+		if i, err := strconv.Atoi(strings.TrimSpace(preds[1 : len(preds)-1])); err == nil {
+			if i < 1 || i > len(ns) {
+				return nil
+			}
+			return ns[i-1 : i]
+		}
+	}
+	kps, err := yang.SplitKeyPredicates(preds, yang.XPathKeys)
+	if err != nil {
+		return nil
+	}
+	for _, kp := range kps {
+		_, name := splitQualified(kp.Name)
+		var selected []DataNode
+		for _, n := range ns {
+			var val interface{}
+			ok := true
+			if name == "." {
+				val = n.Value()
+			} else {
+				val, ok = n.Key(name)
+			}
+			if ok && fmt.Sprint(val) == kp.Value {
+				selected = append(selected, n)
+			}
+		}
+		ns = selected
+	}
+	return ns
+}
+
+// instanceNodes returns the nodes of the data tree rooted at root that the
+// instance-identifier id identifies.
+func instanceNodes(root DataNode, id string) []DataNode {
+	steps := splitTopLevel(id, '/')
+	if len(steps) < 2 || steps[0] != "" {
+		return nil
+	}
+	nodes := []DataNode{root}
+	for _, step := range steps[1:] {
+		name, preds := step, ""
+		if i := strings.IndexByte(step, '['); i >= 0 {
+			name, preds = step[:i], step[i:]
+		}
+		_, name = splitQualified(name)
+		var next []DataNode
+		for _, n := range nodes {
+			for _, c := range n.Children() {
+				if c.Name() == name {
+					next = append(next, c)
+				}
+			}
+		}
+		if nodes = selectInstances(next, preds); len(nodes) == 0 {
+			return nil
+		}
+	}
+	return nodes
+}
+
+// checkValue returns an error if val, a JSON value or a Text, is not a valid
+// value of type t.  Unless lenient is true, a JSON value of an integer or
+// decimal64 type must be encoded as RFC 7951 requires, see jsonNumberError.
+func checkValue(t *yang.YangType, val interface{}, lenient bool) error {
+	s, text := val.(Text)
+	if text && t.Kind != yang.Yunion {
+		val = textValue(t.Kind, s)
+	}
+	if s, ok := val.(string); ok {
+		if _, err := Canonical(t, s); err != nil {
+			return err
+		}
+	}
+	switch t.Kind {
+	case yang.Yempty:
+		switch l, ok := val.([]interface{}); {
+		case ok && isEmptyValue(l):
+			return nil
+		case val == nil:
+			return fmt.Errorf("invalid value null for type empty, want [null]")
+		case isString(val):
+			return fmt.Errorf("invalid value %q for type empty, want [null]", val)
+		}
+		return fmt.Errorf("invalid value %v for type empty", val)
+	case yang.Ybool:
+		if isString(val) {
+			return fmt.Errorf("boolean value %q is a string, want the literal %s", val, val)
+		}
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("invalid value %v for type boolean", val)
+		}
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yint64,
+		yang.Yuint8, yang.Yuint16, yang.Yuint32, yang.Yuint64:
+		n, err := yang.ParseInt(numberString(val))
+		if err != nil {
+			return fmt.Errorf("invalid value %v for type %s", val, t.Kind)
+		}
+		if !t.Range.Contains(yang.YangRange{{Min: n, Max: n}}) {
+			return fmt.Errorf("value %v outside of range %s", val, t.Range)
+		}
+		if !text && !lenient {
+			return jsonNumberError(t.Kind, val)
+		}
+	case yang.Ydecimal64:
+		n, err := yang.ParseDecimal(numberString(val), uint8(t.FractionDigits))
+		if err != nil {
+			return fmt.Errorf("invalid value %v for type decimal64: %v", val, err)
+		}
+		if !t.Range.Contains(yang.YangRange{{Min: n, Max: n}}) {
+			return fmt.Errorf("value %v outside of range %s", val, t.Range)
+		}
+		if !text && !lenient {
+			return jsonNumberError(t.Kind, val)
+		}
+	case yang.Ystring:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("invalid value %v for type string", val)
+		}
+		n := yang.FromInt(int64(utf8.RuneCountInString(s)))
+		if !t.Length.Contains(yang.YangRange{{Min: n, Max: n}}) {
+			return fmt.Errorf("length of %q outside of %s", s, t.Length)
+		}
+		if err := checkPatterns(t, s); err != nil {
+			return err
+		}
+	case yang.Ybinary:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("invalid value %v for type binary", val)
+		}
+		if _, err := yang.DecodeBinary(t, s); err != nil {
+			return err
+		}
+	case yang.Yenum:
+		s, ok := val.(string)
+		if !ok || t.Enum == nil || !t.Enum.IsDefined(s) {
+			return fmt.Errorf("invalid enumeration value %v", val)
+		}
+	case yang.Ybits:
+		s, ok := val.(string)
+		if !ok || t.Bit == nil {
+			return fmt.Errorf("invalid bits value %v", val)
+		}
+		for _, b := range strings.Fields(s) {
+			if !t.Bit.IsDefined(b) {
+				return fmt.Errorf("invalid bit %q", b)
+			}
+		}
+	case yang.Yidentityref:
+		s, ok := val.(string)
+		if !ok {
+			return fmt.Errorf("invalid identityref value %v", val)
+		}
+		if _, name := splitQualified(s); t.IdentityBase != nil && !t.IdentityBase.IsDefined(name) {
+			return fmt.Errorf("%q is not derived from identity %s", s, t.IdentityBase.Name)
+		}
+	case yang.Yunion:
+		for _, ut := range t.Type {
+			if checkValue(ut, val, lenient) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %v does not match any union member type", val)
+	default:
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			return fmt.Errorf("invalid value %v for type %s", val, t.Kind)
+		}
+	}
+	return nil
+}
+
+// checkPatterns returns an error if s does not match all of the patterns of
+// the string type t.  The XSD regular expressions of pattern statements match
+// the whole value, the POSIX patterns of openconfig-extensions:posix-pattern
+// are anchored by the patterns themselves.  Patterns that cannot be compiled
+// as Go regular expressions, such as those using XSD character class
+// subtraction, are not checked.
+func checkPatterns(t *yang.YangType, s string) error {
+	for i, p := range t.Pattern {
+		if re := compilePattern(p, false); re != nil && !re.MatchString(s) {
+			if i < len(t.PatternErrors) && t.PatternErrors[i] != nil && t.PatternErrors[i].Message != "" {
+				return fmt.Errorf("%q does not match pattern %q: %s", s, p, t.PatternErrors[i].Message)
+			}
+			return fmt.Errorf("%q does not match pattern %q", s, p)
+		}
+	}
+	for _, p := range t.POSIXPattern {
+		if re := compilePattern(p, true); re != nil && !re.MatchString(s) {
+			return fmt.Errorf("%q does not match POSIX pattern %q", s, p)
+		}
+	}
+	return nil
+}
+
+// maxPatterns is the most compiled patterns kept by compilePattern.
+const maxPatterns = 1024
+
+// patterns holds the patterns compiled by compilePattern, keyed by whether
+// they are POSIX patterns and then by pattern.  A nil regexp is a pattern
+// that does not compile.
+var patterns struct {
+	sync.Mutex
+	m [2]map[string]*regexp.Regexp
+}
+
+// compilePattern returns the regular expression of the pattern p, a POSIX
+// pattern if posix is true, or nil if it does not compile.  The compiled
+// patterns are kept, up to maxPatterns of them, as the patterns of a schema
+// are used over and over.
+func compilePattern(p string, posix bool) *regexp.Regexp {
+	k := 0
+	if posix {
+		k = 1
+	}
+	patterns.Lock()
+	defer patterns.Unlock()
+	if re, ok := patterns.m[k][p]; ok {
+		return re
+	}
+	var re *regexp.Regexp
+	var err error
+	if posix {
+		re, err = regexp.CompilePOSIX(p)
+	} else {
+		re, err = regexp.Compile("^(?:" + p + ")$")
+	}
+	if err != nil {
+		re = nil
+	}
+	if len(patterns.m[k]) >= maxPatterns || patterns.m[k] == nil {
+		patterns.m[k] = map[string]*regexp.Regexp{}
+	}
+	patterns.m[k][p] = re
+	return re
+}
+
+// textValue returns the JSON form of the value s of a type of kind k.  s is
+// returned as is if it is not valid for k.  An empty leaf is present when its
+// element is, so an element holding only white space is an empty value too.
+func textValue(k yang.TypeKind, s Text) interface{} {
+	switch {
+	case k == yang.Yempty && strings.TrimSpace(string(s)) == "":
+		return []interface{}{nil}
+	case k == yang.Ybool && s == "true":
+		return true
+	case k == yang.Ybool && s == "false":
+		return false
+	}
+	return string(s)
+}
+
+// jsonNumberError returns an error if val, a valid JSON value of a type of
+// kind k, is a number where RFC 7951 section 6.1 requires a string, or the
+// reverse.  Values of int64, uint64 and decimal64 are strings, as JSON
+// numbers are commonly decoded as doubles, which cannot hold all of their
+// values, and values of smaller integers are numbers.
+func jsonNumberError(k yang.TypeKind, val interface{}) error {
+	_, str := val.(string)
+	switch k {
+	case yang.Yint64, yang.Yuint64, yang.Ydecimal64:
+		if !str {
+			return fmt.Errorf("value %v of type %s must be encoded as a JSON string", val, k)
+		}
+	case yang.Yint8, yang.Yint16, yang.Yint32, yang.Yuint8, yang.Yuint16, yang.Yuint32:
+		if str {
+			return fmt.Errorf("value %q of type %s must be encoded as a JSON number", val, k)
+		}
+	}
+	return nil
+}
+
+// isString returns true if val is a JSON string.
+func isString(val interface{}) bool {
+	_, ok := val.(string)
+	return ok
+}
+
+// numberString returns the textual form of the number val, which may be
+// either a JSON number or a string.  The empty string is returned for any
+// other value.
+func numberString(val interface{}) string {
+	switch n := val.(type) {
+	case string:
+		return n
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case json.Number:
+		return string(n)
+	}
+	return ""
+}
+
+// splitQualified splits a member name of the form module:name into its module
+// and name.  The module is empty if name is not qualified.
+func splitQualified(s string) (string, string) {
+	if i := strings.Index(s, ":"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return "", s
+}
@@ -0,0 +1,779 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+const testModule = `
+module test {
+	prefix "t";
+	namespace "urn:t";
+
+	container c {
+		leaf name { type string { length 1..8; } }
+		leaf count { type uint8 { range 1..10; } }
+		leaf flag { type empty; }
+		leaf enabled { type boolean; }
+		leaf total { type uint64; }
+		leaf ratio { type decimal64 { fraction-digits 2; } }
+		leaf data { type binary { length 2..3; } }
+		leaf colour {
+			type enumeration { enum red; enum blue; }
+		}
+		choice transport {
+			default tcp;
+			case tcp {
+				leaf tcp-port { type uint16; }
+				leaf tcp-host { type string; mandatory true; }
+			}
+			case udp {
+				leaf udp-port { type uint16; }
+			}
+			// Shorthand case.
+			leaf unix-path { type string; }
+		}
+		choice required {
+			mandatory true;
+			leaf a { type string; }
+			leaf b { type string; }
+		}
+		list l {
+			key "k";
+			max-elements 2;
+			leaf k { type string; }
+			leaf v { type int32; }
+		}
+		list ml {
+			key "a b";
+			leaf a { type string; }
+			leaf b { type string; }
+		}
+		leaf-list ll { type string; }
+		leaf-list ull { type string; ordered-by user; }
+		list kl {
+			config false;
+			leaf v { type int32; }
+		}
+		anydata content;
+	}
+}
+`
+
+const testContentModule = `
+module content {
+	prefix "ct";
+	namespace "urn:ct";
+
+	container x {
+		leaf y { type uint8; }
+	}
+}
+`
+
+// moduleEntry returns the Entry for the module name, processed along with
+// the modules it needs, which are parsed from srcs.
+func moduleEntry(t *testing.T, name string, srcs ...string) *yang.Entry {
+	t.Helper()
+	ms := yang.NewModules()
+	for i, src := range srcs {
+		if err := ms.Parse(src, fmt.Sprintf("%s-%d.yang", name, i)); err != nil {
+			t.Fatalf("cannot parse module: %v", err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	e, errs := ms.GetModule(name)
+	if len(errs) > 0 {
+		t.Fatalf("cannot get module %s: %v", name, errs)
+	}
+	return e
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       string
+		wantErrs []string
+	}{{
+		desc: "valid data",
+		in: `{"c": {
+			"name": "abc", "count": 3, "flag": [null], "enabled": false, "colour": "red",
+			"tcp-port": 22, "tcp-host": "h", "a": "x",
+			"l": [{"k": "one", "v": 1}, {"k": "two"}], "kl": [{"v": 1}, {"v": 1}],
+			"ll": ["a", "b"], "data": "AAEC",
+			"total": "18446744073709551615", "ratio": "0.5"
+		}}`,
+	}, {
+		desc: "module qualified member names",
+		in:   `{"test:c": {"test:b": "x", "udp-port": 1}}`,
+	}, {
+		desc: "shorthand case",
+		in:   `{"c": {"unix-path": "/tmp/s", "b": "y"}}`,
+	}, {
+		desc:     "more than one case",
+		in:       `{"c": {"tcp-port": 1, "tcp-host": "h", "udp-port": 2, "a": "x"}}`,
+		wantErrs: []string{`/c: data for more than one case of choice "transport": tcp, udp`},
+	}, {
+		desc:     "shorthand case conflicts with case",
+		in:       `{"c": {"unix-path": "/tmp/s", "udp-port": 2, "a": "x"}}`,
+		wantErrs: []string{`/c: data for more than one case of choice "transport": udp, unix-path`},
+	}, {
+		desc:     "mandatory choice",
+		in:       `{"c": {"name": "abc"}}`,
+		wantErrs: []string{`/c: missing data for mandatory choice "required"`},
+	}, {
+		desc:     "mandatory leaf in active case",
+		in:       `{"c": {"tcp-port": 1, "a": "x"}}`,
+		wantErrs: []string{`/c: missing mandatory element "tcp-host"`},
+	}, {
+		desc: "default case without data is not checked for mandatory nodes",
+		in:   `{"c": {"a": "x"}}`,
+	}, {
+		desc:     "unknown element",
+		in:       `{"c": {"a": "x", "bogus": 1}}`,
+		wantErrs: []string{`/c: unknown element "bogus"`},
+	}, {
+		desc: "bad leaf values",
+		in:   `{"c": {"a": "x", "name": "toolongname", "count": 11, "flag": true, "colour": "green"}}`,
+		wantErrs: []string{
+			`/c/colour: invalid enumeration value green`,
+			`/c/count: value 11 outside of range 1..10`,
+			`/c/flag: invalid value true for type empty`,
+			`/c/name: length of "toolongname" outside of 1..8`,
+		},
+	}, {
+		desc: "empty and boolean encodings",
+		in:   `{"c": {"a": "x", "flag": null, "enabled": "true"}}`,
+		wantErrs: []string{
+			`/c/enabled: boolean value "true" is a string, want the literal true`,
+			`/c/flag: invalid value null for type empty, want [null]`,
+		},
+	}, {
+		desc: "booleans are lowercase",
+		in:   `{"c": {"a": "x", "flag": "", "enabled": "True"}}`,
+		wantErrs: []string{
+			`/c/enabled: invalid boolean value "True": want true or false`,
+			`/c/flag: invalid value "" for type empty, want [null]`,
+		},
+	}, {
+		desc: "number encodings",
+		in:   `{"c": {"a": "x", "count": "3", "total": 5, "ratio": 0.5}}`,
+		wantErrs: []string{
+			`/c/count: value "3" of type uint8 must be encoded as a JSON number`,
+			`/c/ratio: value 0.5 of type decimal64 must be encoded as a JSON string`,
+			`/c/total: value 5 of type uint64 must be encoded as a JSON string`,
+		},
+	}, {
+		desc: "leaf-list values",
+		in:   `{"c": {"a": "x", "ll": ["a", "b", "a"]}}`,
+		wantErrs: []string{
+			`/c/ll[2]: duplicate value a`,
+		},
+	}, {
+		desc: "insert annotations",
+		in: `{"c": {"a": "x",
+			"l": [{"k": "one", "@": {"yang:insert": "first"}}],
+			"ull": ["a", "b", "c"],
+			"@ull": [{"yang:insert": "after", "yang:value": "b"}, null, {"yang:insert": "middle"}]
+		}}`,
+		wantErrs: []string{
+			`/c/l[0]: insert requires an ordered-by user list or leaf-list, l is not`,
+			`/c/ull[2]: invalid insert "middle"`,
+		},
+	}, {
+		desc:     "binary length is in decoded octets",
+		in:       `{"c": {"a": "x", "data": "AA=="}}`,
+		wantErrs: []string{`/c/data: length 1 octets outside of 2..3`},
+	}, {
+		desc:     "invalid base64",
+		in:       `{"c": {"a": "x", "data": "AA"}}`,
+		wantErrs: []string{`/c/data: invalid base64 value "AA": illegal base64 data at input byte 0`},
+	}, {
+		desc: "list errors",
+		in:   `{"c": {"a": "x", "l": [{"k": "one"}, {"k": "one"}, {"v": 1}]}}`,
+		wantErrs: []string{
+			`/c/l: 3 elements is more than max-elements 2`,
+			`/c/l[1]: duplicate entry for key =one`,
+			`/c/l[2]: missing key "k"`,
+		},
+	}, {
+		desc: "list entries with keys containing spaces",
+		in:   `{"c": {"a": "x", "ml": [{"a": "x y", "b": "z"}, {"a": "x", "b": "y z"}, {"a": "x", "b": "y z"}]}}`,
+		wantErrs: []string{
+			`/c/ml[2]: duplicate entry for key =x,y%20z`,
+		},
+	}}
+
+	e := moduleEntry(t, "test", testModule)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(tt.in), &data); err != nil {
+				t.Fatalf("invalid test JSON: %v", err)
+			}
+			var got []string
+			for _, err := range Validate(e, data) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("Validate() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidatePatternsAndContainers(t *testing.T) {
+	e := moduleEntry(t, "p", `module p {
+	prefix "p";
+	namespace "urn:p";
+	import openconfig-extensions { prefix oc-ext; }
+	typedef lower { type string { pattern "[a-z]+"; } }
+	container c {
+		leaf word { type lower; }
+		leaf code {
+			type lower {
+				pattern "a.*" { error-message "must start with a"; }
+			}
+		}
+		leaf posix { type string { oc-ext:posix-pattern "^[0-9]+$"; } }
+		leaf either { type union { type lower; type uint8; } }
+		leaf-list words { type lower; }
+	}
+	container outer {
+		container inner {
+			leaf must-have { type string; mandatory true; }
+		}
+		container optional {
+			presence "enables the option";
+			leaf setting { type string; mandatory true; }
+		}
+		container state {
+			config false;
+			leaf counter { type uint32; mandatory true; }
+		}
+	}
+}`, `module openconfig-extensions {
+	prefix "oc-ext";
+	namespace "http://openconfig.net/yang/openconfig-ext";
+	extension posix-pattern { argument "pattern"; }
+}`)
+
+	const outer = `"outer": {"inner": {"must-have": "x"}, "state": {"counter": 1}}`
+	tests := []struct {
+		desc      string
+		in        string
+		datastore Datastore
+		wantErrs  []string
+	}{{
+		desc: "matching patterns",
+		in:   `{"c": {"word": "abc", "code": "abc", "posix": "123", "either": 5, "words": ["a", "b"]}, ` + outer + `}`,
+	}, {
+		desc: "values not matching patterns",
+		in:   `{"c": {"word": "123!!", "code": "bcd", "posix": "12a", "either": "A", "words": ["a", "B"]}, ` + outer + `}`,
+		wantErrs: []string{
+			`/c/code: "bcd" does not match pattern "a.*": must start with a`,
+			`/c/either: value A does not match any union member type`,
+			`/c/posix: "12a" does not match POSIX pattern "^[0-9]+$"`,
+			`/c/word: "123!!" does not match pattern "[a-z]+"`,
+			`/c/words[1]: "B" does not match pattern "[a-z]+"`,
+		},
+	}, {
+		desc: "patterns match the whole value",
+		in:   `{"c": {"word": "abc1"}, ` + outer + `}`,
+		wantErrs: []string{
+			`/c/word: "abc1" does not match pattern "[a-z]+"`,
+		},
+	}, {
+		desc: "mandatory leaves of missing non-presence containers",
+		in:   `{"c": {}}`,
+		wantErrs: []string{
+			`/outer/inner: missing mandatory element "must-have"`,
+			`/outer/state: missing mandatory element "counter"`,
+		},
+	}, {
+		desc:      "state data is not mandatory in running",
+		in:        `{"outer": {}}`,
+		datastore: Running,
+		wantErrs: []string{
+			`/outer/inner: missing mandatory element "must-have"`,
+		},
+	}, {
+		desc: "mandatory leaves of a present presence container",
+		in:   `{"outer": {"inner": {"must-have": "x"}, "state": {"counter": 1}, "optional": {}}}`,
+		wantErrs: []string{
+			`/outer/optional: missing mandatory element "setting"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(tt.in), &data); err != nil {
+				t.Fatalf("invalid test JSON: %v", err)
+			}
+			var got []string
+			for _, err := range ValidateWithOptions(e, NewJSONNode(data), ValidateOptions{Datastore: tt.datastore}) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("Validate() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateLenientNumbers(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"c": {"a": "x", "count": "3", "total": 5, "ratio": 0.5}}`), &data); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	e := moduleEntry(t, "test", testModule)
+	if errs := ValidateWithOptions(e, NewJSONNode(data), ValidateOptions{LenientNumbers: true}); len(errs) > 0 {
+		t.Errorf("ValidateWithOptions() with LenientNumbers got errors %v", errs)
+	}
+	if errs := ValidateNode(e, NewJSONNode(data)); len(errs) != 3 {
+		t.Errorf("ValidateNode() got errors %v, want 3", errs)
+	}
+}
+
+func TestValidateRequireInstance(t *testing.T) {
+	e := moduleEntry(t, "refs", `module refs {
+	prefix "r";
+	namespace "urn:r";
+	typedef name-ref { type leafref { path "/r:c/r:l/r:k"; } }
+	typedef loose-ref { type name-ref { require-instance false; } }
+	container c {
+		list l {
+			key "k";
+			leaf k { type string; }
+			leaf v { type int32; }
+		}
+		list n {
+			key "id";
+			leaf id { type uint8; }
+		}
+		leaf ref { type leafref { path "../l/k"; } }
+		leaf num { type leafref { path "../n/id"; } }
+		leaf num-ref { type leafref { path "../num"; require-instance false; } }
+		leaf typed { type name-ref; }
+		leaf loose { type loose-ref; }
+		leaf optional { type leafref { path "../l/k"; require-instance false; } }
+		leaf-list refs { type name-ref; }
+		leaf id { type instance-identifier; }
+		leaf loose-id { type instance-identifier { require-instance false; } }
+	}
+}`)
+	c := e.Dir["c"]
+	for name, want := range map[string]bool{"ref": true, "typed": true, "loose": false, "optional": false, "id": true, "loose-id": false} {
+		if got := c.Dir[name].Type.RequireInstance(); got != want {
+			t.Errorf("%s: RequireInstance() = %t, want %t", name, got, want)
+		}
+	}
+
+	tests := []struct {
+		desc     string
+		in       string
+		wantErrs []string
+	}{{
+		desc: "existing instances",
+		in: `{"c": {
+			"l": [{"k": "a", "v": 1}, {"k": "b", "v": 2}],
+			"ref": "a", "typed": "b", "refs": ["a", "b"],
+			"id": "/refs:c/l[k='b']/v"
+		}}`,
+	}, {
+		desc: "instances not required",
+		in:   `{"c": {"loose": "x", "optional": "y", "loose-id": "/refs:c/l[k='x']"}}`,
+	}, {
+		desc: "missing instances",
+		in: `{"c": {
+			"l": [{"k": "a"}],
+			"ref": "x", "typed": "y", "refs": ["a", "z"],
+			"id": "/refs:c/l[k='x']/v"
+		}}`,
+		wantErrs: []string{
+			`/c/id: no instance of /refs:c/l[k='x']/v`,
+			`/c/ref: no instance of /refs/c/l/k with value x`,
+			`/c/refs[1]: no instance of /refs/c/l/k with value z`,
+			`/c/typed: no instance of /refs/c/l/k with value y`,
+		},
+	}, {
+		desc: "values of the type of the target",
+		in: `{"c": {
+			"n": [{"id": 5}],
+			"num": 5, "num-ref": 5
+		}}`,
+	}, {
+		desc: "values not of the type of the target",
+		in: `{"c": {
+			"n": [{"id": 5}],
+			"num": "5", "num-ref": 300
+		}}`,
+		wantErrs: []string{
+			`/c/num: value "5" of type uint8 must be encoded as a JSON number`,
+			`/c/num-ref: value 300 outside of range 0..255`,
+		},
+	}, {
+		desc: "instance-identifier positions",
+		in:   `{"c": {"l": [{"k": "a"}, {"k": "b"}], "id": "/refs:c/l[3]"}}`,
+		wantErrs: []string{
+			`/c/id: no instance of /refs:c/l[3]`,
+		},
+	}, {
+		desc: "instance-identifier key with a ] and a position",
+		in:   `{"c": {"l": [{"k": "a]"}, {"k": "b"}], "id": "/refs:c/l[ k = 'a]' ]", "loose-id": "/refs:c/l[2]"}}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(tt.in), &data); err != nil {
+				t.Fatalf("invalid test JSON: %v", err)
+			}
+			var got []string
+			for _, err := range Validate(e, data) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("Validate() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestActiveCase(t *testing.T) {
+	e := moduleEntry(t, "test", testModule)
+	transport := e.Dir["c"].Dir["transport"]
+	tests := []struct {
+		desc    string
+		present map[string]bool
+		want    string
+	}{{
+		desc: "default case",
+		want: "tcp",
+	}, {
+		desc:    "case with data",
+		present: map[string]bool{"udp-port": true},
+		want:    "udp",
+	}, {
+		desc:    "shorthand case",
+		present: map[string]bool{"unix-path": true},
+		want:    "unix-path",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got := ActiveCase(transport, tt.present)
+			if got == nil || got.Name != tt.want {
+				t.Errorf("ActiveCase() got %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAttachedSchema(t *testing.T) {
+	e := moduleEntry(t, "test", testModule)
+	content := moduleEntry(t, "content", testContentModule)
+
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"c": {"a": "x", "content": {"x": {"y": "bad"}}}}`), &data); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	if errs := Validate(e, data); len(errs) != 0 {
+		t.Errorf("Validate() without attached schema got errors %v, want none", errs)
+	}
+
+	anydata := e.Dir["c"].Dir["content"]
+	if err := anydata.AttachSchema(content); err != nil {
+		t.Fatalf("AttachSchema() got unexpected error: %v", err)
+	}
+	defer anydata.AttachSchema(nil)
+	want := "/c/content/x/y: invalid value bad for type uint8"
+	if errs := Validate(e, data); len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("Validate() with attached schema got errors %v, want %s", errs, want)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	ms := yang.NewModules()
+	for name, src := range map[string]string{
+		"test.yang":    testModule,
+		"content.yang": testContentModule,
+		"other.yang":   `module other { prefix "o"; namespace "urn:o"; container c { leaf z { type string; } } }`,
+	} {
+		if err := ms.Parse(src, name); err != nil {
+			t.Fatalf("cannot parse %s: %v", name, err)
+		}
+	}
+	if errs := ms.Process(); len(errs) > 0 {
+		t.Fatalf("cannot process modules: %v", errs)
+	}
+	var modules []*yang.Entry
+	for _, name := range []string{"test", "content", "other"} {
+		e, errs := ms.GetModule(name)
+		if len(errs) > 0 {
+			t.Fatalf("cannot get module %s: %v", name, errs)
+		}
+		modules = append(modules, e)
+	}
+
+	tests := []struct {
+		desc     string
+		in       string
+		xml      bool
+		wantErrs []string
+	}{{
+		desc: "members of several modules",
+		in:   `{"test:c": {"a": "x"}, "content:x": {"y": 1}, "other:c": {"z": "q"}}`,
+	}, {
+		desc: "errors in each module",
+		in:   `{"test:c": {"count": 0}, "content:x": {"y": 300}}`,
+		wantErrs: []string{
+			`/c/count: value 0 outside of range 1..10`,
+			`/c: missing data for mandatory choice "required"`,
+			`/x/y: value 300 outside of range 0..255`,
+		},
+	}, {
+		// The mandatory choice of test's non-presence container c
+		// makes c mandatory.
+		desc:     "unqualified member of one module",
+		in:       `{"x": {"y": 1}}`,
+		wantErrs: []string{`/c: missing data for mandatory choice "required"`},
+	}, {
+		desc: "ambiguous unqualified member",
+		in:   `{"c": {"a": "x"}}`,
+		wantErrs: []string{
+			`/: element "c" is defined by more than one module: other, test`,
+			`/c: missing data for mandatory choice "required"`,
+		},
+	}, {
+		desc: "member of the wrong module",
+		in:   `{"content:c": {}}`,
+		wantErrs: []string{
+			`/: unknown element "c" of content`,
+			`/c: missing data for mandatory choice "required"`,
+		},
+	}, {
+		desc: "XML namespaces",
+		in:   `<data><c xmlns="urn:t"><a>x</a></c><c xmlns="urn:o"><z>q</z></c><x xmlns="urn:ct"><y>1</y></x></data>`,
+		xml:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var n DataNode
+			if tt.xml {
+				x, err := ParseXML(strings.NewReader(tt.in))
+				if err != nil {
+					t.Fatalf("invalid test XML: %v", err)
+				}
+				n = x
+			} else {
+				var data interface{}
+				if err := json.Unmarshal([]byte(tt.in), &data); err != nil {
+					t.Fatalf("invalid test JSON: %v", err)
+				}
+				n = NewJSONNode(data)
+			}
+			var got []string
+			for _, err := range ValidateDocument(modules, n, ValidateOptions{}) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("ValidateDocument() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateOrigin(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       string
+		wantErrs []string
+	}{{
+		desc: "JSON annotations",
+		in: `{"c": {"@": {"ietf-origin:origin": "ietf-origin:intended"}, "a": "x",
+			"name": "n", "@name": {"ietf-origin:origin": "ietf-origin:learned"},
+			"ll": ["a", "b"], "@ll": [null, {"ietf-origin:origin": "ietf-origin:bogus"}],
+			"l": [{"k": "one", "@": {"ietf-origin:origin": "system"}}]
+		}}`,
+		wantErrs: []string{`/c/ll[1]: origin "ietf-origin:bogus" is not derived from ietf-origin:origin`},
+	}, {
+		desc: "XML attributes",
+		in: `<data xmlns:or="urn:ietf:params:xml:ns:yang:ietf-origin"><c or:origin="or:intended"><a>x</a>
+			<name or:origin="or:remote">n</name>
+		</c></data>`,
+		wantErrs: []string{`/c/name: origin "or:remote" is not derived from ietf-origin:origin`},
+	}}
+
+	e := moduleEntry(t, "test", testModule)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var n DataNode
+			if strings.HasPrefix(tt.in, "<") {
+				x, err := ParseXML(strings.NewReader(tt.in))
+				if err != nil {
+					t.Fatalf("invalid test XML: %v", err)
+				}
+				n = x
+			} else {
+				var data interface{}
+				if err := json.Unmarshal([]byte(tt.in), &data); err != nil {
+					t.Fatalf("invalid test JSON: %v", err)
+				}
+				n = NewJSONNode(data)
+			}
+			var got []string
+			for _, err := range ValidateNode(e, n) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("ValidateNode() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateDerivedOrigin(t *testing.T) {
+	e := moduleEntry(t, "vendor", `module ietf-origin {
+			prefix or;
+			namespace "urn:ietf:params:xml:ns:yang:ietf-origin";
+			identity origin;
+			identity learned { base origin; }
+		}`, `module vendor {
+			prefix v;
+			namespace "urn:v";
+			import ietf-origin { prefix or; }
+			identity cached { base or:learned; }
+			container s { config false; leaf x { type string; } }
+		}`)
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"s": {"x": "y", "@x": {"ietf-origin:origin": "vendor:cached"}, "@": {"ietf-origin:origin": "ietf-origin:system"}}}`), &data); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	// system is not defined by this ietf-origin.
+	want := `/s: origin "ietf-origin:system" is not derived from ietf-origin:origin`
+	if errs := Validate(e, data); len(errs) != 1 || errs[0].Error() != want {
+		t.Errorf("Validate() got errors %v, want %s", errs, want)
+	}
+}
+
+func TestValidateXML(t *testing.T) {
+	tests := []struct {
+		desc     string
+		in       string
+		wantErrs []string
+	}{{
+		desc: "valid data",
+		in: `<config><c xmlns="urn:t">
+			<name>abc</name><count>3</count><flag/><enabled>true</enabled><colour>red</colour>
+			<tcp-port>22</tcp-port><tcp-host>h</tcp-host><a>x</a>
+			<l><k>one</k><v>1</v></l><l><k>two</k></l>
+			<ll>a</ll><ll>b</ll>
+		</c></config>`,
+	}, {
+		desc: "unknown empty element",
+		in:   `<config><c><b>y</b><c2/></c></config>`,
+		wantErrs: []string{
+			`/c: unknown element "c2"`,
+		},
+	}, {
+		desc: "bad leaf values",
+		in:   `<config><c><a>x</a><name>toolongname</name><count>eleven</count><flag>true</flag></c></config>`,
+		wantErrs: []string{
+			`/c/count: invalid value eleven for type uint8`,
+			`/c/flag: invalid value "true" for type empty`,
+			`/c/name: length of "toolongname" outside of 1..8`,
+		},
+	}, {
+		desc: "empty leaf with white space",
+		in:   "<config><c><a>x</a><flag>\n\t</flag></c></config>",
+	}, {
+		desc: "booleans are lowercase",
+		in:   `<config><c><a>x</a><enabled>True</enabled></c></config>`,
+		wantErrs: []string{
+			`/c/enabled: invalid boolean value "True": want true or false`,
+		},
+	}, {
+		desc: "insert attributes",
+		in: `<config xmlns:yang="urn:ietf:params:xml:ns:yang:1"><c><a>x</a>
+			<ull yang:insert="before" yang:value="a">b</ull>
+			<l yang:insert="first"><k>one</k></l>
+		</c></config>`,
+		wantErrs: []string{
+			`/c/l[0]: insert requires an ordered-by user list or leaf-list, l is not`,
+		},
+	}, {
+		desc: "repeated leaf",
+		in:   `<config><c><a>x</a><name>one</name><name>two</name></c></config>`,
+		wantErrs: []string{
+			`/c/name: 2 instances of name, want at most 1`,
+		},
+	}, {
+		desc: "leaf with child elements",
+		in:   `<config><c><a><x/></a></c></config>`,
+		wantErrs: []string{
+			`/c/a: expected a value for a`,
+		},
+	}, {
+		desc: "list errors",
+		in:   `<config><c><a>x</a><l><k>one</k></l><l><k>one</k></l><l><v>1</v></l></c></config>`,
+		wantErrs: []string{
+			`/c/l: 3 elements is more than max-elements 2`,
+			`/c/l[1]: duplicate entry for key =one`,
+			`/c/l[2]: missing key "k"`,
+		},
+	}}
+
+	e := moduleEntry(t, "test", testModule)
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			x, err := ParseXML(strings.NewReader(tt.in))
+			if err != nil {
+				t.Fatalf("invalid test XML: %v", err)
+			}
+			var got []string
+			for _, err := range ValidateNode(e, x) {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("ValidateNode() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.wantErrs, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	x, err := ParseXML(strings.NewReader(`<?xml version="1.0"?><data><c xmlns="urn:t"><name>abc</name></c></data>`))
+	if err != nil {
+		t.Fatalf("ParseXML() got unexpected error: %v", err)
+	}
+	c := x.Children()[0]
+	if c.Name() != "c" || c.Namespace() != "urn:t" || c.Value() != nil {
+		t.Errorf("ParseXML() got element %s:%s with value %v, want urn:t:c with no value", c.Namespace(), c.Name(), c.Value())
+	}
+	if v, ok := c.Key("name"); !ok || v != Text("abc") {
+		t.Errorf(`Key("name") got %v, %t, want abc, true`, v, ok)
+	}
+	if _, err := ParseXML(strings.NewReader(`<data><c>`)); err == nil {
+		t.Errorf("ParseXML() of an unterminated document got no error")
+	}
+}
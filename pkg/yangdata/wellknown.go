@@ -0,0 +1,229 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+// A Codec checks that s is a valid value of a type and returns its canonical
+// form.
+type Codec func(s string) (string, error)
+
+// wellKnownCodecs are the codecs of the well-known derived types of
+// ietf-yang-types and ietf-inet-types (RFC 6991), by module:typedef name.
+var wellKnownCodecs = map[string]Codec{
+	"ietf-yang-types:date-and-time":        dateAndTime,
+	"ietf-yang-types:dotted-quad":          ipAddress(true, false, false),
+	"ietf-yang-types:hex-string":           hexString(false),
+	"ietf-yang-types:mac-address":          hexString(true),
+	"ietf-yang-types:phys-address":         hexString(false),
+	"ietf-yang-types:uuid":                 uuid,
+	"ietf-inet-types:ip-address":           ipAddress(true, true, true),
+	"ietf-inet-types:ip-address-no-zone":   ipAddress(true, true, false),
+	"ietf-inet-types:ipv4-address":         ipAddress(true, false, true),
+	"ietf-inet-types:ipv4-address-no-zone": ipAddress(true, false, false),
+	"ietf-inet-types:ipv6-address":         ipAddress(false, true, true),
+	"ietf-inet-types:ipv6-address-no-zone": ipAddress(false, true, false),
+	"ietf-inet-types:ip-prefix":            ipPrefix(true, true),
+	"ietf-inet-types:ipv4-prefix":          ipPrefix(true, false),
+	"ietf-inet-types:ipv6-prefix":          ipPrefix(false, true),
+}
+
+// WellKnownType returns the module:typedef name of the well-known derived
+// type of ietf-yang-types or ietf-inet-types that t is derived from, and its
+// Codec.  The typedef chain of t is followed from t towards its built-in type
+// and the first well-known typedef found is returned, so a typedef derived
+// from inet:ipv4-address is an ipv4-address.  "" and nil are returned if t is
+// not derived from a well-known type.
+func WellKnownType(t *yang.YangType) (string, Codec) {
+	for seen := map[*yang.YangType]bool{}; t != nil && t.Base != nil && !seen[t]; t = t.Base.YangType {
+		seen[t] = true
+		m := yang.RootNode(t.Base)
+		if m == nil {
+			break
+		}
+		module := m.Name
+		if m.BelongsTo != nil {
+			module = m.BelongsTo.Name
+		}
+		name := module + ":" + t.Name
+		if c := wellKnownCodecs[name]; c != nil {
+			return name, c
+		}
+	}
+	return "", nil
+}
+
+// Canonical returns the canonical form of s, a value of type t.  An error is
+// returned if t is derived from a well-known type and s is not a valid value
+// of it.  s is returned unchanged if t is not derived from a well-known type.
+//
+// The only values of a boolean are the lowercase "true" and "false", and the
+// only value of an empty is "", rather than any spelling a parser might
+// accept, so a boolean or empty s is returned unchanged if it is valid and is
+// otherwise an error.
+func Canonical(t *yang.YangType, s string) (string, error) {
+	switch t.Kind {
+	case yang.Ybool:
+		if s != "true" && s != "false" {
+			return "", fmt.Errorf("invalid boolean value %q: want true or false", s)
+		}
+		return s, nil
+	case yang.Yempty:
+		if s != "" {
+			return "", fmt.Errorf("invalid value %q for type empty", s)
+		}
+		return s, nil
+	}
+	name, c := WellKnownType(t)
+	if c == nil {
+		return s, nil
+	}
+	cs, err := c(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q: %v", name, s, err)
+	}
+	return cs, nil
+}
+
+// dateAndTimeRE matches the lexical form of a date-and-time.
+var dateAndTimeRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[\+\-]\d{2}:\d{2})$`)
+
+// dateAndTime is the Codec of date-and-time.  The canonical form has no
+// trailing zeros in its fractional seconds and writes the offset +00:00 as Z.
+// The offset -00:00, meaning the offset to local time is unknown, is kept.
+func dateAndTime(s string) (string, error) {
+	if !dateAndTimeRE.MatchString(s) {
+		return "", fmt.Errorf("want YYYY-MM-DDThh:mm:ss[.fraction](Z|+hh:mm|-hh:mm)")
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(s, "-00:00") {
+		return t.Format("2006-01-02T15:04:05.999999999") + "-00:00", nil
+	}
+	return t.Format("2006-01-02T15:04:05.999999999Z07:00"), nil
+}
+
+// hexString returns the Codec of colon separated hex octets, lower case in
+// canonical form.  There must be exactly six octets if mac is true, otherwise
+// any number of octets, including none.
+func hexString(mac bool) Codec {
+	return func(s string) (string, error) {
+		if s == "" && !mac {
+			return s, nil
+		}
+		octets := strings.Split(s, ":")
+		if mac && len(octets) != 6 {
+			return "", fmt.Errorf("want six octets")
+		}
+		for _, o := range octets {
+			if len(o) != 2 || !isHex(o) {
+				return "", fmt.Errorf("%q is not a two digit hex octet", o)
+			}
+		}
+		return strings.ToLower(s), nil
+	}
+}
+
+// uuid is the Codec of uuid, lower case in canonical form.
+func uuid(s string) (string, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 5 {
+		return "", fmt.Errorf("want 8-4-4-4-12 hex digits")
+	}
+	for i, n := range []int{8, 4, 4, 4, 12} {
+		if len(parts[i]) != n || !isHex(parts[i]) {
+			return "", fmt.Errorf("want 8-4-4-4-12 hex digits")
+		}
+	}
+	return strings.ToLower(s), nil
+}
+
+// isHex returns true if s is all hex digits.
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipAddress returns the Codec of an IPv4 address, if v4 is true, and an IPv6
+// address, if v6 is true, optionally followed by a zone if zone is true.  The
+// canonical form of an IPv6 address is that of RFC 5952.
+func ipAddress(v4, v6, zone bool) Codec {
+	return func(s string) (string, error) {
+		addr, z, hasZone := strings.Cut(s, "%")
+		switch {
+		case hasZone && !zone:
+			return "", fmt.Errorf("unexpected zone")
+		case hasZone && z == "":
+			return "", fmt.Errorf("empty zone")
+		}
+		a, err := parseAddr(addr, v4, v6)
+		if err != nil {
+			return "", err
+		}
+		if hasZone {
+			return a.String() + "%" + z, nil
+		}
+		return a.String(), nil
+	}
+}
+
+// ipPrefix returns the Codec of an IPv4 prefix, if v4 is true, and an IPv6
+// prefix, if v6 is true.  The canonical form has the bits of the address that
+// are not part of the prefix set to zero.
+func ipPrefix(v4, v6 bool) Codec {
+	return func(s string) (string, error) {
+		addr, bits, ok := strings.Cut(s, "/")
+		if !ok {
+			return "", fmt.Errorf("missing prefix length")
+		}
+		if _, err := parseAddr(addr, v4, v6); err != nil {
+			return "", err
+		}
+		p, err := netip.ParsePrefix(addr + "/" + bits)
+		if err != nil {
+			return "", err
+		}
+		return p.Masked().String(), nil
+	}
+}
+
+// parseAddr parses s as an IPv4 address, if v4 is true, or an IPv6 address,
+// if v6 is true.
+func parseAddr(s string, v4, v6 bool) (netip.Addr, error) {
+	a, err := netip.ParseAddr(s)
+	switch {
+	case err != nil:
+		return a, err
+	case a.Is4() && !v4:
+		return a, fmt.Errorf("want an IPv6 address")
+	case a.Is6() && !v6:
+		return a, fmt.Errorf("want an IPv4 address")
+	}
+	return a, nil
+}
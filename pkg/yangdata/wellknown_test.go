@@ -0,0 +1,141 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testWellKnownModules = []string{
+	`
+module ietf-inet-types {
+	prefix "inet";
+	namespace "urn:ietf:params:xml:ns:yang:ietf-inet-types";
+
+	typedef ip-address {
+		type union { type ipv4-address; type ipv6-address; }
+	}
+	typedef ipv4-address { type string { pattern '[0-9.]+(%.+)?'; } }
+	typedef ipv6-address { type string { pattern '[0-9a-fA-F:.]+(%.+)?'; } }
+	typedef ip-prefix {
+		type union { type ipv4-prefix; type ipv6-prefix; }
+	}
+	typedef ipv4-prefix { type string { pattern '[0-9.]+/[0-9]+'; } }
+	typedef ipv6-prefix { type string { pattern '[0-9a-fA-F:.]+/[0-9]+'; } }
+}`,
+	`
+module ietf-yang-types {
+	prefix "yang";
+	namespace "urn:ietf:params:xml:ns:yang:ietf-yang-types";
+
+	typedef date-and-time { type string; }
+	typedef mac-address { type string; }
+	typedef hex-string { type string; }
+	typedef uuid { type string; }
+}`,
+	`
+module wk {
+	prefix "wk";
+	namespace "urn:wk";
+	import ietf-inet-types { prefix inet; }
+	import ietf-yang-types { prefix yang; }
+
+	typedef router-id { type inet:ipv4-address; }
+
+	container c {
+		leaf addr { type inet:ip-address; }
+		leaf v4 { type inet:ipv4-address; }
+		leaf id { type router-id; }
+		leaf prefix { type inet:ip-prefix; }
+		leaf mac { type yang:mac-address; }
+		leaf hex { type yang:hex-string; }
+		leaf when { type yang:date-and-time; }
+		leaf uuid { type yang:uuid; }
+		leaf name { type string; }
+	}
+}`,
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		leaf     string
+		in       string
+		wantType string
+		want     string
+		wantErr  string
+	}{
+		{leaf: "addr", in: "192.0.2.1", wantType: "ietf-inet-types:ip-address", want: "192.0.2.1"},
+		{leaf: "addr", in: "2001:DB8:0:0::1", wantType: "ietf-inet-types:ip-address", want: "2001:db8::1"},
+		{leaf: "addr", in: "fe80::1%eth0", wantType: "ietf-inet-types:ip-address", want: "fe80::1%eth0"},
+		{leaf: "addr", in: "192.0.2.256", wantType: "ietf-inet-types:ip-address", wantErr: `invalid ietf-inet-types:ip-address value "192.0.2.256"`},
+		{leaf: "hex", in: "01:02%x", wantType: "ietf-yang-types:hex-string", wantErr: "not a two digit hex octet"},
+		{leaf: "v4", in: "2001:db8::1", wantType: "ietf-inet-types:ipv4-address", wantErr: "want an IPv4 address"},
+		{leaf: "id", in: "10.0.0.1", wantType: "ietf-inet-types:ipv4-address", want: "10.0.0.1"},
+		{leaf: "prefix", in: "192.0.2.77/24", wantType: "ietf-inet-types:ip-prefix", want: "192.0.2.0/24"},
+		{leaf: "prefix", in: "2001:db8::1/129", wantType: "ietf-inet-types:ip-prefix", wantErr: "invalid"},
+		{leaf: "mac", in: "00:1A:2B:3C:4D:5E", wantType: "ietf-yang-types:mac-address", want: "00:1a:2b:3c:4d:5e"},
+		{leaf: "mac", in: "00:1a:2b", wantType: "ietf-yang-types:mac-address", wantErr: "want six octets"},
+		{leaf: "hex", in: "", wantType: "ietf-yang-types:hex-string", want: ""},
+		{leaf: "hex", in: "0A:b", wantType: "ietf-yang-types:hex-string", wantErr: `"b" is not a two digit hex octet`},
+		{leaf: "when", in: "2026-10-14T12:30:00.500+00:00", wantType: "ietf-yang-types:date-and-time", want: "2026-10-14T12:30:00.5Z"},
+		{leaf: "when", in: "2026-10-14T12:30:00-00:00", wantType: "ietf-yang-types:date-and-time", want: "2026-10-14T12:30:00-00:00"},
+		{leaf: "when", in: "2026-10-14 12:30:00Z", wantType: "ietf-yang-types:date-and-time", wantErr: "want YYYY-MM-DD"},
+		{leaf: "uuid", in: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", wantType: "ietf-yang-types:uuid", want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{leaf: "name", in: "Any Thing", want: "Any Thing"},
+	}
+
+	c := moduleEntry(t, "wk", testWellKnownModules...).Dir["c"]
+	for _, tt := range tests {
+		t.Run(tt.leaf+" "+tt.in, func(t *testing.T) {
+			typ := c.Dir[tt.leaf].Type
+			if name, _ := WellKnownType(typ); name != tt.wantType {
+				t.Errorf("WellKnownType() got %q, want %q", name, tt.wantType)
+			}
+			got, err := Canonical(typ, tt.in)
+			if err != nil {
+				if tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Canonical() got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatalf("Canonical() got %q, want error %q", got, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Canonical() got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWellKnown(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"c": {"addr": "2001:db8::1", "mac": "00:11:22:33:44", "id": "10.0.0.1%"}}`), &data); err != nil {
+		t.Fatalf("invalid test JSON: %v", err)
+	}
+	var got []string
+	for _, err := range Validate(moduleEntry(t, "wk", testWellKnownModules...), data) {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`/c/id: invalid ietf-inet-types:ipv4-address value "10.0.0.1%": empty zone`,
+		`/c/mac: invalid ietf-yang-types:mac-address value "00:11:22:33:44": want six octets`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate() got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yangdata
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// An XMLElement is an element of an XML document, as encoded by RFC 7950
+// section 9.  It is a DataNode: an element with child elements is a container
+// or list entry, any other element is a leaf or leaf-list value whose value
+// is its Text.
+//
+// The element passed to ValidateNode is the one holding the top level data
+// nodes, such as the <config> or <data> element of a NETCONF message.
+type XMLElement struct {
+	XMLName  xml.Name      // XMLName is the namespace and local name of the element.
+	Attr     []xml.Attr    // Attr holds the attributes of the element, see NodeInsertion.
+	Text     string        // Text is the character data of the element.
+	Elements []*XMLElement // Elements are the child elements, in document order.
+}
+
+// ParseXML reads an XML document from r and returns its document element.
+func ParseXML(r io.Reader) (*XMLElement, error) {
+	d := xml.NewDecoder(r)
+	var stack []*XMLElement
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			x := &XMLElement{XMLName: t.Name}
+			if len(t.Attr) > 0 {
+				x.Attr = t.Copy().Attr
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Elements = append(parent.Elements, x)
+			}
+			stack = append(stack, x)
+		case xml.EndElement:
+			x := stack[len(stack)-1]
+			if stack = stack[:len(stack)-1]; len(stack) == 0 {
+				return x, nil
+			}
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+}
+
+func (x *XMLElement) Name() string      { return x.XMLName.Local }
+func (x *XMLElement) Namespace() string { return x.XMLName.Space }
+
+// Value returns nil if x has child elements, otherwise it returns the Text
+// of x.
+func (x *XMLElement) Value() interface{} {
+	if len(x.Elements) > 0 {
+		return nil
+	}
+	return Text(x.Text)
+}
+
+func (x *XMLElement) Children() []DataNode {
+	children := make([]DataNode, len(x.Elements))
+	for i, c := range x.Elements {
+		children[i] = c
+	}
+	return children
+}
+
+func (x *XMLElement) Key(name string) (interface{}, bool) {
+	for _, c := range x.Elements {
+		if c.XMLName.Local == name {
+			return c.Value(), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func init() {
+	register(&formatter{
+		name: "repl",
+		f:    doRepl,
+		help: "read commands from standard input to navigate and search the schema",
+	})
+}
+
+// replHelp is printed by the help command.
+const replHelp = `commands:
+  pwd               print the current node
+  ls [PATH]         list the children of the node
+  cd [PATH]         change the current node, to the top if PATH is omitted
+  type [PATH]       show the type of the node
+  tree [PATH]       print the subtree rooted at the node
+  explain [PATH]    show everything known about the node
+  find REGEX        list the nodes below the current node whose names match
+  help              print this help
+  quit              exit
+PATH is either absolute, starting with / and a module name, such as /module/c
+or /module:c, or relative to the current node, and may contain . and ..
+`
+
+// A repl is the state of an interactive session.  The current node is cwd,
+// or the list of modules when cwd is nil.
+type repl struct {
+	w       io.Writer
+	modules []*yang.Entry
+	cwd     *yang.Entry
+}
+
+// replArgs returns args, the arguments of "goyang repl ...", as the
+// arguments of "goyang --format=repl ...".
+func replArgs(args []string) []string {
+	return append([]string{args[0], "--format=repl"}, args[2:]...)
+}
+
+// doRepl reads commands from standard input, writing a prompt and the output
+// of each command to w, until quit or the end of the input.
+func doRepl(w io.Writer, entries []*yang.Entry) {
+	r := &repl{w: w, modules: entries}
+	s := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Fprintf(w, "goyang:%s> ", r.pwd())
+		if !s.Scan() {
+			fmt.Fprintln(w)
+			break
+		}
+		if !r.run(strings.Fields(s.Text())) {
+			break
+		}
+	}
+	if err := s.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop(1)
+	}
+}
+
+// run runs the command args, returning false if the session is over.
+func (r *repl) run(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "quit", "exit":
+		return false
+	case "help", "?":
+		fmt.Fprint(r.w, replHelp)
+		return true
+	case "pwd":
+		fmt.Fprintln(r.w, r.pwd())
+		return true
+	case "find":
+		if len(args) != 1 {
+			fmt.Fprintln(r.w, "usage: find REGEX")
+			return true
+		}
+		re, err := regexp.Compile(args[0])
+		if err != nil {
+			fmt.Fprintf(r.w, "find: %v\n", err)
+			return true
+		}
+		for _, e := range r.children(r.cwd) {
+			r.find(e, re)
+		}
+		return true
+	}
+
+	switch cmd {
+	case "cd", "ls", "type", "tree", "explain":
+	default:
+		fmt.Fprintf(r.w, "unknown command %s, try help\n", cmd)
+		return true
+	}
+	if len(args) > 1 {
+		fmt.Fprintf(r.w, "%s: too many arguments\n", cmd)
+		return true
+	}
+	path := ""
+	if len(args) == 1 {
+		path = args[0]
+	}
+	e, ok := r.lookup(path)
+	if !ok {
+		fmt.Fprintf(r.w, "%s: %s not found\n", cmd, path)
+		return true
+	}
+	switch cmd {
+	case "cd":
+		switch {
+		case path == "":
+			r.cwd = nil
+		case e != nil && e.Dir == nil && e.RPC == nil:
+			fmt.Fprintf(r.w, "cd: %s has no children\n", path)
+		default:
+			r.cwd = e
+		}
+	case "ls":
+		r.ls(e)
+	case "type":
+		switch {
+		case e == nil:
+			fmt.Fprintln(r.w, "type: no node")
+		case e.Type == nil:
+			fmt.Fprintf(r.w, "%s has no type\n", e.Path())
+		default:
+			printType(r.w, e.Type, true)
+			for _, td := range e.Type.TypedefChain() {
+				fmt.Fprintf(r.w, "derived from typedef %s: %s\n", td.Name, yang.Source(td))
+			}
+		}
+	case "tree":
+		if e == nil {
+			for _, m := range r.modules {
+				Write(r.w, m)
+			}
+		} else {
+			Write(r.w, e)
+		}
+	case "explain":
+		if e == nil {
+			fmt.Fprintln(r.w, "explain: no node")
+		} else {
+			explain(r.w, e)
+		}
+	}
+	return true
+}
+
+// pwd returns the path of the current node.
+func (r *repl) pwd() string {
+	if r.cwd == nil {
+		return "/"
+	}
+	return r.cwd.Path()
+}
+
+// children returns the children of e, including the input and output of an
+// RPC, sorted by name, or the modules if e is nil.
+func (r *repl) children(e *yang.Entry) []*yang.Entry {
+	if e == nil {
+		return r.modules
+	}
+	var children []*yang.Entry
+	if e.RPC != nil {
+		for _, c := range []*yang.Entry{e.RPC.Input, e.RPC.Output} {
+			if c != nil {
+				children = append(children, c)
+			}
+		}
+	}
+	return append(children, e.SortedDir()...)
+}
+
+// child returns the child of e named name, which may have a prefix, or nil.
+func (r *repl) child(e *yang.Entry, name string) *yang.Entry {
+	if i := strings.Index(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, c := range r.children(e) {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// lookup returns the node at path, relative to the current node unless path
+// starts with /.  An absolute path starts with a module, either as its own
+// element or as the prefix of the first.  A nil node with true is the list of
+// modules, above the modules.
+func (r *repl) lookup(path string) (*yang.Entry, bool) {
+	e := r.cwd
+	if strings.HasPrefix(path, "/") {
+		e = nil
+	}
+	for _, name := range strings.Split(path, "/") {
+		switch name {
+		case "", ".":
+		case "..":
+			if e != nil {
+				e = e.Parent
+			}
+		default:
+			if prefix, n, ok := strings.Cut(name, ":"); ok && e == nil {
+				// A data path, such as /module:container, starts with the
+				// module of the top-level node.
+				if e = r.child(nil, prefix); e != nil {
+					name = n
+				}
+			}
+			if e = r.child(e, name); e == nil {
+				return nil, false
+			}
+		}
+	}
+	return e, true
+}
+
+// ls writes a line for each child of e to r.w, with its config state, type
+// and name, followed by a / for the children that have children.
+func (r *repl) ls(e *yang.Entry) {
+	for _, c := range r.children(e) {
+		config := "rw"
+		switch {
+		case c.RPC != nil:
+			config = "rpc"
+		case c.ReadOnly():
+			config = "ro"
+		}
+		name := c.Name
+		switch {
+		case c.ListAttr != nil && c.Dir != nil:
+			name = fmt.Sprintf("%s[%s]/", name, c.Key)
+		case c.ListAttr != nil:
+			name += "[]"
+		case c.Dir != nil || c.RPC != nil:
+			name += "/"
+		}
+		fmt.Fprintf(r.w, "%-3s %-12s %s\n", config, getTypeName(c), name)
+	}
+}
+
+// find writes the paths of e and its descendants whose names match re to r.w.
+func (r *repl) find(e *yang.Entry, re *regexp.Regexp) {
+	if re.MatchString(e.Name) {
+		fmt.Fprintln(r.w, e.Path())
+	}
+	for _, c := range r.children(e) {
+		r.find(c, re)
+	}
+}
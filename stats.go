@@ -0,0 +1,74 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func init() {
+	register(&formatter{
+		name: "stats",
+		f:    doStats,
+		help: "display statistics of the size and complexity of the schema",
+	})
+}
+
+// doStats writes the statistics of the modules that entries were read from
+// to w.
+func doStats(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	st := entries[0].Modules().Stats()
+	fmt.Fprintf(w, "modules: %d\n", st.Modules)
+	fmt.Fprintf(w, "submodules: %d\n", st.Submodules)
+	fmt.Fprintf(w, "nodes: %d\n", st.Nodes)
+	fmt.Fprintf(w, "max depth: %d\n", st.MaxDepth)
+	fmt.Fprintf(w, "leafrefs: %d\n", st.Leafrefs)
+	fmt.Fprintf(w, "unions: %d\n", st.Unions)
+	fmt.Fprintf(w, "identityrefs: %d\n", st.Identityrefs)
+	fmt.Fprintf(w, "augments: %d\n", st.Augments)
+	fmt.Fprintf(w, "deviations: %d\n", st.Deviations)
+
+	fmt.Fprintln(w, "nodes by kind:")
+	var kinds []string
+	for k := range st.Kinds {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		fmt.Fprintf(w, "  %s: %d\n", k, st.Kinds[k])
+	}
+
+	fmt.Fprintln(w, "nodes by depth:")
+	for d := 1; d <= st.MaxDepth; d++ {
+		fmt.Fprintf(w, "  %d: %d\n", d, st.Depths[d])
+	}
+
+	fmt.Fprintln(w, "nodes by module:")
+	var mods []string
+	for m := range st.ModuleNodes {
+		mods = append(mods, m)
+	}
+	sort.Strings(mods)
+	for _, m := range mods {
+		fmt.Fprintf(w, "  %s: %d\n", m, st.ModuleNodes[m])
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/openconfig/goyang/pkg/yang"
+)
+
+func init() {
+	register(&formatter{
+		name: "status",
+		f:    doStatus,
+		help: "list the deprecated and obsolete nodes of the schema and their replacements",
+	})
+}
+
+// doStatus writes the location, module, status and schema path of each
+// deprecated and obsolete node of the modules entries were read from to w,
+// followed by the sentence of its description naming its replacement, if any.
+func doStatus(w io.Writer, entries []*yang.Entry) {
+	if len(entries) == 0 {
+		return
+	}
+	for _, si := range entries[0].Modules().StatusReport() {
+		fmt.Fprintf(w, "%s: %s %s %s", yang.Source(si.Entry.Node), si.Module, si.Status, si.Path)
+		if si.Replacement != "" {
+			fmt.Fprintf(w, ": %q", si.Replacement)
+		}
+		fmt.Fprintln(w)
+	}
+}
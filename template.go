@@ -0,0 +1,61 @@
+// Copyright 2026 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/openconfig/goyang/pkg/format"
+	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/pborman/getopt"
+)
+
+var templateFile string
+
+func init() {
+	flags := getopt.New()
+	register(&formatter{
+		name:  "template",
+		f:     doTemplate,
+		help:  "write the output of a Go text/template executed with the module entries",
+		flags: flags,
+	})
+	flags.StringVarLong(&templateFile, "template_file", 0, "template to execute, see package github.com/openconfig/goyang/pkg/format", "FILE")
+}
+
+// doTemplate writes the output of the --template_file template executed
+// with entries to w.
+func doTemplate(w io.Writer, entries []*yang.Entry) {
+	if templateFile == "" {
+		fmt.Fprintln(os.Stderr, "template: no --template_file")
+		stop(1)
+	}
+	data, err := ioutil.ReadFile(templateFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop(1)
+	}
+	f, err := format.Template("template", "", string(data))
+	if err == nil {
+		err = f.Format(w, entries)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		stop(1)
+	}
+}
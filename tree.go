@@ -17,7 +17,6 @@ package main
 import (
 	"fmt"
 	"io"
-	"sort"
 
 	"github.com/openconfig/goyang/pkg/indent"
 	"github.com/openconfig/goyang/pkg/yang"
@@ -89,13 +88,8 @@ func Write(w io.Writer, e *yang.Entry) {
 			Write(indent.NewWriter(w, "  "), r.Output)
 		}
 	}
-	var names []string
-	for k := range e.Dir {
-		names = append(names, k)
-	}
-	sort.Strings(names)
-	for _, k := range names {
-		Write(indent.NewWriter(w, "  "), e.Dir[k])
+	for _, c := range e.SortedDir() {
+		Write(indent.NewWriter(w, "  "), c)
 	}
 	// { to match the brace below to keep brace matching working
 	fmt.Fprintln(w, "}")
@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/openconfig/goyang/pkg/indent"
@@ -47,8 +48,16 @@ func doTypes(w io.Writer, entries []*yang.Entry) {
 		types.AddEntry(e)
 	}
 
+	// Print the types in a stable order.
+	var printed []string
 	for t := range types {
-		printType(w, t, typesVerbose)
+		var b strings.Builder
+		printType(&b, t, typesVerbose)
+		printed = append(printed, b.String())
+	}
+	sort.Strings(printed)
+	for _, p := range printed {
+		io.WriteString(w, p)
 	}
 	if typesDebug {
 		for _, e := range entries {
@@ -71,6 +80,10 @@ func (t Types) AddEntry(e *yang.Entry) {
 	for _, d := range e.Dir {
 		t.AddEntry(d)
 	}
+	if e.RPC != nil {
+		t.AddEntry(e.RPC.Input)
+		t.AddEntry(e.RPC.Output)
+	}
 }
 
 // printType prints type t in a moderately human readable format to w.
@@ -107,8 +120,9 @@ func printType(w io.Writer, t *yang.YangType, verbose bool) {
 	if len(t.Pattern) > 0 {
 		fmt.Fprintf(w, " pattern=%s", strings.Join(t.Pattern, "|"))
 	}
-	b := yang.BaseTypedefs[t.Kind.String()].YangType
-	if len(t.Range) > 0 && !t.Range.Equal(b.Range) {
+	// Types from placeholder modules have no base type.
+	b := yang.BaseTypedefs[t.Kind.String()]
+	if len(t.Range) > 0 && (b == nil || !t.Range.Equal(b.YangType.Range)) {
 		fmt.Fprintf(w, " range=%s", t.Range)
 	}
 	if len(t.Type) > 0 {
@@ -129,7 +143,7 @@ func showall(w io.Writer, e *yang.Entry) {
 		fmt.Fprintf(w, "\n%s\n  ", e.Node.Statement().Location())
 		printType(w, e.Type.Root, false)
 	}
-	for _, d := range e.Dir {
+	for _, d := range e.SortedDir() {
 		showall(w, d)
 	}
 }
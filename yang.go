@@ -15,7 +15,7 @@
 // Program yang parses YANG files, displays errors, and possibly writes
 // something related to the input on output.
 //
-// Usage: yang [--path DIR] [--format FORMAT] [FORMAT OPTIONS] [MODULE] [FILE ...]
+// Usage: yang [--path DIR] [--git REPO@REF[:DIR]] [--format FORMAT] [FORMAT OPTIONS] [MODULE] [FILE ...]
 //
 // If MODULE is specified (an argument that does not end in .yang), it is taken
 // as the name of the module to display.  Any FILEs specified are read, and the
@@ -28,14 +28,34 @@
 //
 // If DIR is specified, it is considered a comma separated list of paths
 // to append to the search directory.  If DIR appears as DIR/... then
-// DIR and all direct and indirect subdirectories are checked.
+// DIR and all direct and indirect subdirectories are checked.  The files and
+// directories matching the --exclude patterns, and subdirectories deeper than
+// --max-depth, are not checked.  Symbolic links to directories are only
+// followed with --follow-symlinks.
+//
+// If REPO@REF[:DIR] is specified with --git, the git repository REPO is
+// checked out at REF, and the directories under DIR within it that contain
+// .yang files are appended to the search directory.  REPO may be given as
+// owner/repository for repositories on GitHub, e.g., openconfig/public@v4.1.0.
+//
+// If VENDOR/PLATFORM/VERSION is specified with --bundle, the directories of
+// the modules of that vendor platform and OS version, in the --bundle-root
+// repository laid out like github.com/YangModels/yang, are appended to the
+// search directory, e.g., --bundle-root yang --bundle cisco/xr/7.2.1.
 //
 // FORMAT, which defaults to "tree", specifies the format of output to produce.
 // Use "goyang --help" for a list of available formats.
 //
+// If FORMAT is not a format of goyang, the plugin goyang-format-FORMAT is
+// run if it is found in $PATH, see package
+// github.com/openconfig/goyang/pkg/format.
+//
 // FORMAT OPTIONS are flags that apply to a specific format.  They must follow
 // --format.
 //
+// "goyang new-module" writes the skeleton of a new module instead, use
+// "goyang new-module --help" for its flags.
+//
 // THIS PROGRAM IS STILL JUST A DEVELOPMENT TOOL.
 package main
 
@@ -45,11 +65,13 @@ import (
 	"io/ioutil"
 	"os"
 	"runtime/trace"
-	"sort"
 	"strings"
+	"time"
 
+	"github.com/openconfig/goyang/pkg/format"
 	"github.com/openconfig/goyang/pkg/indent"
 	"github.com/openconfig/goyang/pkg/yang"
+	"github.com/openconfig/goyang/pkg/yangbench"
 	"github.com/pborman/getopt"
 )
 
@@ -62,10 +84,52 @@ type formatter struct {
 	flags *getopt.Set
 }
 
-var formatters = map[string]*formatter{}
-
+// register adds f to the registry of package format, which other programs
+// may also add formatters to.
 func register(f *formatter) {
-	formatters[f.name] = f
+	format.Register(&format.Formatter{
+		Name:  f.name,
+		Help:  f.help,
+		Flags: f.flags,
+		Format: func(w io.Writer, entries []*yang.Entry) error {
+			f.f(w, entries)
+			return nil
+		},
+	})
+}
+
+// lookupFormat returns the formatter of the format name, which is either
+// registered or the plugin goyang-format-NAME found in $PATH, or nil.
+func lookupFormat(name string) *format.Formatter {
+	if f := format.Lookup(name); f != nil {
+		return f
+	}
+	f, err := format.FindPlugin(name)
+	if err != nil {
+		return nil
+	}
+	format.Register(f)
+	return f
+}
+
+// showErrorCodes is set to prefix errors with their codes.
+var showErrorCodes bool
+
+// snippetModules, if set, is used to show the source errors refer to.
+var snippetModules *yang.Modules
+
+// errorString returns err as a string, prefixed by its code if showErrorCodes
+// is set and err has a code, and followed by the source it refers to if
+// snippetModules is set.
+func errorString(err error) string {
+	s := err.Error()
+	if snippetModules != nil {
+		s = snippetModules.FormatError(err)
+	}
+	if code := yang.Code(err); showErrorCodes && code != "" {
+		return fmt.Sprintf("[%s] %s", code, s)
+	}
+	return s
 }
 
 // exitIfError writes errs to standard error and exits with an exit status of 1.
@@ -73,7 +137,7 @@ func register(f *formatter) {
 func exitIfError(errs []error) {
 	if len(errs) > 0 {
 		for _, err := range errs {
-			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, errorString(err))
 		}
 		stop(1)
 	}
@@ -81,34 +145,90 @@ func exitIfError(errs []error) {
 
 var stop = os.Exit
 
+// stderrLogger is a yang.Logger that writes each message, and its key=value
+// pairs, on a line to standard error.
+type stderrLogger struct{}
+
+func (stderrLogger) Debug(msg string, args ...interface{}) {
+	for i := 0; i+1 < len(args); i += 2 {
+		msg += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	fmt.Fprintln(os.Stderr, msg)
+}
+
 func main() {
-	var format string
-	formats := make([]string, 0, len(formatters))
-	for k := range formatters {
-		formats = append(formats, k)
+	if len(os.Args) > 1 && os.Args[1] == "new-module" {
+		newModule(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		os.Args = explainArgs(os.Args)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "repl" {
+		os.Args = replArgs(os.Args)
 	}
-	sort.Strings(formats)
+
+	var outFormat string
+	formats := format.Names()
 
 	var traceP string
+	var cpuProfile, memProfile string
+	var timings bool
 	var help bool
 	var paths []string
+	var gitSources []string
+	var bundles []string
+	var bundleRoot string
+	var scanOptions yang.ScanOptions
+	duplicates := "error"
 	var ignoreSubmoduleCircularDependencies bool
+	var allowMissingImports bool
+	var allowImportCycles bool
+	var strictStrings bool
+	var strictYANGVersion bool
+	var importOnly []string
+	var dropStatus string
+	var verbose bool
+	var explainCode string
+	var snippets bool
+	var profile string
 	getopt.ListVarLong(&paths, "path", 'p', "comma separated list of directories to add to search path", "DIR[,DIR...]")
-	getopt.StringVarLong(&format, "format", 'f', "format to display: "+strings.Join(formats, ", "), "FORMAT")
+	getopt.ListVarLong(&scanOptions.Exclude, "exclude", 0, "comma separated list of glob patterns of files and directories not to search", "PATTERN[,PATTERN...]")
+	getopt.IntVarLong(&scanOptions.MaxDepth, "max-depth", 0, "deepest subdirectory of a search directory to search", "DEPTH")
+	getopt.BoolVarLong(&scanOptions.FollowSymlinks, "follow-symlinks", 0, "follow symbolic links to directories when searching")
+	getopt.StringVarLong(&duplicates, "duplicates", 0, "module used when a module revision is read with differing content: error, first or last", "POLICY")
+	getopt.ListVarLong(&gitSources, "git", 0, "comma separated list of git repositories, at a ref, to add to search path", "REPO@REF[:DIR][,...]")
+	getopt.ListVarLong(&importOnly, "import-only", 0, "comma separated list of modules that are only imported, adding no data nodes, augments or deviations", "MODULE[,MODULE...]")
+	getopt.ListVarLong(&bundles, "bundle", 0, "comma separated list of vendor bundles in the --bundle-root repository to add to search path", "VENDOR[/PLATFORM[/VERSION]][,...]")
+	getopt.StringVarLong(&bundleRoot, "bundle-root", 0, "repository, laid out like YangModels/yang, that bundles are found in", "DIR")
+	getopt.StringVarLong(&outFormat, "format", 'f', "format to display: "+strings.Join(formats, ", ")+", or a goyang-format-FORMAT plugin", "FORMAT")
 	getopt.StringVarLong(&traceP, "trace", 't', "write trace into to TRACEFILE", "TRACEFILE")
+	getopt.StringVarLong(&cpuProfile, "cpuprofile", 0, "write a CPU profile to FILE", "FILE")
+	getopt.StringVarLong(&memProfile, "memprofile", 0, "write a memory profile to FILE once the modules are processed", "FILE")
+	getopt.BoolVarLong(&timings, "timings", 0, "write the time of each phase of reading and processing the modules to standard error")
 	getopt.BoolVarLong(&help, "help", 'h', "display help")
 	getopt.BoolVarLong(&ignoreSubmoduleCircularDependencies, "ignore-circdep", 'g', "ignore circular dependencies between submodules")
+	getopt.BoolVarLong(&showErrorCodes, "error-codes", 0, "prefix errors with their codes")
+	getopt.BoolVarLong(&snippets, "snippets", 0, "show the source errors refer to")
+	getopt.StringVarLong(&explainCode, "explain-code", 0, "describe the errors with CODE and exit", "CODE")
+	getopt.BoolVarLong(&verbose, "verbose", 'v', "log how modules are found and processed to standard error")
+	getopt.BoolVarLong(&allowMissingImports, "allow-missing-imports", 0, "use placeholders for imported modules that cannot be found")
+	getopt.BoolVarLong(&allowImportCycles, "allow-import-cycles", 0, "accept modules that import each other")
+	getopt.BoolVarLong(&strictStrings, "strict-strings", 0, "enforce the RFC 7950 rules for quoted and unquoted strings")
+	getopt.BoolVarLong(&strictYANGVersion, "strict-yang-version", 0, "reject YANG 1.1 statements in YANG 1 modules")
+	getopt.StringVarLong(&profile, "profile", 0, "read the modules, revisions, features and deviations of the device profile in PROFILE", "PROFILE")
+	getopt.StringVarLong(&dropStatus, "drop-status", 0, "remove the obsolete, or the deprecated and obsolete, nodes from the schema", "obsolete|deprecated")
 	getopt.SetParameters("[FORMAT OPTIONS] [SOURCE] [...]")
 
 	if err := getopt.Getopt(func(o getopt.Option) bool {
 		if o.Name() == "--format" {
-			f, ok := formatters[format]
-			if !ok {
-				fmt.Fprintf(os.Stderr, "%s: invalid format.  Choices are %s\n", format, strings.Join(formats, ", "))
+			f := lookupFormat(outFormat)
+			if f == nil {
+				fmt.Fprintf(os.Stderr, "%s: invalid format.  Choices are %s\n", outFormat, strings.Join(formats, ", "))
 				stop(1)
 			}
-			if f.flags != nil {
-				f.flags.VisitAll(func(o getopt.Option) {
+			if f.Flags != nil {
+				f.Flags.VisitAll(func(o getopt.Option) {
 					getopt.AddOption(o)
 				})
 			}
@@ -130,6 +250,29 @@ func main() {
 		stop = func(c int) { trace.Stop(); os.Exit(c) }
 		defer func() { trace.Stop() }()
 	}
+	if cpuProfile != "" {
+		stopProfile, err := yangbench.StartCPUProfile(cpuProfile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+		s := stop
+		stop = func(c int) { stopProfile(); s(c) }
+		defer stopProfile()
+	}
+
+	if explainCode != "" {
+		e := yang.Explain(yang.ErrorCode(explainCode))
+		if e == nil {
+			fmt.Fprintf(os.Stderr, "%s: unknown error code\n", explainCode)
+			stop(1)
+		}
+		fmt.Printf("%s: %s\n\n%s\n", e.Code, e.Title, e.Description)
+		if e.Reference != "" {
+			fmt.Printf("\nSee %s.\n", e.Reference)
+		}
+		stop(0)
+	}
 
 	if help {
 		getopt.CommandLine.PrintUsage(os.Stderr)
@@ -139,10 +282,10 @@ SOURCE may be a module name or a .yang file.
 Formats:
 `)
 		for _, fn := range formats {
-			f := formatters[fn]
-			fmt.Fprintf(os.Stderr, "    %s - %s\n", f.name, f.help)
-			if f.flags != nil {
-				f.flags.PrintOptions(indent.NewWriter(os.Stderr, "   "))
+			f := format.Lookup(fn)
+			fmt.Fprintf(os.Stderr, "    %s - %s\n", f.Name, f.Help)
+			if f.Flags != nil {
+				f.Flags.PrintOptions(indent.NewWriter(os.Stderr, "   "))
 			}
 			fmt.Fprintln(os.Stderr)
 		}
@@ -151,64 +294,143 @@ Formats:
 
 	ms := yang.NewModules()
 	ms.ParseOptions.IgnoreSubmoduleCircularDependencies = ignoreSubmoduleCircularDependencies
+	ms.ParseOptions.AllowMissingImports = allowMissingImports
+	ms.ParseOptions.AllowImportCycles = allowImportCycles
+	ms.ParseOptions.StrictStrings = strictStrings
+	ms.ParseOptions.StrictYANGVersion = strictYANGVersion
+	ms.SetConformance(yang.ImportOnly, importOnly...)
+	if verbose {
+		ms.ParseOptions.Logger = stderrLogger{}
+	}
+	var recorder yangbench.Recorder
+	if timings {
+		ms.ParseOptions.PhaseTimer = recorder.Record
+	}
+	start := time.Now()
+	if snippets {
+		// Modules fetched or read from git sources are not files that
+		// can be read again to show their source.
+		ms.ParseOptions.RetainSources = true
+		snippetModules = ms
+	}
+	ms.ParseOptions.ScanOptions = scanOptions
+	switch duplicates {
+	case "error":
+		ms.ParseOptions.DuplicatePolicy = yang.DuplicateError
+	case "first":
+		ms.ParseOptions.DuplicatePolicy = yang.DuplicateFirst
+	case "last":
+		ms.ParseOptions.DuplicatePolicy = yang.DuplicateLast
+	default:
+		fmt.Fprintf(os.Stderr, "%s: invalid duplicates policy.  Choices are error, first, last\n", duplicates)
+		stop(1)
+	}
+	switch dropStatus {
+	case "":
+	case "obsolete":
+		ms.ParseOptions.DropStatus = yang.DropObsolete
+	case "deprecated":
+		ms.ParseOptions.DropStatus = yang.DropDeprecated
+	default:
+		fmt.Fprintf(os.Stderr, "%s: invalid status to drop.  Choices are obsolete, deprecated\n", dropStatus)
+		stop(1)
+	}
 
 	for _, path := range paths {
-		expanded, err := yang.PathsWithModules(path)
+		expanded, err := yang.PathsWithModulesOptions(path, scanOptions)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			continue
 		}
 		ms.AddPath(expanded...)
 	}
+	for _, bundle := range bundles {
+		b, err := yang.ParseBundle(bundle)
+		if err == nil {
+			err = ms.AddBundle(bundleRoot, b)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+	}
+	for _, src := range gitSources {
+		g, err := yang.ParseGitSource(src)
+		if err == nil {
+			err = ms.AddGitSource(g)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+	}
 
-	if format == "" {
-		format = "tree"
+	if outFormat == "" {
+		outFormat = "tree"
 	}
-	if _, ok := formatters[format]; !ok {
-		fmt.Fprintf(os.Stderr, "%s: invalid format.  Choices are %s\n", format, strings.Join(formats, ", "))
+	f := lookupFormat(outFormat)
+	if f == nil {
+		fmt.Fprintf(os.Stderr, "%s: invalid format.  Choices are %s\n", outFormat, strings.Join(formats, ", "))
 		stop(1)
 
 	}
 
+	if profile != "" {
+		p, err := yang.LoadProfile(profile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+		exitIfError(ms.ReadProfile(p))
+	}
+
 	files := getopt.Args()
 
-	if len(files) == 0 {
+	if len(files) == 0 && profile == "" {
 		data, err := ioutil.ReadAll(os.Stdin)
 		if err == nil {
 			err = ms.Parse(string(data), "<STDIN>")
 		}
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, errorString(err))
 			stop(1)
 		}
 	}
 
 	for _, name := range files {
 		if err := ms.Read(name); err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, errorString(err))
 			continue
 		}
 	}
 
 	// Process the read files, exiting if any errors were found.
 	exitIfError(ms.Process())
+	for _, w := range ms.Warnings() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", errorString(w))
+	}
+	if timings {
+		r := recorder.Report()
+		r.Total = time.Since(start)
+		r.Write(os.Stderr)
+	}
+	if memProfile != "" {
+		if err := yangbench.WriteHeapProfile(memProfile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			stop(1)
+		}
+	}
 
 	// Keep track of the top level modules we read in.
 	// Those are the only modules we want to print below.
-	mods := map[string]*yang.Module{}
-	var names []string
-
-	for _, m := range ms.Modules {
-		if mods[m.Name] == nil {
-			mods[m.Name] = m
-			names = append(names, m.Name)
-		}
-	}
-	sort.Strings(names)
-	entries := make([]*yang.Entry, len(names))
-	for x, n := range names {
-		entries[x] = yang.ToEntry(mods[n])
+	mods := ms.SortedModules()
+	entries := make([]*yang.Entry, len(mods))
+	for x, m := range mods {
+		entries[x] = yang.ToEntry(m)
 	}
 
-	formatters[format].f(os.Stdout, entries)
+	if err := f.Format(os.Stdout, entries); err != nil {
+		fmt.Fprintln(os.Stderr, errorString(err))
+		stop(1)
+	}
 }